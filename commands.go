@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/lazyvibe/vibemux/internal/app"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/store"
+	"github.com/lazyvibe/vibemux/internal/web"
+)
+
+// runSubcommand handles non-interactive subcommands. It reports whether
+// args named a subcommand.
+func runSubcommand(configDir string, args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	switch args[0] {
+	case "mirror":
+		if err := runMirror(configDir, args[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "mirror: %v\n", err)
+			os.Exit(1)
+		}
+		return true
+	case "run":
+		code, err := runHeadless(configDir, args[1:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "run: %v\n", err)
+		}
+		if code != 0 {
+			os.Exit(code)
+		}
+		return true
+	case "secret":
+		if err := runSecret(configDir, args[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "secret: %v\n", err)
+			os.Exit(1)
+		}
+		return true
+	case "version", "--version", "-v":
+		fmt.Printf("%s %s\n", appName, appVersion)
+		return true
+	}
+	return false
+}
+
+// runMirror attaches a view of a running session to this terminal. With
+// --drive it also takes the session's input lock and forwards keystrokes.
+func runMirror(configDir string, args []string) error {
+	drive := false
+	if len(args) > 0 && args[0] == "--drive" {
+		drive = true
+		args = args[1:]
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("usage: vibemux mirror [--drive] <project>[#n]")
+	}
+	// "api#2" targets the project's second session.
+	ref, suffix, hasSuffix := strings.Cut(args[0], "#")
+
+	config, err := app.LoadConfig(configDir)
+	if err != nil {
+		return err
+	}
+	project, err := findProject(configDir, config.Store, ref)
+	if err != nil {
+		return err
+	}
+	sessionID := project.ID
+	if hasSuffix {
+		sessionID = project.ID + "#" + suffix
+	}
+
+	addr := config.DashboardAddr
+	if addr == "" {
+		addr = web.DefaultAddr
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if drive {
+		state, err := term.MakeRaw(os.Stdin.Fd())
+		if err != nil {
+			return err
+		}
+		defer term.Restore(os.Stdin.Fd(), state)
+
+		driveCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			defer cancel()
+			if err := web.Drive(driveCtx, addr, sessionID, operatorName(), web.ReadToken(configDir), os.Stdin); err != nil {
+				fmt.Printf("\r\n[drive: %v]\r\n", err)
+			}
+		}()
+		ctx = driveCtx
+	}
+
+	// Start from a clean screen; the history replay redraws the agent UI.
+	fmt.Print("\x1b[H\x1b[2J")
+	if drive {
+		fmt.Print("[driving: Ctrl+] to detach]\r\n")
+	}
+	err = web.Mirror(ctx, addr, sessionID, web.ReadToken(configDir), os.Stdout)
+	fmt.Printf("\r\n\x1b[0m[%s: mirror ended]\r\n", project.DisplayName())
+	return err
+}
+
+// operatorName identifies this terminal in input lock indicators.
+func operatorName() string {
+	name := os.Getenv("USER")
+	if name == "" {
+		name = os.Getenv("USERNAME")
+	}
+	if host, err := os.Hostname(); err == nil {
+		name += "@" + host
+	}
+	if name == "" {
+		name = "mirror"
+	}
+	return name
+}
+
+// findProject looks a project up by ID, ID prefix or case-insensitive name.
+func findProject(configDir, backend, ref string) (*model.Project, error) {
+	s, err := store.Open(configDir, backend)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+	return lookupProject(s, ref)
+}
+
+// lookupProject finds a project in s the way findProject does.
+func lookupProject(s store.Store, ref string) (*model.Project, error) {
+	projects, err := s.List(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	var match *model.Project
+	for i := range projects {
+		p := &projects[i]
+		if p.ID == ref {
+			return p, nil
+		}
+		if strings.EqualFold(p.Name, ref) || strings.HasPrefix(p.ID, ref) {
+			if match != nil {
+				return nil, fmt.Errorf("%q matches more than one project", ref)
+			}
+			match = p
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("unknown project: %s", ref)
+	}
+	return match, nil
+}
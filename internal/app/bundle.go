@@ -0,0 +1,325 @@
+package app
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lazyvibe/vibemux/internal/secrets"
+	"github.com/lazyvibe/vibemux/pkg/utils"
+)
+
+// MaskedSecret replaces credentials in an exported config bundle.
+const MaskedSecret = "********"
+
+// bundleFiles and bundleDirs are what a config bundle carries, relative to
+// the config directory. Directories that don't exist are skipped.
+var (
+	bundleFiles = []string{"config.json", "data.json"}
+	bundleDirs  = []string{"themes", "keymaps", "roles", "snippets"}
+)
+
+// machineKeys are config.json settings that describe the local machine. An
+// import keeps the local values when they are set.
+var machineKeys = []string{"claude_path", "codex_path", "default_shell"}
+
+// webhookKeys are the profile notification settings masked on export.
+var webhookKeys = []string{"webhook_url", "reminder_webhook_url", "slack_webhook_url", "discord_webhook_url"}
+
+// BundleResult summarizes an export or import.
+type BundleResult struct {
+	Files int
+	// Secrets is the number of masked values: written on export, or left
+	// for the user to re-enter on import.
+	Secrets int
+}
+
+// ExportBundle writes the configuration to a gzipped tar archive for moving
+// to another machine. Secret-looking profile env vars and webhook URLs are
+// masked.
+func ExportBundle(configDir, file string) (BundleResult, error) {
+	var result BundleResult
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return result, err
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		return result, err
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	add := func(name string, content []byte) error {
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), ModTime: time.Now()}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err := tw.Write(content)
+		result.Files++
+		return err
+	}
+
+	for _, name := range bundleFiles {
+		content, err := os.ReadFile(filepath.Join(configDir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return result, err
+		}
+		if name == "data.json" {
+			var masked int
+			if content, masked, err = maskSecrets(content); err != nil {
+				return result, fmt.Errorf("data.json: %w", err)
+			}
+			result.Secrets += masked
+		}
+		if err := add(name, content); err != nil {
+			return result, err
+		}
+	}
+	for _, dir := range bundleDirs {
+		entries, _ := os.ReadDir(filepath.Join(configDir, dir))
+		for _, e := range entries {
+			if !e.Type().IsRegular() {
+				continue
+			}
+			content, err := os.ReadFile(filepath.Join(configDir, dir, e.Name()))
+			if err != nil {
+				return result, err
+			}
+			if err := add(dir+"/"+e.Name(), content); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return result, err
+	}
+	if err := gz.Close(); err != nil {
+		return result, err
+	}
+	return result, f.Close()
+}
+
+// ImportBundle unpacks a bundle made by ExportBundle into the config
+// directory. Masked secrets are restored from the local profile with the
+// same ID where possible and dropped otherwise. The replaced config.json
+// and data.json are kept as .bak files.
+func ImportBundle(configDir, file string) (BundleResult, error) {
+	var result BundleResult
+	files, err := readBundle(file)
+	if err != nil {
+		return result, err
+	}
+	if _, ok := files["data.json"]; !ok {
+		if _, ok := files["config.json"]; !ok {
+			return result, fmt.Errorf("%s is not a vibemux config bundle", filepath.Base(file))
+		}
+	}
+
+	for name, content := range files {
+		target := filepath.Join(configDir, filepath.FromSlash(name))
+		local, err := os.ReadFile(target)
+		hasLocal := err == nil
+		switch name {
+		case "data.json":
+			var missing int
+			if content, missing, err = unmaskSecrets(content, local); err != nil {
+				return result, fmt.Errorf("data.json: %w", err)
+			}
+			result.Secrets += missing
+		case "config.json":
+			if content, err = keepMachineKeys(content, local); err != nil {
+				return result, fmt.Errorf("config.json: %w", err)
+			}
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return result, err
+		}
+		if hasLocal && !strings.Contains(name, "/") {
+			if err := os.WriteFile(target+".bak", local, 0644); err != nil {
+				return result, err
+			}
+		}
+		if err := os.WriteFile(target, content, 0644); err != nil {
+			return result, err
+		}
+		result.Files++
+	}
+	return result, nil
+}
+
+// readBundle returns the bundle's entries by name. Entries outside the
+// known files and directories are ignored.
+func readBundle(file string) (map[string][]byte, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	tr := tar.NewReader(gz)
+
+	files := make(map[string][]byte)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg || !bundleEntry(hdr.Name) {
+			continue
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		files[hdr.Name] = content
+	}
+	return files, nil
+}
+
+// bundleEntry reports whether name is something a bundle may contain.
+func bundleEntry(name string) bool {
+	for _, f := range bundleFiles {
+		if name == f {
+			return true
+		}
+	}
+	dir, base := path.Split(name)
+	for _, d := range bundleDirs {
+		if dir == d+"/" && base != "" && base != "." && base != ".." {
+			return true
+		}
+	}
+	return false
+}
+
+// maskSecrets masks credentials in a data.json document.
+func maskSecrets(content []byte) ([]byte, int, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, 0, err
+	}
+	count := 0
+	for _, p := range profileMaps(doc) {
+		if env, ok := p["env_vars"].(map[string]any); ok {
+			for k, v := range env {
+				// References name a secret without holding it.
+				if s, _ := v.(string); s != "" && utils.IsSecretEnvKey(k) && !secrets.IsRef(s) {
+					env[k] = MaskedSecret
+					count++
+				}
+			}
+		}
+		if n, ok := p["notification"].(map[string]any); ok {
+			for _, key := range webhookKeys {
+				if s, _ := n[key].(string); s != "" {
+					n[key] = MaskedSecret
+					count++
+				}
+			}
+		}
+	}
+	out, err := json.MarshalIndent(doc, "", "  ")
+	return out, count, err
+}
+
+// unmaskSecrets fills masked values in an imported data.json from the local
+// one, matching profiles by ID. Values it cannot restore are removed and
+// counted.
+func unmaskSecrets(content, local []byte) ([]byte, int, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, 0, err
+	}
+	localProfiles := make(map[string]map[string]any)
+	var localDoc map[string]any
+	if json.Unmarshal(local, &localDoc) == nil {
+		for _, p := range profileMaps(localDoc) {
+			if id, _ := p["id"].(string); id != "" {
+				localProfiles[id] = p
+			}
+		}
+	}
+
+	missing := 0
+	for _, p := range profileMaps(doc) {
+		id, _ := p["id"].(string)
+		old := localProfiles[id]
+		if env, ok := p["env_vars"].(map[string]any); ok {
+			oldEnv, _ := old["env_vars"].(map[string]any)
+			for k, v := range env {
+				if v != MaskedSecret {
+					continue
+				}
+				if s, _ := oldEnv[k].(string); s != "" && s != MaskedSecret {
+					env[k] = s
+				} else {
+					delete(env, k)
+					missing++
+				}
+			}
+		}
+		if n, ok := p["notification"].(map[string]any); ok {
+			oldNotify, _ := old["notification"].(map[string]any)
+			for _, key := range webhookKeys {
+				if n[key] != MaskedSecret {
+					continue
+				}
+				if s, _ := oldNotify[key].(string); s != "" && s != MaskedSecret {
+					n[key] = s
+				} else {
+					n[key] = ""
+					missing++
+				}
+			}
+		}
+	}
+	out, err := json.MarshalIndent(doc, "", "  ")
+	return out, missing, err
+}
+
+// keepMachineKeys carries the local machine's paths over into an imported
+// config.json.
+func keepMachineKeys(content, local []byte) ([]byte, error) {
+	var doc, localDoc map[string]any
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	if json.Unmarshal(local, &localDoc) != nil {
+		return content, nil
+	}
+	for _, key := range machineKeys {
+		if s, _ := localDoc[key].(string); s != "" {
+			doc[key] = s
+		}
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func profileMaps(doc map[string]any) []map[string]any {
+	list, _ := doc["profiles"].([]any)
+	result := make([]map[string]any, 0, len(list))
+	for _, item := range list {
+		if p, ok := item.(map[string]any); ok {
+			result = append(result, p)
+		}
+	}
+	return result
+}
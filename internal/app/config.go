@@ -8,6 +8,10 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+
+	"github.com/lazyvibe/vibemux/internal/hooks"
+	"github.com/lazyvibe/vibemux/internal/secrets"
+	"github.com/lazyvibe/vibemux/pkg/utils"
 )
 
 // Config holds the application configuration.
@@ -20,7 +24,8 @@ type Config struct {
 	DefaultShell string `json:"default_shell"`
 	// Initialized indicates if the first-run setup has been completed.
 	Initialized bool `json:"initialized"`
-	// Theme is the color theme (future use).
+	// Theme is the color theme: catppuccin-mocha (default),
+	// catppuccin-latte, dracula, gruvbox or high-contrast.
 	Theme string `json:"theme"`
 	// RecentPaths stores recently used project paths for completion.
 	RecentPaths []string `json:"recent_paths,omitempty"`
@@ -28,6 +33,123 @@ type Config struct {
 	GridRows int `json:"grid_rows,omitempty"`
 	// GridCols is the number of terminal columns in the grid layout.
 	GridCols int `json:"grid_cols,omitempty"`
+	// GridAuto sizes the grid from the window and the number of sessions,
+	// ignoring GridRows/GridCols.
+	GridAuto bool `json:"grid_auto,omitempty"`
+	// Layouts maps a layout name to a saved set of projects and grid shape.
+	Layouts map[string]Layout `json:"layouts,omitempty"`
+	// GridSplits holds the split sizes set in resize mode, keyed by grid
+	// shape ("2x3" is 2 rows by 3 columns).
+	GridSplits map[string]GridSplit `json:"grid_splits,omitempty"`
+	// AllQuietMinutes is how long every running session must stay idle before
+	// a single "all quiet" notification fires. Zero disables it.
+	AllQuietMinutes int `json:"all_quiet_minutes"`
+	// TmuxMode hosts each agent inside a named tmux session so it can be
+	// attached from an existing tmux workflow.
+	TmuxMode bool `json:"tmux_mode,omitempty"`
+	// DashboardAddr is the listen address of the read-only web dashboard
+	// (e.g. "127.0.0.1:7681"). Empty disables it.
+	DashboardAddr string `json:"dashboard_addr,omitempty"`
+	// DashboardAllowInput lets dashboard users and `vibemux mirror --drive`
+	// take the input lock of a session and type into it.
+	DashboardAllowInput bool `json:"dashboard_allow_input,omitempty"`
+	// ControlSocket is where the JSON-RPC control API listens: a unix
+	// socket path (relative paths are in the config directory) or a
+	// "host:port" TCP address. Empty disables it.
+	ControlSocket string `json:"control_socket,omitempty"`
+	// LaunchPicker shows the launch dialog (profile picker) when Enter is
+	// pressed on a project instead of starting it right away.
+	LaunchPicker bool `json:"launch_picker,omitempty"`
+	// ProjectPanel is the project list width: "wide" (default), "narrow"
+	// (status dots only) or "hidden".
+	ProjectPanel string `json:"project_panel,omitempty"`
+	// Macros maps a register to its recorded keystrokes (raw PTY input).
+	Macros map[string][]string `json:"macros,omitempty"`
+	// WorkspaceProfiles maps a workspace name to the profile ID its projects
+	// use when they have no profile of their own.
+	WorkspaceProfiles map[string]string `json:"workspace_profiles,omitempty"`
+	// SharedConfigDir is a read-only directory (typically a git checkout)
+	// whose profiles, roles and snippets are merged with the local ones.
+	SharedConfigDir string `json:"shared_config_dir,omitempty"`
+	// TabOrder is the session tab strip order: "opened" (default) or
+	// "activity" (most recent output first).
+	TabOrder string `json:"tab_order,omitempty"`
+	// ChainAutosaveSeconds is how long unsaved chain context changes may
+	// stay in memory before they are written to disk. Zero saves only on
+	// quit.
+	ChainAutosaveSeconds int `json:"chain_autosave_seconds"`
+	// ChainMaxChars is the injected chain size above which older entries
+	// are compacted. Zero disables compaction.
+	ChainMaxChars int `json:"chain_max_chars"`
+	// ChainKeepLast is how many recent entries compaction keeps verbatim.
+	ChainKeepLast int `json:"chain_keep_last"`
+	// ChainEntryChars is what compaction truncates older entries to.
+	ChainEntryChars int `json:"chain_entry_chars"`
+	// ChainSummarizer is the pane (project name, alias or grid number)
+	// asked to summarize older entries before a compacted injection.
+	// Empty compacts by truncation only.
+	ChainSummarizer string `json:"chain_summarizer,omitempty"`
+	// ConclusionSummarizer is the pane asked to summarize conclusions longer
+	// than ConclusionMaxChars before they are added to the chain. Empty
+	// adds them as extracted.
+	ConclusionSummarizer string `json:"conclusion_summarizer,omitempty"`
+	// ConclusionMaxChars is the conclusion length that triggers a summary.
+	ConclusionMaxChars int `json:"conclusion_max_chars"`
+	// MeetingSummarizer is the pane asked to write the minutes of an
+	// organizer-mode discussion (:minutes). Empty uses the organizer pane.
+	MeetingSummarizer string `json:"meeting_summarizer,omitempty"`
+	// PasteGuardBytes and PasteGuardLines ask for confirmation before a
+	// larger terminal-mode paste reaches a pane. Zero disables each check.
+	PasteGuardBytes int `json:"paste_guard_bytes"`
+	PasteGuardLines int `json:"paste_guard_lines"`
+	// MaxSessions caps how many sessions run at once; further launches
+	// wait in a queue until one exits. Zero means no limit.
+	MaxSessions int `json:"max_sessions,omitempty"`
+	// StopGraceSeconds is how long a closing session may take to exit after
+	// SIGTERM (Ctrl+C on Windows) before it is killed. Zero kills at once.
+	StopGraceSeconds int `json:"stop_grace_seconds"`
+	// Store selects where projects and profiles are kept: "json"
+	// (data.json, the default) or "bolt" (data.db, which also keeps session
+	// and chain history). Switching to bolt imports data.json once.
+	Store string `json:"store,omitempty"`
+	// SessionLog records the PTY output of sessions to disk.
+	SessionLog SessionLogConfig `json:"session_log"`
+	// SecretsFile holds the values of secret:<name> env var references.
+	// Relative paths are in the config directory; default secrets.enc.
+	SecretsFile string `json:"secrets_file,omitempty"`
+	// SecretsIdentity is an age identity file. When set, SecretsFile is
+	// age-encrypted and decrypted with the age CLI instead of a passphrase.
+	SecretsIdentity string `json:"secrets_identity,omitempty"`
+	// Hooks are scripts run on session events; what they print is typed
+	// back into the session.
+	Hooks []hooks.Hook `json:"hooks,omitempty"`
+}
+
+// Layout is a named set of projects, in grid order, with the grid shape
+// they were arranged in.
+type Layout struct {
+	Projects []string `json:"projects"`
+	GridRows int      `json:"grid_rows,omitempty"`
+	GridCols int      `json:"grid_cols,omitempty"`
+	GridAuto bool     `json:"grid_auto,omitempty"`
+}
+
+// GridSplit gives the relative size of each column and row of a grid
+// shape. Missing or mismatched lists fall back to equal sizes.
+type GridSplit struct {
+	Cols []int `json:"cols,omitempty"`
+	Rows []int `json:"rows,omitempty"`
+}
+
+// SessionLogConfig controls session output logs, written to
+// <configDir>/logs/<project>/. A profile's "session_log" setting
+// overrides Enabled.
+type SessionLogConfig struct {
+	Enabled bool `json:"enabled"`
+	// MaxMB starts a new file once the current one reaches this size.
+	MaxMB int `json:"max_mb"`
+	// Keep is how many files each project keeps; older ones are deleted.
+	Keep int `json:"keep"`
 }
 
 // DefaultConfig returns a config with sensible defaults.
@@ -42,11 +164,21 @@ func DefaultConfig() *Config {
 	}
 
 	return &Config{
-		DefaultShell: shell,
-		Theme:        "catppuccin-mocha",
-		RecentPaths:  []string{},
-		GridRows:     2,
-		GridCols:     2,
+		DefaultShell:         shell,
+		Theme:                "catppuccin-mocha",
+		RecentPaths:          []string{},
+		GridRows:             2,
+		GridCols:             2,
+		AllQuietMinutes:      5,
+		ChainAutosaveSeconds: 30,
+		ChainMaxChars:        24000,
+		ChainKeepLast:        3,
+		ChainEntryChars:      600,
+		ConclusionMaxChars:   4000,
+		PasteGuardBytes:      8192,
+		PasteGuardLines:      200,
+		StopGraceSeconds:     3,
+		SessionLog:           SessionLogConfig{MaxMB: 10, Keep: 20},
 	}
 }
 
@@ -55,6 +187,25 @@ func ConfigPath(configDir string) string {
 	return filepath.Join(configDir, "config.json")
 }
 
+// NewSecretResolver returns the resolver for the secret references in
+// profile env vars, reading the configured secrets file.
+func NewSecretResolver(configDir string, config *Config) *secrets.Resolver {
+	file := "secrets.enc"
+	identity := ""
+	if config != nil {
+		if config.SecretsFile != "" {
+			file = utils.ExpandPath(config.SecretsFile)
+		}
+		if config.SecretsIdentity != "" {
+			identity = utils.ExpandPath(config.SecretsIdentity)
+		}
+	}
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(configDir, file)
+	}
+	return secrets.NewResolver(file, identity)
+}
+
 // LoadConfig loads the configuration from disk.
 func LoadConfig(configDir string) (*Config, error) {
 	path := ConfigPath(configDir)
@@ -0,0 +1,202 @@
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lazyvibe/vibemux/pkg/utils"
+)
+
+// ImportKind is what an import candidate creates.
+type ImportKind int
+
+const (
+	ImportProject ImportKind = iota
+	ImportProfile
+)
+
+// ImportCandidate is a project or profile found in another tool's config.
+type ImportCandidate struct {
+	Kind   ImportKind
+	Source string // Tool the candidate was found in
+	Name   string
+	// Path is the project directory (projects only).
+	Path string
+	// Command and EnvVars describe the profile (profiles only).
+	Command string
+	EnvVars map[string]string
+}
+
+// DetectImports looks for projects and profiles in Claude Code (~/.claude),
+// Codex (~/.codex) and tmuxinator configs. Projects whose directory no
+// longer exists are skipped, and each path is listed once.
+func DetectImports() []ImportCandidate {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	var found []ImportCandidate
+	found = append(found, detectClaudeImports(home)...)
+	found = append(found, detectCodexImports(home)...)
+	found = append(found, detectTmuxinatorImports(home)...)
+
+	seen := make(map[string]bool)
+	result := found[:0]
+	for _, c := range found {
+		if c.Kind == ImportProject {
+			if seen[c.Path] {
+				continue
+			}
+			if info, err := os.Stat(c.Path); err != nil || !info.IsDir() {
+				continue
+			}
+			seen[c.Path] = true
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
+// detectClaudeImports reads the projects Claude Code has been run in from
+// ~/.claude.json, and env vars from ~/.claude/settings.json as a profile.
+func detectClaudeImports(home string) []ImportCandidate {
+	var result []ImportCandidate
+
+	var state struct {
+		Projects map[string]json.RawMessage `json:"projects"`
+	}
+	if data, err := os.ReadFile(filepath.Join(home, ".claude.json")); err == nil && json.Unmarshal(data, &state) == nil {
+		paths := make([]string, 0, len(state.Projects))
+		for path := range state.Projects {
+			if path != home {
+				paths = append(paths, path)
+			}
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			result = append(result, projectCandidate("Claude Code", path))
+		}
+	}
+
+	var settings struct {
+		Env map[string]string `json:"env"`
+	}
+	if data, err := os.ReadFile(filepath.Join(home, ".claude", "settings.json")); err == nil &&
+		json.Unmarshal(data, &settings) == nil && len(settings.Env) > 0 {
+		result = append(result, ImportCandidate{
+			Kind:    ImportProfile,
+			Source:  "Claude Code",
+			Name:    "Claude (imported)",
+			Command: "claude",
+			EnvVars: settings.Env,
+		})
+	}
+	return result
+}
+
+// detectCodexImports offers a codex profile when Codex is set up, plus the
+// trusted projects listed in ~/.codex/config.toml.
+func detectCodexImports(home string) []ImportCandidate {
+	configPath := filepath.Join(home, ".codex", "config.toml")
+	f, err := os.Open(configPath)
+	if err != nil {
+		if DetectCodexPath() == "" {
+			return nil
+		}
+		return []ImportCandidate{codexProfile()}
+	}
+	defer f.Close()
+
+	result := []ImportCandidate{codexProfile()}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Tables look like [projects."/abs/path"] or [projects.'/abs/path'].
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "[projects.") || !strings.HasSuffix(line, "]") {
+			continue
+		}
+		path := strings.Trim(strings.TrimSuffix(strings.TrimPrefix(line, "[projects."), "]"), `"'`)
+		if path != "" {
+			result = append(result, projectCandidate("Codex", path))
+		}
+	}
+	return result
+}
+
+func codexProfile() ImportCandidate {
+	return ImportCandidate{Kind: ImportProfile, Source: "Codex", Name: "Codex", Command: "codex"}
+}
+
+// detectTmuxinatorImports reads the name and root of each tmuxinator
+// project file.
+func detectTmuxinatorImports(home string) []ImportCandidate {
+	dirs := []string{
+		filepath.Join(home, ".config", "tmuxinator"),
+		filepath.Join(home, ".tmuxinator"),
+	}
+	if dir := os.Getenv("TMUXINATOR_CONFIG"); dir != "" {
+		dirs = append([]string{dir}, dirs...)
+	}
+
+	var result []ImportCandidate
+	for _, dir := range dirs {
+		files, _ := filepath.Glob(filepath.Join(dir, "*.yml"))
+		for _, file := range files {
+			name, root := readTmuxinatorProject(file)
+			if root == "" {
+				continue
+			}
+			if name == "" {
+				name = strings.TrimSuffix(filepath.Base(file), ".yml")
+			}
+			c := projectCandidate("tmuxinator", root)
+			c.Name = name
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// readTmuxinatorProject extracts the top-level name and root keys. Only
+// plain scalar values are understood, which covers generated configs.
+func readTmuxinatorProject(file string) (string, string) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", ""
+	}
+	defer f.Close()
+
+	var name, root string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			continue
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+		switch strings.TrimSpace(key) {
+		case "name":
+			name = value
+		case "root", "project_root":
+			if value != "" {
+				root = utils.ExpandPath(value)
+			}
+		}
+	}
+	return name, root
+}
+
+func projectCandidate(source, path string) ImportCandidate {
+	path = filepath.Clean(path)
+	return ImportCandidate{
+		Kind:   ImportProject,
+		Source: source,
+		Name:   filepath.Base(path),
+		Path:   path,
+	}
+}
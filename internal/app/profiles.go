@@ -0,0 +1,91 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/lazyvibe/vibemux/internal/model"
+)
+
+// profileFileVersion is written to exported profile files.
+const profileFileVersion = 1
+
+// profileFile is the format of an exported profile file.
+type profileFile struct {
+	Version  int             `json:"vibemux_profiles"`
+	Profiles []model.Profile `json:"profiles"`
+}
+
+// ExportProfiles writes profiles to a JSON file to share with others.
+// Secret-looking env vars and webhook URLs are masked unless withSecrets is
+// set; the number of masked values is returned.
+func ExportProfiles(file string, profiles []model.Profile, withSecrets bool) (int, error) {
+	doc := profileFile{Version: profileFileVersion, Profiles: make([]model.Profile, 0, len(profiles))}
+	for _, p := range profiles {
+		p.IsDefault = false
+		doc.Profiles = append(doc.Profiles, p)
+	}
+	content, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	masked := 0
+	if !withSecrets {
+		if content, masked, err = maskSecrets(content); err != nil {
+			return 0, err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return 0, err
+	}
+	return masked, os.WriteFile(file, append(content, '\n'), 0600)
+}
+
+// ImportProfiles reads profiles exported by ExportProfiles, or a single
+// profile object. Masked secrets are restored from the local profile with
+// the same ID where possible and dropped otherwise; the number dropped is
+// returned. Imported profiles are never the default.
+func ImportProfiles(file string, local []model.Profile) ([]model.Profile, int, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, 0, err
+	}
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(content, &probe); err != nil {
+		return nil, 0, fmt.Errorf("not a profile file: %w", err)
+	}
+	if _, ok := probe["profiles"]; !ok {
+		// A single profile, e.g. copied from data.json.
+		content = []byte(`{"profiles":[` + string(content) + `]}`)
+	}
+	localDoc, err := json.Marshal(profileFile{Profiles: local})
+	if err != nil {
+		return nil, 0, err
+	}
+	content, missing, err := unmaskSecrets(content, localDoc)
+	if err != nil {
+		return nil, 0, err
+	}
+	var doc profileFile
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, 0, err
+	}
+	profiles := make([]model.Profile, 0, len(doc.Profiles))
+	for _, p := range doc.Profiles {
+		if p.ID == "" || p.Command == "" {
+			continue
+		}
+		if p.Name == "" {
+			p.Name = p.ID
+		}
+		p.IsDefault = false
+		profiles = append(profiles, p)
+	}
+	if len(profiles) == 0 {
+		return nil, 0, errors.New("no profiles in " + file)
+	}
+	return profiles, missing, nil
+}
@@ -0,0 +1,250 @@
+package control
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lazyvibe/vibemux/internal/runtime"
+)
+
+// Methods the TUI carries out (see Request).
+const (
+	MethodStart = "session.start"
+	MethodStop  = "session.stop"
+)
+
+// MethodAuth opens a TCP connection with the token from TokenFile.
+const MethodAuth = "auth"
+
+// JSON-RPC error codes.
+const (
+	codeParseError     = -32700
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeFailed         = -32000
+	codeUnauthorized   = -32001
+)
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcNotification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+}
+
+func errorResponse(id json.RawMessage, code int, msg string) rpcResponse {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	return rpcResponse{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: msg}}
+}
+
+func failed(err error) *rpcError {
+	return &rpcError{Code: codeFailed, Message: err.Error()}
+}
+
+// SessionInfo describes a session for sessions.list.
+type SessionInfo struct {
+	ID        string `json:"id"`
+	ProjectID string `json:"projectId"`
+	Name      string `json:"name"`
+	Path      string `json:"path"`
+	Status    string `json:"status"`
+	Tool      string `json:"tool"`
+	Lock      string `json:"lock,omitempty"`
+}
+
+// ProjectInfo describes a project for projects.list.
+type ProjectInfo struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Path      string `json:"path"`
+	Workspace string `json:"workspace,omitempty"`
+	Running   bool   `json:"running"`
+}
+
+// sessionParams are the parameters of the session methods.
+type sessionParams struct {
+	Session string `json:"session"`
+	Project string `json:"project"`
+	Text    string `json:"text"`
+	Submit  bool   `json:"submit"`
+	Plain   bool   `json:"plain"`
+	History bool   `json:"history"`
+	Token   string `json:"token"`
+}
+
+// call runs one method.
+func (s *Server) call(c *client, req rpcRequest) (any, *rpcError) {
+	var p sessionParams
+	if len(req.Params) > 0 {
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+	}
+	switch req.Method {
+	case MethodAuth:
+		c.authed = s.validToken(p.Token)
+		return map[string]bool{"ok": c.authed}, nil
+	case "sessions.list":
+		return s.listSessions(c), nil
+	case "projects.list":
+		return s.listProjects(c)
+	case MethodStart:
+		if p.Project == "" {
+			return nil, &rpcError{Code: codeInvalidParams, Message: "project is required"}
+		}
+		reply, err := s.ask(c.ctx, MethodStart, p.Project)
+		if err != nil {
+			return nil, failed(err)
+		}
+		return map[string]string{"session": reply.SessionID}, nil
+	case MethodStop:
+		if _, ok := s.engine.GetSession(p.Session); !ok {
+			return nil, &rpcError{Code: codeInvalidParams, Message: "unknown session: " + p.Session}
+		}
+		if _, err := s.ask(c.ctx, MethodStop, p.Session); err != nil {
+			return nil, failed(err)
+		}
+		return map[string]string{"session": p.Session}, nil
+	case "session.send":
+		return s.sendInput(p)
+	case "session.subscribe":
+		return s.subscribe(c, p)
+	}
+	return nil, &rpcError{Code: codeMethodNotFound, Message: "unknown method: " + req.Method}
+}
+
+func (s *Server) listSessions(c *client) []SessionInfo {
+	infos := make([]SessionInfo, 0)
+	for _, sess := range s.engine.ListSessions() {
+		info := SessionInfo{ID: sess.ID(), ProjectID: sess.ProjectID(), Name: sess.ID(), Status: string(sess.Status()), Tool: string(sess.Tool()), Lock: sess.InputOwner()}
+		if project, err := s.projects.Get(c.ctx, sess.ProjectID()); err == nil {
+			info.Name = project.DisplayName() + runtime.SessionSuffix(sess.ID(), project.ID)
+			info.Path = project.Path
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+func (s *Server) listProjects(c *client) ([]ProjectInfo, *rpcError) {
+	projects, err := s.projects.List(c.ctx)
+	if err != nil {
+		return nil, failed(err)
+	}
+	infos := make([]ProjectInfo, 0, len(projects))
+	for _, p := range projects {
+		infos = append(infos, ProjectInfo{
+			ID:        p.ID,
+			Name:      p.DisplayName(),
+			Path:      p.Path,
+			Workspace: p.Workspace,
+			Running:   len(s.engine.SessionsForProject(p.ID)) > 0,
+		})
+	}
+	return infos, nil
+}
+
+// sendInput types text into a session, pressing Enter after it when
+// submit is set. Sessions whose input lock is held by a remote operator
+// refuse input.
+func (s *Server) sendInput(p sessionParams) (any, *rpcError) {
+	sess, ok := s.engine.GetSession(p.Session)
+	if !ok {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "unknown session: " + p.Session}
+	}
+	if owner := sess.InputOwner(); owner != "" && owner != runtime.LocalOperator {
+		return nil, failed(errors.New("input is locked by " + owner))
+	}
+	if err := runtime.Paste(sess, []byte(p.Text), runtime.DefaultPasteOptions); err != nil {
+		return nil, failed(err)
+	}
+	if p.Submit {
+		time.Sleep(200 * time.Millisecond)
+		if _, err := sess.Write([]byte("\r")); err != nil {
+			return nil, failed(err)
+		}
+	}
+	return map[string]int{"bytes": len(p.Text)}, nil
+}
+
+// outputEvent is the params of session.output notifications. Data is the
+// base64 encoded raw PTY output, or the text without escape sequences when
+// the subscription asked for plain output.
+type outputEvent struct {
+	Session string `json:"session"`
+	Data    []byte `json:"data,omitempty"`
+	Text    string `json:"text,omitempty"`
+}
+
+// subscribe streams a session's output to the client as session.output
+// notifications, optionally starting with its history, and sends
+// session.exit when the session ends.
+func (s *Server) subscribe(c *client, p sessionParams) (any, *rpcError) {
+	sess, ok := s.engine.GetSession(p.Session)
+	if !ok {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "unknown session: " + p.Session}
+	}
+	ch, cancel := sess.Subscribe()
+	event := func(data []byte) rpcNotification {
+		ev := outputEvent{Session: p.Session}
+		if p.Plain {
+			ev.Text = ansi.Strip(string(data))
+		} else {
+			ev.Data = data
+		}
+		return rpcNotification{JSONRPC: "2.0", Method: "session.output", Params: ev}
+	}
+	var history []byte
+	if p.History {
+		history = sess.History()
+	}
+	stream := func() {
+		defer cancel()
+		if len(history) > 0 {
+			if err := c.send(event(history)); err != nil {
+				return
+			}
+		}
+		for {
+			select {
+			case <-c.ctx.Done():
+				return
+			case data, ok := <-ch:
+				if !ok {
+					_ = c.send(rpcNotification{JSONRPC: "2.0", Method: "session.exit", Params: map[string]string{"session": p.Session}})
+					return
+				}
+				if err := c.send(event(data)); err != nil {
+					return
+				}
+			}
+		}
+	}
+	// Start streaming once the response is written, so it comes first.
+	c.after = append(c.after, func() { go stream() })
+	return map[string]string{"session": p.Session}, nil
+}
@@ -0,0 +1,357 @@
+// Package control serves a local JSON-RPC 2.0 API for scripts and tools:
+// listing sessions, starting and stopping projects, sending input and
+// following output. Messages are single-line JSON objects over a unix
+// socket, or a localhost TCP port.
+package control
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lazyvibe/vibemux/internal/runtime"
+	"github.com/lazyvibe/vibemux/internal/store"
+)
+
+// maxMessage caps a single request line.
+const maxMessage = 1 << 20
+
+// replyTimeout is how long start and stop wait for the TUI.
+const replyTimeout = 10 * time.Second
+
+// TokenFile is where VibeMux keeps the TCP API's token, in the config
+// directory and readable only by the user.
+const TokenFile = "control.token"
+
+// Server is the local control server. Sessions are read and written
+// through the shared engine; starting and stopping go through the TUI so
+// its panes follow.
+type Server struct {
+	engine   *runtime.DefaultEngine
+	projects store.ProjectStore
+	requests chan Request
+
+	mu    sync.Mutex
+	ln    net.Listener
+	addr  string
+	conns map[net.Conn]struct{}
+	// token must open every TCP connection (see MethodAuth); unix sockets
+	// rely on their file permissions and need none.
+	token     string
+	tokenFile string
+}
+
+// Request asks the TUI to start or stop a session.
+type Request struct {
+	// Method is MethodStart or MethodStop.
+	Method string
+	// Target is a project ID or name to start, or a session ID to stop.
+	Target string
+	reply  chan Reply
+}
+
+// Reply is the TUI's answer to a Request.
+type Reply struct {
+	// SessionID is the session started or stopped.
+	SessionID string
+	Err       error
+}
+
+// Respond sends the answer back to the waiting client.
+func (r Request) Respond(reply Reply) {
+	r.reply <- reply
+}
+
+// NewServer creates a control server backed by the engine and project store.
+func NewServer(e *runtime.DefaultEngine, projects store.ProjectStore) *Server {
+	return &Server{
+		engine:   e,
+		projects: projects,
+		requests: make(chan Request),
+		conns:    make(map[net.Conn]struct{}),
+	}
+}
+
+// Requests returns the start and stop requests the TUI must carry out.
+func (s *Server) Requests() <-chan Request {
+	return s.requests
+}
+
+// SetTokenFile sets where Start writes the token of a TCP listener.
+func (s *Server) SetTokenFile(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokenFile = path
+}
+
+// Start listens on addr: "host:port" for TCP, anything else is a unix
+// socket path. TCP addresses must be on the loopback interface, and their
+// clients must send the token written to the token file first. A stale
+// socket file left by a crashed instance is replaced; one that still
+// answers is an error.
+func (s *Server) Start(addr string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ln != nil {
+		return errors.New("control server already running")
+	}
+	network := "unix"
+	if IsTCPAddr(addr) {
+		network = "tcp"
+		if host, _, _ := net.SplitHostPort(addr); !isLoopback(host) {
+			return errors.New("control API only listens on loopback addresses such as 127.0.0.1, not " + addr)
+		}
+	} else if _, err := os.Stat(addr); err == nil {
+		if conn, err := net.Dial("unix", addr); err == nil {
+			conn.Close()
+			return errors.New("another instance is listening on " + addr)
+		}
+		_ = os.Remove(addr)
+	}
+	var ln net.Listener
+	var err error
+	if network == "unix" {
+		ln, err = listenUnix(addr)
+	} else {
+		ln, err = net.Listen(network, addr)
+	}
+	if err != nil {
+		return err
+	}
+	if network == "tcp" {
+		token, err := newToken()
+		if err == nil && s.tokenFile != "" {
+			err = os.WriteFile(s.tokenFile, []byte(token+"\n"), 0o600)
+		}
+		if err != nil {
+			ln.Close()
+			return err
+		}
+		s.token = token
+	}
+	s.ln = ln
+	s.addr = ln.Addr().String()
+	if network == "unix" {
+		s.addr = addr
+	}
+	go s.serve(ln)
+	return nil
+}
+
+// listenUnix binds a unix socket at path that only the user can connect
+// to. The socket is made in a private directory and moved to path once
+// its mode is set, so other users never get a window to connect.
+func listenUnix(path string) (net.Listener, error) {
+	if goruntime.GOOS == "windows" {
+		return net.Listen("unix", path)
+	}
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".vibemux-control-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	tmp := filepath.Join(dir, "sock")
+	ln, err := net.Listen("unix", tmp)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.Chmod(tmp, 0o600); err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		ln.Close()
+		return nil, err
+	}
+	return &unixListener{Listener: ln, path: path}, nil
+}
+
+// unixListener removes its socket file on Close. The net package would
+// only remove the name the socket was created under.
+type unixListener struct {
+	net.Listener
+	path string
+}
+
+func (l *unixListener) Close() error {
+	err := l.Listener.Close()
+	_ = os.Remove(l.path)
+	return err
+}
+
+// IsTCPAddr reports whether addr is a host:port pair rather than a socket
+// path.
+func IsTCPAddr(addr string) bool {
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	_, err = strconv.Atoi(port)
+	return err == nil
+}
+
+// isLoopback reports whether host names the loopback interface.
+func isLoopback(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// validToken reports whether token opens connections to the server.
+func (s *Server) validToken(token string) bool {
+	s.mu.Lock()
+	want := s.token
+	s.mu.Unlock()
+	return want == "" || subtle.ConstantTimeCompare([]byte(token), []byte(want)) == 1
+}
+
+// Addr returns the bound address, or "" when not running.
+func (s *Server) Addr() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addr
+}
+
+// Close stops the server and drops its clients.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	ln := s.ln
+	s.ln = nil
+	s.addr = ""
+	for conn := range s.conns {
+		conn.Close()
+	}
+	if s.token != "" && s.tokenFile != "" {
+		_ = os.Remove(s.tokenFile)
+	}
+	s.token = ""
+	s.mu.Unlock()
+
+	if ln == nil {
+		return nil
+	}
+	return ln.Close()
+}
+
+func (s *Server) serve(ln net.Listener) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+		go s.handleConn(conn)
+	}
+}
+
+// client is one connection. Responses and notifications from
+// subscriptions share it, so writes are serialized.
+type client struct {
+	mu    sync.Mutex
+	enc   *json.Encoder
+	ctx   context.Context
+	after []func() // Run once the current response is written
+	// authed is set once the connection sent the server's token.
+	authed bool
+}
+
+func (c *client) send(v any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.enc.Encode(v)
+}
+
+// handleConn answers the requests of one connection, one per line. A
+// line that is not JSON-RPC ends the connection, so a browser cannot
+// smuggle a request past its HTTP headers in a form post.
+func (s *Server) handleConn(conn net.Conn) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		conn.Close()
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+	}()
+
+	c := &client{enc: json.NewEncoder(conn), ctx: ctx, authed: s.validToken("")}
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxMessage)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var req rpcRequest
+		if err := json.Unmarshal(line, &req); err != nil {
+			_ = c.send(errorResponse(nil, codeParseError, "parse error: "+err.Error()))
+			return
+		}
+		if !c.authed && req.Method != MethodAuth {
+			_ = c.send(errorResponse(req.ID, codeUnauthorized, "send "+MethodAuth+" with the token first"))
+			return
+		}
+		result, rpcErr := s.call(c, req)
+		if !c.authed {
+			_ = c.send(errorResponse(req.ID, codeUnauthorized, "invalid token"))
+			return
+		}
+		// Requests without an ID are notifications and get no response.
+		if req.ID != nil {
+			resp := rpcResponse{JSONRPC: "2.0", ID: req.ID, Result: result, Error: rpcErr}
+			if err := c.send(resp); err != nil {
+				return
+			}
+		}
+		for _, fn := range c.after {
+			fn()
+		}
+		c.after = nil
+	}
+}
+
+// ask hands a request to the TUI and waits for its answer.
+func (s *Server) ask(ctx context.Context, method, target string) (Reply, error) {
+	req := Request{Method: method, Target: target, reply: make(chan Reply, 1)}
+	timer := time.NewTimer(replyTimeout)
+	defer timer.Stop()
+	select {
+	case s.requests <- req:
+	case <-timer.C:
+		return Reply{}, errors.New("vibemux is busy")
+	case <-ctx.Done():
+		return Reply{}, ctx.Err()
+	}
+	select {
+	case reply := <-req.reply:
+		return reply, reply.Err
+	case <-timer.C:
+		return Reply{}, errors.New("no answer from vibemux")
+	case <-ctx.Done():
+		return Reply{}, ctx.Err()
+	}
+}
@@ -0,0 +1,169 @@
+package control
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lazyvibe/vibemux/internal/runtime"
+)
+
+// startTCP starts a server on a free loopback port and returns its address
+// and token.
+func startTCP(t *testing.T) (string, string) {
+	t.Helper()
+	srv := NewServer(runtime.NewEngine(), nil)
+	tokenFile := filepath.Join(t.TempDir(), TokenFile)
+	srv.SetTokenFile(tokenFile)
+	if err := srv.Start("127.0.0.1:0"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { srv.Close() })
+	token, err := os.ReadFile(tokenFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srv.Addr(), strings.TrimSpace(string(token))
+}
+
+type testConn struct {
+	t    *testing.T
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func dial(t *testing.T, addr string) *testConn {
+	t.Helper()
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	return &testConn{t: t, conn: conn, r: bufio.NewReader(conn)}
+}
+
+// call sends one request and returns its response.
+func (c *testConn) call(method string, params any) rpcResponse {
+	c.t.Helper()
+	req := map[string]any{"jsonrpc": "2.0", "id": 1, "method": method}
+	if params != nil {
+		req["params"] = params
+	}
+	if err := json.NewEncoder(c.conn).Encode(req); err != nil {
+		c.t.Fatal(err)
+	}
+	line, err := c.r.ReadBytes('\n')
+	if err != nil {
+		c.t.Fatalf("%s: no response: %v", method, err)
+	}
+	var resp rpcResponse
+	if err := json.Unmarshal(line, &resp); err != nil {
+		c.t.Fatal(err)
+	}
+	return resp
+}
+
+// closed reports whether the server hung up.
+func (c *testConn) closed() bool {
+	_, err := c.r.ReadByte()
+	return err != nil
+}
+
+func TestTCPRequiresTokenFirst(t *testing.T) {
+	addr, _ := startTCP(t)
+	c := dial(t, addr)
+	resp := c.call("sessions.list", nil)
+	if resp.Error == nil || resp.Error.Code != codeUnauthorized {
+		t.Fatalf("unauthenticated call got %+v, want unauthorized", resp)
+	}
+	if !c.closed() {
+		t.Fatal("connection stayed open after an unauthenticated call")
+	}
+}
+
+func TestTCPRejectsWrongToken(t *testing.T) {
+	addr, _ := startTCP(t)
+	c := dial(t, addr)
+	resp := c.call(MethodAuth, map[string]string{"token": "wrong"})
+	if resp.Error == nil || resp.Error.Code != codeUnauthorized {
+		t.Fatalf("wrong token got %+v, want unauthorized", resp)
+	}
+	if !c.closed() {
+		t.Fatal("connection stayed open after a wrong token")
+	}
+}
+
+func TestTCPAcceptsToken(t *testing.T) {
+	addr, token := startTCP(t)
+	c := dial(t, addr)
+	if resp := c.call(MethodAuth, map[string]string{"token": token}); resp.Error != nil {
+		t.Fatalf("auth failed: %+v", resp.Error)
+	}
+	if resp := c.call("sessions.list", nil); resp.Error != nil {
+		t.Fatalf("sessions.list after auth failed: %+v", resp.Error)
+	}
+}
+
+func TestNonJSONLineClosesConnection(t *testing.T) {
+	addr, _ := startTCP(t)
+	c := dial(t, addr)
+	if _, err := c.conn.Write([]byte("POST / HTTP/1.1\r\n")); err != nil {
+		t.Fatal(err)
+	}
+	line, _ := c.r.ReadBytes('\n')
+	var resp rpcResponse
+	if err := json.Unmarshal(line, &resp); err != nil || resp.Error == nil || resp.Error.Code != codeParseError {
+		t.Fatalf("got %q, want a parse error", line)
+	}
+	if !c.closed() {
+		t.Fatal("connection stayed open after a non-JSON line")
+	}
+}
+
+func TestTCPOnlyOnLoopback(t *testing.T) {
+	srv := NewServer(runtime.NewEngine(), nil)
+	if err := srv.Start("0.0.0.0:0"); err == nil {
+		srv.Close()
+		t.Fatal("listening beyond loopback was allowed")
+	}
+}
+
+func TestUnixSocketIsPrivate(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("unix socket permissions are not enforced on Windows")
+	}
+	path := filepath.Join(t.TempDir(), "control.sock")
+	srv := NewServer(runtime.NewEngine(), nil)
+	if err := srv.Start(path); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("socket mode = %o, want 600", perm)
+	}
+	if srv.Addr() != path {
+		t.Errorf("Addr() = %q, want %q", srv.Addr(), path)
+	}
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+	srv.Close()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("socket file left behind after Close")
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 0 {
+		t.Errorf("leftover files: %v", entries)
+	}
+}
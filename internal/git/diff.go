@@ -0,0 +1,136 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileDiff is the diff of one file.
+type FileDiff struct {
+	// Path is the file's path in the repository; for deleted files, the
+	// path it had.
+	Path string
+	// OldPath is the path before a rename; empty otherwise.
+	OldPath string
+	// Status is "A" (added), "D" (deleted), "R" (renamed) or "M".
+	Status  string
+	Added   int
+	Removed int
+	Binary  bool
+	// Lines are the diff's lines from the "diff --git" header on.
+	Lines []string
+}
+
+// Snapshot records the work tree of the repository containing dir,
+// tracked and untracked files alike (ignored files excepted), and returns
+// the ID of the tree it wrote. The file contents are stored as git objects;
+// the repository's index, branches and stash are left alone.
+func Snapshot(ctx context.Context, dir string) (string, error) {
+	indexPath, err := run(ctx, dir, "rev-parse", "--git-path", "index")
+	if err != nil {
+		return "", err
+	}
+	indexPath = strings.TrimSpace(indexPath)
+	if !filepath.IsAbs(indexPath) {
+		indexPath = filepath.Join(dir, indexPath)
+	}
+
+	// Staging into a copy of the index keeps the real one untouched, and
+	// starting from it lets git skip files whose stat data is unchanged.
+	tmp, err := os.CreateTemp("", "vibemux-index-*")
+	if err != nil {
+		return "", err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+	data, err := os.ReadFile(indexPath)
+	if err == nil {
+		_, err = tmp.Write(data)
+	} else if os.IsNotExist(err) {
+		// A repository without commits may have no index yet; git wants
+		// a missing file rather than an empty one.
+		err = os.Remove(tmpPath)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+
+	env := []string{"GIT_INDEX_FILE=" + tmpPath}
+	if _, err := runEnv(ctx, dir, env, "add", "--all"); err != nil {
+		return "", err
+	}
+	tree, err := runEnv(ctx, dir, env, "write-tree")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(tree), nil
+}
+
+// DiffSince returns what changed in the work tree of the repository
+// containing dir since the snapshot base was taken, file by file.
+func DiffSince(ctx context.Context, dir, base string) ([]FileDiff, error) {
+	now, err := Snapshot(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	out, err := run(ctx, dir, "diff", "--no-color", "--no-ext-diff", "--find-renames", base, now)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDiff(out), nil
+}
+
+// ParseDiff splits the output of git diff into files.
+func ParseDiff(out string) []FileDiff {
+	var files []FileDiff
+	var cur *FileDiff
+	inHunk := false
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			files = append(files, FileDiff{Status: "M", Path: diffHeaderPath(line)})
+			cur = &files[len(files)-1]
+			inHunk = false
+		}
+		if cur == nil {
+			continue
+		}
+		cur.Lines = append(cur.Lines, line)
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+		case inHunk && strings.HasPrefix(line, "+"):
+			cur.Added++
+		case inHunk && strings.HasPrefix(line, "-"):
+			cur.Removed++
+		case inHunk:
+		case strings.HasPrefix(line, "new file mode"):
+			cur.Status = "A"
+		case strings.HasPrefix(line, "deleted file mode"):
+			cur.Status = "D"
+		case strings.HasPrefix(line, "rename from "):
+			cur.Status = "R"
+			cur.OldPath = strings.TrimPrefix(line, "rename from ")
+		case strings.HasPrefix(line, "rename to "):
+			cur.Path = strings.TrimPrefix(line, "rename to ")
+		case strings.HasPrefix(line, "Binary files "):
+			cur.Binary = true
+		}
+	}
+	return files
+}
+
+// diffHeaderPath takes the new path from "diff --git a/<path> b/<path>".
+// Paths with spaces are ambiguous there; the rename lines correct renamed
+// ones.
+func diffHeaderPath(line string) string {
+	rest := strings.TrimPrefix(line, "diff --git ")
+	if i := strings.LastIndex(rest, " b/"); i >= 0 {
+		return strings.Trim(rest[i+3:], `"`)
+	}
+	return rest
+}
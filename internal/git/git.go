@@ -0,0 +1,161 @@
+// Package git reads and manages the git repositories projects live in.
+// It runs the git command line rather than reading .git itself, so it
+// sees the same state agents do.
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// ErrNotRepository is returned for a directory outside any git work tree.
+var ErrNotRepository = errors.New("not a git repository")
+
+// Status is the state of a work tree: its branch, how far it is from its
+// upstream and how many files have changed.
+type Status struct {
+	// Branch is the checked-out branch; empty when HEAD is detached.
+	Branch string
+	// Commit is the abbreviated HEAD commit; empty before the first commit.
+	Commit string
+	// Upstream is the branch's upstream, e.g. "origin/main"; empty when
+	// none is set.
+	Upstream string
+	Ahead    int
+	Behind   int
+	// Staged, Modified, Untracked and Conflicts count files.
+	Staged    int
+	Modified  int
+	Untracked int
+	Conflicts int
+}
+
+// Dirty reports whether the work tree has uncommitted changes.
+func (s Status) Dirty() bool {
+	return s.Staged+s.Modified+s.Untracked+s.Conflicts > 0
+}
+
+// Changes returns how many files have uncommitted changes.
+func (s Status) Changes() int {
+	return s.Staged + s.Modified + s.Untracked + s.Conflicts
+}
+
+// Head returns the branch name, or the commit when HEAD is detached.
+func (s Status) Head() string {
+	switch {
+	case s.Branch != "":
+		return s.Branch
+	case s.Commit != "":
+		return "@" + s.Commit
+	}
+	return "(no commits)"
+}
+
+// Summary describes the changes in words, e.g. "2 staged, 1 modified".
+func (s Status) Summary() string {
+	if !s.Dirty() {
+		return "clean"
+	}
+	var parts []string
+	for _, c := range []struct {
+		n    int
+		what string
+	}{{s.Conflicts, "conflicted"}, {s.Staged, "staged"}, {s.Modified, "modified"}, {s.Untracked, "untracked"}} {
+		if c.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", c.n, c.what))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ReadStatus returns the status of the work tree containing dir.
+func ReadStatus(ctx context.Context, dir string) (Status, error) {
+	out, err := run(ctx, dir, "status", "--porcelain=v2", "--branch")
+	if err != nil {
+		return Status{}, err
+	}
+	return parseStatus(out), nil
+}
+
+// parseStatus reads the output of git status --porcelain=v2 --branch.
+func parseStatus(out string) Status {
+	var s Status
+	for _, line := range strings.Split(out, "\n") {
+		if line == "" {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(line, "# "); ok {
+			key, value, _ := strings.Cut(rest, " ")
+			switch key {
+			case "branch.oid":
+				if value != "(initial)" && len(value) >= 7 {
+					s.Commit = value[:7]
+				}
+			case "branch.head":
+				if value != "(detached)" {
+					s.Branch = value
+				}
+			case "branch.upstream":
+				s.Upstream = value
+			case "branch.ab":
+				ahead, behind, _ := strings.Cut(value, " ")
+				s.Ahead, _ = strconv.Atoi(strings.TrimPrefix(ahead, "+"))
+				s.Behind, _ = strconv.Atoi(strings.TrimPrefix(behind, "-"))
+			}
+			continue
+		}
+		switch line[0] {
+		case '1', '2':
+			// "1 XY ...": X is the index, Y the work tree; "." is unchanged.
+			if len(line) < 4 {
+				continue
+			}
+			if line[2] != '.' {
+				s.Staged++
+			}
+			if line[3] != '.' {
+				s.Modified++
+			}
+		case 'u':
+			s.Conflicts++
+		case '?':
+			s.Untracked++
+		}
+	}
+	return s
+}
+
+// run runs git in dir and returns its output. Optional locks are skipped
+// so polling never gets in the way of an agent's own git commands.
+func run(ctx context.Context, dir string, args ...string) (string, error) {
+	return runEnv(ctx, dir, nil, args...)
+}
+
+// runEnv is run with env added to git's environment.
+func runEnv(ctx context.Context, dir string, env []string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"--no-optional-locks"}, args...)...)
+	cmd.Dir = dir
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if strings.Contains(msg, "not a git repository") {
+			return "", ErrNotRepository
+		}
+		if line, _, _ := strings.Cut(msg, "\n"); line != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], line)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,113 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Worktree is a linked work tree of a repository with its own branch.
+type Worktree struct {
+	// Root is the top directory of the work tree.
+	Root string
+	// Dir is the directory in the work tree that corresponds to the one
+	// it was created from, which may be below the repository's top.
+	Dir string
+	// Branch is the branch checked out in the work tree.
+	Branch string
+}
+
+// AddWorktree creates a work tree for the repository containing dir, on a
+// new branch starting at dir's HEAD. Work trees live next to the
+// repository in "<repo>.worktrees/", in a directory named after the last
+// part of the branch. A number is added to both when the branch or the
+// directory already exists.
+func AddWorktree(ctx context.Context, dir, branch string) (Worktree, error) {
+	out, err := run(ctx, dir, "rev-parse", "--show-toplevel", "--show-prefix")
+	if err != nil {
+		return Worktree{}, err
+	}
+	top, prefix, _ := strings.Cut(strings.TrimRight(out, "\n"), "\n")
+	top = filepath.FromSlash(top)
+	parent := filepath.Join(filepath.Dir(top), filepath.Base(top)+".worktrees")
+
+	for n := 1; ; n++ {
+		name := branch
+		if n > 1 {
+			name += "-" + strconv.Itoa(n)
+		}
+		root := filepath.Join(parent, path.Base(name))
+		if _, err := os.Stat(root); err == nil {
+			continue
+		}
+		if _, err := run(ctx, dir, "rev-parse", "--verify", "--quiet", "refs/heads/"+name); err == nil {
+			continue
+		}
+		if err := os.MkdirAll(parent, 0o755); err != nil {
+			return Worktree{}, err
+		}
+		if _, err := run(ctx, dir, "worktree", "add", "-b", name, root, "HEAD"); err != nil {
+			return Worktree{}, err
+		}
+		return Worktree{
+			Root:   root,
+			Dir:    filepath.Join(root, filepath.FromSlash(prefix)),
+			Branch: name,
+		}, nil
+	}
+}
+
+// RemoveWorktree deletes a work tree, uncommitted changes included, and its
+// branch. dir is any directory of the repository outside the work tree.
+func RemoveWorktree(ctx context.Context, dir string, wt Worktree) error {
+	if _, err := run(ctx, dir, "worktree", "remove", "--force", wt.Root); err != nil {
+		return err
+	}
+	_ = os.Remove(filepath.Dir(wt.Root)) // Only succeeds once the last one is gone
+	_, err := run(ctx, dir, "branch", "-D", wt.Branch)
+	return err
+}
+
+// CommitAll commits every change in the work tree containing dir, untracked
+// files included, with message. It reports whether there was anything to
+// commit.
+func CommitAll(ctx context.Context, dir, message string) (bool, error) {
+	status, err := ReadStatus(ctx, dir)
+	if err != nil || !status.Dirty() {
+		return false, err
+	}
+	if _, err := run(ctx, dir, "add", "--all"); err != nil {
+		return false, err
+	}
+	if _, err := run(ctx, dir, "commit", "--quiet", "-m", message); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Merge merges branch into the branch checked out in dir's work tree, which
+// must have no uncommitted changes to tracked files. A merge that runs into
+// conflicts is aborted, leaving the work tree as it was.
+func Merge(ctx context.Context, dir, branch string) error {
+	status, err := ReadStatus(ctx, dir)
+	if err != nil {
+		return err
+	}
+	if status.Staged+status.Modified+status.Conflicts > 0 {
+		return fmt.Errorf("%s has uncommitted changes; commit or stash them before merging %s", dir, branch)
+	}
+	_, err = run(ctx, dir, "merge", "--no-edit", branch)
+	if err == nil {
+		return nil
+	}
+	if status, statusErr := ReadStatus(ctx, dir); statusErr == nil && status.Conflicts > 0 {
+		_, abortErr := run(ctx, dir, "merge", "--abort")
+		return errors.Join(fmt.Errorf("%s conflicts with %s; merge it by hand", branch, status.Head()), abortErr)
+	}
+	return err
+}
@@ -0,0 +1,137 @@
+// Package hooks runs user scripts on session events. A hook reads the event
+// as JSON on stdin; whatever it prints is typed back into the session, so a
+// script can drive an agent in a loop.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/lazyvibe/vibemux/pkg/utils"
+)
+
+// Events hooks can be attached to.
+const (
+	// SessionStarted fires once a session's process is running.
+	SessionStarted = "session_started"
+	// TaskCompleted fires when an agent reports a finished task.
+	TaskCompleted = "task_completed"
+	// InputRequired fires when an agent asks a question or for approval.
+	InputRequired = "input_required"
+	// TurnAdvanced fires when auto-turn hands the turn to a pane.
+	TurnAdvanced = "turn_advanced"
+)
+
+// DefaultTimeout is how long a hook may run before it is killed.
+const DefaultTimeout = 30 * time.Second
+
+// maxResponse caps what is read from a hook's output.
+const maxResponse = 64 << 10
+
+// Hook is a script run on an event, configured in config.json.
+type Hook struct {
+	// Event is session_started, task_completed, input_required or
+	// turn_advanced.
+	Event string `json:"event"`
+	// Command is the command line to run, split like a profile command
+	// (quotes group words; no shell). A leading ~ is expanded.
+	Command string `json:"command"`
+	// Project limits the hook to one project, by name or ID. Empty runs it
+	// for every project.
+	Project string `json:"project,omitempty"`
+	// TimeoutSeconds overrides DefaultTimeout.
+	TimeoutSeconds int `json:"timeout_seconds,omitempty"`
+}
+
+// Payload is the JSON a hook reads on stdin.
+type Payload struct {
+	Event     string `json:"event"`
+	Project   string `json:"project"`
+	ProjectID string `json:"project_id"`
+	// Session is the pane's session ID, which differs from ProjectID for
+	// extra sessions of a project.
+	Session string `json:"session"`
+	// Path is the project directory.
+	Path string `json:"path"`
+	// WorkDir is the session's working directory, its worktree or
+	// subdirectory when it has one; the hook runs there.
+	WorkDir string `json:"workdir"`
+	// Message is the question for input_required and the turn message for
+	// turn_advanced.
+	Message string `json:"message,omitempty"`
+	// Text is the conclusion extracted from the pane for task_completed.
+	Text      string    `json:"text,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Matches reports whether h runs for event in the project with the given ID
+// and name.
+func (h Hook) Matches(event, projectID, projectName string) bool {
+	if h.Event != event || strings.TrimSpace(h.Command) == "" {
+		return false
+	}
+	return h.Project == "" || h.Project == projectID || strings.EqualFold(h.Project, projectName)
+}
+
+// Timeout returns how long the hook may run.
+func (h Hook) Timeout() time.Duration {
+	if h.TimeoutSeconds > 0 {
+		return time.Duration(h.TimeoutSeconds) * time.Second
+	}
+	return DefaultTimeout
+}
+
+// Run runs the hook in p.WorkDir (p.Path when empty) with p as JSON on stdin and returns its
+// output, trimmed. A hook that exits with an error has its first line of
+// stderr in the error.
+func Run(ctx context.Context, h Hook, p Payload) (string, error) {
+	args, err := utils.SplitCommandLine(h.Command)
+	if err != nil {
+		return "", err
+	}
+	if len(args) == 0 {
+		return "", errors.New("empty hook command")
+	}
+	input, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.Timeout())
+	defer cancel()
+	name := args[0]
+	if strings.HasPrefix(name, "~") {
+		name = utils.ExpandPath(name)
+	}
+	cmd := exec.CommandContext(ctx, name, args[1:]...)
+	cmd.Dir = p.WorkDir
+	if cmd.Dir == "" {
+		cmd.Dir = p.Path
+	}
+	cmd.Env = append(os.Environ(), "VIBEMUX_EVENT="+p.Event, "VIBEMUX_PROJECT="+p.Project, "VIBEMUX_SESSION="+p.Session)
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", fmt.Errorf("timed out after %s", h.Timeout())
+		}
+		if line, _, _ := strings.Cut(strings.TrimSpace(stderr.String()), "\n"); line != "" {
+			return "", fmt.Errorf("%w: %s", err, line)
+		}
+		return "", err
+	}
+	out := stdout.Bytes()
+	if len(out) > maxResponse {
+		out = out[:maxResponse]
+	}
+	return strings.TrimSpace(string(out)), nil
+}
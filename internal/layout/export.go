@@ -0,0 +1,170 @@
+// Package layout converts VibeMux pane layouts to and from other tools.
+package layout
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Pane describes one open pane of the current layout.
+type Pane struct {
+	Name string
+	Dir  string
+	// Command is the program and arguments the pane runs.
+	Command []string
+	Env     map[string]string
+}
+
+// Format is a supported export format.
+type Format string
+
+const (
+	FormatTmuxinator Format = "tmuxinator"
+	FormatZellij     Format = "zellij"
+)
+
+// ParseFormat maps user input to an export format.
+func ParseFormat(input string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "tmux", "tmuxinator":
+		return FormatTmuxinator, nil
+	case "zellij", "kdl":
+		return FormatZellij, nil
+	}
+	return "", fmt.Errorf("unknown layout format %q (use tmux or zellij)", input)
+}
+
+// Extension returns the conventional file extension for the format.
+func (f Format) Extension() string {
+	if f == FormatZellij {
+		return ".kdl"
+	}
+	return ".yml"
+}
+
+// Export renders the panes in the given format. Panes are laid out row by row
+// in a rows x cols grid, matching the VibeMux grid order.
+func Export(f Format, name string, panes []Pane, rows, cols int) string {
+	if f == FormatZellij {
+		return Zellij(panes, rows, cols)
+	}
+	return Tmuxinator(name, panes)
+}
+
+// Tmuxinator renders a tmuxinator project file with one tiled window.
+func Tmuxinator(name string, panes []Pane) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "name: %s\n", yamlQuote(name))
+	b.WriteString("root: ~/\n")
+	b.WriteString("windows:\n")
+	b.WriteString("  - agents:\n")
+	b.WriteString("      layout: tiled\n")
+	b.WriteString("      panes:\n")
+	for _, p := range panes {
+		fmt.Fprintf(&b, "        - %s:\n", yamlQuote(p.Name))
+		fmt.Fprintf(&b, "          - %s\n", yamlQuote("cd "+shellQuote(p.Dir)))
+		fmt.Fprintf(&b, "          - %s\n", yamlQuote(envPrefix(p.Env)+shellJoin(p.Command)))
+	}
+	return b.String()
+}
+
+// Zellij renders a zellij KDL layout reproducing the grid.
+func Zellij(panes []Pane, rows, cols int) string {
+	if cols < 1 {
+		cols = 1
+	}
+	if rows < 1 {
+		rows = (len(panes) + cols - 1) / cols
+	}
+
+	var b strings.Builder
+	b.WriteString("layout {\n")
+	b.WriteString("    pane split_direction=\"horizontal\" {\n")
+	for start := 0; start < len(panes); start += cols {
+		end := start + cols
+		if end > len(panes) {
+			end = len(panes)
+		}
+		b.WriteString("        pane split_direction=\"vertical\" {\n")
+		for _, p := range panes[start:end] {
+			writeZellijPane(&b, p)
+		}
+		b.WriteString("        }\n")
+	}
+	b.WriteString("    }\n")
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func writeZellijPane(b *strings.Builder, p Pane) {
+	command := "claude"
+	var args []string
+	if len(p.Command) > 0 {
+		command = p.Command[0]
+		args = p.Command[1:]
+	}
+	if len(p.Env) > 0 {
+		// zellij has no per-pane env, so route through env(1).
+		args = append(envArgs(p.Env), append([]string{command}, args...)...)
+		command = "env"
+	}
+	fmt.Fprintf(b, "            pane name=%s cwd=%s command=%s", kdlQuote(p.Name), kdlQuote(p.Dir), kdlQuote(command))
+	if len(args) == 0 {
+		b.WriteString("\n")
+		return
+	}
+	b.WriteString(" {\n                args")
+	for _, arg := range args {
+		b.WriteString(" " + kdlQuote(arg))
+	}
+	b.WriteString("\n            }\n")
+}
+
+func envArgs(env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := make([]string, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, k+"="+env[k])
+	}
+	return out
+}
+
+func envPrefix(env map[string]string) string {
+	var b strings.Builder
+	for _, kv := range envArgs(env) {
+		b.WriteString(shellQuote(kv) + " ")
+	}
+	return b.String()
+}
+
+// shellJoin renders argv as a shell command line, quoting the arguments a
+// shell would split or expand.
+func shellJoin(argv []string) string {
+	words := make([]string, len(argv))
+	for i, arg := range argv {
+		words[i] = arg
+		if arg == "" || strings.ContainsAny(arg, " \t\n'\"\\$`*?;&|<>()#~") {
+			words[i] = shellQuote(arg)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func yamlQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func kdlQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
@@ -1,6 +1,9 @@
 package model
 
 import (
+	"strings"
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -10,20 +13,87 @@ type Profile struct {
 	ID string `json:"id"`
 	// Name is the display name (e.g., "Work-Strict", "Personal-Haiku").
 	Name string `json:"name"`
-	// Driver specifies the launch method (native/ccr/custom).
+	// Driver specifies the launch method
+	// (native/ccr/custom/codex/gemini/container).
 	Driver DriverType `json:"driver"`
 	// Command is the base command to execute (e.g., "claude", "codex").
 	Command string `json:"command"`
 	// CommandArgs are additional arguments passed to the command.
 	CommandArgs []string `json:"command_args,omitempty"`
+	// Agent holds the model and sandbox settings of tool drivers.
+	Agent AgentOptions `json:"agent,omitempty"`
+	// Container configures the container driver.
+	Container ContainerConfig `json:"container,omitempty"`
 	// EnvVars are environment variables injected into the process.
 	EnvVars map[string]string `json:"env_vars,omitempty"`
 	// AutoApprove sets the automatic approval level.
 	AutoApprove AutoApproveLevel `json:"auto_approve"`
+	// Approval lists the commands approved or refused without asking.
+	Approval ApprovalRules `json:"approval,omitempty"`
 	// Notification configures alert settings.
 	Notification NotificationConfig `json:"notification"`
 	// IsDefault marks this as the default profile for new projects.
 	IsDefault bool `json:"is_default"`
+	// StartupSteps are sent to the session, in order, once the CLI is ready.
+	StartupSteps []StartupStep `json:"startup_steps,omitempty"`
+	// Turn customizes the auto-turn prompt, completion detection and timeout.
+	Turn TurnConfig `json:"turn,omitempty"`
+	// Extraction decides how conclusions are read from the CLI's output.
+	Extraction ExtractionConfig `json:"extraction,omitempty"`
+	// Watchdog detects a CLI stuck in its working state.
+	Watchdog WatchdogConfig `json:"watchdog,omitempty"`
+	// CtrlC decides what Ctrl+C typed in terminal mode does.
+	CtrlC CtrlCPolicy `json:"ctrl_c,omitempty"`
+	// SessionLog overrides the global session log setting when set.
+	SessionLog *bool `json:"session_log,omitempty"`
+	// Restart decides whether sessions are started again after they exit.
+	Restart RestartConfig `json:"restart,omitempty"`
+	// Stop decides how sessions are asked to exit before they are killed.
+	Stop StopConfig `json:"stop,omitempty"`
+	// Shared marks a read-only profile from the shared config source.
+	Shared bool `json:"-"`
+}
+
+// StartupStep is one line typed into a session after launch.
+type StartupStep struct {
+	// Send is the text submitted (followed by Enter), e.g. "/model opus".
+	Send string `json:"send"`
+	// DelayMs is how long to wait before sending this step.
+	DelayMs int `json:"delay_ms,omitempty"`
+}
+
+// ParseStartupSteps parses a ";"-separated step list such as
+// "/model opus; 2s; /permissions". Durations with a unit, such as "2s" or
+// "500ms", are pauses added to the delay of the following step; a bare
+// number like "0" is sent as a step.
+func ParseStartupSteps(input string) []StartupStep {
+	var steps []StartupStep
+	delay := 0
+	for _, part := range strings.Split(input, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(part); err == nil && d >= 0 && strings.TrimLeft(part, "+-0123456789.") != "" {
+			delay += int(d / time.Millisecond)
+			continue
+		}
+		steps = append(steps, StartupStep{Send: part, DelayMs: delay})
+		delay = 0
+	}
+	return steps
+}
+
+// FormatStartupSteps is the inverse of ParseStartupSteps.
+func FormatStartupSteps(steps []StartupStep) string {
+	parts := make([]string, 0, len(steps)*2)
+	for _, step := range steps {
+		if step.DelayMs > 0 {
+			parts = append(parts, (time.Duration(step.DelayMs) * time.Millisecond).String())
+		}
+		parts = append(parts, step.Send)
+	}
+	return strings.Join(parts, "; ")
 }
 
 // NewProfile creates a new profile with sensible defaults.
@@ -76,6 +146,19 @@ func (p *Profile) GetEnvSlice() []string {
 	return result
 }
 
+// Tool returns the driver the profile's sessions run with: its Driver when
+// that is a tool driver, else the tool its command names. Container
+// profiles report the tool running inside the container.
+func (p *Profile) Tool() DriverType {
+	switch p.Driver {
+	case DriverCodex, DriverGemini:
+		return p.Driver
+	case DriverNative, DriverContainer, "":
+		return ToolForCommand(p.Command)
+	}
+	return p.Driver
+}
+
 // Clone creates a deep copy of the profile with a new ID and name.
 func (p *Profile) Clone(newName string) *Profile {
 	newEnv := make(map[string]string, len(p.EnvVars))
@@ -84,6 +167,11 @@ func (p *Profile) Clone(newName string) *Profile {
 	}
 	newArgs := make([]string, len(p.CommandArgs))
 	copy(newArgs, p.CommandArgs)
+	var sessionLog *bool
+	if p.SessionLog != nil {
+		v := *p.SessionLog
+		sessionLog = &v
+	}
 
 	return &Profile{
 		ID:           uuid.New().String(),
@@ -91,9 +179,23 @@ func (p *Profile) Clone(newName string) *Profile {
 		Driver:       p.Driver,
 		Command:      p.Command,
 		CommandArgs:  newArgs,
+		Agent:        p.Agent,
+		Container:    p.Container.Clone(),
 		EnvVars:      newEnv,
 		AutoApprove:  p.AutoApprove,
+		Approval:     p.Approval.Clone(),
 		Notification: p.Notification,
 		IsDefault:    false,
+		StartupSteps: append([]StartupStep(nil), p.StartupSteps...),
+		Turn:         p.Turn,
+		Extraction:   p.Extraction,
+		Watchdog:     p.Watchdog,
+		CtrlC:        p.CtrlC,
+		SessionLog:   sessionLog,
+		Restart:      p.Restart,
+		Stop: StopConfig{
+			Sequence:     append([]string(nil), p.Stop.Sequence...),
+			GraceSeconds: p.Stop.GraceSeconds,
+		},
 	}
 }
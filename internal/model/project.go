@@ -1,11 +1,15 @@
 package model
 
 import (
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// DefaultReadyPattern matches the idle banners of common agent CLIs.
+const DefaultReadyPattern = `(?i)(\? for shortcuts|welcome to claude|openai codex|type your message|send a message)`
+
 // Project represents a managed project directory.
 type Project struct {
 	// ID is the unique identifier for this project.
@@ -20,6 +24,20 @@ type Project struct {
 	LastUsed int64 `json:"last_used"`
 	// CreatedAt is when the project was added.
 	CreatedAt int64 `json:"created_at"`
+	// InitialPrompt is sent once the agent CLI is ready after launch.
+	InitialPrompt string `json:"initial_prompt,omitempty"`
+	// ReadyPattern is a regex matched against the CLI banner to detect
+	// readiness for InitialPrompt. Empty uses the built-in pattern.
+	ReadyPattern string `json:"ready_pattern,omitempty"`
+	// RecentSubdirs lists subdirectories recently used as session cwd,
+	// most recent first.
+	RecentSubdirs []string `json:"recent_subdirs,omitempty"`
+	// Workspace groups projects (e.g. per client). A workspace can have its
+	// own default profile, used when ProfileID is empty.
+	Workspace string `json:"workspace,omitempty"`
+	// Worktree starts every session in a git worktree of its own, on a new
+	// branch, so sessions of the same repository do not share files.
+	Worktree bool `json:"worktree,omitempty"`
 }
 
 // NewProject creates a new project with a generated UUID.
@@ -34,11 +52,47 @@ func NewProject(name, path string) *Project {
 	}
 }
 
+// Duplicate returns a copy of the project with a new ID and name, keeping
+// its path, profile and other settings.
+func (p *Project) Duplicate(name string) *Project {
+	dup := *p
+	dup.RecentSubdirs = append([]string(nil), p.RecentSubdirs...)
+	now := time.Now().Unix()
+	dup.ID = uuid.New().String()
+	dup.Name = name
+	dup.CreatedAt = now
+	dup.LastUsed = now
+	return &dup
+}
+
+// maxRecentSubdirs bounds Project.RecentSubdirs.
+const maxRecentSubdirs = 8
+
+// AddRecentSubdir moves subdir to the front of RecentSubdirs.
+func (p *Project) AddRecentSubdir(subdir string) {
+	recent := []string{subdir}
+	for _, s := range p.RecentSubdirs {
+		if s != subdir && len(recent) < maxRecentSubdirs {
+			recent = append(recent, s)
+		}
+	}
+	p.RecentSubdirs = recent
+}
+
 // Touch updates the LastUsed timestamp to now.
 func (p *Project) Touch() {
 	p.LastUsed = time.Now().Unix()
 }
 
+// LastUsedTime returns when a session of the project last started, or the
+// zero time if none has since it was added.
+func (p *Project) LastUsedTime() time.Time {
+	if p.LastUsed <= p.CreatedAt {
+		return time.Time{}
+	}
+	return time.Unix(p.LastUsed, 0)
+}
+
 // SetProfile binds a profile to this project.
 func (p *Project) SetProfile(profileID string) {
 	p.ProfileID = profileID
@@ -58,3 +112,18 @@ func (p *Project) DisplayName() string {
 	}
 	return p.Path
 }
+
+// RecentLimit is how many projects count as recent.
+const RecentLimit = 5
+
+// RecentProjects returns up to n projects, most recently used first.
+func RecentProjects(projects []Project, n int) []Project {
+	recent := append([]Project(nil), projects...)
+	sort.SliceStable(recent, func(i, j int) bool {
+		return recent[i].LastUsed > recent[j].LastUsed
+	})
+	if len(recent) > n {
+		recent = recent[:n]
+	}
+	return recent
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Template is a reusable prompt in the template library. Its body may use
+// variables such as {{PROJECT}} and {{TOPIC}}, filled in when it is used.
+type Template struct {
+	// ID is the unique identifier for this template.
+	ID string `json:"id"`
+	// Name is shown in the template picker.
+	Name string `json:"name"`
+	// Body is the prompt text.
+	Body string `json:"body"`
+}
+
+// IDs of the default role templates used by the role assignment dialog.
+const (
+	TemplateRoleJudge     = "role-judge"
+	TemplateRoleProponent = "role-proponent"
+	TemplateRoleOpponent  = "role-opponent"
+	TemplateRoleObserver  = "role-observer"
+)
+
+// roleConfirmation asks an agent to confirm its role briefly.
+const roleConfirmation = " [系统指令：请确认你的角色。无需多言，仅回复“收到”即可。]"
+
+// NewTemplate creates a template with a generated UUID.
+func NewTemplate(name, body string) *Template {
+	return &Template{
+		ID:   uuid.New().String(),
+		Name: name,
+		Body: body,
+	}
+}
+
+// DefaultTemplates returns the templates a new library starts with: the
+// debate roles assigned by the role dialog.
+func DefaultTemplates() []Template {
+	return []Template{
+		{
+			ID:   TemplateRoleJudge,
+			Name: "Role: Judge",
+			Body: `你现在是【裁判长/调度员】。
+任务目标：[在此填入待讨论的议题]
+职责：不参与辩论，只负责分析【正方】和【反方】的论据。
+` + roleConfirmation,
+		},
+		{
+			ID:   TemplateRoleProponent,
+			Name: "Role: Proponent",
+			Body: `你现在是【正方】。
+职责：坚定支持该议题。提供具体的论据。
+` + roleConfirmation,
+		},
+		{
+			ID:   TemplateRoleOpponent,
+			Name: "Role: Opponent",
+			Body: `你现在是【反方】。
+职责：对该议题持有审慎或反对态度。寻找漏洞。
+` + roleConfirmation,
+		},
+		{
+			ID:   TemplateRoleObserver,
+			Name: "Role: Observer",
+			Body: `你现在是【观察员】。
+职责：记录会议要点，不直接参与讨论。
+` + roleConfirmation,
+		},
+	}
+}
+
+// ExpandTemplate replaces each {{NAME}} in body with vars[NAME]. Unknown
+// variables are kept as written.
+func ExpandTemplate(body string, vars map[string]string) string {
+	if !strings.Contains(body, "{{") {
+		return body
+	}
+	pairs := make([]string, 0, 2*len(vars))
+	for name, value := range vars {
+		pairs = append(pairs, "{{"+name+"}}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(body)
+}
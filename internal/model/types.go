@@ -1,6 +1,13 @@
 // Package model defines core data structures for VibeMux.
 package model
 
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
 // DriverType represents the type of driver used to launch AI agent.
 type DriverType string
 
@@ -11,8 +18,79 @@ const (
 	DriverCCR DriverType = "ccr"
 	// DriverCustom allows arbitrary shell commands.
 	DriverCustom DriverType = "custom"
+	// DriverCodex launches the OpenAI Codex CLI with its own flags.
+	DriverCodex DriverType = "codex"
+	// DriverGemini launches the Google Gemini CLI with its own flags.
+	DriverGemini DriverType = "gemini"
+	// DriverContainer runs the command inside a docker or podman container.
+	DriverContainer DriverType = "container"
 )
 
+// ToolForCommand returns the tool driver for a command line: codex or
+// gemini when its executable is named so, native otherwise.
+func ToolForCommand(command string) DriverType {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return DriverNative
+	}
+	name := fields[0]
+	if i := strings.LastIndexAny(name, `/\`); i != -1 {
+		name = name[i+1:]
+	}
+	switch strings.TrimSuffix(strings.ToLower(name), ".exe") {
+	case "codex":
+		return DriverCodex
+	case "gemini":
+		return DriverGemini
+	}
+	return DriverNative
+}
+
+// AgentOptions are CLI settings that tool drivers turn into the tool's own
+// flags. The native driver ignores them.
+type AgentOptions struct {
+	// Model selects the model, e.g. "gpt-5-codex" or "gemini-2.5-pro".
+	Model string `json:"model,omitempty"`
+	// Sandbox is the tool's sandbox mode: read-only, workspace-write or
+	// danger-full-access for Codex; on, docker, podman or sandbox-exec for
+	// Gemini.
+	Sandbox string `json:"sandbox,omitempty"`
+}
+
+// ContainerConfig configures the container driver. The project directory is
+// mounted read-write; nothing else of the host is visible unless listed in
+// Mounts.
+type ContainerConfig struct {
+	// Runtime is "docker" or "podman"; empty uses whichever is installed.
+	Runtime string `json:"runtime,omitempty"`
+	// Image is the image the agent runs in; it must contain the CLI.
+	Image string `json:"image,omitempty"`
+	// Workdir is where the project is mounted; empty uses the project's
+	// host path (/workspace on Windows).
+	Workdir string `json:"workdir,omitempty"`
+	// CPUs and Memory limit the container, e.g. "2" and "4g".
+	CPUs   string `json:"cpus,omitempty"`
+	Memory string `json:"memory,omitempty"`
+	// PidsLimit caps the number of processes; zero means no limit.
+	PidsLimit int `json:"pids_limit,omitempty"`
+	// Network is the container network, e.g. "none"; empty uses the
+	// runtime's default.
+	Network string `json:"network,omitempty"`
+	// Mounts are extra volumes in -v syntax ("/host:/container[:ro]"); a
+	// leading ~/ is the home directory. They may contain {{PROJECT_PATH}},
+	// {{PROJECT_NAME}} and {{SESSION_ID}}.
+	Mounts []string `json:"mounts,omitempty"`
+	// Args are extra arguments for the run command, e.g. ["--user", "1000"].
+	Args []string `json:"args,omitempty"`
+}
+
+// Clone returns a copy that shares no slices with c.
+func (c ContainerConfig) Clone() ContainerConfig {
+	c.Mounts = append([]string(nil), c.Mounts...)
+	c.Args = append([]string(nil), c.Args...)
+	return c
+}
+
 // AutoApproveLevel defines the level of automatic approval for operations.
 type AutoApproveLevel string
 
@@ -41,10 +119,377 @@ const (
 	SessionStatusError SessionStatus = "error"
 )
 
+// ActivityState is what a running session appears to be doing, judged from
+// its output.
+type ActivityState string
+
+const (
+	// ActivityNone means the session is not running or has not been seen.
+	ActivityNone ActivityState = ""
+	// ActivityWorking means output is flowing or the CLI shows its busy
+	// indicator.
+	ActivityWorking ActivityState = "working"
+	// ActivityWaiting means the CLI asked for input that is unanswered.
+	ActivityWaiting ActivityState = "waiting"
+	// ActivityIdle means the session has gone quiet.
+	ActivityIdle ActivityState = "idle"
+	// ActivityError means the CLI printed an error and has not resumed work.
+	ActivityError ActivityState = "error"
+)
+
 // NotificationConfig holds notification settings for a profile.
 type NotificationConfig struct {
 	// Desktop enables desktop notifications via system APIs.
 	Desktop bool `json:"desktop"`
 	// WebhookURL is the optional URL to send webhook notifications.
 	WebhookURL string `json:"webhook_url,omitempty"`
+	// SlackWebhookURL and DiscordWebhookURL are incoming webhooks of a chat
+	// channel that receives the notifications as messages.
+	SlackWebhookURL   string `json:"slack_webhook_url,omitempty"`
+	DiscordWebhookURL string `json:"discord_webhook_url,omitempty"`
+	// ReminderMinutes re-notifies about an unanswered input request every
+	// this many minutes, with increasing urgency. Zero disables reminders.
+	ReminderMinutes int `json:"reminder_minutes,omitempty"`
+	// ReminderWebhookURL additionally receives urgent reminders (the second
+	// one onwards), e.g. a pager or chat channel.
+	ReminderWebhookURL string `json:"reminder_webhook_url,omitempty"`
+}
+
+// DefaultTurnTimeout is how long an agent may take on its auto-turn before
+// the engine falls back to manual mode.
+const DefaultTurnTimeout = 2 * time.Minute
+
+// defaultTurnPrompt is the "your turn" message; %s is the turn file.
+const defaultTurnPrompt = "[SYSTEM] 你的回合已到。请读取文件 %s 并执行输出。"
+
+// TurnConfig tunes the auto-turn engine for a profile's CLI.
+type TurnConfig struct {
+	// Prompt is the "your turn" message. {{FILE}} is replaced by the turn
+	// file path. Empty uses the built-in message.
+	Prompt string `json:"prompt,omitempty"`
+	// CompletionPattern is a regex matched against the agent's output to
+	// detect that its turn is done, which starts the countdown to the next
+	// turn. Empty leaves advancing to Alt+N.
+	CompletionPattern string `json:"completion_pattern,omitempty"`
+	// TimeoutSeconds overrides DefaultTurnTimeout.
+	TimeoutSeconds int `json:"timeout_seconds,omitempty"`
+}
+
+// PromptFor returns the turn message for the given turn file.
+func (t TurnConfig) PromptFor(file string) string {
+	if t.Prompt == "" {
+		return fmt.Sprintf(defaultTurnPrompt, file)
+	}
+	return strings.ReplaceAll(t.Prompt, "{{FILE}}", file)
+}
+
+// Timeout returns how long a turn may take.
+func (t TurnConfig) Timeout() time.Duration {
+	if t.TimeoutSeconds > 0 {
+		return time.Duration(t.TimeoutSeconds) * time.Second
+	}
+	return DefaultTurnTimeout
+}
+
+// DefaultBusyPattern matches the "working" hint of Claude Code and Codex.
+const DefaultBusyPattern = `(?i)esc to interrupt`
+
+// GeminiBusyPattern matches the "working" hint of the Gemini CLI.
+const GeminiBusyPattern = `(?i)esc to cancel`
+
+// DefaultStuckAfter is how long a pane may stay busy before the watchdog
+// offers to interrupt it.
+const DefaultStuckAfter = 15 * time.Minute
+
+// WatchdogConfig tunes stuck-session detection for a profile's CLI.
+type WatchdogConfig struct {
+	// BusyPattern is a regex shown by the CLI while it is working and can
+	// be interrupted. Empty uses DefaultBusyPattern.
+	BusyPattern string `json:"busy_pattern,omitempty"`
+	// StuckMinutes overrides DefaultStuckAfter. Negative disables the
+	// watchdog.
+	StuckMinutes int `json:"stuck_minutes,omitempty"`
+}
+
+// PatternFor returns the busy regex for a session of the given tool.
+func (w WatchdogConfig) PatternFor(tool DriverType) string {
+	switch {
+	case w.BusyPattern != "":
+		return w.BusyPattern
+	case tool == DriverGemini:
+		return GeminiBusyPattern
+	}
+	return DefaultBusyPattern
+}
+
+// StuckAfter returns how long a pane may stay busy, or zero when the
+// watchdog is disabled.
+func (w WatchdogConfig) StuckAfter() time.Duration {
+	switch {
+	case w.StuckMinutes < 0:
+		return 0
+	case w.StuckMinutes > 0:
+		return time.Duration(w.StuckMinutes) * time.Minute
+	}
+	return DefaultStuckAfter
+}
+
+// ExtractionStrategy selects how an agent's conclusion is read from its pane.
+type ExtractionStrategy string
+
+const (
+	// ExtractAuto uses the output marker when present and TUI frame
+	// heuristics otherwise.
+	ExtractAuto ExtractionStrategy = ""
+	// ExtractMarker takes everything after the last marker token.
+	ExtractMarker ExtractionStrategy = "marker"
+	// ExtractRegex takes the last match of a regex.
+	ExtractRegex ExtractionStrategy = "regex"
+	// ExtractLastLines takes the last lines of output.
+	ExtractLastLines ExtractionStrategy = "last_lines"
+	// ExtractJSON takes the last JSON object or fenced json block.
+	ExtractJSON ExtractionStrategy = "json"
+)
+
+// DefaultExtractMarker is the token agents are asked to start their output
+// with.
+const DefaultExtractMarker = ":::VIBE_OUTPUT:::"
+
+// DefaultExtractLines is how many lines the last_lines strategy keeps.
+const DefaultExtractLines = 20
+
+// ExtractionConfig tunes conclusion extraction for a profile's CLI.
+type ExtractionConfig struct {
+	// Strategy is auto (empty), marker, regex, last_lines or json.
+	Strategy ExtractionStrategy `json:"strategy,omitempty"`
+	// Marker overrides DefaultExtractMarker for the marker strategy.
+	Marker string `json:"marker,omitempty"`
+	// Pattern is the regex strategy's expression; its first capture group
+	// is the conclusion when it has one, the whole match otherwise.
+	Pattern string `json:"pattern,omitempty"`
+	// Lines overrides DefaultExtractLines for the last_lines strategy.
+	Lines int `json:"lines,omitempty"`
+}
+
+// MarkerToken returns the marker the marker strategy looks for.
+func (e ExtractionConfig) MarkerToken() string {
+	if e.Marker == "" {
+		return DefaultExtractMarker
+	}
+	return e.Marker
+}
+
+// LineCount returns how many lines the last_lines strategy keeps.
+func (e ExtractionConfig) LineCount() int {
+	if e.Lines > 0 {
+		return e.Lines
+	}
+	return DefaultExtractLines
+}
+
+// CtrlCPolicy is what Ctrl+C typed in terminal mode does to a session.
+type CtrlCPolicy string
+
+const (
+	// CtrlCPass sends Ctrl+C to the CLI like any other key (the default).
+	CtrlCPass CtrlCPolicy = "pass"
+	// CtrlCDoubleKill sends Ctrl+C and stops the session when it is
+	// pressed again within CtrlCWindow, for CLIs that ignore it.
+	CtrlCDoubleKill CtrlCPolicy = "double_kill"
+	// CtrlCGuarded only sends Ctrl+C when it is pressed twice within
+	// CtrlCWindow, so a stray press cannot interrupt a long run.
+	CtrlCGuarded CtrlCPolicy = "guarded"
+)
+
+// CtrlCWindow is how quickly a second Ctrl+C must follow the first.
+const CtrlCWindow = 1500 * time.Millisecond
+
+// RestartPolicy is when an exited session is started again.
+type RestartPolicy string
+
+const (
+	// RestartNever leaves an exited session stopped (the default).
+	RestartNever RestartPolicy = "never"
+	// RestartOnCrash restarts sessions whose process exits with an error
+	// status or is killed by a signal.
+	RestartOnCrash RestartPolicy = "on-crash"
+	// RestartAlways also restarts sessions that exit cleanly.
+	RestartAlways RestartPolicy = "always"
+)
+
+// DefaultMaxRestarts is how many times in a row a session is restarted
+// before VibeMux gives up.
+const DefaultMaxRestarts = 5
+
+// RestartStableAfter is how long a restarted session must run before its
+// restart count starts over.
+const RestartStableAfter = time.Minute
+
+// RestartConfig restarts a profile's sessions when their process exits
+// without being closed from VibeMux.
+type RestartConfig struct {
+	// Policy is never (the default), on-crash or always.
+	Policy RestartPolicy `json:"policy,omitempty"`
+	// ResumePrompt is sent once the restarted CLI is ready, e.g.
+	// "Continue where you left off". Empty sends nothing.
+	ResumePrompt string `json:"resume_prompt,omitempty"`
+	// MaxRestarts overrides DefaultMaxRestarts. Negative means no limit.
+	MaxRestarts int `json:"max_restarts,omitempty"`
+}
+
+// Restarts reports whether a session that exited with err is restarted.
+func (r RestartConfig) Restarts(err error) bool {
+	switch r.Policy {
+	case RestartAlways:
+		return true
+	case RestartOnCrash:
+		return err != nil
+	}
+	return false
+}
+
+// Limit returns how many restarts in a row are allowed, or zero for no
+// limit.
+func (r RestartConfig) Limit() int {
+	switch {
+	case r.MaxRestarts < 0:
+		return 0
+	case r.MaxRestarts > 0:
+		return r.MaxRestarts
+	}
+	return DefaultMaxRestarts
+}
+
+// Backoff returns the wait before the attempt-th restart in a row (from
+// zero): one second, doubling up to a minute.
+func (r RestartConfig) Backoff(attempt int) time.Duration {
+	d := time.Second
+	for i := 0; i < attempt && d < time.Minute; i++ {
+		d *= 2
+	}
+	return min(d, time.Minute)
+}
+
+// StopConfig decides how a profile's sessions are asked to exit when their
+// pane is closed.
+type StopConfig struct {
+	// Sequence lists what is sent, in order, until the process exits: a
+	// signal name (SIGINT, SIGTERM, SIGHUP, SIGQUIT) or a CLI command typed
+	// and submitted, e.g. "/exit". Empty sends SIGTERM.
+	Sequence []string `json:"sequence,omitempty"`
+	// GraceSeconds is how long each step may take before the next one is
+	// sent, or the process is killed after the last. Zero uses the global
+	// stop_grace_seconds; negative kills at once.
+	GraceSeconds int `json:"grace_seconds,omitempty"`
+}
+
+// Grace returns the wait after each step, given the global default.
+func (c StopConfig) Grace(fallback time.Duration) time.Duration {
+	switch {
+	case c.GraceSeconds < 0:
+		return 0
+	case c.GraceSeconds > 0:
+		return time.Duration(c.GraceSeconds) * time.Second
+	}
+	return fallback
+}
+
+// ApprovalDecision is what is done with a command approval prompt.
+type ApprovalDecision int
+
+const (
+	// ApprovalAsk leaves the prompt to the user.
+	ApprovalAsk ApprovalDecision = iota
+	// ApprovalAllow answers yes.
+	ApprovalAllow
+	// ApprovalDeny answers no.
+	ApprovalDeny
+)
+
+// DestructivePatterns match commands that are never approved on their own,
+// whatever the profile's rules and auto_approve level say.
+var DestructivePatterns = []string{
+	`(?i)\brm\s+(-\S+\s+)*-[a-z]*(r[a-z]*f|f[a-z]*r)`,
+	`(?i)\brm\s+(-\S+\s+)*(-[a-z]*r[a-z]*|--recursive)\s+(-\S+\s+)*(-[a-z]*f[a-z]*|--force)(\s|$)`,
+	`(?i)\brm\s+(-\S+\s+)*(-[a-z]*f[a-z]*|--force)\s+(-\S+\s+)*(-[a-z]*r[a-z]*|--recursive)(\s|$)`,
+	`\bgit\s+push\b.*(\s--force\b|\s--force-with-lease\b|\s-f\b|\s\+\S)`,
+	`\bgit\s+reset\s+--hard\b`,
+	`\bgit\s+clean\s+(-\S+\s+)*-[a-zA-Z]*f`,
+	`\bmkfs(\.\w+)?\b`,
+	`\bdd\s+.*\bof=/dev/`,
+	`(?i)\bdrop\s+(table|database)\b`,
+}
+
+var destructiveExprs = compileApprovalPatterns(DestructivePatterns)
+
+// ApprovalRules decide which command approval prompts a profile's sessions
+// answer on their own. Patterns are regexes matched against the command;
+// invalid ones are ignored.
+type ApprovalRules struct {
+	// Allow lists commands approved without asking.
+	Allow []string `json:"allow,omitempty"`
+	// Deny lists commands refused without asking. Deny wins over Allow.
+	Deny []string `json:"deny,omitempty"`
+}
+
+// Clone returns a copy that shares no slices with r.
+func (r ApprovalRules) Clone() ApprovalRules {
+	r.Allow = append([]string(nil), r.Allow...)
+	r.Deny = append([]string(nil), r.Deny...)
+	return r
+}
+
+// Decide returns what to do with a prompt to run command at the given
+// auto_approve level, and the pattern that decided it ("" when the level
+// did). block is the prompt's whole text, which may show more of the
+// command than the line it was taken from, and guessed reports that
+// command was inferred from the output above the prompt rather than read
+// from the prompt itself. Destructive commands and the deny list are
+// refused when they match either, the allow list is approved only for
+// commands that were not guessed, and anything else is approved at yolo
+// and asked about otherwise.
+func (r ApprovalRules) Decide(level AutoApproveLevel, command, block string, guessed bool) (ApprovalDecision, string) {
+	deny := compileApprovalPatterns(r.Deny)
+	for _, s := range []string{command, block} {
+		if expr := firstMatch(destructiveExprs, s); expr != "" {
+			return ApprovalDeny, expr
+		}
+		if expr := firstMatch(deny, s); expr != "" {
+			return ApprovalDeny, expr
+		}
+	}
+	if !guessed {
+		if expr := firstMatch(compileApprovalPatterns(r.Allow), command); expr != "" {
+			return ApprovalAllow, expr
+		}
+	}
+	if level == AutoApproveYolo {
+		return ApprovalAllow, ""
+	}
+	return ApprovalAsk, ""
+}
+
+// ApprovalPattern returns the rule that matches exactly command.
+func ApprovalPattern(command string) string {
+	return "^" + regexp.QuoteMeta(command) + "$"
+}
+
+func compileApprovalPatterns(patterns []string) []*regexp.Regexp {
+	exprs := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		if re, err := regexp.Compile(p); err == nil {
+			exprs = append(exprs, re)
+		}
+	}
+	return exprs
+}
+
+// firstMatch returns the source of the first expression matching s.
+func firstMatch(exprs []*regexp.Regexp, s string) string {
+	for _, re := range exprs {
+		if re.MatchString(s) {
+			return re.String()
+		}
+	}
+	return ""
 }
@@ -0,0 +1,18 @@
+package notify
+
+import (
+	"github.com/gen2brain/beeep"
+)
+
+// appName is shown as the sender of desktop notifications where supported.
+const appName = "VibeMux"
+
+// sendDesktop delivers a desktop notification through the native backend of
+// the current platform, falling back to beeep when the native tool is missing
+// or fails.
+func sendDesktop(title, message string) error {
+	if err := platformNotify(title, message); err == nil {
+		return nil
+	}
+	return beeep.Notify(title, message, "")
+}
@@ -0,0 +1,29 @@
+package notify
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// platformNotify prefers terminal-notifier (clickable, groups per app) and
+// falls back to AppleScript, which is always available on macOS.
+func platformNotify(title, message string) error {
+	if path, err := exec.LookPath("terminal-notifier"); err == nil {
+		return exec.Command(path,
+			"-title", appName,
+			"-subtitle", title,
+			"-message", message,
+			"-group", appName,
+		).Run()
+	}
+	script := "display notification " + appleScriptQuote(message) +
+		" with title " + appleScriptQuote(appName) +
+		" subtitle " + appleScriptQuote(title)
+	return exec.Command("osascript", "-e", script).Run()
+}
+
+func appleScriptQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,17 @@
+//go:build !darwin && !windows
+
+package notify
+
+import (
+	"os/exec"
+)
+
+// platformNotify uses notify-send (libnotify), available on most Linux and
+// BSD desktops.
+func platformNotify(title, message string) error {
+	path, err := exec.LookPath("notify-send")
+	if err != nil {
+		return err
+	}
+	return exec.Command(path, "--app-name="+appName, "--", title, message).Run()
+}
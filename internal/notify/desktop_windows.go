@@ -0,0 +1,38 @@
+package notify
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// powershellAppID is the AUMID of Windows PowerShell, which is registered on
+// every installation and therefore allowed to raise toast notifications.
+const powershellAppID = `{1AC14E77-02E7-4E5D-B744-2EB1AE5198B7}\WindowsPowerShell\v1.0\powershell.exe`
+
+// toastScript raises a toast from the text in the VIBEMUX_* variables. The
+// notification text never becomes part of the script itself, so no quoting of
+// agent output is needed.
+const toastScript = `
+[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null
+[Windows.Data.Xml.Dom.XmlDocument, Windows.Data.Xml.Dom.XmlDocument, ContentType = WindowsRuntime] | Out-Null
+$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $template.GetElementsByTagName("text")
+$text.Item(0).AppendChild($template.CreateTextNode($env:VIBEMUX_TITLE)) | Out-Null
+$text.Item(1).AppendChild($template.CreateTextNode($env:VIBEMUX_MESSAGE)) | Out-Null
+$toast = [Windows.UI.Notifications.ToastNotification]::new($template)
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier($env:VIBEMUX_APPID).Show($toast)
+`
+
+// platformNotify shows a Windows toast notification through PowerShell and
+// the WinRT ToastNotificationManager.
+func platformNotify(title, message string) error {
+	cmd := exec.Command("powershell.exe", "-NoProfile", "-NonInteractive", "-Command", toastScript)
+	cmd.Env = append(os.Environ(),
+		"VIBEMUX_TITLE="+appName+": "+title,
+		"VIBEMUX_MESSAGE="+message,
+		"VIBEMUX_APPID="+powershellAppID,
+	)
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+	return cmd.Run()
+}
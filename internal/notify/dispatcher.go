@@ -9,7 +9,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/gen2brain/beeep"
 	"github.com/lazyvibe/vibemux/internal/model"
 )
 
@@ -19,8 +18,10 @@ type EventType string
 const (
 	EventNotify        EventType = "notify"
 	EventInputRequired EventType = "input_required"
+	EventInputReminder EventType = "input_reminder"
 	EventTaskCompleted EventType = "task_completed"
 	EventError         EventType = "error"
+	EventAllQuiet      EventType = "all_quiet"
 )
 
 // Event describes a notification event.
@@ -66,32 +67,33 @@ func (d *Dispatcher) Dispatch(ctx context.Context, cfg model.NotificationConfig,
 	}
 
 	if cfg.Desktop {
-		_ = beeep.Notify(title, message, "")
+		_ = sendDesktop(title, message)
 	}
 
-	if cfg.WebhookURL != "" {
-		payload := map[string]any{
-			"project":   event.ProjectName,
-			"projectId": event.ProjectID,
-			"event":     event.Type,
-			"title":     title,
-			"message":   message,
-			"timestamp": event.Timestamp.Unix(),
-		}
-		body, err := json.Marshal(payload)
-		if err != nil {
-			return
-		}
-		req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.WebhookURL, bytes.NewReader(body))
-		if err != nil {
-			return
-		}
-		req.Header.Set("Content-Type", "application/json")
-		resp, err := d.client.Do(req)
-		if err != nil {
-			return
-		}
-		_, _ = io.Copy(io.Discard, resp.Body)
-		_ = resp.Body.Close()
+	d.post(ctx, cfg.WebhookURL, formatFor(cfg.WebhookURL), event, title, message)
+	d.post(ctx, cfg.SlackWebhookURL, formatSlack, event, title, message)
+	d.post(ctx, cfg.DiscordWebhookURL, formatDiscord, event, title, message)
+}
+
+// post sends the event to a webhook in the given format. Empty URLs are
+// skipped; delivery errors are ignored like desktop ones.
+func (d *Dispatcher) post(ctx context.Context, url string, format webhookFormat, event Event, title, message string) {
+	if url == "" {
+		return
+	}
+	body, err := json.Marshal(webhookPayload(format, event, title, message))
+	if err != nil {
+		return
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return
 	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
 }
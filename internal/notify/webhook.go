@@ -0,0 +1,107 @@
+package notify
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// webhookFormat is the payload shape a webhook expects.
+type webhookFormat int
+
+const (
+	// formatJSON is VibeMux's own event object.
+	formatJSON webhookFormat = iota
+	// formatSlack is a Slack incoming webhook message; Mattermost and
+	// Rocket.Chat accept it too.
+	formatSlack
+	// formatDiscord is a Discord webhook message with an embed.
+	formatDiscord
+)
+
+// formatFor detects Slack and Discord webhook URLs, so a plain webhook URL
+// pointing at a chat channel still gets a readable message.
+func formatFor(rawURL string) webhookFormat {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return formatJSON
+	}
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case host == "hooks.slack.com":
+		return formatSlack
+	case (host == "discord.com" || host == "discordapp.com" || strings.HasSuffix(host, ".discord.com")) &&
+		strings.HasPrefix(u.Path, "/api/webhooks/"):
+		return formatDiscord
+	}
+	return formatJSON
+}
+
+// eventStyle returns the emoji and embed color of an event type.
+func eventStyle(t EventType) (string, int) {
+	switch t {
+	case EventInputRequired, EventInputReminder:
+		return "⏳", 0xFAB387
+	case EventTaskCompleted:
+		return "✅", 0xA6E3A1
+	case EventError:
+		return "❌", 0xF38BA8
+	case EventAllQuiet:
+		return "💤", 0x89B4FA
+	}
+	return "🔔", 0xCBA6F7
+}
+
+func webhookPayload(format webhookFormat, event Event, title, message string) any {
+	emoji, color := eventStyle(event.Type)
+	switch format {
+	case formatSlack:
+		text := emoji + " *" + slackEscape(title) + "*"
+		if event.ProjectName != "" && event.ProjectName != title {
+			text += " · " + slackEscape(event.ProjectName)
+		}
+		return map[string]any{
+			"username": appName,
+			"text":     text + "\n" + slackEscape(message),
+		}
+	case formatDiscord:
+		embed := map[string]any{
+			"title":       truncate(emoji+" "+title, 256),
+			"description": message,
+			"color":       color,
+		}
+		if !event.Timestamp.IsZero() {
+			embed["timestamp"] = event.Timestamp.UTC().Format(time.RFC3339)
+		}
+		if event.ProjectName != "" {
+			embed["footer"] = map[string]string{"text": event.ProjectName}
+		}
+		// Agent output must not ping the channel (@everyone).
+		return map[string]any{
+			"username":         appName,
+			"embeds":           []any{embed},
+			"allowed_mentions": map[string]any{"parse": []string{}},
+		}
+	}
+	return map[string]any{
+		"project":   event.ProjectName,
+		"projectId": event.ProjectID,
+		"event":     event.Type,
+		"title":     title,
+		"message":   message,
+		"timestamp": event.Timestamp.Unix(),
+	}
+}
+
+// slackEscape escapes the characters Slack reserves for links and mentions.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
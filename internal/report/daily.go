@@ -0,0 +1,105 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lazyvibe/vibemux/internal/runtime"
+)
+
+// ReportPath returns the Markdown report path for the given day.
+func ReportPath(configDir string, day time.Time) string {
+	return filepath.Join(configDir, "reports", day.Format(dayLayout)+".md")
+}
+
+// Conclusion is a chain conclusion recorded on the report day.
+type Conclusion struct {
+	Task  string
+	Entry runtime.ChainEntry
+}
+
+// LoadConclusions collects chain conclusions saved on the given day from <configDir>/chain.
+func LoadConclusions(configDir string, day time.Time) []Conclusion {
+	paths, _ := filepath.Glob(filepath.Join(configDir, "chain", "*.json"))
+	date := day.Format(dayLayout)
+
+	var out []Conclusion
+	for _, path := range paths {
+		ctx, err := runtime.LoadChainContext(path)
+		if err != nil {
+			continue
+		}
+		for _, entry := range ctx.Chain {
+			if entry.Timestamp.Local().Format(dayLayout) == date {
+				out = append(out, Conclusion{Task: ctx.Task, Entry: entry})
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Entry.Timestamp.Before(out[j].Entry.Timestamp)
+	})
+	return out
+}
+
+// Render builds the Markdown report for the given stats and conclusions.
+func Render(stats *DailyStats, conclusions []Conclusion) string {
+	var b strings.Builder
+	sessions, prompts, cost := stats.Totals()
+
+	fmt.Fprintf(&b, "# VibeMux Daily Report — %s\n\n", stats.Date)
+	b.WriteString("## Summary\n\n")
+	fmt.Fprintf(&b, "- Sessions run: %d\n", sessions)
+	fmt.Fprintf(&b, "- Prompts sent: %d\n", prompts)
+	fmt.Fprintf(&b, "- Reported cost: $%.2f\n", cost)
+	fmt.Fprintf(&b, "- Chain conclusions: %d\n\n", len(conclusions))
+
+	if len(stats.Projects) > 0 {
+		b.WriteString("## Projects\n\n")
+		b.WriteString("| Project | Sessions | Prompts | Cost |\n")
+		b.WriteString("|---|---:|---:|---:|\n")
+		for _, p := range stats.SortedProjects() {
+			fmt.Fprintf(&b, "| %s | %d | %d | $%.2f |\n", p.Name, p.Sessions, p.Prompts, p.Cost)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(conclusions) > 0 {
+		b.WriteString("## Conclusions\n\n")
+		for _, c := range conclusions {
+			fmt.Fprintf(&b, "### %s — %s (%s)\n\n", c.Entry.Timestamp.Local().Format("15:04"), c.Entry.Agent, c.Task)
+			b.WriteString(strings.TrimSpace(c.Entry.Conclusion))
+			b.WriteString("\n\n")
+		}
+	}
+
+	return b.String()
+}
+
+// Generate renders the report for the given day and writes it to disk.
+// It returns the path of the written report.
+func Generate(configDir string, day time.Time) (string, error) {
+	stats, err := LoadDailyStats(configDir, day)
+	if err != nil {
+		return "", err
+	}
+	content := Render(stats, LoadConclusions(configDir, day))
+
+	path := ReportPath(configDir, day)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// NarrativePrompt returns the instruction sent to an agent asked to write
+// the narrative section of a report.
+func NarrativePrompt(path string) string {
+	return fmt.Sprintf("Read the daily report at %s and append a \"## Narrative\" section to that file: a short summary of what was accomplished today, open issues and suggested next steps.", path)
+}
@@ -0,0 +1,151 @@
+// Package report collects per-day usage statistics and renders them as Markdown reports.
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// dayLayout is the date format used for stats and report file names.
+const dayLayout = "2006-01-02"
+
+// ProjectStats holds the counters for a single project on a given day.
+type ProjectStats struct {
+	Name     string  `json:"name"`
+	Sessions int     `json:"sessions"`
+	Prompts  int     `json:"prompts"`
+	Cost     float64 `json:"cost"`
+}
+
+// DailyStats holds all counters recorded on a given day.
+type DailyStats struct {
+	Date     string                   `json:"date"`
+	Projects map[string]*ProjectStats `json:"projects"`
+}
+
+// Totals returns the aggregated session, prompt and cost counters.
+func (d *DailyStats) Totals() (sessions, prompts int, cost float64) {
+	for _, p := range d.Projects {
+		sessions += p.Sessions
+		prompts += p.Prompts
+		cost += p.Cost
+	}
+	return sessions, prompts, cost
+}
+
+// SortedProjects returns the project stats ordered by name.
+func (d *DailyStats) SortedProjects() []ProjectStats {
+	out := make([]ProjectStats, 0, len(d.Projects))
+	for _, p := range d.Projects {
+		out = append(out, *p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Recorder records usage counters and persists them under <dir>/stats/<date>.json.
+type Recorder struct {
+	mu    sync.Mutex
+	dir   string
+	stats *DailyStats
+}
+
+// NewRecorder creates a recorder that stores its files under configDir.
+func NewRecorder(configDir string) *Recorder {
+	return &Recorder{dir: configDir}
+}
+
+// StatsPath returns the stats file path for the given day.
+func StatsPath(configDir string, day time.Time) string {
+	return filepath.Join(configDir, "stats", day.Format(dayLayout)+".json")
+}
+
+// LoadDailyStats loads the stats recorded on the given day.
+// A missing file yields empty stats.
+func LoadDailyStats(configDir string, day time.Time) (*DailyStats, error) {
+	stats := &DailyStats{
+		Date:     day.Format(dayLayout),
+		Projects: make(map[string]*ProjectStats),
+	}
+	data, err := os.ReadFile(StatsPath(configDir, day))
+	if os.IsNotExist(err) {
+		return stats, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, stats); err != nil {
+		return nil, err
+	}
+	if stats.Projects == nil {
+		stats.Projects = make(map[string]*ProjectStats)
+	}
+	return stats, nil
+}
+
+// RecordSession counts a started session for the project.
+func (r *Recorder) RecordSession(projectID, name string) {
+	r.update(projectID, name, func(p *ProjectStats) { p.Sessions++ })
+}
+
+// RecordPrompt counts a prompt submitted to the project's session.
+func (r *Recorder) RecordPrompt(projectID, name string) {
+	r.update(projectID, name, func(p *ProjectStats) { p.Prompts++ })
+}
+
+// RecordCost adds a reported cost (in USD) to the project.
+func (r *Recorder) RecordCost(projectID, name string, amount float64) {
+	if amount <= 0 {
+		return
+	}
+	r.update(projectID, name, func(p *ProjectStats) { p.Cost += amount })
+}
+
+func (r *Recorder) update(projectID, name string, apply func(*ProjectStats)) {
+	if r == nil || projectID == "" {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	today := time.Now().Format(dayLayout)
+	if r.stats == nil || r.stats.Date != today {
+		stats, err := LoadDailyStats(r.dir, time.Now())
+		if err != nil {
+			stats = &DailyStats{Date: today, Projects: make(map[string]*ProjectStats)}
+		}
+		r.stats = stats
+	}
+
+	p, ok := r.stats.Projects[projectID]
+	if !ok {
+		p = &ProjectStats{}
+		r.stats.Projects[projectID] = p
+	}
+	if name != "" {
+		p.Name = name
+	}
+	apply(p)
+	_ = r.save()
+}
+
+// save writes the current stats to disk. Caller must hold r.mu.
+func (r *Recorder) save() error {
+	path := StatsPath(r.dir, time.Now())
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(r.stats, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
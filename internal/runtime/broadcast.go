@@ -0,0 +1,147 @@
+package runtime
+
+import (
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// BroadcastOptions paces writes that go to many sessions at once.
+type BroadcastOptions struct {
+	// Stagger delays each further session by this much, so panes do not
+	// all receive the write in the same instant.
+	Stagger time.Duration
+	// ChunkSize caps a single PTY write; larger payloads are split.
+	ChunkSize int
+	// ChunkDelay is the pause between chunks written to one session.
+	ChunkDelay time.Duration
+}
+
+// DefaultBroadcastOptions suit interactive agent CLIs.
+var DefaultBroadcastOptions = BroadcastOptions{
+	Stagger:    30 * time.Millisecond,
+	ChunkSize:  256,
+	ChunkDelay: 10 * time.Millisecond,
+}
+
+// broadcastQueueLen bounds the writes waiting for one session.
+const broadcastQueueLen = 256
+
+// broadcastJob is one queued write.
+type broadcastJob struct {
+	data      []byte
+	notBefore time.Time
+	opts      BroadcastOptions
+}
+
+// writeQueue serializes broadcast writes to one session.
+type writeQueue struct {
+	jobs chan broadcastJob
+	once sync.Once
+}
+
+func (q *writeQueue) close() {
+	q.once.Do(func() { close(q.jobs) })
+}
+
+// Broadcast queues data for each of the sessions, staggered in the given
+// order. Each session has its own queue, so consecutive broadcasts arrive
+// in order and never interleave, and a slow CLI only delays itself. It
+// returns how many sessions the write was queued for; sessions whose queue
+// is full are skipped.
+func (e *DefaultEngine) Broadcast(ids []string, data []byte, opts BroadcastOptions) int {
+	if len(data) == 0 {
+		return 0
+	}
+	now := time.Now()
+	queued := 0
+	for i, id := range ids {
+		q := e.writeQueue(id)
+		if q == nil {
+			continue
+		}
+		job := broadcastJob{
+			data:      append([]byte(nil), data...),
+			notBefore: now.Add(time.Duration(i) * opts.Stagger),
+			opts:      opts,
+		}
+		select {
+		case q.jobs <- job:
+			queued++
+		default:
+		}
+	}
+	return queued
+}
+
+// writeQueue returns the session's queue, starting its writer on first use.
+func (e *DefaultEngine) writeQueue(id string) *writeQueue {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	session, ok := e.sessions[id]
+	if !ok {
+		return nil
+	}
+	if q, ok := e.queues[id]; ok {
+		return q
+	}
+	q := &writeQueue{jobs: make(chan broadcastJob, broadcastQueueLen)}
+	e.queues[id] = q
+	go drainQueue(session, q)
+	return q
+}
+
+// closeQueue stops the session's writer. Callers must hold e.mu.
+func (e *DefaultEngine) closeQueue(id string) {
+	if q, ok := e.queues[id]; ok {
+		q.close()
+		delete(e.queues, id)
+	}
+}
+
+// drainQueue writes queued jobs to the session until the queue is closed.
+func drainQueue(session Session, q *writeQueue) {
+	for job := range q.jobs {
+		if wait := time.Until(job.notBefore); wait > 0 {
+			time.Sleep(wait)
+		}
+		chunks := splitWrite(job.data, job.opts.ChunkSize)
+		for i, chunk := range chunks {
+			if _, err := session.Write(chunk); err != nil {
+				break
+			}
+			if i < len(chunks)-1 && job.opts.ChunkDelay > 0 {
+				time.Sleep(job.opts.ChunkDelay)
+			}
+		}
+	}
+}
+
+// splitWrite cuts data into chunks of at most size bytes without splitting
+// a UTF-8 character or a short escape sequence.
+func splitWrite(data []byte, size int) [][]byte {
+	if size <= 0 || len(data) <= size {
+		return [][]byte{data}
+	}
+	var chunks [][]byte
+	for len(data) > size {
+		cut := size
+		for cut > 0 && !utf8.RuneStart(data[cut]) {
+			cut--
+		}
+		// Keep an escape sequence that starts near the cut in one piece.
+		for i := cut - 1; i >= 0 && i >= cut-16; i-- {
+			if data[i] == 0x1b {
+				cut = i
+				break
+			}
+		}
+		if cut == 0 {
+			cut = size
+		}
+		chunks = append(chunks, data[:cut])
+		data = data[cut:]
+	}
+	return append(chunks, data)
+}
@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -13,6 +16,13 @@ const (
 	ChainPromptHeader = "Based on the above context, please continue."
 	// ChainPromptInstruction is the text instructing the agent on output format.
 	ChainPromptInstruction = "IMPORTANT: Please start your output with ':::VIBE_OUTPUT:::' so I can extract it reliably."
+	// ChainSummaryRequest asks an agent to condense earlier chain entries.
+	ChainSummaryRequest = "Summarize the following earlier chain entries in a few bullets. Keep decisions, open questions and file names; drop everything else."
+	// ConclusionSummaryRequest asks an agent to condense one long conclusion.
+	ConclusionSummaryRequest = "Summarize the following conclusion in 5 bullets. Keep decisions, open questions and file names."
+	// MeetingSummaryRequest asks an agent to write the minutes of an
+	// organizer-mode discussion.
+	MeetingSummaryRequest = "Summarize the following meeting transcript as minutes: the positions of each role, points of agreement, open disagreements and next steps."
 )
 
 // ChainEntry represents a single conclusion from an agent in the chain.
@@ -28,8 +38,15 @@ type ChainContext struct {
 	CreatedAt time.Time    `json:"created_at"`
 	Task      string       `json:"task"`
 	Chain     []ChainEntry `json:"chain"`
+	// Summary condenses the first SummaryCovers entries when the chain is
+	// compacted.
+	Summary       string `json:"summary,omitempty"`
+	SummaryCovers int    `json:"summary_covers,omitempty"`
 	mu        sync.RWMutex
 	path      string `json:"-"`
+	// dirtySince is when the first unsaved change was made; zero when the
+	// file on disk is up to date.
+	dirtySince time.Time
 }
 
 // NewChainContext creates a new chain context.
@@ -65,28 +82,88 @@ func LoadChainContext(path string) (*ChainContext, error) {
 
 // Save persists the chain context to file.
 func (c *ChainContext) Save() error {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
 	data, err := json.MarshalIndent(c, "", "  ")
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(c.path, data, 0644)
+	if err := os.WriteFile(c.path, data, 0644); err != nil {
+		return err
+	}
+	c.dirtySince = time.Time{}
+	return nil
 }
 
-// AppendConclusion adds a new entry to the chain and saves it.
-func (c *ChainContext) AppendConclusion(agent, conclusion string) error {
+// AppendConclusion adds a new entry to the chain. The change stays in
+// memory until the next Save.
+func (c *ChainContext) AppendConclusion(agent, conclusion string) {
 	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.Chain = append(c.Chain, ChainEntry{
 		Agent:      agent,
 		Timestamp:  time.Now(),
 		Conclusion: conclusion,
 	})
-	c.mu.Unlock()
-	
-	return c.Save()
+	c.markDirty()
+}
+
+// SetTask replaces the task the chain works on.
+func (c *ChainContext) SetTask(task string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Task = task
+	c.markDirty()
+}
+
+// Clear removes every entry from the chain.
+func (c *ChainContext) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Chain = nil
+	c.Summary = ""
+	c.SummaryCovers = 0
+	c.markDirty()
+}
+
+// SetSummary stores an agent-written summary of the first covers entries.
+func (c *ChainContext) SetSummary(summary string, covers int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if covers > len(c.Chain) {
+		covers = len(c.Chain)
+	}
+	c.Summary = summary
+	c.SummaryCovers = covers
+	c.markDirty()
+}
+
+// Dirty reports whether the chain has changes that are not saved yet.
+func (c *ChainContext) Dirty() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return !c.dirtySince.IsZero()
+}
+
+// DirtySince returns when the oldest unsaved change was made, or the zero
+// time when there is none.
+func (c *ChainContext) DirtySince() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.dirtySince
+}
+
+// markDirty records an unsaved change. Callers must hold the write lock.
+func (c *ChainContext) markDirty() {
+	if c.dirtySince.IsZero() {
+		c.dirtySince = time.Now()
+	}
 }
 
 // GetLatestConclusion returns the most recent conclusion text.
@@ -105,15 +182,174 @@ func (c *ChainContext) FormatContext() string {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	result := "【Chain Context】\n"
-	result += "Task: " + c.Task + "\n\n"
-	
-	for _, entry := range c.Chain {
-		result += "--- Agent: " + entry.Agent + " ---\n"
-		result += entry.Conclusion + "\n\n"
+	return c.format("", c.Chain)
+}
+
+// CompactOptions controls how FormatCompact shrinks a long chain.
+type CompactOptions struct {
+	// MaxChars is the size above which the chain is compacted. Zero
+	// disables compaction.
+	MaxChars int
+	// KeepLast is how many recent entries are always kept verbatim.
+	KeepLast int
+	// EntryChars is what older entries are truncated to when no summary
+	// covers them.
+	EntryChars int
+}
+
+// FormatCompact formats the chain like FormatContext, but once it exceeds
+// opts.MaxChars the older entries are replaced by the stored summary (for
+// the entries it covers) and truncated, oldest dropped first, so the result
+// fits. It reports whether anything was compacted.
+func (c *ChainContext) FormatCompact(opts CompactOptions) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	full := c.format("", c.Chain)
+	if opts.MaxChars <= 0 || len(full) <= opts.MaxChars {
+		return full, false
 	}
-	
-	result += ChainPromptHeader + "\n"
-	result += ChainPromptInstruction
-	return result
+
+	split := len(c.Chain) - opts.KeepLast
+	if split < 0 {
+		split = 0
+	}
+	var preface strings.Builder
+	older := c.Chain[:split]
+	if c.Summary != "" && c.SummaryCovers > 0 && c.SummaryCovers <= split {
+		preface.WriteString("--- Summary of entries 1-" + strconv.Itoa(c.SummaryCovers) + " ---\n")
+		preface.WriteString(c.Summary + "\n\n")
+		older = older[c.SummaryCovers:]
+	}
+	truncated := make([]ChainEntry, 0, len(c.Chain))
+	for _, entry := range older {
+		entry.Conclusion = truncateRunes(entry.Conclusion, opts.EntryChars)
+		truncated = append(truncated, entry)
+	}
+	truncated = append(truncated, c.Chain[split:]...)
+
+	// Drop the oldest truncated entries until the context fits.
+	dropped := 0
+	result := c.format(preface.String(), truncated)
+	for len(result) > opts.MaxChars && dropped < len(older) {
+		dropped++
+		note := preface.String() + "(earlier entries omitted: " + strconv.Itoa(dropped) + ")\n\n"
+		result = c.format(note, truncated[dropped:])
+	}
+	return result, true
+}
+
+// OlderEntries returns the text of the entries before the last keepLast ones,
+// formatted for a summary request, and how many entries it covers.
+func (c *ChainContext) OlderEntries(keepLast int) (string, int) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	covers := len(c.Chain) - keepLast
+	if covers <= 0 {
+		return "", 0
+	}
+	var b strings.Builder
+	for _, entry := range c.Chain[:covers] {
+		b.WriteString("--- Agent: " + entry.Agent + " ---\n")
+		b.WriteString(entry.Conclusion + "\n\n")
+	}
+	return b.String(), covers
+}
+
+// format renders the task, an optional preface and entries with the prompt
+// trailer. Callers must hold the lock.
+func (c *ChainContext) format(preface string, entries []ChainEntry) string {
+	var b strings.Builder
+	b.WriteString("【Chain Context】\n")
+	b.WriteString("Task: " + c.Task + "\n\n")
+	b.WriteString(preface)
+
+	for _, entry := range entries {
+		b.WriteString("--- Agent: " + entry.Agent + " ---\n")
+		b.WriteString(entry.Conclusion + "\n\n")
+	}
+
+	b.WriteString(ChainPromptHeader + "\n")
+	b.WriteString(ChainPromptInstruction)
+	return b.String()
+}
+
+// truncateRunes shortens s to at most n runes, marking the cut.
+func truncateRunes(s string, n int) string {
+	if n <= 0 {
+		return s
+	}
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n]) + " …[truncated]"
+}
+
+// ChainInfo describes a saved chain context file.
+type ChainInfo struct {
+	Path      string
+	SessionID string
+	Task      string
+	Entries   int
+	Modified  time.Time
+}
+
+// ListChainContexts describes the chain context files in dir, most recently
+// modified first. Files that cannot be read are skipped.
+func ListChainContexts(dir string) ([]ChainInfo, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]ChainInfo, 0, len(paths))
+	for _, path := range paths {
+		stat, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		ctx, err := LoadChainContext(path)
+		if err != nil {
+			continue
+		}
+		infos = append(infos, ChainInfo{
+			Path:      path,
+			SessionID: ctx.SessionID,
+			Task:      ctx.Task,
+			Entries:   len(ctx.Chain),
+			Modified:  stat.ModTime(),
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Modified.After(infos[j].Modified) })
+	return infos, nil
+}
+
+// Path returns the file the chain context is saved to.
+func (c *ChainContext) Path() string {
+	return c.path
+}
+
+// LatestChainContext loads the most recently modified chain context in dir.
+func LatestChainContext(dir string) (*ChainContext, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var latest string
+	var latestMod time.Time
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if latest == "" || info.ModTime().After(latestMod) {
+			latest = path
+			latestMod = info.ModTime()
+		}
+	}
+	if latest == "" {
+		return nil, os.ErrNotExist
+	}
+	return LoadChainContext(latest)
 }
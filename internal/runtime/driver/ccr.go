@@ -23,7 +23,7 @@ func (d *CCRDriver) Name() string {
 }
 
 // BuildCommand constructs the command for CCR execution.
-func (d *CCRDriver) BuildCommand(workDir string, profile *model.Profile) (*exec.Cmd, error) {
+func (d *CCRDriver) BuildCommand(workDir string, profile *model.Profile, vars Vars) (*exec.Cmd, error) {
 	if err := d.Validate(profile); err != nil {
 		return nil, err
 	}
@@ -40,6 +40,7 @@ func (d *CCRDriver) BuildCommand(workDir string, profile *model.Profile) (*exec.
 	if len(parts) == 0 {
 		return nil, errors.New("command is empty")
 	}
+	parts = vars.expand(append(parts, profile.CommandArgs...))
 
 	command := parts[0]
 	args := parts[1:]
@@ -0,0 +1,126 @@
+package driver
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/lazyvibe/vibemux/internal/model"
+)
+
+// codexSandboxes are the values codex accepts for --sandbox.
+var codexSandboxes = []string{"read-only", "workspace-write", "danger-full-access"}
+
+// CodexDriver launches the OpenAI Codex CLI. It turns the profile's model,
+// sandbox and auto-approve level into codex flags, unless the command line
+// already sets them.
+type CodexDriver struct {
+	native *NativeDriver
+}
+
+// NewCodexDriver creates a CodexDriver that resolves codex like native.
+func NewCodexDriver(native *NativeDriver) *CodexDriver {
+	return &CodexDriver{native: native}
+}
+
+// Name returns the driver identifier.
+func (d *CodexDriver) Name() string {
+	return "codex"
+}
+
+// BuildCommand constructs the interactive codex command.
+func (d *CodexDriver) BuildCommand(workDir string, profile *model.Profile, vars Vars) (*exec.Cmd, error) {
+	if err := d.Validate(profile); err != nil {
+		return nil, err
+	}
+	p, err := withToolArgs(profile, "codex", func(present flagSet) []string {
+		return codexFlags(profile, present)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d.native.BuildCommand(workDir, p, vars)
+}
+
+// BuildPromptCommand constructs `codex exec`, which answers one prompt
+// without the interactive UI and prints the final message.
+func (d *CodexDriver) BuildPromptCommand(workDir string, profile *model.Profile, vars Vars, prompt string) (*exec.Cmd, error) {
+	if err := d.Validate(profile); err != nil {
+		return nil, err
+	}
+	p, err := withToolArgs(profile, "codex", func(present flagSet) []string {
+		flags := []string{"exec", "--skip-git-repo-check"}
+		switch profile.AutoApprove {
+		case model.AutoApproveYolo:
+			if !present.has("--dangerously-bypass-approvals-and-sandbox") {
+				flags = append(flags, "--dangerously-bypass-approvals-and-sandbox")
+			}
+		case model.AutoApproveVibe:
+			if !present.has("--full-auto") && profile.Agent.Sandbox == "" {
+				flags = append(flags, "--full-auto")
+			}
+		}
+		return append(append(flags, codexCommonFlags(profile, present)...), "--", prompt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d.native.BuildCommand(workDir, p, vars)
+}
+
+// Validate checks that codex is installed and logged in.
+func (d *CodexDriver) Validate(profile *model.Profile) error {
+	if err := d.native.Validate(withDefaultCommand(profile, "codex")); err != nil {
+		return err
+	}
+	if s := profile.Agent.Sandbox; s != "" && !contains(codexSandboxes, s) {
+		return errors.New("codex sandbox must be read-only, workspace-write or danger-full-access")
+	}
+	if hasEnv(profile, "OPENAI_API_KEY") || hasEnv(profile, "CODEX_API_KEY") {
+		return nil
+	}
+	home := lookupEnv(profile, "CODEX_HOME")
+	if home == "" {
+		dir, _ := os.UserHomeDir()
+		home = filepath.Join(dir, ".codex")
+	}
+	if _, err := os.Stat(filepath.Join(home, "auth.json")); err != nil {
+		return errors.New("codex is not logged in: run `codex login` or set OPENAI_API_KEY")
+	}
+	return nil
+}
+
+// codexFlags are the flags of the interactive UI.
+func codexFlags(profile *model.Profile, present flagSet) []string {
+	return append(codexApprovalFlags(profile.AutoApprove, present), codexCommonFlags(profile, present)...)
+}
+
+// codexApprovalFlags maps the auto-approve level to codex's approval
+// policy for the interactive UI.
+func codexApprovalFlags(level model.AutoApproveLevel, present flagSet) []string {
+	if present.has("--ask-for-approval", "-a", "--full-auto", "--dangerously-bypass-approvals-and-sandbox") {
+		return nil
+	}
+	switch level {
+	case model.AutoApproveNone:
+		return []string{"--ask-for-approval", "untrusted"}
+	case model.AutoApproveSafe:
+		return []string{"--ask-for-approval", "on-request"}
+	case model.AutoApproveYolo:
+		return []string{"--ask-for-approval", "never"}
+	}
+	return []string{"--ask-for-approval", "on-failure"}
+}
+
+// codexCommonFlags are the model and sandbox flags.
+func codexCommonFlags(profile *model.Profile, present flagSet) []string {
+	var flags []string
+	if m := profile.Agent.Model; m != "" && !present.has("--model", "-m") {
+		flags = append(flags, "--model", m)
+	}
+	if s := profile.Agent.Sandbox; s != "" && !present.has("--sandbox", "-s") {
+		flags = append(flags, "--sandbox", s)
+	}
+	return flags
+}
@@ -0,0 +1,179 @@
+package driver
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/lazyvibe/vibemux/internal/model"
+)
+
+// containerConfigDirs are env vars naming agent config directories. They are
+// mounted at the same path so logins survive the container.
+var containerConfigDirs = []string{"CLAUDE_CONFIG_DIR", "CODEX_HOME"}
+
+// ContainerDriver runs the profile's command inside a docker or podman
+// container that sees only the project directory, so agents on YOLO
+// auto-approve cannot touch the rest of the host. The container gets a TTY
+// and is removed when the agent exits.
+type ContainerDriver struct{}
+
+// NewContainerDriver creates a new ContainerDriver instance.
+func NewContainerDriver() *ContainerDriver {
+	return &ContainerDriver{}
+}
+
+// Name returns the driver identifier.
+func (d *ContainerDriver) Name() string {
+	return "container"
+}
+
+// BuildCommand constructs the `docker run` (or `podman run`) command. Env
+// vars are passed by name, so their values stay out of the process list.
+func (d *ContainerDriver) BuildCommand(workDir string, profile *model.Profile, vars Vars) (*exec.Cmd, error) {
+	if err := d.Validate(profile); err != nil {
+		return nil, err
+	}
+	rt, _ := containerRuntime(profile.Container.Runtime)
+	p, err := withAgentArgs(withDefaultCommand(profile, "claude"))
+	if err != nil {
+		return nil, err
+	}
+	parts, err := splitCommandLine(strings.TrimSpace(p.Command))
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) == 0 {
+		return nil, errors.New("command is empty")
+	}
+	parts = vars.expand(append(parts, p.CommandArgs...))
+
+	cfg := profile.Container
+	target := cfg.Workdir
+	if target == "" {
+		target = workDir
+		if runtime.GOOS == "windows" {
+			target = "/workspace"
+		}
+	}
+	args := []string{"run", "--rm", "-it", "--init",
+		"--name", ContainerName(vars.ProjectName, vars.SessionID),
+		"-v", workDir + ":" + target, "-w", target,
+	}
+	if cfg.CPUs != "" {
+		args = append(args, "--cpus", cfg.CPUs)
+	}
+	if cfg.Memory != "" {
+		args = append(args, "--memory", cfg.Memory)
+	}
+	if cfg.PidsLimit > 0 {
+		args = append(args, "--pids-limit", strconv.Itoa(cfg.PidsLimit))
+	}
+	if cfg.Network != "" {
+		args = append(args, "--network", cfg.Network)
+	}
+	for _, key := range containerConfigDirs {
+		dir := profile.EnvVars[key]
+		if runtime.GOOS == "windows" || !strings.HasPrefix(dir, "/") {
+			continue
+		}
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			args = append(args, "-v", dir+":"+dir)
+		}
+	}
+	home, _ := os.UserHomeDir()
+	for _, mount := range vars.expand(cfg.Mounts) {
+		if strings.HasPrefix(mount, "~/") && home != "" {
+			mount = home + mount[1:]
+		}
+		args = append(args, "-v", mount)
+	}
+
+	env := os.Environ()
+	for k, v := range profile.EnvVars {
+		env = append(env, k+"="+v)
+		args = append(args, "-e", k)
+	}
+	if _, ok := profile.EnvVars["TERM"]; !ok {
+		args = append(args, "-e", "TERM=xterm-256color", "-e", "COLORTERM=truecolor")
+	}
+	if _, ok := profile.EnvVars["NODE_OPTIONS"]; !ok {
+		args = append(args, "-e", "NODE_OPTIONS=--max-old-space-size=4096")
+	}
+	args = append(args, vars.expand(cfg.Args)...)
+	args = append(args, cfg.Image)
+	args = append(args, parts...)
+
+	cmd := exec.Command(rt, args...)
+	cmd.Dir = workDir
+	cmd.Env = env
+	return cmd, nil
+}
+
+// Validate checks that an image is set and the container runtime is
+// installed. The agent's CLI is looked up inside the image, not here.
+func (d *ContainerDriver) Validate(profile *model.Profile) error {
+	if profile == nil {
+		return errors.New("profile is nil")
+	}
+	if strings.TrimSpace(profile.Container.Image) == "" {
+		return errors.New("container driver needs container.image")
+	}
+	_, err := containerRuntime(profile.Container.Runtime)
+	return err
+}
+
+// Container identifies the container a session runs in.
+type Container struct {
+	Runtime string
+	Name    string
+}
+
+// ContainerFor returns the container of a session launched with profile,
+// or false when the profile does not use the container driver.
+func ContainerFor(profile *model.Profile, projectName, sessionID string) (Container, bool) {
+	if profile == nil || profile.Driver != model.DriverContainer {
+		return Container{}, false
+	}
+	rt, err := containerRuntime(profile.Container.Runtime)
+	if err != nil {
+		return Container{}, false
+	}
+	return Container{Runtime: rt, Name: ContainerName(projectName, sessionID)}, true
+}
+
+// Remove force-removes the container, ignoring missing containers. Killing
+// the runtime's client does not stop a container attached to a TTY.
+func (c Container) Remove() {
+	_ = exec.Command(c.Runtime, "rm", "-f", c.Name).Run()
+}
+
+// ContainerName returns the container name used for a session.
+func ContainerName(projectName, sessionID string) string {
+	name := strings.Trim(tmuxNameSanitizer.ReplaceAllString(projectName, "-"), "-")
+	id := strings.Trim(tmuxNameSanitizer.ReplaceAllString(sessionID, "-"), "-")
+	if name == "" {
+		return "vibemux-" + id
+	}
+	return "vibemux-" + name + "-" + id
+}
+
+// containerRuntime resolves the runtime executable: name when given, else
+// docker or podman, whichever is found first.
+func containerRuntime(name string) (string, error) {
+	if name != "" {
+		if path, ok := resolveExecutablePath(name); ok {
+			return path, nil
+		}
+		return "", errors.New("container runtime not found: " + name)
+	}
+	for _, candidate := range []string{"docker", "podman"} {
+		if path, err := exec.LookPath(candidate); err == nil {
+			return path, nil
+		}
+	}
+	return "", errors.New("container driver needs docker or podman in PATH")
+}
@@ -11,8 +11,9 @@ import (
 type Driver interface {
 	// Name returns the driver identifier.
 	Name() string
-	// BuildCommand constructs the exec.Cmd for the given profile and working directory.
-	BuildCommand(workDir string, profile *model.Profile) (*exec.Cmd, error)
+	// BuildCommand constructs the exec.Cmd for the given profile and working
+	// directory, substituting vars for placeholders in the command line.
+	BuildCommand(workDir string, profile *model.Profile, vars Vars) (*exec.Cmd, error)
 	// Validate checks if the profile configuration is valid for this driver.
 	Validate(profile *model.Profile) error
 }
@@ -21,6 +22,8 @@ type Driver interface {
 type Config struct {
 	ClaudePath string
 	CodexPath  string
+	// Tmux hosts every session inside a named tmux session.
+	Tmux bool
 }
 
 // Registry holds all available drivers.
@@ -42,8 +45,12 @@ func NewRegistryWithConfig(cfg Config) *Registry {
 	}
 
 	// Register built-in drivers with configuration
-	r.Register(NewNativeDriverWithConfig(cfg.ClaudePath, cfg.CodexPath))
+	native := NewNativeDriverWithConfig(cfg.ClaudePath, cfg.CodexPath)
+	r.Register(native)
 	r.Register(NewCCRDriver())
+	r.Register(NewCodexDriver(native))
+	r.Register(NewGeminiDriver(native))
+	r.Register(NewContainerDriver())
 
 	return r
 }
@@ -57,6 +64,12 @@ func (r *Registry) Register(d Driver) {
 		r.drivers[model.DriverCCR] = d
 	case "custom":
 		r.drivers[model.DriverCustom] = d
+	case "codex":
+		r.drivers[model.DriverCodex] = d
+	case "gemini":
+		r.drivers[model.DriverGemini] = d
+	case "container":
+		r.drivers[model.DriverContainer] = d
 	}
 }
 
@@ -65,3 +78,19 @@ func (r *Registry) Get(t model.DriverType) (Driver, bool) {
 	d, ok := r.drivers[t]
 	return d, ok
 }
+
+// ForProfile returns the driver a profile's sessions launch with: the
+// container driver for container profiles, the tool driver for codex and
+// gemini profiles, native for everything else.
+func (r *Registry) ForProfile(profile *model.Profile) Driver {
+	if profile != nil {
+		if profile.Driver == model.DriverContainer {
+			return r.drivers[model.DriverContainer]
+		}
+		switch tool := profile.Tool(); tool {
+		case model.DriverCodex, model.DriverGemini:
+			return r.drivers[tool]
+		}
+	}
+	return r.drivers[model.DriverNative]
+}
@@ -0,0 +1,121 @@
+package driver
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/lazyvibe/vibemux/internal/model"
+)
+
+// geminiSandboxes are the sandbox settings Gemini CLI understands; all but
+// "on" name the container tool through GEMINI_SANDBOX.
+var geminiSandboxes = []string{"on", "docker", "podman", "sandbox-exec"}
+
+// geminiAuthEnv are the env vars any of which lets Gemini CLI sign in.
+var geminiAuthEnv = []string{"GEMINI_API_KEY", "GOOGLE_API_KEY", "GOOGLE_GENAI_USE_VERTEXAI", "GOOGLE_GENAI_USE_GCA"}
+
+// GeminiDriver launches the Google Gemini CLI. It turns the profile's model,
+// sandbox and auto-approve level into gemini flags, unless the command line
+// already sets them.
+type GeminiDriver struct {
+	native *NativeDriver
+}
+
+// NewGeminiDriver creates a GeminiDriver that resolves gemini like native.
+func NewGeminiDriver(native *NativeDriver) *GeminiDriver {
+	return &GeminiDriver{native: native}
+}
+
+// Name returns the driver identifier.
+func (d *GeminiDriver) Name() string {
+	return "gemini"
+}
+
+// BuildCommand constructs the interactive gemini command.
+func (d *GeminiDriver) BuildCommand(workDir string, profile *model.Profile, vars Vars) (*exec.Cmd, error) {
+	if err := d.Validate(profile); err != nil {
+		return nil, err
+	}
+	p, err := withToolArgs(profile, "gemini", func(present flagSet) []string {
+		return geminiFlags(profile, present)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d.native.BuildCommand(workDir, geminiSandboxEnv(p), vars)
+}
+
+// BuildPromptCommand constructs `gemini --prompt`, which answers one prompt
+// without the interactive UI.
+func (d *GeminiDriver) BuildPromptCommand(workDir string, profile *model.Profile, vars Vars, prompt string) (*exec.Cmd, error) {
+	if err := d.Validate(profile); err != nil {
+		return nil, err
+	}
+	p, err := withToolArgs(profile, "gemini", func(present flagSet) []string {
+		return append(geminiFlags(profile, present), "--prompt", prompt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d.native.BuildCommand(workDir, geminiSandboxEnv(p), vars)
+}
+
+// Validate checks that gemini is installed and can sign in.
+func (d *GeminiDriver) Validate(profile *model.Profile) error {
+	if err := d.native.Validate(withDefaultCommand(profile, "gemini")); err != nil {
+		return err
+	}
+	if s := profile.Agent.Sandbox; s != "" && !contains(geminiSandboxes, s) {
+		return errors.New("gemini sandbox must be on, docker, podman or sandbox-exec")
+	}
+	for _, key := range geminiAuthEnv {
+		if hasEnv(profile, key) {
+			return nil
+		}
+	}
+	home, _ := os.UserHomeDir()
+	for _, name := range []string{"oauth_creds.json", "settings.json"} {
+		if _, err := os.Stat(filepath.Join(home, ".gemini", name)); err == nil {
+			return nil
+		}
+	}
+	return errors.New("gemini is not signed in: run `gemini` once to log in or set GEMINI_API_KEY")
+}
+
+// geminiFlags maps the model, sandbox and auto-approve level to flags.
+func geminiFlags(profile *model.Profile, present flagSet) []string {
+	var flags []string
+	if m := profile.Agent.Model; m != "" && !present.has("--model", "-m") {
+		flags = append(flags, "--model", m)
+	}
+	if profile.Agent.Sandbox != "" && !present.has("--sandbox", "-s") {
+		flags = append(flags, "--sandbox")
+	}
+	if !present.has("--yolo", "-y", "--approval-mode") {
+		switch profile.AutoApprove {
+		case model.AutoApproveVibe:
+			flags = append(flags, "--approval-mode", "auto_edit")
+		case model.AutoApproveYolo:
+			flags = append(flags, "--yolo")
+		}
+	}
+	return flags
+}
+
+// geminiSandboxEnv names the container tool for sandbox settings other
+// than "on".
+func geminiSandboxEnv(p *model.Profile) *model.Profile {
+	s := p.Agent.Sandbox
+	if s == "" || s == "on" || hasEnv(p, "GEMINI_SANDBOX") {
+		return p
+	}
+	env := make(map[string]string, len(p.EnvVars)+1)
+	for k, v := range p.EnvVars {
+		env[k] = v
+	}
+	env["GEMINI_SANDBOX"] = s
+	p.EnvVars = env
+	return p
+}
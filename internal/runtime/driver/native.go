@@ -33,10 +33,12 @@ func (d *NativeDriver) Name() string {
 	return "native"
 }
 
-// BuildCommand constructs the command for native execution.
-func (d *NativeDriver) BuildCommand(workDir string, profile *model.Profile) (*exec.Cmd, error) {
-	if err := d.Validate(profile); err != nil {
-		return nil, err
+// BuildCommand constructs the command for native execution. Profile.Command
+// and CommandArgs may contain {{PROJECT_PATH}}, {{PROJECT_NAME}} and
+// {{SESSION_ID}}, e.g. `docker run -v {{PROJECT_PATH}}:/work ...`.
+func (d *NativeDriver) BuildCommand(workDir string, profile *model.Profile, vars Vars) (*exec.Cmd, error) {
+	if profile == nil {
+		return nil, errors.New("profile is nil")
 	}
 
 	commandLine := strings.TrimSpace(profile.Command)
@@ -51,17 +53,12 @@ func (d *NativeDriver) BuildCommand(workDir string, profile *model.Profile) (*ex
 	if len(parts) == 0 {
 		return nil, errors.New("command is empty")
 	}
-	command := parts[0]
+	parts = vars.expand(append(parts, profile.CommandArgs...))
+	command := d.resolveCommand(parts[0])
 	args := parts[1:]
 
-	if command == "" || command == "claude" {
-		if d.claudePath != "" {
-			command = d.claudePath
-		} else {
-			command = "claude"
-		}
-	} else if command == "codex" && d.codexPath != "" {
-		command = d.codexPath
+	if _, resolved := resolveExecutablePath(command); !resolved {
+		return nil, errors.New("command not found: " + command)
 	}
 
 	cmd := exec.Command(command, args...)
@@ -116,16 +113,10 @@ func (d *NativeDriver) Validate(profile *model.Profile) error {
 	if len(parts) == 0 {
 		return errors.New("command is empty")
 	}
-	command := parts[0]
-
-	if command == "" || command == "claude" {
-		if d.claudePath != "" {
-			command = d.claudePath
-		} else {
-			command = "claude"
-		}
-	} else if command == "codex" && d.codexPath != "" {
-		command = d.codexPath
+	command := d.resolveCommand(parts[0])
+	// Placeholders are only known at launch; BuildCommand checks then.
+	if hasPlaceholder(command) {
+		return nil
 	}
 
 	if _, resolved := resolveExecutablePath(command); !resolved {
@@ -134,3 +125,17 @@ func (d *NativeDriver) Validate(profile *model.Profile) error {
 
 	return nil
 }
+
+// resolveCommand maps the bare claude/codex names to their configured paths.
+func (d *NativeDriver) resolveCommand(command string) string {
+	if command == "" || command == "claude" {
+		if d.claudePath != "" {
+			return d.claudePath
+		}
+		return "claude"
+	}
+	if command == "codex" && d.codexPath != "" {
+		return d.codexPath
+	}
+	return command
+}
@@ -0,0 +1,30 @@
+package driver
+
+import "strings"
+
+// Vars are the launch values substituted for command placeholders.
+type Vars struct {
+	ProjectPath string // {{PROJECT_PATH}}: the session's working directory
+	ProjectName string // {{PROJECT_NAME}}
+	SessionID   string // {{SESSION_ID}}
+}
+
+// hasPlaceholder reports whether s still contains a {{...}} placeholder.
+func hasPlaceholder(s string) bool {
+	return strings.Contains(s, "{{") && strings.Contains(s, "}}")
+}
+
+// expand replaces the placeholders in each command word. Expansion runs
+// after the command line is split, so values with spaces stay one argument.
+func (v Vars) expand(parts []string) []string {
+	r := strings.NewReplacer(
+		"{{PROJECT_PATH}}", v.ProjectPath,
+		"{{PROJECT_NAME}}", v.ProjectName,
+		"{{SESSION_ID}}", v.SessionID,
+	)
+	out := make([]string, len(parts))
+	for i, p := range parts {
+		out[i] = r.Replace(p)
+	}
+	return out
+}
@@ -0,0 +1,165 @@
+package driver
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// tmuxNameSanitizer matches characters tmux does not accept in session names.
+var tmuxNameSanitizer = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// envName matches the variable names a shell can export.
+var envName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// TmuxSessionName returns the tmux session name used for a session. It
+// holds the whole session ID, "#N" suffix of extra sessions included, so
+// two sessions never attach to the same tmux session.
+func TmuxSessionName(projectName, sessionID string) string {
+	name := strings.Trim(tmuxNameSanitizer.ReplaceAllString(projectName, "-"), "-")
+	id := strings.Trim(tmuxNameSanitizer.ReplaceAllString(sessionID, "-"), "-")
+	if name == "" {
+		return "vibemux-" + id
+	}
+	return "vibemux-" + name + "-" + id
+}
+
+// WrapTmux rewrites cmd so that it runs inside a named tmux session.
+// The session is created now unless it already exists, and the returned
+// command runs a tmux client attached to it, which lets users join the
+// same agent from their own tmux setup with `tmux attach -t <name>` or
+// link-window.
+func WrapTmux(cmd *exec.Cmd, sessionName string) (*exec.Cmd, error) {
+	tmuxPath, err := exec.LookPath("tmux")
+	if err != nil {
+		return nil, errors.New("tmux mode is enabled but tmux was not found in PATH")
+	}
+
+	if exec.Command(tmuxPath, "has-session", "-t", "="+sessionName).Run() != nil {
+		if err := newTmuxSession(tmuxPath, cmd, sessionName); err != nil {
+			return nil, err
+		}
+	}
+	// Hide the tmux status line; VibeMux draws its own pane chrome.
+	wrapped := exec.Command(tmuxPath, "attach-session", "-t", "="+sessionName,
+		";", "set-option", "-t", sessionName, "status", "off")
+	wrapped.Dir = cmd.Dir
+	wrapped.Env = cmd.Env
+	return wrapped, nil
+}
+
+// newTmuxSession starts cmd in a new detached tmux session. Only the
+// variables the driver added on top of our environment are forwarded; the
+// tmux server already has the rest. They may hold resolved secrets, so they
+// go through a private file the shell reads and deletes rather than through
+// argv, where every local user could see them. When another client created
+// the session first, nothing is started and the file is removed here.
+func newTmuxSession(tmuxPath string, cmd *exec.Cmd, sessionName string) error {
+	args := []string{"new-session", "-d", "-s", sessionName}
+	if cmd.Dir != "" {
+		args = append(args, "-c", cmd.Dir)
+	}
+	args = append(args, "--")
+	var envFile string
+	if delta := envDelta(cmd.Env); len(delta) > 0 {
+		var err error
+		if envFile, err = writeEnvFile(delta); err != nil {
+			return err
+		}
+		args = append(args, "/bin/sh", "-c", `. "$0"; rm -f "$0"; exec "$@"`, envFile)
+	}
+	args = append(args, cmd.Path)
+	args = append(args, cmd.Args[1:]...)
+
+	create := exec.Command(tmuxPath, args...)
+	create.Dir = cmd.Dir
+	create.Env = cmd.Env
+	out, err := create.CombinedOutput()
+	if err == nil {
+		return nil
+	}
+	if envFile != "" {
+		os.Remove(envFile)
+	}
+	if exec.Command(tmuxPath, "has-session", "-t", "="+sessionName).Run() == nil {
+		return nil
+	}
+	if msg := strings.TrimSpace(string(out)); msg != "" {
+		return errors.New("tmux: " + msg)
+	}
+	return err
+}
+
+// KillTmuxSession terminates the named tmux session, ignoring missing sessions.
+func KillTmuxSession(sessionName string) error {
+	tmuxPath, err := exec.LookPath("tmux")
+	if err != nil {
+		return err
+	}
+	_ = exec.Command(tmuxPath, "kill-session", "-t", sessionName).Run()
+	return nil
+}
+
+// TmuxPanePID returns the PID of the process running in the named tmux
+// session's pane.
+func TmuxPanePID(sessionName string) (int, error) {
+	tmuxPath, err := exec.LookPath("tmux")
+	if err != nil {
+		return 0, err
+	}
+	out, err := exec.Command(tmuxPath, "display-message", "-p", "-t", sessionName, "#{pane_pid}").Output()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(out)))
+}
+
+// writeEnvFile writes env as shell exports to a new file only the user can
+// read and returns its path.
+func writeEnvFile(env []string) (string, error) {
+	f, err := os.CreateTemp("", "vibemux-env-*")
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, kv := range env {
+		k, v, _ := strings.Cut(kv, "=")
+		if !envName.MatchString(k) {
+			continue
+		}
+		b.WriteString("export " + k + "='" + strings.ReplaceAll(v, "'", `'\''`) + "'\n")
+	}
+	_, err = f.WriteString(b.String())
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+func envDelta(env []string) []string {
+	base := make(map[string]string)
+	for _, kv := range os.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			base[k] = v
+		}
+	}
+	var out []string
+	for _, kv := range env {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			continue
+		}
+		if old, exists := base[k]; exists && old == v {
+			continue
+		}
+		out = append(out, kv)
+	}
+	return out
+}
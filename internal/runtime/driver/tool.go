@@ -0,0 +1,130 @@
+package driver
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/lazyvibe/vibemux/internal/model"
+)
+
+// PromptRunner is a driver whose CLI can answer a single prompt without
+// its interactive UI, printing the answer to stdout.
+type PromptRunner interface {
+	BuildPromptCommand(workDir string, profile *model.Profile, vars Vars, prompt string) (*exec.Cmd, error)
+}
+
+// flagSet holds the flags already on a command line.
+type flagSet map[string]bool
+
+func newFlagSet(args []string) flagSet {
+	set := make(flagSet)
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		name, _, _ := strings.Cut(arg, "=")
+		set[name] = true
+	}
+	return set
+}
+
+// has reports whether any of the flags is present.
+func (s flagSet) has(names ...string) bool {
+	for _, name := range names {
+		if s[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// withToolArgs returns a copy of profile whose command line has the
+// tool's flags appended. flags is given the flags the user already set, so
+// explicit choices win.
+func withToolArgs(profile *model.Profile, defaultCommand string, flags func(present flagSet) []string) (*model.Profile, error) {
+	p := *profile
+	command := strings.TrimSpace(p.Command)
+	if command == "" {
+		command = defaultCommand
+	}
+	parts, err := splitCommandLine(command)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) == 0 {
+		return nil, errors.New("command is empty")
+	}
+	user := append(append([]string(nil), parts[1:]...), p.CommandArgs...)
+	extra := flags(newFlagSet(user))
+	// A subcommand such as codex exec must come before the user's flags.
+	var sub []string
+	if len(extra) > 0 && !strings.HasPrefix(extra[0], "-") {
+		sub, extra = []string{extra[0]}, extra[1:]
+	}
+	p.Command = quoteCommandWord(parts[0])
+	p.CommandArgs = append(append(sub, user...), extra...)
+	return &p, nil
+}
+
+// withAgentArgs returns profile, or for a Codex or Gemini command a copy
+// with the tool's interactive flags, for drivers that launch the tool
+// indirectly.
+func withAgentArgs(profile *model.Profile) (*model.Profile, error) {
+	switch profile.Tool() {
+	case model.DriverCodex:
+		return withToolArgs(profile, "codex", func(present flagSet) []string {
+			return codexFlags(profile, present)
+		})
+	case model.DriverGemini:
+		return withToolArgs(profile, "gemini", func(present flagSet) []string {
+			return geminiFlags(profile, present)
+		})
+	}
+	return profile, nil
+}
+
+// withDefaultCommand returns profile, or a copy running command when the
+// profile has none.
+func withDefaultCommand(profile *model.Profile, command string) *model.Profile {
+	if profile == nil || strings.TrimSpace(profile.Command) != "" {
+		return profile
+	}
+	p := *profile
+	p.Command = command
+	return &p
+}
+
+// quoteCommandWord quotes an executable path so splitCommandLine keeps it
+// one word.
+func quoteCommandWord(word string) string {
+	if !strings.ContainsAny(word, " \t\"'") {
+		return word
+	}
+	if strings.Contains(word, `"`) {
+		return "'" + word + "'"
+	}
+	return `"` + word + `"`
+}
+
+// lookupEnv returns key from the profile's env vars, then the process's.
+func lookupEnv(profile *model.Profile, key string) string {
+	if v := profile.EnvVars[key]; v != "" {
+		return v
+	}
+	return os.Getenv(key)
+}
+
+func hasEnv(profile *model.Profile, key string) bool {
+	return lookupEnv(profile, key) != ""
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -4,33 +4,80 @@ import (
 	"context"
 	"errors"
 	"os"
+	"os/exec"
     "path/filepath"
     "fmt"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/lazyvibe/vibemux/internal/model"
 	"github.com/lazyvibe/vibemux/internal/runtime/driver"
 )
 
-// Engine manages PTY sessions for multiple projects.
+// Engine manages PTY sessions for multiple projects. Sessions are keyed by
+// session ID; a project's first session uses the project ID, extra ones
+// get IDs from NextSessionID.
 type Engine interface {
 	// CreateSession creates and starts a new session for a project.
 	CreateSession(ctx context.Context, project *model.Project, profile *model.Profile, rows, cols int) (Session, error)
-	// GetSession retrieves an existing session by project ID.
-	GetSession(projectID string) (Session, bool)
+	// CreateSessionWithOptions creates and starts a session with per-launch
+	// options such as an extra session ID or a working subdirectory.
+	CreateSessionWithOptions(ctx context.Context, project *model.Project, profile *model.Profile, opts SessionOptions, rows, cols int) (Session, error)
+	// GetSession retrieves an existing session by session ID.
+	GetSession(sessionID string) (Session, bool)
 	// ListSessions returns all active sessions.
 	ListSessions() []Session
+	// SessionsForProject returns the sessions belonging to a project.
+	SessionsForProject(projectID string) []Session
 	// CloseSession stops and removes a session.
-	CloseSession(projectID string) error
+	CloseSession(sessionID string) error
 	// CloseAll stops and removes all sessions.
 	CloseAll() error
 }
 
+// SessionOptions customizes a single session launch.
+type SessionOptions struct {
+	// ID keys the session; empty uses the project ID. Set it to run more
+	// than one session per project (e.g. a clone next to the original).
+	ID string
+	// WorkDir is the session's working directory; empty uses the project
+	// path. Useful to start in a monorepo subdirectory.
+	WorkDir string
+}
+
 // DefaultEngine is the default implementation of Engine.
 type DefaultEngine struct {
 	mu       sync.RWMutex
 	sessions map[string]*PTYSession
 	registry *driver.Registry
+	tmux     bool
+	// tmuxNames maps session IDs to their tmux session names in tmux mode.
+	tmuxNames map[string]string
+	// containers maps session IDs to the containers of container profiles.
+	containers map[string]driver.Container
+	// queues holds the broadcast writer of each session that received one.
+	queues map[string]*writeQueue
+	// maxSessions caps running sessions, including those still stopping;
+	// zero means no limit. Launches over the cap wait in pending, and
+	// starting counts slots reserved by launches in progress.
+	maxSessions int
+	pending     []string
+	starting    int
+	// stopGrace is passed to new sessions, see PTYSession.SetStopGrace.
+	stopGrace time.Duration
+	// stops tracks sessions still shutting down after CloseSession;
+	// stopping maps their IDs to a channel closed once they are gone.
+	stops    sync.WaitGroup
+	stopping map[string]chan struct{}
+	// secrets resolves secret references in profile env vars at launch.
+	secrets SecretResolver
+}
+
+// SecretResolver replaces secret references in a profile's env vars with
+// their values. It returns a new map and leaves env unchanged.
+type SecretResolver interface {
+	Resolve(env map[string]string) (map[string]string, error)
 }
 
 // NewEngine creates a new runtime engine.
@@ -43,70 +90,131 @@ func NewEngineWithConfig(cfg driver.Config) *DefaultEngine {
 	return &DefaultEngine{
 		sessions: make(map[string]*PTYSession),
 		registry: driver.NewRegistryWithConfig(cfg),
+		tmux:     cfg.Tmux,
+		tmuxNames: make(map[string]string),
+		containers: make(map[string]driver.Container),
+		queues:    make(map[string]*writeQueue),
+		stopping:  make(map[string]chan struct{}),
+		stopGrace: DefaultStopGrace,
 	}
 }
 
-// CreateSession creates and starts a new PTY session.
+// SetStopGrace sets how long closing a session waits for the agent to
+// exit before it is killed. It applies to sessions started afterwards.
+func (e *DefaultEngine) SetStopGrace(d time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.stopGrace = d
+}
+
+// SetSecrets sets the resolver for secret references in env vars. Nil
+// passes env vars through as written.
+func (e *DefaultEngine) SetSecrets(r SecretResolver) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.secrets = r
+}
+
+// CreateSession creates and starts a new PTY session keyed by the project ID.
 func (e *DefaultEngine) CreateSession(ctx context.Context, project *model.Project, profile *model.Profile, rows, cols int) (Session, error) {
+	return e.CreateSessionWithOptions(ctx, project, profile, SessionOptions{}, rows, cols)
+}
+
+// CreateSessionWithOptions creates and starts a PTY session using opts.
+func (e *DefaultEngine) CreateSessionWithOptions(ctx context.Context, project *model.Project, profile *model.Profile, opts SessionOptions, rows, cols int) (Session, error) {
+	if project == nil {
+		return nil, errors.New("project is nil")
+	}
+	sessionID := opts.ID
+	if sessionID == "" {
+		sessionID = project.ID
+	}
+	workDir := opts.WorkDir
+	if workDir == "" {
+		workDir = project.Path
+	}
+
+	e.mu.RLock()
+	existing, ok := e.sessions[sessionID]
+	e.mu.RUnlock()
+	if ok && existing.Status() == model.SessionStatusRunning {
+		return existing, nil
+	}
+
+	// A session closed under the same ID may still be stopping; its tmux
+	// session and container must be gone before new ones take their names.
+	if err := e.WaitStopped(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	// Wait for a free slot when the session limit is reached.
+	release, err := e.acquireSlot(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	// Work on a copy so resolved secrets never reach the caller's profile.
+	launch := *profile
+	profile = &launch
+	// Keychain lookups may prompt, so resolve before taking the lock.
+	e.mu.RLock()
+	secrets := e.secrets
+	e.mu.RUnlock()
+	if secrets != nil {
+		env, err := secrets.Resolve(profile.EnvVars)
+		if err != nil {
+			return nil, fmt.Errorf("resolve secrets: %w", err)
+		}
+		profile.EnvVars = env
+	} else {
+		env := make(map[string]string, len(profile.EnvVars))
+		for k, v := range profile.EnvVars {
+			env[k] = v
+		}
+		profile.EnvVars = env
+	}
+
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
 	// Check if session already exists
-	if existing, ok := e.sessions[project.ID]; ok {
+	if existing, ok := e.sessions[sessionID]; ok {
 		if existing.Status() == model.SessionStatusRunning {
 			return existing, nil
 		}
 		// Remove stopped session to create new one
-		delete(e.sessions, project.ID)
+		e.closeQueue(sessionID)
+		delete(e.sessions, sessionID)
 	}
 
-	// Use native driver for all profiles; command line is user-defined.
-	d, ok := e.registry.Get(model.DriverNative)
-	if !ok {
-		return nil, errors.New("driver not found: native")
+	if info, err := os.Stat(workDir); err != nil || !info.IsDir() {
+		return nil, errors.New("project path not found: " + workDir)
 	}
 
-	if project == nil {
-		return nil, errors.New("project is nil")
-	}
-	if info, err := os.Stat(project.Path); err != nil || !info.IsDir() {
-		return nil, errors.New("project path not found: " + project.Path)
-	}
-
-	// Inject CLAUDE_CONFIG_DIR for isolation if not present
-    // We isolate by Project ID to ensure multiple projects don't conflict
-    sessionConfigDir := filepath.Join(os.Getenv("USERPROFILE"), ".config", "vibemux", "sessions", project.ID)
-    if err := os.MkdirAll(sessionConfigDir, 0755); err != nil {
-        return nil, fmt.Errorf("failed to create session config dir: %w", err)
-    }
-    
-    // Copy the existing EnvVars to avoid mutating the original profile
-    if profile.EnvVars == nil {
-        profile.EnvVars = make(map[string]string)
-    } else {
-        // Deep copy needed if we reuse profile pointer, but profile here comes from caller. 
-        // To be safe, we can just modify the map if the caller (UI) doesn't reuse it for other sessions.
-        // Or we can create a temporary profile clone.
-        newEnv := make(map[string]string)
-        for k, v := range profile.EnvVars {
-            newEnv[k] = v
-        }
-        profile.EnvVars = newEnv
-    }
-    
-    // Only set if not already set by user
-    if _, ok := profile.EnvVars["CLAUDE_CONFIG_DIR"]; !ok {
-        profile.EnvVars["CLAUDE_CONFIG_DIR"] = sessionConfigDir
-    }
-
-	// Build command
-	cmd, err := d.BuildCommand(project.Path, profile)
+	cmd, err := e.buildCommand(project, profile, workDir, sessionID)
 	if err != nil {
 		return nil, err
 	}
+	if e.tmux {
+		name := driver.TmuxSessionName(project.Name, sessionID)
+		if cmd, err = driver.WrapTmux(cmd, name); err != nil {
+			return nil, err
+		}
+		e.tmuxNames[sessionID] = name
+	}
+	if c, ok := driver.ContainerFor(profile, project.Name, sessionID); ok {
+		e.containers[sessionID] = c
+	} else {
+		delete(e.containers, sessionID)
+	}
 
 	// Create session
-	session := NewPTYSession(project.ID, cmd)
+	session := NewPTYSession(sessionID, cmd)
+	session.projectID = project.ID
+	session.tool = profile.Tool()
+	session.stopGrace = profile.Stop.Grace(e.stopGrace)
+	session.stopSteps = profile.Stop.Sequence
     if rows > 0 && cols > 0 {
         session.SetInitialSize(rows, cols)
     }
@@ -117,17 +225,71 @@ func (e *DefaultEngine) CreateSession(ctx context.Context, project *model.Projec
 	}
 
 	// Store session
-	e.sessions[project.ID] = session
+	e.sessions[sessionID] = session
 
 	return session, nil
 }
 
+// LaunchArgs returns the argv a session of project with the given ID runs
+// in workDir ("" for the project path), built as CreateSessionWithOptions
+// builds it but without the tmux wrapper. Secret references in env vars
+// are left unresolved.
+func (e *DefaultEngine) LaunchArgs(project *model.Project, profile *model.Profile, workDir, sessionID string) ([]string, error) {
+	if project == nil || profile == nil {
+		return nil, errors.New("project or profile is nil")
+	}
+	if workDir == "" {
+		workDir = project.Path
+	}
+	if sessionID == "" {
+		sessionID = project.ID
+	}
+	launch := *profile
+	launch.EnvVars = make(map[string]string, len(profile.EnvVars))
+	for k, v := range profile.EnvVars {
+		launch.EnvVars[k] = v
+	}
+	cmd, err := e.buildCommand(project, &launch, workDir, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return cmd.Args, nil
+}
+
+// buildCommand builds a session's command with the profile's driver:
+// container profiles run in a container, Codex and Gemini get their tool
+// drivers and everything else runs the user-defined command line natively.
+// profile.EnvVars must be the caller's own map; the session's config
+// directory is added to it.
+func (e *DefaultEngine) buildCommand(project *model.Project, profile *model.Profile, workDir, sessionID string) (*exec.Cmd, error) {
+	d := e.registry.ForProfile(profile)
+	if d == nil {
+		return nil, errors.New("driver not found: " + string(profile.Tool()))
+	}
+
+	// Inject CLAUDE_CONFIG_DIR for isolation if not present. Projects are
+	// isolated by ID so multiple projects don't conflict.
+	sessionConfigDir := filepath.Join(os.Getenv("USERPROFILE"), ".config", "vibemux", "sessions", project.ID)
+	if err := os.MkdirAll(sessionConfigDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create session config dir: %w", err)
+	}
+	if _, ok := profile.EnvVars["CLAUDE_CONFIG_DIR"]; !ok {
+		profile.EnvVars["CLAUDE_CONFIG_DIR"] = sessionConfigDir
+	}
+
+	return d.BuildCommand(workDir, profile, driver.Vars{
+		ProjectPath: workDir,
+		ProjectName: project.Name,
+		SessionID:   sessionID,
+	})
+}
+
 // GetSession retrieves an existing session.
-func (e *DefaultEngine) GetSession(projectID string) (Session, bool) {
+func (e *DefaultEngine) GetSession(sessionID string) (Session, bool) {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
 
-	session, ok := e.sessions[projectID]
+	session, ok := e.sessions[sessionID]
 	if !ok {
 		return nil, false
 	}
@@ -146,45 +308,242 @@ func (e *DefaultEngine) ListSessions() []Session {
 	return result
 }
 
-// CloseSession stops and removes a session.
-func (e *DefaultEngine) CloseSession(projectID string) error {
+// SessionsForProject returns the sessions belonging to a project.
+func (e *DefaultEngine) SessionsForProject(projectID string) []Session {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var result []Session
+	for _, s := range e.sessions {
+		if s.ProjectID() == projectID {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// CloseSession removes a session and stops it in the background, so the
+// caller does not wait out the agent's shutdown grace period.
+func (e *DefaultEngine) CloseSession(sessionID string) error {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	session, ok := e.sessions[projectID]
+	session, ok := e.sessions[sessionID]
 	if !ok {
 		return nil
 	}
 
-	if err := session.Stop(); err != nil {
-		return err
-	}
+	// Closing a pane explicitly also ends its tmux session; quitting VibeMux
+	// (CloseAll) only detaches so agents keep running in tmux.
+	tmuxName, tmux := e.tmuxNames[sessionID]
+	delete(e.tmuxNames, sessionID)
+	container, inContainer := e.containers[sessionID]
+	delete(e.containers, sessionID)
 
-	delete(e.sessions, projectID)
+	done := make(chan struct{})
+	e.stopping[sessionID] = done
+	e.stops.Add(1)
+	go func() {
+		defer e.stops.Done()
+		_ = session.Stop()
+		if tmux {
+			_ = driver.KillTmuxSession(tmuxName)
+		}
+		if inContainer {
+			container.Remove()
+		}
+		e.mu.Lock()
+		if e.stopping[sessionID] == done {
+			delete(e.stopping, sessionID)
+		}
+		e.mu.Unlock()
+		close(done)
+	}()
+
+	e.closeQueue(sessionID)
+	delete(e.sessions, sessionID)
 	return nil
 }
 
-// CloseAll stops and removes all sessions.
+// WaitStopped waits until a session closed with CloseSession has exited
+// and its tmux session or container is gone. It returns at once when no
+// session of that ID is stopping.
+func (e *DefaultEngine) WaitStopped(ctx context.Context, sessionID string) error {
+	e.mu.RLock()
+	done := e.stopping[sessionID]
+	e.mu.RUnlock()
+	if done == nil {
+		return nil
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// CloseAll stops and removes all sessions. Sessions shut down in parallel,
+// and CloseAll returns once all of them, including those still closing
+// after CloseSession, have exited.
 func (e *DefaultEngine) CloseAll() error {
 	e.mu.Lock()
-	defer e.mu.Unlock()
-
-	var lastErr error
+	var (
+		wg      sync.WaitGroup
+		errMu   sync.Mutex
+		lastErr error
+	)
 	for id, session := range e.sessions {
-		if err := session.Stop(); err != nil {
-			lastErr = err
+		// Containers hosted by tmux keep running with it.
+		container, inContainer := e.containers[id]
+		if _, tmux := e.tmuxNames[id]; tmux {
+			inContainer = false
 		}
+		delete(e.containers, id)
+		wg.Add(1)
+		go func(session *PTYSession) {
+			defer wg.Done()
+			if err := session.Stop(); err != nil {
+				errMu.Lock()
+				lastErr = err
+				errMu.Unlock()
+			}
+			if inContainer {
+				container.Remove()
+			}
+		}(session)
+		e.closeQueue(id)
 		delete(e.sessions, id)
 	}
+	e.pending = nil
+	e.mu.Unlock()
+
+	wg.Wait()
+	e.stops.Wait()
 	return lastErr
 }
 
+// NextSessionID returns an unused session ID for another session of the
+// project: "<projectID>#2", "<projectID>#3", ...
+func (e *DefaultEngine) NextSessionID(projectID string) string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	for n := 2; ; n++ {
+		id := fmt.Sprintf("%s#%d", projectID, n)
+		if _, ok := e.sessions[id]; !ok {
+			return id
+		}
+	}
+}
+
+// SessionSuffix returns the label suffix (" #2") distinguishing an extra
+// session of a project from its first one, or "" for the first session.
+func SessionSuffix(sessionID, projectID string) string {
+	if sessionID == projectID || !strings.HasPrefix(sessionID, projectID+"#") {
+		return ""
+	}
+	return " " + strings.TrimPrefix(sessionID, projectID)
+}
+
+// RekeySession moves a running session to newID and re-associates it with
+// projectID without stopping its process. It fails if newID already has a
+// running session; a stopped leftover under newID is discarded.
+func (e *DefaultEngine) RekeySession(oldID, newID, projectID string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	session, ok := e.sessions[oldID]
+	if !ok {
+		return errors.New("session not found: " + oldID)
+	}
+	if existing, ok := e.sessions[newID]; ok {
+		if existing.Status() == model.SessionStatusRunning {
+			return errors.New("target already has a running session")
+		}
+		e.closeQueue(newID)
+		delete(e.sessions, newID)
+	}
+
+	session.setID(newID, projectID)
+	if q, ok := e.queues[oldID]; ok {
+		e.queues[newID] = q
+		delete(e.queues, oldID)
+	}
+	e.sessions[newID] = session
+	delete(e.sessions, oldID)
+	if name, ok := e.tmuxNames[oldID]; ok {
+		e.tmuxNames[newID] = name
+		delete(e.tmuxNames, oldID)
+	}
+	if c, ok := e.containers[oldID]; ok {
+		e.containers[newID] = c
+		delete(e.containers, oldID)
+	}
+	return nil
+}
+
+// TmuxSessionName returns the tmux session hosting a session, if any.
+func (e *DefaultEngine) TmuxSessionName(sessionID string) (string, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	name, ok := e.tmuxNames[sessionID]
+	return name, ok
+}
+
+// ProcessID returns the PID of the process tree running a session's agent:
+// the PTY command, or the tmux pane's process when tmux hosts the session.
+// It returns 0 when the session is not running.
+func (e *DefaultEngine) ProcessID(sessionID string) int {
+	e.mu.RLock()
+	session, ok := e.sessions[sessionID]
+	tmuxName, tmux := e.tmuxNames[sessionID]
+	e.mu.RUnlock()
+	if !ok {
+		return 0
+	}
+	pid := session.PID()
+	if pid == 0 || !tmux {
+		return pid
+	}
+	if panePID, err := driver.TmuxPanePID(tmuxName); err == nil {
+		return panePID
+	}
+	return pid
+}
+
+// KillSession ends a session's agent at once, without the stop sequence or
+// grace period, and keeps the session listed as stopped. A session hosted
+// in tmux or a container has that ended too.
+func (e *DefaultEngine) KillSession(sessionID string) error {
+	e.mu.Lock()
+	session, ok := e.sessions[sessionID]
+	tmuxName, tmux := e.tmuxNames[sessionID]
+	delete(e.tmuxNames, sessionID)
+	container, inContainer := e.containers[sessionID]
+	delete(e.containers, sessionID)
+	e.mu.Unlock()
+	if !ok {
+		return errors.New("session not found: " + sessionID)
+	}
+
+	err := session.Kill()
+	if tmux {
+		_ = driver.KillTmuxSession(tmuxName)
+	}
+	if inContainer {
+		container.Remove()
+	}
+	return err
+}
+
 // GetSessionStatus returns the status of a session without the full session.
-func (e *DefaultEngine) GetSessionStatus(projectID string) model.SessionStatus {
+func (e *DefaultEngine) GetSessionStatus(sessionID string) model.SessionStatus {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
 
-	session, ok := e.sessions[projectID]
+	session, ok := e.sessions[sessionID]
 	if !ok {
 		return model.SessionStatusIdle
 	}
@@ -1,9 +1,13 @@
 package runtime
 
 import (
+	"encoding/json"
+	"fmt"
 	"regexp"
 	"strings"
 	"unicode"
+
+	"github.com/lazyvibe/vibemux/internal/model"
 )
 
 // ANSI escape code regex - enhanced to cover more sequences
@@ -70,6 +74,12 @@ func ExtractConclusion(input string) string {
 		content = isolateFinalFrame(clean) 
 	}
 
+	return cleanupConclusion(content)
+}
+
+// cleanupConclusion removes TUI noise and repeated lines from extracted
+// content.
+func cleanupConclusion(content string) string {
 	// 4. Common Cleanup
 	// Regardless of strategy, we remove generic TUI noise (help tips, status bars)
 	// that might remain at the bottom of the output.
@@ -83,6 +93,151 @@ func ExtractConclusion(input string) string {
 	return strings.Join(result, "\n")
 }
 
+// ExtractResult is a conclusion and how it was found.
+type ExtractResult struct {
+	Text string
+	// Fallback is set when the configured strategy found nothing and the
+	// built-in heuristics were used instead.
+	Fallback bool
+}
+
+// ExtractConclusionWith extracts a conclusion with a profile's strategy.
+// When the strategy finds nothing, it falls back to ExtractConclusion.
+func ExtractConclusionWith(input string, cfg model.ExtractionConfig) (ExtractResult, error) {
+	clean := CleanOutput(input)
+	var text string
+	switch cfg.Strategy {
+	case model.ExtractAuto:
+		return ExtractResult{Text: ExtractConclusion(input)}, nil
+	case model.ExtractMarker:
+		if idx := strings.LastIndex(clean, cfg.MarkerToken()); idx != -1 {
+			text = cleanupConclusion(clean[idx+len(cfg.MarkerToken()):])
+		}
+	case model.ExtractRegex:
+		if cfg.Pattern == "" {
+			return ExtractResult{}, fmt.Errorf("regex extraction needs a pattern")
+		}
+		re, err := regexp.Compile(cfg.Pattern)
+		if err != nil {
+			return ExtractResult{}, fmt.Errorf("invalid extraction pattern: %w", err)
+		}
+		if matches := re.FindAllStringSubmatch(clean, -1); len(matches) > 0 {
+			last := matches[len(matches)-1]
+			text = last[0]
+			if len(last) > 1 {
+				text = last[1]
+			}
+		}
+	case model.ExtractLastLines:
+		lines := filterNoiseLines(strings.Split(removeTUINoise(clean), "\n"))
+		if n := cfg.LineCount(); len(lines) > n {
+			lines = lines[len(lines)-n:]
+		}
+		text = strings.Join(deduplicateConsecutive(lines), "\n")
+	case model.ExtractJSON:
+		text = lastJSONBlock(clean)
+	default:
+		return ExtractResult{}, fmt.Errorf("unknown extraction strategy %q", cfg.Strategy)
+	}
+	if text = strings.TrimSpace(text); text == "" {
+		return ExtractResult{Text: ExtractConclusion(input), Fallback: true}, nil
+	}
+	return ExtractResult{Text: text}, nil
+}
+
+// toolNoise are status and hint lines specific to one CLI, removed from
+// conclusions of its sessions only, so they cannot eat another tool's
+// answer.
+var toolNoise = map[model.DriverType][]*regexp.Regexp{
+	model.DriverCodex: {
+		// Footer hints and the input box
+		regexp.MustCompile(`(?i)(⏎|enter) send\b|ctrl\s*\+?\s*j newline|⌃[a-z] \w+`),
+		regexp.MustCompile(`^\s*▌`),
+		// Token counters and session banner
+		regexp.MustCompile(`(?i)^\s*(tokens used|token usage)[:\s]`),
+		regexp.MustCompile(`(?i)^\s*(>_ )?openai codex\b`),
+		regexp.MustCompile(`(?i)^\s*│\s*(model|directory|approval|sandbox|reasoning effort|workdir):\s.*│\s*$`),
+		regexp.MustCompile(`(?i)\(\d+s\s*•\s*esc to interrupt\)`),
+	},
+	model.DriverGemini: {
+		// Context, model and sandbox footer
+		regexp.MustCompile(`(?i)\(\d+% context left\)`),
+		regexp.MustCompile(`(?i)^\s*(no sandbox|sandbox-exec|docker|podman)\b.*\(see /docs\)`),
+		regexp.MustCompile(`(?i)^\s*using:?\s+\d+\s+\S+\.md files?`),
+		regexp.MustCompile(`(?i)accepting edits|yolo mode|shift\s*\+\s*tab to`),
+		regexp.MustCompile(`(?i)\(esc to cancel,\s*\d+s\)`),
+		regexp.MustCompile(`(?i)^\s*✦\s*$`),
+	},
+}
+
+// ExtractForTool extracts a conclusion with a profile's strategy, then
+// drops the status lines of the CLI the session runs.
+func ExtractForTool(input string, cfg model.ExtractionConfig, tool model.DriverType) (ExtractResult, error) {
+	result, err := ExtractConclusionWith(input, cfg)
+	if err != nil {
+		return result, err
+	}
+	patterns := toolNoise[tool]
+	if len(patterns) == 0 {
+		return result, nil
+	}
+	lines := strings.Split(result.Text, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		noise := false
+		for _, re := range patterns {
+			if re.MatchString(line) {
+				noise = true
+				break
+			}
+		}
+		if !noise {
+			kept = append(kept, line)
+		}
+	}
+	result.Text = strings.TrimSpace(strings.Join(kept, "\n"))
+	return result, nil
+}
+
+// jsonFenceRegex matches a fenced json code block.
+var jsonFenceRegex = regexp.MustCompile("(?s)```json[ \t]*\n(.*?)```")
+
+// jsonSearchLimit bounds how far back lastJSONBlock looks for an object.
+const jsonSearchLimit = 64 * 1024
+
+// lastJSONBlock returns the last valid fenced json block, or else the last
+// top-level JSON object in input, or "".
+func lastJSONBlock(input string) string {
+	if matches := jsonFenceRegex.FindAllStringSubmatch(input, -1); len(matches) > 0 {
+		for i := len(matches) - 1; i >= 0; i-- {
+			if block := strings.TrimSpace(matches[i][1]); json.Valid([]byte(block)) {
+				return block
+			}
+		}
+	}
+	if len(input) > jsonSearchLimit {
+		input = input[len(input)-jsonSearchLimit:]
+	}
+	// Walk back over the opening braces; an object that ends at or after
+	// the best one found so far encloses it.
+	bestStart, bestEnd := -1, -1
+	for start := strings.LastIndex(input, "{"); start >= 0; start = strings.LastIndex(input[:start], "{") {
+		var raw json.RawMessage
+		dec := json.NewDecoder(strings.NewReader(input[start:]))
+		if dec.Decode(&raw) != nil {
+			continue
+		}
+		end := start + int(dec.InputOffset())
+		if bestEnd == -1 || end >= bestEnd {
+			bestStart, bestEnd = start, end
+		}
+	}
+	if bestStart == -1 {
+		return ""
+	}
+	return input[bestStart:bestEnd]
+}
+
 // isolateFinalFrame uses a heuristic to detect the "Frame Separator" automatically.
 // It assumes that in a TUI recording, the "Status Bar" or "Header" appears repeatedly between frames.
 // We find the most frequent "complex" line and use it as the delimiter.
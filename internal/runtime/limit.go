@@ -0,0 +1,141 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lazyvibe/vibemux/internal/model"
+)
+
+// ErrLaunchCancelled is returned for a launch that was cancelled while it
+// waited for a free session slot.
+var ErrLaunchCancelled = errors.New("launch cancelled while queued")
+
+// slotPoll is how often a queued launch rechecks for a free slot. Sessions
+// that exit on their own free their slot without telling the engine.
+const slotPoll = 200 * time.Millisecond
+
+// SetMaxSessions limits how many sessions may run at once; further
+// launches wait in a first-come queue. Zero or less means no limit.
+func (e *DefaultEngine) SetMaxSessions(n int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.maxSessions = n
+}
+
+// MaxSessions returns the session limit, or zero when there is none.
+func (e *DefaultEngine) MaxSessions() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.maxSessions
+}
+
+// AtCapacity reports whether a new launch would have to wait.
+func (e *DefaultEngine) AtCapacity() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return len(e.pending) > 0 || !e.slotFree()
+}
+
+// Pending returns the IDs of launches waiting for a slot, oldest first.
+func (e *DefaultEngine) Pending() []string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return append([]string(nil), e.pending...)
+}
+
+// QueuePosition returns the 1-based place of sessionID in the launch
+// queue, or 0 if it is not waiting.
+func (e *DefaultEngine) QueuePosition(sessionID string) int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.pendingIndex(sessionID) + 1
+}
+
+// CancelPending drops a queued launch; its CreateSession call returns
+// ErrLaunchCancelled. It reports whether the launch was waiting.
+func (e *DefaultEngine) CancelPending(sessionID string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	i := e.pendingIndex(sessionID)
+	if i < 0 {
+		return false
+	}
+	e.pending = append(e.pending[:i], e.pending[i+1:]...)
+	return true
+}
+
+// pendingIndex returns the queue index of sessionID or -1. Callers hold mu.
+func (e *DefaultEngine) pendingIndex(sessionID string) int {
+	for i, id := range e.pending {
+		if id == sessionID {
+			return i
+		}
+	}
+	return -1
+}
+
+// slotFree reports whether another session may start. Sessions closed
+// with CloseSession keep their slot until they have exited. Callers hold
+// mu.
+func (e *DefaultEngine) slotFree() bool {
+	if e.maxSessions <= 0 {
+		return true
+	}
+	used := e.starting + len(e.stopping)
+	for _, s := range e.sessions {
+		if s.Status() == model.SessionStatusRunning {
+			used++
+		}
+	}
+	return used < e.maxSessions
+}
+
+// acquireSlot waits until sessionID is first in the queue and a slot is
+// free, then reserves the slot until the returned release is called.
+func (e *DefaultEngine) acquireSlot(ctx context.Context, sessionID string) (func(), error) {
+	e.mu.Lock()
+	if e.maxSessions <= 0 && len(e.pending) == 0 {
+		e.starting++
+		e.mu.Unlock()
+		return e.releaseSlot, nil
+	}
+	// A second launch under the same ID replaces the queued one.
+	if i := e.pendingIndex(sessionID); i >= 0 {
+		e.pending = append(e.pending[:i], e.pending[i+1:]...)
+	}
+	e.pending = append(e.pending, sessionID)
+	e.mu.Unlock()
+
+	ticker := time.NewTicker(slotPoll)
+	defer ticker.Stop()
+	for {
+		e.mu.Lock()
+		i := e.pendingIndex(sessionID)
+		if i < 0 {
+			e.mu.Unlock()
+			return nil, ErrLaunchCancelled
+		}
+		if i == 0 && e.slotFree() {
+			e.pending = e.pending[1:]
+			e.starting++
+			e.mu.Unlock()
+			return e.releaseSlot, nil
+		}
+		e.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			e.CancelPending(sessionID)
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *DefaultEngine) releaseSlot() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.starting--
+}
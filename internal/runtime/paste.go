@@ -0,0 +1,85 @@
+package runtime
+
+import (
+	"errors"
+	"time"
+
+	"github.com/lazyvibe/vibemux/internal/model"
+)
+
+// PasteOptions controls how large text is delivered to a session.
+type PasteOptions struct {
+	// Threshold is the largest payload written in one go.
+	Threshold int
+	// ChunkSize is the size of each write for larger payloads.
+	ChunkSize int
+	// ChunkDelay is the pause after each chunk.
+	ChunkDelay time.Duration
+	// EchoTimeout is how long to wait for the CLI to echo a chunk before
+	// writing the next one anyway.
+	EchoTimeout time.Duration
+}
+
+// DefaultPasteOptions keep multi-KB prompts intact in agent CLIs that
+// truncate or mangle single large writes.
+var DefaultPasteOptions = PasteOptions{
+	Threshold:   1024,
+	ChunkSize:   512,
+	ChunkDelay:  20 * time.Millisecond,
+	EchoTimeout: 500 * time.Millisecond,
+}
+
+// Paste writes data to the session. Payloads above opts.Threshold are split
+// into chunks; before each further chunk Paste waits until the CLI has
+// echoed the previous one (or EchoTimeout passes) and checks that the
+// session is still running. It blocks until everything is written.
+func Paste(s Session, data []byte, opts PasteOptions) error {
+	if len(data) <= opts.Threshold || opts.ChunkSize <= 0 {
+		_, err := s.Write(data)
+		return err
+	}
+	echo, cancel := s.Subscribe()
+	defer cancel()
+
+	chunks := splitWrite(data, opts.ChunkSize)
+	for i, chunk := range chunks {
+		if s.Status() != model.SessionStatusRunning {
+			return errors.New("session stopped while pasting")
+		}
+		if _, err := s.Write(chunk); err != nil {
+			return err
+		}
+		if i == len(chunks)-1 {
+			break
+		}
+		waitForEcho(echo, opts.EchoTimeout)
+		time.Sleep(opts.ChunkDelay)
+	}
+	return nil
+}
+
+// waitForEcho returns once output arrives (draining what is already
+// queued), the timeout passes or the stream is closed because the session
+// ended.
+func waitForEcho(echo <-chan []byte, timeout time.Duration) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case _, ok := <-echo:
+		if !ok {
+			return
+		}
+	case <-timer.C:
+		return
+	}
+	for {
+		select {
+		case _, ok := <-echo:
+			if !ok {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package runtime
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/lazyvibe/vibemux/internal/model"
+)
+
+// pasteSession records writes and echoes each one on its subscriber
+// stream. Only the methods Paste uses are implemented.
+type pasteSession struct {
+	Session
+	writes [][]byte
+	echo   chan []byte
+	status model.SessionStatus
+}
+
+func newPasteSession() *pasteSession {
+	return &pasteSession{echo: make(chan []byte, 64), status: model.SessionStatusRunning}
+}
+
+func (s *pasteSession) Write(data []byte) (int, error) {
+	s.writes = append(s.writes, append([]byte(nil), data...))
+	s.echo <- data
+	return len(data), nil
+}
+
+func (s *pasteSession) Status() model.SessionStatus { return s.status }
+
+func (s *pasteSession) Subscribe() (<-chan []byte, func()) { return s.echo, func() {} }
+
+func TestWaitForEchoReturnsOnClosedStream(t *testing.T) {
+	for name, echo := range map[string]chan []byte{
+		"closed before":    make(chan []byte),
+		"closed after one": make(chan []byte, 1),
+	} {
+		if cap(echo) > 0 {
+			echo <- []byte("x")
+		}
+		close(echo)
+		done := make(chan struct{})
+		go func() {
+			waitForEcho(echo, time.Hour)
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatalf("%s: waitForEcho did not return for a closed stream", name)
+		}
+	}
+}
+
+func TestWaitForEchoTimesOut(t *testing.T) {
+	start := time.Now()
+	waitForEcho(make(chan []byte), 20*time.Millisecond)
+	if time.Since(start) > time.Second {
+		t.Fatal("waitForEcho did not return after its timeout")
+	}
+}
+
+func TestPasteSmallPayloadIsOneWrite(t *testing.T) {
+	s := newPasteSession()
+	if err := Paste(s, []byte("hello"), DefaultPasteOptions); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.writes) != 1 || string(s.writes[0]) != "hello" {
+		t.Fatalf("writes = %q, want one write of hello", s.writes)
+	}
+}
+
+func TestPasteSplitsLargePayload(t *testing.T) {
+	s := newPasteSession()
+	data := bytes.Repeat([]byte("abcdefgh"), 200)
+	opts := PasteOptions{Threshold: 100, ChunkSize: 300, EchoTimeout: time.Second}
+	if err := Paste(s, data, opts); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.writes) != 6 {
+		t.Fatalf("got %d writes, want 6", len(s.writes))
+	}
+	for i, w := range s.writes {
+		if len(w) > opts.ChunkSize {
+			t.Errorf("write %d is %d bytes, over the chunk size", i, len(w))
+		}
+	}
+	if got := bytes.Join(s.writes, nil); !bytes.Equal(got, data) {
+		t.Fatal("chunks do not add up to the payload")
+	}
+}
+
+func TestPasteStopsWhenSessionStops(t *testing.T) {
+	s := newPasteSession()
+	s.status = model.SessionStatusStopped
+	opts := PasteOptions{Threshold: 1, ChunkSize: 2, EchoTimeout: time.Second}
+	if err := Paste(s, []byte("abcdef"), opts); err == nil {
+		t.Fatal("Paste into a stopped session succeeded")
+	}
+	if len(s.writes) != 0 {
+		t.Fatalf("wrote %q to a stopped session", s.writes)
+	}
+}
+
+func TestSplitWriteKeepsRunesWhole(t *testing.T) {
+	data := []byte("ééééé")
+	for _, chunk := range splitWrite(data, 3) {
+		if !bytes.Equal(bytes.ToValidUTF8(chunk, nil), chunk) {
+			t.Fatalf("chunk %q splits a rune", chunk)
+		}
+	}
+}
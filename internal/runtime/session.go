@@ -9,6 +9,8 @@ import (
 	"os/exec"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/aymanbagabas/go-pty"
 	"github.com/lazyvibe/vibemux/internal/model"
@@ -16,12 +18,18 @@ import (
 
 // Session represents a PTY session for an AI agent process.
 type Session interface {
-	// ID returns the session's unique identifier (project ID).
+	// ID returns the session's unique identifier. It equals the project ID
+	// for a project's first session.
 	ID() string
+	// ProjectID returns the ID of the project the session belongs to.
+	ProjectID() string
 	// Start launches the PTY process.
 	Start(ctx context.Context) error
-	// Stop terminates the PTY process.
+	// Stop asks the PTY process to exit and kills it if it has not after
+	// the grace period.
 	Stop() error
+	// Kill ends the PTY process at once.
+	Kill() error
 	// Write sends data to the PTY stdin.
 	Write(data []byte) (int, error)
 	// Output returns the channel for receiving PTY output.
@@ -30,11 +38,47 @@ type Session interface {
 	Status() model.SessionStatus
 	// Resize updates the PTY terminal size.
 	Resize(rows, cols uint16) error
+	// History returns the buffered output history.
+	History() []byte
+	// Subscribe returns an extra output stream for observers (dashboard,
+	// mirrors) and a function to cancel it. Slow subscribers lose chunks
+	// instead of blocking the session.
+	Subscribe() (<-chan []byte, func())
+	// AcquireInput claims the input lock for owner. It succeeds when the
+	// lock is free or already held by owner.
+	AcquireInput(owner string) bool
+	// ReleaseInput frees the input lock if owner holds it.
+	ReleaseInput(owner string)
+	// InputOwner returns the current input lock holder, or "" when free.
+	InputOwner() string
+	// DroppedBytes returns how much output was discarded because the
+	// output channel was full. History still holds it.
+	DroppedBytes() int64
+	// Interrupt sends SIGINT to the process (Ctrl+C through the pseudo
+	// console on Windows), even when the CLI reads Ctrl+C as a key.
+	Interrupt() error
+	// Tool returns the driver the agent was launched with (native, codex,
+	// gemini), so output heuristics can match the CLI.
+	Tool() model.DriverType
+	// Size returns the PTY size, so viewers can render the output at the
+	// width the agent drew it for.
+	Size() (rows, cols uint16)
+	// PID returns the process ID of the command run in the PTY, or 0 when
+	// it is not running.
+	PID() int
+	// ExitError returns how the process exited: nil while it runs or after
+	// a clean exit, else the exit status or signal.
+	ExitError() error
 }
 
+// LocalOperator is the input lock owner name used by the local TUI.
+const LocalOperator = "local"
+
 // PTYSession implements Session using creack/pty.
 type PTYSession struct {
 	id        string
+	projectID string
+	tool      model.DriverType
 	cmd       *exec.Cmd
 	pCmd      *pty.Cmd // Active PTY command
 	ptmx      pty.Pty
@@ -49,15 +93,46 @@ type PTYSession struct {
 	buffer    *RingBuffer // Output history buffer
 	initialRows uint16
 	initialCols uint16
+
+	inputOwner string // 输入锁持有者，空表示任何人都可以输入
+
+	// writes feeds writeLoop, the only goroutine that writes to the PTY;
+	// urgent jumps its queue, for Ctrl+C and stop steps.
+	writes chan writeRequest
+	urgent chan writeRequest
+	// dropped counts output bytes readLoop could not deliver.
+	dropped atomic.Int64
+	// size is the PTY size set by Resize, rows<<16 | cols; zero until the
+	// first resize.
+	size atomic.Uint32
+	// exited is closed once the process has exited.
+	exited chan struct{}
+	// stopGrace is how long Stop waits after asking the process to exit
+	// before it is killed; stopping is set while Stop waits.
+	stopGrace time.Duration
+	stopping  bool
+	// stopSteps is the stop sequence, see SetStopSequence.
+	stopSteps []string
+	// stopped is set once Stop is called, so the exit it causes is not
+	// reported as a crash.
+	stopped bool
+
+	subsMu  sync.Mutex
+	subs    map[int]chan []byte
+	nextSub int
+	ended   bool
 }
 
 // NewPTYSession creates a new PTY session.
 func NewPTYSession(id string, cmd *exec.Cmd) *PTYSession {
 	return &PTYSession{
 		id:          id,
+		projectID:   id,
 		cmd:         cmd,
 		output:      make(chan []byte, 512), // 缓冲通道，增大容量减少高输出时丢包
 		done:        make(chan struct{}),
+		exited:      make(chan struct{}),
+		stopGrace:   DefaultStopGrace,
 		status:      model.SessionStatusIdle,
 		buffer:      NewRingBuffer(50000), // ~50KB history
 		initialRows: 24,                   // Default fallback
@@ -75,11 +150,58 @@ func (s *PTYSession) SetInitialSize(rows, cols int) {
 	}
 }
 
+// DefaultStopGrace is how long Stop lets an agent flush its state (e.g.
+// write its transcript) before the process is killed.
+const DefaultStopGrace = 3 * time.Second
+
+// SetStopGrace sets how long Stop waits for the process to exit on its
+// own; zero or less kills it right away.
+func (s *PTYSession) SetStopGrace(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopGrace = d
+}
+
+// SetStopSequence sets what Stop sends, in order, until the process exits:
+// signal names such as "SIGINT" or "SIGTERM", or text typed into the CLI
+// such as "/exit". Empty sends SIGTERM.
+func (s *PTYSession) SetStopSequence(steps []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopSteps = append([]string(nil), steps...)
+}
+
 // ID returns the session identifier.
 func (s *PTYSession) ID() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.id
 }
 
+// ProjectID returns the owning project ID.
+func (s *PTYSession) ProjectID() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.projectID
+}
+
+// Tool returns the driver the agent was launched with.
+func (s *PTYSession) Tool() model.DriverType {
+	if s.tool == "" {
+		return model.DriverNative
+	}
+	return s.tool
+}
+
+// setID changes the session and project identifiers when the session is
+// re-homed.
+func (s *PTYSession) setID(id, projectID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.id = id
+	s.projectID = projectID
+}
+
 // Start launches the PTY process.
 func (s *PTYSession) Start(ctx context.Context) error {
 	s.mu.Lock()
@@ -146,8 +268,17 @@ func (s *PTYSession) Start(ctx context.Context) error {
 	}
 	s.status = model.SessionStatusRunning
 
-	// Start output reader goroutine
+	// Drop our copy of the terminal side, so reads fail and readLoop ends
+	// once the process and everything it started have exited.
+	if u, ok := ptmx.(pty.UnixPty); ok {
+		_ = u.Slave().Close()
+	}
+
+	// Start output reader and input writer goroutines
+	s.writes = make(chan writeRequest, writeQueueLen)
+	s.urgent = make(chan writeRequest, urgentQueueLen)
 	go s.readLoop()
+	go s.writeLoop(s.writes, s.urgent)
 
 	// Start process monitor monitoring
 	go s.waitLoop()
@@ -175,6 +306,7 @@ func (s *PTYSession) readLoop() {
 	for {
 		select {
 		case <-s.done:
+			s.closeSubscribers()
 			return
 		default:
 			n, err := s.ptmx.Read(buf)
@@ -185,7 +317,14 @@ func (s *PTYSession) readLoop() {
 					s.status = model.SessionStatusStopped
 				}
 				s.mu.Unlock()
+				// Let waitLoop record the exit status before the output
+				// channel reports the end.
+				select {
+				case <-s.exited:
+				case <-time.After(time.Second):
+				}
 				close(s.output)
+				s.closeSubscribers()
 				return
 			}
 			if n > 0 {
@@ -194,6 +333,7 @@ func (s *PTYSession) readLoop() {
 
 				// 存储到环形缓冲区以保存历史记录
 				s.buffer.Write(data)
+				s.publish(data)
 
 				// 非阻塞发送到 output channel
 				// 策略：优先保证最新数据，如果 channel 满了则丢弃最旧的数据
@@ -203,8 +343,9 @@ func (s *PTYSession) readLoop() {
 				default:
 					// Channel 已满，尝试丢弃一个旧数据后重试
 					select {
-					case <-s.output:
+					case old := <-s.output:
 						// 成功丢弃一个旧数据
+						s.dropped.Add(int64(len(old)))
 					default:
 						// Channel 已空（可能同时被消费），继续尝试发送
 					}
@@ -214,6 +355,7 @@ func (s *PTYSession) readLoop() {
 					default:
 						// 仍然失败，丢弃当前数据（极端情况）
 						// 数据已保存在 RingBuffer 中，不会完全丢失
+						s.dropped.Add(int64(len(data)))
 					}
 				}
 			}
@@ -225,6 +367,7 @@ func (s *PTYSession) readLoop() {
 func (s *PTYSession) waitLoop() {
 	if s.pCmd != nil {
 		err := s.pCmd.Wait()
+		close(s.exited)
 		s.mu.Lock()
 		s.exitErr = err
 		if s.status == model.SessionStatusRunning {
@@ -234,16 +377,61 @@ func (s *PTYSession) waitLoop() {
 	}
 }
 
-// Stop 终止 PTY 进程。
-// 使用 sync.Once 确保 done channel 只关闭一次，防止重复调用导致 panic。
+// Stop 终止 PTY 进程。It works through the stop sequence (SIGTERM by
+// default), giving the process the grace period to exit after each step,
+// and kills it only if it is still running after the last one.
 func (s *PTYSession) Stop() error {
+	s.mu.Lock()
+	if s.status != model.SessionStatusRunning || s.stopping {
+		s.mu.Unlock()
+		return nil
+	}
+	s.stopping = true
+	s.stopped = true
+	grace := s.stopGrace
+	steps := s.stopSteps
+	s.mu.Unlock()
+
+	// Wait without the lock so Status and Write stay responsive.
+	if grace > 0 && s.pCmd != nil && s.pCmd.Process != nil {
+		if len(steps) == 0 {
+			steps = []string{"SIGTERM"}
+		}
+	wait:
+		for _, step := range steps {
+			s.sendStopStep(step)
+			timer := time.NewTimer(grace)
+			select {
+			case <-s.exited:
+				timer.Stop()
+				break wait
+			case <-timer.C:
+			}
+		}
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.stopping = false
+	s.kill()
+	return nil
+}
 
+// Kill ends the process at once, skipping the stop sequence. It also cuts
+// short a Stop that is waiting for the process to exit.
+func (s *PTYSession) Kill() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if s.status != model.SessionStatusRunning {
 		return nil
 	}
+	s.stopped = true
+	s.kill()
+	return nil
+}
 
+// kill closes the PTY and kills the process. The caller holds s.mu.
+func (s *PTYSession) kill() {
 	// 使用 sync.Once 安全关闭 done channel
 	s.closeOnce.Do(func() {
 		close(s.done)
@@ -265,23 +453,147 @@ func (s *PTYSession) Stop() error {
 	}
 
 	s.status = model.SessionStatusStopped
+}
+
+// sendStopStep sends one step of the stop sequence: a signal such as
+// SIGINT, or text typed into the CLI and submitted with Enter.
+func (s *PTYSession) sendStopStep(step string) {
+	if isSignalName(step) {
+		if err := signalNamed(s.pCmd.Process, step); err != nil {
+			// Signals are not available (Windows); Ctrl+C comes closest.
+			_, _ = s.writeUrgent([]byte{0x03})
+		}
+		return
+	}
+	if !strings.HasSuffix(step, "\r") && !strings.HasSuffix(step, "\n") {
+		step += "\r"
+	}
+	_, _ = s.writeUrgent([]byte(step))
+}
+
+// isSignalName reports whether a stop step names a signal, e.g. "SIGTERM".
+func isSignalName(step string) bool {
+	name, ok := strings.CutPrefix(step, "SIG")
+	if !ok || name == "" {
+		return false
+	}
+	for _, r := range name {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Interrupt sends SIGINT to the process.
+func (s *PTYSession) Interrupt() error {
+	s.mu.RLock()
+	running := s.status == model.SessionStatusRunning && s.pCmd != nil && s.pCmd.Process != nil
+	s.mu.RUnlock()
+	if !running {
+		return errors.New("session not running")
+	}
+	if err := interrupt(s.pCmd.Process); err != nil {
+		_, err = s.writeUrgent([]byte{0x03})
+		return err
+	}
 	return nil
 }
 
-// Write sends data to PTY stdin.
+// writeQueueLen bounds the writes waiting for the PTY. Writers block once
+// it is full, which slows them to the pace the CLI reads its input at.
+const writeQueueLen = 64
+
+// urgentQueueLen bounds the urgent writes waiting for the PTY.
+const urgentQueueLen = 4
+
+// writeTimeout is how long Write waits for a congested PTY.
+const writeTimeout = 5 * time.Second
+
+// ErrWriteTimeout is returned when the PTY does not take input in time.
+var ErrWriteTimeout = errors.New("pty write timed out")
+
+// writeRequest is one Write waiting for writeLoop.
+type writeRequest struct {
+	data   []byte
+	result chan writeResult
+}
+
+type writeResult struct {
+	n   int
+	err error
+}
+
+// Write sends data to PTY stdin. Writes from different goroutines (typing,
+// auto-replies, broadcasts, the turn engine) are queued and written one at
+// a time, so each arrives in one piece.
 func (s *PTYSession) Write(data []byte) (int, error) {
+	return s.enqueue(data, false)
+}
+
+// writeUrgent is Write ahead of the queued writes, which still arrive
+// whole: a paste is never split by it.
+func (s *PTYSession) writeUrgent(data []byte) (int, error) {
+	return s.enqueue(data, true)
+}
+
+// enqueue hands data to writeLoop and waits for the write.
+func (s *PTYSession) enqueue(data []byte, urgent bool) (int, error) {
 	s.mu.RLock()
-	defer s.mu.RUnlock()
+	running := s.status == model.SessionStatusRunning
+	writes := s.writes
+	if urgent {
+		writes = s.urgent
+	}
+	s.mu.RUnlock()
 
-	if s.status != model.SessionStatusRunning {
+	if !running {
 		return 0, errors.New("session not running")
 	}
-
-	if s.ptmx == nil {
+	if writes == nil {
 		return 0, errors.New("pty not initialized")
 	}
 
-	return s.ptmx.Write(data)
+	req := writeRequest{data: data, result: make(chan writeResult, 1)}
+	timer := time.NewTimer(writeTimeout)
+	defer timer.Stop()
+	select {
+	case writes <- req:
+	case <-s.done:
+		return 0, errors.New("session not running")
+	case <-timer.C:
+		return 0, ErrWriteTimeout
+	}
+	select {
+	case res := <-req.result:
+		return res.n, res.err
+	case <-s.done:
+		return 0, errors.New("session not running")
+	case <-timer.C:
+		return 0, ErrWriteTimeout
+	}
+}
+
+// writeLoop writes queued input to the PTY until the session stops,
+// urgent writes first.
+func (s *PTYSession) writeLoop(writes, urgent <-chan writeRequest) {
+	for {
+		var req writeRequest
+		select {
+		case <-s.done:
+			return
+		case req = <-urgent:
+		default:
+			select {
+			case <-s.done:
+				return
+			case req = <-urgent:
+			case req = <-writes:
+			}
+		}
+		n, err := s.ptmx.Write(req.data)
+		req.result <- writeResult{n: n, err: err}
+	}
 }
 
 // Output returns the output channel.
@@ -310,24 +622,135 @@ func (s *PTYSession) Resize(rows, cols uint16) error {
     if err := s.ptmx.Resize(int(cols), int(rows)); err != nil {
         return err
     }
+	s.size.Store(uint32(rows)<<16 | uint32(cols))
     
     // Send ANSI escape sequence to force terminal redraw
     // CSI 8 ; rows ; cols t = Resize window to rows x cols (xterm)
     // Some terminals may ignore this, but sending it won't hurt.
     // Also send a simple query that forces apps to update their size.
     // We use DSR (Device Status Report, CSI 6 n) to prompt a response.
-    _, _ = s.ptmx.Write([]byte("\x1b[6n"))
+    // Queued behind pending input instead of cutting into it; skipped when
+    // the queue is full.
+    if s.writes != nil {
+        select {
+        case s.writes <- writeRequest{data: []byte("\x1b[6n"), result: make(chan writeResult, 1)}:
+        default:
+        }
+    }
     return nil
 }
 
+// Size returns the PTY size.
+func (s *PTYSession) Size() (rows, cols uint16) {
+	if size := s.size.Load(); size != 0 {
+		return uint16(size >> 16), uint16(size)
+	}
+	return s.initialRows, s.initialCols
+}
+
+// Subscribe registers an additional output observer.
+func (s *PTYSession) Subscribe() (<-chan []byte, func()) {
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+
+	ch := make(chan []byte, 256)
+	if s.ended {
+		close(ch)
+		return ch, func() {}
+	}
+	if s.subs == nil {
+		s.subs = make(map[int]chan []byte)
+	}
+	id := s.nextSub
+	s.nextSub++
+	s.subs[id] = ch
+
+	return ch, func() {
+		s.subsMu.Lock()
+		defer s.subsMu.Unlock()
+		if c, ok := s.subs[id]; ok {
+			delete(s.subs, id)
+			close(c)
+		}
+	}
+}
+
+// publish 非阻塞地把输出分发给所有订阅者，慢订阅者直接丢弃数据。
+func (s *PTYSession) publish(data []byte) {
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+	for _, ch := range s.subs {
+		select {
+		case ch <- data:
+		default:
+		}
+	}
+}
+
+func (s *PTYSession) closeSubscribers() {
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+	s.ended = true
+	for id, ch := range s.subs {
+		close(ch)
+		delete(s.subs, id)
+	}
+}
+
+// AcquireInput claims the input lock for owner.
+func (s *PTYSession) AcquireInput(owner string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.inputOwner != "" && s.inputOwner != owner {
+		return false
+	}
+	s.inputOwner = owner
+	return true
+}
+
+// ReleaseInput frees the input lock if owner holds it.
+func (s *PTYSession) ReleaseInput(owner string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.inputOwner == owner {
+		s.inputOwner = ""
+	}
+}
+
+// InputOwner returns the current input lock holder.
+func (s *PTYSession) InputOwner() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.inputOwner
+}
+
 // History returns the buffered output history.
 func (s *PTYSession) History() []byte {
 	return s.buffer.Bytes()
 }
 
-// ExitError returns the process exit error if any.
+// DroppedBytes returns how much output readLoop discarded.
+func (s *PTYSession) DroppedBytes() int64 {
+	return s.dropped.Load()
+}
+
+// PID returns the process ID of the PTY command, or 0 when not running.
+func (s *PTYSession) PID() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.status != model.SessionStatusRunning || s.pCmd == nil || s.pCmd.Process == nil {
+		return 0
+	}
+	return s.pCmd.Process.Pid
+}
+
+// ExitError returns the process exit error if any. Exits caused by Stop
+// are not errors.
 func (s *PTYSession) ExitError() error {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	if s.stopped {
+		return nil
+	}
 	return s.exitErr
 }
@@ -0,0 +1,180 @@
+package runtime
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogOptions configures a session output log.
+type LogOptions struct {
+	// Dir receives the log files, e.g. <configDir>/logs/<project>.
+	Dir string
+	// MaxBytes starts a new file once the current one reaches this size;
+	// zero never rotates.
+	MaxBytes int64
+	// Keep is how many log files Dir keeps; older ones are deleted. Zero
+	// keeps all of them.
+	Keep int
+}
+
+// logFlushInterval bounds how stale a log file may be while output flows.
+const logFlushInterval = time.Second
+
+// SessionLog records everything a session prints to timestamped files.
+type SessionLog struct {
+	id     string
+	opts   LogOptions
+	cancel func()
+	done   chan struct{}
+
+	mu   sync.Mutex
+	path string
+	file *os.File
+	buf  *bufio.Writer
+	size int64
+}
+
+// StartLog records the output of s until the session ends or Close is
+// called.
+func StartLog(s Session, opts LogOptions) (*SessionLog, error) {
+	if err := os.MkdirAll(opts.Dir, 0755); err != nil {
+		return nil, err
+	}
+	l := &SessionLog{id: s.ID(), opts: opts, done: make(chan struct{})}
+	if err := l.rotate(); err != nil {
+		return nil, err
+	}
+	out, cancel := s.Subscribe()
+	l.cancel = cancel
+	go l.run(out)
+	return l, nil
+}
+
+// Path returns the file currently being written.
+func (l *SessionLog) Path() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.path
+}
+
+// Flush writes buffered output to the file.
+func (l *SessionLog) Flush() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.buf != nil {
+		_ = l.buf.Flush()
+	}
+}
+
+// Close stops recording and closes the file once buffered output is
+// written.
+func (l *SessionLog) Close() {
+	l.cancel()
+	<-l.done
+}
+
+func (l *SessionLog) run(out <-chan []byte) {
+	ticker := time.NewTicker(logFlushInterval)
+	defer close(l.done)
+	defer ticker.Stop()
+	defer l.closeFile()
+	for {
+		select {
+		case data, ok := <-out:
+			if !ok {
+				return
+			}
+			l.write(data)
+		case <-ticker.C:
+			l.Flush()
+		}
+	}
+}
+
+func (l *SessionLog) write(data []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.buf == nil {
+		return
+	}
+	if l.opts.MaxBytes > 0 && l.size > 0 && l.size+int64(len(data)) > l.opts.MaxBytes {
+		if err := l.rotateLocked(); err != nil {
+			return
+		}
+	}
+	n, _ := l.buf.Write(data)
+	l.size += int64(n)
+}
+
+func (l *SessionLog) rotate() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rotateLocked()
+}
+
+// rotateLocked closes the current file and opens a new one. Callers hold mu.
+func (l *SessionLog) rotateLocked() error {
+	l.closeFileLocked()
+	name := strings.ReplaceAll(l.id, "#", "-") + "-" + time.Now().Format("20060102-150405.000") + ".log"
+	path := filepath.Join(l.opts.Dir, name)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	l.path, l.file, l.buf, l.size = path, f, bufio.NewWriter(f), 0
+	pruneLogs(l.opts.Dir, l.opts.Keep)
+	return nil
+}
+
+func (l *SessionLog) closeFile() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.closeFileLocked()
+}
+
+func (l *SessionLog) closeFileLocked() {
+	if l.file == nil {
+		return
+	}
+	_ = l.buf.Flush()
+	_ = l.file.Close()
+	l.file, l.buf = nil, nil
+}
+
+// pruneLogs deletes the oldest .log files in dir beyond keep.
+func pruneLogs(dir string, keep int) {
+	if keep <= 0 {
+		return
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	type logFile struct {
+		path string
+		mod  time.Time
+	}
+	var logs []logFile
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".log" {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		logs = append(logs, logFile{filepath.Join(dir, e.Name()), info.ModTime()})
+	}
+	if len(logs) <= keep {
+		return
+	}
+	sort.Slice(logs, func(i, j int) bool { return logs[i].mod.Before(logs[j].mod) })
+	for _, lf := range logs[:len(logs)-keep] {
+		_ = os.Remove(lf.path)
+	}
+}
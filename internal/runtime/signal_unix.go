@@ -0,0 +1,31 @@
+//go:build !windows
+
+package runtime
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// stopSignals are the signals a stop sequence may send.
+var stopSignals = map[string]syscall.Signal{
+	"SIGINT":  syscall.SIGINT,
+	"SIGTERM": syscall.SIGTERM,
+	"SIGHUP":  syscall.SIGHUP,
+	"SIGQUIT": syscall.SIGQUIT,
+}
+
+// signalNamed sends the process a signal given by name, e.g. "SIGTERM".
+func signalNamed(p *os.Process, name string) error {
+	sig, ok := stopSignals[name]
+	if !ok {
+		return fmt.Errorf("unknown signal %s", name)
+	}
+	return p.Signal(sig)
+}
+
+// interrupt sends the process SIGINT.
+func interrupt(p *os.Process) error {
+	return p.Signal(syscall.SIGINT)
+}
@@ -0,0 +1,19 @@
+//go:build windows
+
+package runtime
+
+import (
+	"errors"
+	"os"
+)
+
+// signalNamed cannot signal a console process on Windows; Stop sends
+// Ctrl+C through the pseudo console instead.
+func signalNamed(p *os.Process, name string) error {
+	return errors.New("signals not supported")
+}
+
+// interrupt cannot signal either; callers write Ctrl+C instead.
+func interrupt(p *os.Process) error {
+	return errors.New("signals not supported")
+}
@@ -0,0 +1,100 @@
+package runtime
+
+import (
+	"sync"
+	"time"
+)
+
+// ProcessUsage is the resource use of a session's process tree.
+type ProcessUsage struct {
+	// CPU is the share of one core used, in percent, since the previous
+	// sample.
+	CPU float64
+	// RSS is the resident memory in bytes.
+	RSS uint64
+	// Procs counts the processes in the tree.
+	Procs int
+}
+
+// procInfo is one row of the system process table.
+type procInfo struct {
+	pid, ppid int
+	rss       uint64
+	// cpuTime is the CPU time used so far. Tables that report a ready
+	// percentage instead set cpuPercent and hasPercent.
+	cpuTime    time.Duration
+	cpuPercent float64
+	hasPercent bool
+}
+
+// ResourceMonitor samples the CPU and memory use of process trees. CPU use
+// is measured between consecutive samples, so the first one reports none
+// where the system only gives CPU time.
+type ResourceMonitor struct {
+	mu   sync.Mutex
+	prev map[int]time.Duration
+	at   time.Time
+}
+
+// NewResourceMonitor creates a monitor with no previous sample.
+func NewResourceMonitor() *ResourceMonitor {
+	return &ResourceMonitor{}
+}
+
+// Sample measures the process tree under each root PID and returns the
+// usage under the same keys. Roots that are not running are left out.
+func (m *ResourceMonitor) Sample(roots map[string]int) (map[string]ProcessUsage, error) {
+	procs, err := processTable()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	elapsed := now.Sub(m.at)
+	byPID := make(map[int]procInfo, len(procs))
+	children := make(map[int][]int, len(procs))
+	for _, p := range procs {
+		byPID[p.pid] = p
+		children[p.ppid] = append(children[p.ppid], p.pid)
+	}
+
+	out := make(map[string]ProcessUsage, len(roots))
+	for key, root := range roots {
+		if _, ok := byPID[root]; !ok || root <= 0 {
+			continue
+		}
+		var u ProcessUsage
+		seen := make(map[int]bool)
+		stack := []int{root}
+		for len(stack) > 0 {
+			pid := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if seen[pid] {
+				continue
+			}
+			seen[pid] = true
+			p := byPID[pid]
+			u.Procs++
+			u.RSS += p.rss
+			switch {
+			case p.hasPercent:
+				u.CPU += p.cpuPercent
+			case !m.at.IsZero() && elapsed > 0:
+				if last, ok := m.prev[pid]; ok && p.cpuTime >= last {
+					u.CPU += float64(p.cpuTime-last) / float64(elapsed) * 100
+				}
+			}
+			stack = append(stack, children[pid]...)
+		}
+		out[key] = u
+	}
+
+	m.prev = make(map[int]time.Duration, len(procs))
+	for _, p := range procs {
+		m.prev[p.pid] = p.cpuTime
+	}
+	m.at = now
+	return out, nil
+}
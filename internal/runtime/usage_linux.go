@@ -0,0 +1,57 @@
+//go:build linux
+
+package runtime
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// clockTicks is USER_HZ, the unit of CPU times in /proc, which is 100 on
+// every Linux platform Go supports.
+const clockTicks = 100
+
+// processTable reads every process from /proc.
+func processTable() ([]procInfo, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+	pageSize := uint64(os.Getpagesize())
+	procs := make([]procInfo, 0, len(entries))
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		// Processes may exit while the table is read.
+		data, err := os.ReadFile("/proc/" + entry.Name() + "/stat")
+		if err != nil {
+			continue
+		}
+		// The command name may contain spaces and parentheses; the fields
+		// after the last ')' start with the state.
+		stat := string(data)
+		end := strings.LastIndexByte(stat, ')')
+		if end < 0 {
+			continue
+		}
+		fields := strings.Fields(stat[end+1:])
+		if len(fields) < 22 {
+			continue
+		}
+		ppid, _ := strconv.Atoi(fields[1])
+		utime, _ := strconv.ParseUint(fields[11], 10, 64)
+		stime, _ := strconv.ParseUint(fields[12], 10, 64)
+		rss, _ := strconv.ParseUint(fields[21], 10, 64)
+		procs = append(procs, procInfo{
+			pid:     pid,
+			ppid:    ppid,
+			rss:     rss * pageSize,
+			cpuTime: time.Duration(utime+stime) * time.Second / clockTicks,
+		})
+	}
+	return procs, nil
+}
@@ -0,0 +1,40 @@
+//go:build !linux && !windows
+
+package runtime
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// processTable lists every process with ps, which reports a decaying CPU
+// percentage rather than CPU time on macOS and the BSDs.
+func processTable() ([]procInfo, error) {
+	out, err := exec.Command("ps", "-A", "-o", "pid=", "-o", "ppid=", "-o", "rss=", "-o", "%cpu=").Output()
+	if err != nil {
+		return nil, err
+	}
+	var procs []procInfo
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		pid, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		ppid, _ := strconv.Atoi(fields[1])
+		rssKB, _ := strconv.ParseUint(fields[2], 10, 64)
+		cpu, _ := strconv.ParseFloat(strings.Replace(fields[3], ",", ".", 1), 64)
+		procs = append(procs, procInfo{
+			pid:        pid,
+			ppid:       ppid,
+			rss:        rssKB * 1024,
+			cpuPercent: cpu,
+			hasPercent: true,
+		})
+	}
+	return procs, nil
+}
@@ -0,0 +1,11 @@
+//go:build windows
+
+package runtime
+
+import "errors"
+
+// processTable is not implemented on Windows, where walking process trees
+// needs the Toolhelp API.
+func processTable() ([]procInfo, error) {
+	return nil, errors.New("resource monitoring is not supported on Windows")
+}
@@ -0,0 +1,143 @@
+package secrets
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// kdfIterations is the PBKDF2-SHA256 work factor for new files.
+const kdfIterations = 600000
+
+// sealedFile is the on-disk format of a passphrase-encrypted secrets file:
+// a JSON object of names to values, sealed with AES-256-GCM under a key
+// derived from the passphrase.
+type sealedFile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Data       []byte `json:"data"`
+}
+
+// ErrPassphrase is returned when a secrets file does not open with the
+// passphrase given.
+var ErrPassphrase = errors.New("wrong passphrase for the secrets file")
+
+// Seal encrypts values with the passphrase.
+func Seal(values map[string]string, passphrase string) ([]byte, error) {
+	plain, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	sealed := sealedFile{Version: 1, KDF: "pbkdf2-sha256", Iterations: kdfIterations, Salt: make([]byte, 16)}
+	if _, err := rand.Read(sealed.Salt); err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(passphrase, sealed.Salt, sealed.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	sealed.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(sealed.Nonce); err != nil {
+		return nil, err
+	}
+	sealed.Data = gcm.Seal(nil, sealed.Nonce, plain, nil)
+	return json.MarshalIndent(sealed, "", "  ")
+}
+
+// Open decrypts content made by Seal.
+func Open(content []byte, passphrase string) (map[string]string, error) {
+	var sealed sealedFile
+	if err := json.Unmarshal(content, &sealed); err != nil {
+		return nil, fmt.Errorf("not a secrets file: %w", err)
+	}
+	if sealed.Version != 1 || sealed.KDF != "pbkdf2-sha256" {
+		return nil, fmt.Errorf("unsupported secrets file version %d (%s)", sealed.Version, sealed.KDF)
+	}
+	gcm, err := newGCM(passphrase, sealed.Salt, sealed.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed.Nonce) != gcm.NonceSize() {
+		return nil, errors.New("corrupt secrets file")
+	}
+	plain, err := gcm.Open(nil, sealed.Nonce, sealed.Data, nil)
+	if err != nil {
+		return nil, ErrPassphrase
+	}
+	values := make(map[string]string)
+	if err := json.Unmarshal(plain, &values); err != nil {
+		return nil, fmt.Errorf("corrupt secrets file: %w", err)
+	}
+	return values, nil
+}
+
+func newGCM(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// ReadFile decrypts the secrets file.
+func ReadFile(file, passphrase string) (map[string]string, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return Open(content, passphrase)
+}
+
+// WriteFile encrypts values into the secrets file, readable by the owner
+// only.
+func WriteFile(file string, values map[string]string, passphrase string) error {
+	content, err := Seal(values, passphrase)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, append(content, '\n'), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
+
+// readAgeFile decrypts an age-encrypted JSON object of names to values with
+// the age CLI and the identity file.
+func readAgeFile(file, identity string) (map[string]string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("age", "--decrypt", "--identity", identity, file)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("age: %s", msg)
+		}
+		return nil, fmt.Errorf("age: %w", err)
+	}
+	values := make(map[string]string)
+	if err := json.Unmarshal(stdout.Bytes(), &values); err != nil {
+		return nil, fmt.Errorf("%s is not a JSON object of secrets: %w", file, err)
+	}
+	return values, nil
+}
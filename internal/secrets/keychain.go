@@ -0,0 +1,50 @@
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// keychainGet reads "<service>[/<account>]" from the OS keychain: the
+// login keychain on macOS, the Secret Service (secret-tool) elsewhere.
+func keychainGet(ref string) (string, error) {
+	service, account, _ := strings.Cut(ref, "/")
+	if service == "" {
+		return "", errors.New("keychain reference needs a service: keychain:<service>[/<account>]")
+	}
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		args := []string{"find-generic-password", "-s", service, "-w"}
+		if account != "" {
+			args = append(args, "-a", account)
+		}
+		cmd = exec.Command("security", args...)
+	case "windows":
+		return "", errors.New("keychain references are not supported on Windows; use secret:<name>")
+	default:
+		args := []string{"lookup", "service", service}
+		if account != "" {
+			args = append(args, "account", account)
+		}
+		cmd = exec.Command("secret-tool", args...)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = errors.New(msg)
+		}
+		return "", fmt.Errorf("keychain %s: %w", ref, err)
+	}
+	value := strings.TrimRight(stdout.String(), "\r\n")
+	if value == "" {
+		return "", fmt.Errorf("keychain %s: not found", ref)
+	}
+	return value, nil
+}
@@ -0,0 +1,145 @@
+// Package secrets keeps API keys out of data.json. A profile env var may
+// hold a reference instead of a value:
+//
+//	keychain:<service>[/<account>]  an OS keychain entry
+//	secret:<name>                   an entry of the encrypted secrets file
+//
+// References are resolved only when a session is launched, and the values
+// are handed to the agent's environment without being stored.
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Reference prefixes of env var values.
+const (
+	KeychainPrefix = "keychain:"
+	FilePrefix     = "secret:"
+)
+
+// PassphraseEnv unlocks the secrets file without a prompt.
+const PassphraseEnv = "VIBEMUX_SECRETS_PASSPHRASE"
+
+// ErrLocked is returned when a secret: reference needs the passphrase of
+// the secrets file and none was given.
+var ErrLocked = errors.New("secrets file is locked (run :secrets unlock or set " + PassphraseEnv + ")")
+
+// IsRef reports whether an env var value is a secret reference.
+func IsRef(value string) bool {
+	return strings.HasPrefix(value, KeychainPrefix) || strings.HasPrefix(value, FilePrefix)
+}
+
+// Resolver resolves secret references. The secrets file is read on every
+// launch so edits apply at once; only its passphrase is kept in memory.
+type Resolver struct {
+	// file is the secrets file; identity is the age identity used to
+	// decrypt it, empty for a passphrase-encrypted file.
+	file     string
+	identity string
+
+	mu         sync.Mutex
+	passphrase string
+}
+
+// NewResolver creates a resolver for the secrets file. The passphrase is
+// taken from PassphraseEnv when set.
+func NewResolver(file, identity string) *Resolver {
+	return &Resolver{file: file, identity: identity, passphrase: os.Getenv(PassphraseEnv)}
+}
+
+// File returns the secrets file path.
+func (r *Resolver) File() string {
+	return r.file
+}
+
+// Age reports whether the secrets file is decrypted with age.
+func (r *Resolver) Age() bool {
+	return r.identity != ""
+}
+
+// Unlock checks the passphrase against the secrets file and keeps it for
+// later launches. A missing file accepts any passphrase, which is then
+// used to create it.
+func (r *Resolver) Unlock(passphrase string) error {
+	if _, err := ReadFile(r.file, passphrase); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	r.mu.Lock()
+	r.passphrase = passphrase
+	r.mu.Unlock()
+	return nil
+}
+
+// Lock forgets the passphrase.
+func (r *Resolver) Lock() {
+	r.mu.Lock()
+	r.passphrase = ""
+	r.mu.Unlock()
+}
+
+// Resolve returns a copy of env with references replaced by their values.
+// Plain values are copied unchanged.
+func (r *Resolver) Resolve(env map[string]string) (map[string]string, error) {
+	resolved := make(map[string]string, len(env))
+	var values map[string]string
+	for k, v := range env {
+		switch {
+		case strings.HasPrefix(v, KeychainPrefix):
+			secret, err := keychainGet(strings.TrimPrefix(v, KeychainPrefix))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			resolved[k] = secret
+		case strings.HasPrefix(v, FilePrefix):
+			if values == nil {
+				var err error
+				if values, err = r.values(); err != nil {
+					return nil, fmt.Errorf("%s: %w", k, err)
+				}
+			}
+			name := strings.TrimPrefix(v, FilePrefix)
+			secret, ok := values[name]
+			if !ok {
+				return nil, fmt.Errorf("%s: no secret %q in %s", k, name, r.file)
+			}
+			resolved[k] = secret
+		default:
+			resolved[k] = v
+		}
+	}
+	return resolved, nil
+}
+
+// Names lists the entries of the secrets file.
+func (r *Resolver) Names() ([]string, error) {
+	values, err := r.values()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// values decrypts the secrets file.
+func (r *Resolver) values() (map[string]string, error) {
+	if r.identity != "" {
+		return readAgeFile(r.file, r.identity)
+	}
+	r.mu.Lock()
+	passphrase := r.passphrase
+	r.mu.Unlock()
+	if passphrase == "" {
+		return nil, ErrLocked
+	}
+	return ReadFile(r.file, passphrase)
+}
@@ -0,0 +1,602 @@
+package store
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lazyvibe/vibemux/internal/model"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Bucket names of the bolt database.
+var (
+	bucketProjects  = []byte("projects")
+	bucketProfiles  = []byte("profiles")
+	bucketTemplates = []byte("templates")
+	bucketSessions  = []byte("sessions")
+	bucketChains    = []byte("chains")
+	bucketMeta      = []byte("meta")
+)
+
+// keyMigrated marks a database that has imported data.json.
+var keyMigrated = []byte("migrated_from_json")
+
+// boltLockTimeout bounds the wait for another process holding the database.
+const boltLockTimeout = 3 * time.Second
+
+// record wraps a stored item with its insertion order, so lists come back
+// in the order items were added, like in data.json.
+type record[T any] struct {
+	Seq  uint64 `json:"seq"`
+	Item T      `json:"item"`
+}
+
+// BoltStore implements Store and HistoryStore in a bbolt database
+// (data.db). Every call opens the database for a single transaction, so
+// other vibemux processes (mirror, run) can use it in between, and each
+// change is written atomically.
+type BoltStore struct {
+	path     string
+	jsonPath string
+
+	mu     sync.RWMutex
+	shared *Shared // Read-only profiles merged into the profile list
+}
+
+// NewBoltStore opens or creates data.db in configDir. A new database
+// imports data.json when there is one.
+func NewBoltStore(configDir string) (*BoltStore, error) {
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return nil, err
+	}
+	s := &BoltStore{
+		path:     filepath.Join(configDir, "data.db"),
+		jsonPath: filepath.Join(configDir, "data.json"),
+	}
+	err := s.update(func(tx *bolt.Tx) error {
+		// Databases created before the template library get the defaults.
+		newTemplates := tx.Bucket(bucketTemplates) == nil
+		for _, name := range [][]byte{bucketProjects, bucketProfiles, bucketTemplates, bucketSessions, bucketChains, bucketMeta} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		if tx.Bucket(bucketMeta).Get(keyMigrated) != nil {
+			if newTemplates {
+				return putTemplates(tx.Bucket(bucketTemplates), model.DefaultTemplates())
+			}
+			return nil
+		}
+		if err := s.importJSON(tx); err != nil {
+			return err
+		}
+		return tx.Bucket(bucketMeta).Put(keyMigrated, []byte(time.Now().Format(time.RFC3339)))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// open opens the database for one transaction.
+func (s *BoltStore) open(readOnly bool) (*bolt.DB, error) {
+	return bolt.Open(s.path, 0600, &bolt.Options{Timeout: boltLockTimeout, ReadOnly: readOnly})
+}
+
+func (s *BoltStore) view(fn func(tx *bolt.Tx) error) error {
+	db, err := s.open(true)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.View(fn)
+}
+
+func (s *BoltStore) update(fn func(tx *bolt.Tx) error) error {
+	db, err := s.open(false)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.Update(fn)
+}
+
+// importJSON replaces projects, profiles and templates with the content of
+// data.json, or adds the default profile when there is no data.json.
+func (s *BoltStore) importJSON(tx *bolt.Tx) error {
+	d := data{}
+	content, err := os.ReadFile(s.jsonPath)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(content, &d); err != nil {
+			return err
+		}
+	case os.IsNotExist(err):
+		d.Profiles = []model.Profile{*model.DefaultProfile()}
+	default:
+		return err
+	}
+	if d.Templates == nil {
+		d.Templates = model.DefaultTemplates()
+	}
+	for _, name := range [][]byte{bucketProjects, bucketProfiles, bucketTemplates} {
+		if err := tx.DeleteBucket(name); err != nil && !errors.Is(err, bolt.ErrBucketNotFound) {
+			return err
+		}
+		if _, err := tx.CreateBucket(name); err != nil {
+			return err
+		}
+	}
+	for i := range d.Projects {
+		if err := putRecord(tx.Bucket(bucketProjects), d.Projects[i].ID, d.Projects[i]); err != nil {
+			return err
+		}
+	}
+	for i := range d.Profiles {
+		normalizeProfile(&d.Profiles[i])
+		if err := putRecord(tx.Bucket(bucketProfiles), d.Profiles[i].ID, d.Profiles[i]); err != nil {
+			return err
+		}
+	}
+	return putTemplates(tx.Bucket(bucketTemplates), d.Templates)
+}
+
+// putTemplates stores templates in order.
+func putTemplates(b *bolt.Bucket, templates []model.Template) error {
+	for i := range templates {
+		if err := putRecord(b, templates[i].ID, templates[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reload replaces the database's projects, profiles and templates with
+// data.json, e.g. after a config bundle was imported.
+func (s *BoltStore) Reload() error {
+	return s.update(s.importJSON)
+}
+
+// Snapshot writes projects, profiles and templates to data.json, so config
+// bundles and older versions see the current data.
+func (s *BoltStore) Snapshot() error {
+	d := data{}
+	err := s.view(func(tx *bolt.Tx) error {
+		var err error
+		if d.Projects, err = listRecords[model.Project](tx.Bucket(bucketProjects)); err != nil {
+			return err
+		}
+		if d.Profiles, err = listRecords[model.Profile](tx.Bucket(bucketProfiles)); err != nil {
+			return err
+		}
+		d.Templates, err = listRecords[model.Template](tx.Bucket(bucketTemplates))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if d.Templates == nil {
+		d.Templates = []model.Template{}
+	}
+	content, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmpPath := s.jsonPath + ".tmp"
+	if err := os.WriteFile(tmpPath, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, s.jsonPath)
+}
+
+// Close is a no-op: the database is only open during a transaction.
+func (s *BoltStore) Close() error {
+	return nil
+}
+
+// putRecord stores item under id, keeping the sequence of an existing
+// record.
+func putRecord[T any](b *bolt.Bucket, id string, item T) error {
+	rec := record[T]{Item: item}
+	if existing := b.Get([]byte(id)); existing != nil {
+		var old record[T]
+		if err := json.Unmarshal(existing, &old); err != nil {
+			return err
+		}
+		rec.Seq = old.Seq
+	} else {
+		seq, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		rec.Seq = seq
+	}
+	content, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return b.Put([]byte(id), content)
+}
+
+// getRecord loads the item stored under id.
+func getRecord[T any](b *bolt.Bucket, id string) (*T, error) {
+	content := b.Get([]byte(id))
+	if content == nil {
+		return nil, ErrNotFound
+	}
+	var rec record[T]
+	if err := json.Unmarshal(content, &rec); err != nil {
+		return nil, err
+	}
+	return &rec.Item, nil
+}
+
+// listRecords returns a bucket's items in insertion order.
+func listRecords[T any](b *bolt.Bucket) ([]T, error) {
+	var recs []record[T]
+	err := b.ForEach(func(_, v []byte) error {
+		var rec record[T]
+		if err := json.Unmarshal(v, &rec); err != nil {
+			return err
+		}
+		recs = append(recs, rec)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(recs, func(i, j int) bool { return recs[i].Seq < recs[j].Seq })
+	items := make([]T, len(recs))
+	for i := range recs {
+		items[i] = recs[i].Item
+	}
+	return items, nil
+}
+
+// ---------- ProjectStore Implementation ----------
+
+// List returns all projects sorted by LastUsed descending.
+func (s *BoltStore) List(_ context.Context) ([]model.Project, error) {
+	var result []model.Project
+	err := s.view(func(tx *bolt.Tx) error {
+		var err error
+		result, err = listRecords[model.Project](tx.Bucket(bucketProjects))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].LastUsed > result[j].LastUsed
+	})
+	return result, nil
+}
+
+// Get retrieves a project by ID.
+func (s *BoltStore) Get(_ context.Context, id string) (*model.Project, error) {
+	var p *model.Project
+	err := s.view(func(tx *bolt.Tx) error {
+		var err error
+		p, err = getRecord[model.Project](tx.Bucket(bucketProjects), id)
+		return err
+	})
+	return p, err
+}
+
+// Create adds a new project.
+func (s *BoltStore) Create(_ context.Context, p *model.Project) error {
+	return s.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketProjects)
+		if b.Get([]byte(p.ID)) != nil {
+			return ErrAlreadyExists
+		}
+		// Assign default profile if not set
+		if p.ProfileID == "" {
+			profiles, err := listRecords[model.Profile](tx.Bucket(bucketProfiles))
+			if err != nil {
+				return err
+			}
+			for _, profile := range profiles {
+				if profile.IsDefault {
+					p.ProfileID = profile.ID
+					break
+				}
+			}
+		}
+		return putRecord(b, p.ID, *p)
+	})
+}
+
+// Update modifies an existing project.
+func (s *BoltStore) Update(_ context.Context, p *model.Project) error {
+	return s.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketProjects)
+		if b.Get([]byte(p.ID)) == nil {
+			return ErrNotFound
+		}
+		return putRecord(b, p.ID, *p)
+	})
+}
+
+// Delete removes a project by ID.
+func (s *BoltStore) Delete(_ context.Context, id string) error {
+	return s.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketProjects)
+		if b.Get([]byte(id)) == nil {
+			return ErrNotFound
+		}
+		return b.Delete([]byte(id))
+	})
+}
+
+// ---------- Shared Source ----------
+
+// SetShared merges a shared config source into the profile list. A local
+// profile with the same ID takes precedence. Pass nil to detach it.
+func (s *BoltStore) SetShared(sh *Shared) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sh != nil {
+		for i := range sh.Profiles {
+			normalizeProfile(&sh.Profiles[i])
+		}
+	}
+	s.shared = sh
+}
+
+// Shared returns the shared config source, or nil.
+func (s *BoltStore) Shared() *Shared {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.shared
+}
+
+// sharedProfile returns the profile of shared with the given ID that no
+// local profile overrides.
+func sharedProfile(tx *bolt.Tx, shared *Shared, id string) *model.Profile {
+	if shared == nil || tx.Bucket(bucketProfiles).Get([]byte(id)) != nil {
+		return nil
+	}
+	for i := range shared.Profiles {
+		if shared.Profiles[i].ID == id {
+			p := shared.Profiles[i]
+			return &p
+		}
+	}
+	return nil
+}
+
+// ---------- ProfileStore Implementation ----------
+
+// ListProfiles returns all profiles: local ones first, then shared ones.
+func (s *BoltStore) ListProfiles(_ context.Context) ([]model.Profile, error) {
+	var result []model.Profile
+	err := s.view(func(tx *bolt.Tx) error {
+		var err error
+		if result, err = listRecords[model.Profile](tx.Bucket(bucketProfiles)); err != nil {
+			return err
+		}
+		if shared := s.Shared(); shared != nil {
+			for _, p := range shared.Profiles {
+				if sharedProfile(tx, shared, p.ID) != nil {
+					result = append(result, p)
+				}
+			}
+		}
+		return nil
+	})
+	return result, err
+}
+
+// GetProfile retrieves a profile by ID.
+func (s *BoltStore) GetProfile(_ context.Context, id string) (*model.Profile, error) {
+	var p *model.Profile
+	err := s.view(func(tx *bolt.Tx) error {
+		var err error
+		p, err = getRecord[model.Profile](tx.Bucket(bucketProfiles), id)
+		if errors.Is(err, ErrNotFound) {
+			if shared := sharedProfile(tx, s.Shared(), id); shared != nil {
+				p, err = shared, nil
+			}
+		}
+		return err
+	})
+	return p, err
+}
+
+// CreateProfile adds a new profile.
+func (s *BoltStore) CreateProfile(_ context.Context, p *model.Profile) error {
+	return s.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketProfiles)
+		if b.Get([]byte(p.ID)) != nil {
+			return ErrAlreadyExists
+		}
+		normalizeProfile(p)
+		return putRecord(b, p.ID, *p)
+	})
+}
+
+// UpdateProfile modifies an existing profile.
+func (s *BoltStore) UpdateProfile(_ context.Context, p *model.Profile) error {
+	normalizeProfile(p)
+	return s.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketProfiles)
+		if b.Get([]byte(p.ID)) == nil {
+			if sharedProfile(tx, s.Shared(), p.ID) != nil {
+				return ErrReadOnly
+			}
+			return ErrNotFound
+		}
+		return putRecord(b, p.ID, *p)
+	})
+}
+
+// DeleteProfile removes a profile by ID.
+func (s *BoltStore) DeleteProfile(_ context.Context, id string) error {
+	return s.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketProfiles)
+		p, err := getRecord[model.Profile](b, id)
+		if errors.Is(err, ErrNotFound) && sharedProfile(tx, s.Shared(), id) != nil {
+			return ErrReadOnly
+		}
+		if err != nil {
+			return err
+		}
+		// Prevent deletion of default profile
+		if p.IsDefault {
+			return errors.New("cannot delete default profile")
+		}
+		return b.Delete([]byte(id))
+	})
+}
+
+// GetDefault returns the default profile.
+func (s *BoltStore) GetDefault(_ context.Context) (*model.Profile, error) {
+	var profiles []model.Profile
+	err := s.view(func(tx *bolt.Tx) error {
+		var err error
+		profiles, err = listRecords[model.Profile](tx.Bucket(bucketProfiles))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range profiles {
+		if profiles[i].IsDefault {
+			return &profiles[i], nil
+		}
+	}
+	// Fallback: return first profile if no default set
+	if len(profiles) > 0 {
+		return &profiles[0], nil
+	}
+	return nil, ErrNotFound
+}
+
+// ---------- TemplateStore Implementation ----------
+
+// ListTemplates returns all templates in the order they were added.
+func (s *BoltStore) ListTemplates(_ context.Context) ([]model.Template, error) {
+	var result []model.Template
+	err := s.view(func(tx *bolt.Tx) error {
+		var err error
+		result, err = listRecords[model.Template](tx.Bucket(bucketTemplates))
+		return err
+	})
+	return result, err
+}
+
+// CreateTemplate adds a new template.
+func (s *BoltStore) CreateTemplate(_ context.Context, t *model.Template) error {
+	return s.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketTemplates)
+		if b.Get([]byte(t.ID)) != nil {
+			return ErrAlreadyExists
+		}
+		return putRecord(b, t.ID, *t)
+	})
+}
+
+// UpdateTemplate modifies an existing template.
+func (s *BoltStore) UpdateTemplate(_ context.Context, t *model.Template) error {
+	return s.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketTemplates)
+		if b.Get([]byte(t.ID)) == nil {
+			return ErrNotFound
+		}
+		return putRecord(b, t.ID, *t)
+	})
+}
+
+// DeleteTemplate removes a template by ID.
+func (s *BoltStore) DeleteTemplate(_ context.Context, id string) error {
+	return s.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketTemplates)
+		if b.Get([]byte(id)) == nil {
+			return ErrNotFound
+		}
+		return b.Delete([]byte(id))
+	})
+}
+
+// ---------- HistoryStore Implementation ----------
+
+// historyKey orders history records by time; id keeps keys unique.
+func historyKey(t time.Time, id string) []byte {
+	key := make([]byte, 8, 8+len(id))
+	binary.BigEndian.PutUint64(key, uint64(t.UnixNano()))
+	return append(key, id...)
+}
+
+// RecordSession stores a session record; recording the same session (ID
+// and Started) again updates it, e.g. when it ends.
+func (s *BoltStore) RecordSession(_ context.Context, rec SessionRecord) error {
+	content, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return s.update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketSessions).Put(historyKey(rec.Started, rec.ID), content)
+	})
+}
+
+// SessionHistory returns a project's sessions, newest first; limit <= 0
+// returns all of them.
+func (s *BoltStore) SessionHistory(_ context.Context, projectID string, limit int) ([]SessionRecord, error) {
+	var result []SessionRecord
+	err := s.view(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketSessions).Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			var rec SessionRecord
+			if err := json.Unmarshal(v, &rec); err != nil {
+				return err
+			}
+			if projectID != "" && rec.ProjectID != projectID {
+				continue
+			}
+			result = append(result, rec)
+			if limit > 0 && len(result) == limit {
+				break
+			}
+		}
+		return nil
+	})
+	return result, err
+}
+
+// RecordChainEntry stores a conclusion added to a chain.
+func (s *BoltStore) RecordChainEntry(_ context.Context, rec ChainRecord) error {
+	content, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return s.update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketChains).Put(historyKey(rec.Added, rec.ChainID), content)
+	})
+}
+
+// ChainHistory returns the entries recorded for a chain, oldest first.
+func (s *BoltStore) ChainHistory(_ context.Context, chainID string) ([]ChainRecord, error) {
+	var result []ChainRecord
+	err := s.view(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketChains).ForEach(func(_, v []byte) error {
+			var rec ChainRecord
+			if err := json.Unmarshal(v, &rec); err != nil {
+				return err
+			}
+			if rec.ChainID == chainID {
+				result = append(result, rec)
+			}
+			return nil
+		})
+	})
+	return result, err
+}
@@ -24,6 +24,9 @@ var (
 type data struct {
 	Projects []model.Project `json:"projects"`
 	Profiles []model.Profile `json:"profiles"`
+	// Templates is nil in files written before the template library, which
+	// then start with the default templates.
+	Templates []model.Template `json:"templates"`
 }
 
 // JSONStore implements Store using JSON file persistence.
@@ -32,6 +35,7 @@ type JSONStore struct {
 	path     string
 	data     *data
 	modified bool
+	shared   *Shared // Read-only profiles merged into the profile list
 }
 
 // NewJSONStore creates a new JSON file-based store.
@@ -45,8 +49,9 @@ func NewJSONStore(configDir string) (*JSONStore, error) {
 	s := &JSONStore{
 		path: path,
 		data: &data{
-			Projects: []model.Project{},
-			Profiles: []model.Profile{},
+			Projects:  []model.Project{},
+			Profiles:  []model.Profile{},
+			Templates: model.DefaultTemplates(),
 		},
 	}
 
@@ -73,15 +78,31 @@ func (s *JSONStore) load() error {
 	if err != nil {
 		return err
 	}
+	s.data.Templates = nil
 	if err := json.Unmarshal(content, s.data); err != nil {
 		return err
 	}
-	if s.normalizeProfiles() {
+	changed := normalizeProfiles(s.data.Profiles)
+	if s.data.Templates == nil {
+		s.data.Templates = model.DefaultTemplates()
+		changed = true
+	}
+	if changed {
 		return s.save()
 	}
 	return nil
 }
 
+// Reload re-reads data.json, e.g. after a config bundle was imported.
+func (s *JSONStore) Reload() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = &data{Projects: []model.Project{}, Profiles: []model.Profile{}}
+	s.modified = false
+	return s.load()
+}
+
 // save 将数据写入 JSON 文件。
 // 使用原子写入策略：先写入临时文件，再原子性重命名，防止写入中断导致配置损坏。
 func (s *JSONStore) save() error {
@@ -100,6 +121,11 @@ func (s *JSONStore) save() error {
 	return os.Rename(tmpPath, s.path)
 }
 
+// Snapshot writes pending changes; data.json is always current otherwise.
+func (s *JSONStore) Snapshot() error {
+	return s.Close()
+}
+
 // Close persists any pending changes.
 func (s *JSONStore) Close() error {
 	s.mu.Lock()
@@ -199,15 +225,65 @@ func (s *JSONStore) Delete(_ context.Context, id string) error {
 	return ErrNotFound
 }
 
+// ---------- Shared Source ----------
+
+// SetShared merges a shared config source into the profile list. A local
+// profile with the same ID takes precedence. Pass nil to detach it.
+func (s *JSONStore) SetShared(sh *Shared) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sh != nil {
+		for i := range sh.Profiles {
+			normalizeProfile(&sh.Profiles[i])
+		}
+	}
+	s.shared = sh
+}
+
+// Shared returns the shared config source, or nil.
+func (s *JSONStore) Shared() *Shared {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.shared
+}
+
+// sharedProfile returns the shared profile with the given ID that no local
+// profile overrides.
+func (s *JSONStore) sharedProfile(id string) *model.Profile {
+	if s.shared == nil {
+		return nil
+	}
+	for _, p := range s.data.Profiles {
+		if p.ID == id {
+			return nil
+		}
+	}
+	for i := range s.shared.Profiles {
+		if s.shared.Profiles[i].ID == id {
+			p := s.shared.Profiles[i]
+			return &p
+		}
+	}
+	return nil
+}
+
 // ---------- ProfileStore Implementation ----------
 
-// List returns all profiles.
+// List returns all profiles: local ones first, then shared ones.
 func (s *JSONStore) ListProfiles(_ context.Context) ([]model.Profile, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	result := make([]model.Profile, len(s.data.Profiles))
 	copy(result, s.data.Profiles)
+	if s.shared != nil {
+		for _, p := range s.shared.Profiles {
+			if s.sharedProfile(p.ID) != nil {
+				result = append(result, p)
+			}
+		}
+	}
 	return result, nil
 }
 
@@ -222,6 +298,9 @@ func (s *JSONStore) GetProfile(_ context.Context, id string) (*model.Profile, er
 			return &p, nil
 		}
 	}
+	if p := s.sharedProfile(id); p != nil {
+		return p, nil
+	}
 	return nil, ErrNotFound
 }
 
@@ -236,7 +315,7 @@ func (s *JSONStore) CreateProfile(_ context.Context, p *model.Profile) error {
 		}
 	}
 
-	s.normalizeProfile(p)
+	normalizeProfile(p)
 	s.data.Profiles = append(s.data.Profiles, *p)
 	s.modified = true
 	return s.save()
@@ -247,7 +326,7 @@ func (s *JSONStore) UpdateProfile(_ context.Context, p *model.Profile) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.normalizeProfile(p)
+	normalizeProfile(p)
 	for i := range s.data.Profiles {
 		if s.data.Profiles[i].ID == p.ID {
 			s.data.Profiles[i] = *p
@@ -255,6 +334,9 @@ func (s *JSONStore) UpdateProfile(_ context.Context, p *model.Profile) error {
 			return s.save()
 		}
 	}
+	if s.sharedProfile(p.ID) != nil {
+		return ErrReadOnly
+	}
 	return ErrNotFound
 }
 
@@ -274,6 +356,9 @@ func (s *JSONStore) DeleteProfile(_ context.Context, id string) error {
 			return s.save()
 		}
 	}
+	if s.sharedProfile(id) != nil {
+		return ErrReadOnly
+	}
 	return ErrNotFound
 }
 
@@ -298,17 +383,74 @@ func (s *JSONStore) GetDefault(_ context.Context) (*model.Profile, error) {
 	return nil, ErrNotFound
 }
 
-func (s *JSONStore) normalizeProfiles() bool {
+// ---------- TemplateStore Implementation ----------
+
+// ListTemplates returns all templates in the order they were added.
+func (s *JSONStore) ListTemplates(_ context.Context) ([]model.Template, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]model.Template, len(s.data.Templates))
+	copy(result, s.data.Templates)
+	return result, nil
+}
+
+// CreateTemplate adds a new template.
+func (s *JSONStore) CreateTemplate(_ context.Context, t *model.Template) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.data.Templates {
+		if existing.ID == t.ID {
+			return ErrAlreadyExists
+		}
+	}
+	s.data.Templates = append(s.data.Templates, *t)
+	s.modified = true
+	return s.save()
+}
+
+// UpdateTemplate modifies an existing template.
+func (s *JSONStore) UpdateTemplate(_ context.Context, t *model.Template) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.data.Templates {
+		if s.data.Templates[i].ID == t.ID {
+			s.data.Templates[i] = *t
+			s.modified = true
+			return s.save()
+		}
+	}
+	return ErrNotFound
+}
+
+// DeleteTemplate removes a template by ID.
+func (s *JSONStore) DeleteTemplate(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.data.Templates {
+		if s.data.Templates[i].ID == id {
+			s.data.Templates = append(s.data.Templates[:i], s.data.Templates[i+1:]...)
+			s.modified = true
+			return s.save()
+		}
+	}
+	return ErrNotFound
+}
+
+func normalizeProfiles(profiles []model.Profile) bool {
 	changed := false
-	for i := range s.data.Profiles {
-		if s.normalizeProfile(&s.data.Profiles[i]) {
+	for i := range profiles {
+		if normalizeProfile(&profiles[i]) {
 			changed = true
 		}
 	}
 	return changed
 }
 
-func (s *JSONStore) normalizeProfile(p *model.Profile) bool {
+func normalizeProfile(p *model.Profile) bool {
 	if p == nil {
 		return false
 	}
@@ -323,7 +465,9 @@ func (s *JSONStore) normalizeProfile(p *model.Profile) bool {
 		p.Command = "claude"
 		changed = true
 	}
-	if p.Driver != model.DriverNative {
+	switch p.Driver {
+	case model.DriverNative, model.DriverCodex, model.DriverGemini, model.DriverContainer:
+	default:
 		p.Driver = model.DriverNative
 		changed = true
 	}
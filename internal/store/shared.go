@@ -0,0 +1,121 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lazyvibe/vibemux/internal/model"
+)
+
+// ErrReadOnly is returned when changing a profile from the shared source.
+var ErrReadOnly = errors.New("shared profiles are read-only")
+
+// Shared is a read-only config source merged with the local store, usually
+// a git checkout a team keeps its agent setup in:
+//
+//	profiles/*.json  one profile per file
+//	roles/*.md       role prompts, named after the file
+//	snippets/*       text snippets, named after the file
+//
+// Missing subdirectories are fine.
+type Shared struct {
+	Dir      string
+	Profiles []model.Profile
+	Roles    map[string]string
+	Snippets map[string]string
+}
+
+// LoadShared reads a shared config directory.
+func LoadShared(dir string) (*Shared, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	sh := &Shared{Dir: dir}
+	files, _ := filepath.Glob(filepath.Join(dir, "profiles", "*.json"))
+	sort.Strings(files)
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var p model.Profile
+		if err := json.Unmarshal(content, &p); err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(file), err)
+		}
+		base := fileName(file)
+		if p.ID == "" {
+			p.ID = "shared-" + base
+		}
+		if p.Name == "" {
+			p.Name = base
+		}
+		p.IsDefault = false
+		p.Shared = true
+		sh.Profiles = append(sh.Profiles, p)
+	}
+	if sh.Roles, err = readTextFiles(filepath.Join(dir, "roles")); err != nil {
+		return nil, err
+	}
+	if sh.Snippets, err = readTextFiles(filepath.Join(dir, "snippets")); err != nil {
+		return nil, err
+	}
+	return sh, nil
+}
+
+// RoleNames returns the role names, sorted.
+func (sh *Shared) RoleNames() []string {
+	return sortedKeys(sh.Roles)
+}
+
+// SnippetNames returns the snippet names, sorted.
+func (sh *Shared) SnippetNames() []string {
+	return sortedKeys(sh.Snippets)
+}
+
+// readTextFiles maps each regular, non-hidden file in dir to its content.
+func readTextFiles(dir string) (map[string]string, error) {
+	result := make(map[string]string)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return result, nil
+		}
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		result[fileName(e.Name())] = strings.TrimRight(string(content), "\r\n")
+	}
+	return result, nil
+}
+
+// fileName is the base name of path without its extension.
+func fileName(path string) string {
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
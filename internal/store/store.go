@@ -3,6 +3,9 @@ package store
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/lazyvibe/vibemux/internal/model"
 )
@@ -37,10 +40,88 @@ type ProfileStore interface {
 	GetDefault(ctx context.Context) (*model.Profile, error)
 }
 
+// TemplateStore defines the interface for prompt template persistence.
+type TemplateStore interface {
+	// ListTemplates returns all templates in the order they were added.
+	ListTemplates(ctx context.Context) ([]model.Template, error)
+	// CreateTemplate adds a new template.
+	CreateTemplate(ctx context.Context, t *model.Template) error
+	// UpdateTemplate modifies an existing template.
+	UpdateTemplate(ctx context.Context, t *model.Template) error
+	// DeleteTemplate removes a template by its ID.
+	DeleteTemplate(ctx context.Context, id string) error
+}
+
 // Store combines all storage interfaces.
 type Store interface {
 	ProjectStore
 	ProfileStore
+	TemplateStore
+	// SetShared merges a read-only shared config source into the profiles.
+	SetShared(sh *Shared)
+	// Shared returns the shared config source, or nil.
+	Shared() *Shared
+	// Reload re-reads data.json, e.g. after a config bundle was imported.
+	Reload() error
+	// Snapshot brings data.json up to date for exporting it.
+	Snapshot() error
 	// Close releases any resources held by the store.
 	Close() error
 }
+
+// SessionRecord is one agent session in the history.
+type SessionRecord struct {
+	ID        string    `json:"id"`
+	ProjectID string    `json:"project_id"`
+	ProfileID string    `json:"profile_id,omitempty"`
+	Started   time.Time `json:"started"`
+	// Ended is zero while the session runs.
+	Ended  time.Time           `json:"ended,omitempty"`
+	Status model.SessionStatus `json:"status"`
+}
+
+// ChainRecord is one conclusion added to a chain.
+type ChainRecord struct {
+	ChainID string    `json:"chain_id"`
+	Agent   string    `json:"agent"`
+	Content string    `json:"content"`
+	Added   time.Time `json:"added"`
+}
+
+// HistoryStore keeps session and chain history. Only the database backend
+// implements it.
+type HistoryStore interface {
+	// RecordSession adds or updates a session record.
+	RecordSession(ctx context.Context, rec SessionRecord) error
+	// SessionHistory returns a project's sessions, newest first.
+	SessionHistory(ctx context.Context, projectID string, limit int) ([]SessionRecord, error)
+	// RecordChainEntry adds a chain entry.
+	RecordChainEntry(ctx context.Context, rec ChainRecord) error
+	// ChainHistory returns a chain's entries, oldest first.
+	ChainHistory(ctx context.Context, chainID string) ([]ChainRecord, error)
+}
+
+// Store backends selectable with the config "store" setting.
+const (
+	BackendJSON = "json"
+	BackendBolt = "bolt"
+)
+
+// Open opens the store backend named in the config; "" means JSON.
+func Open(configDir, backend string) (Store, error) {
+	switch strings.ToLower(backend) {
+	case "", BackendJSON:
+		s, err := NewJSONStore(configDir)
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
+	case BackendBolt:
+		s, err := NewBoltStore(configDir)
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("unknown store backend %q (use %s or %s)", backend, BackendJSON, BackendBolt)
+}
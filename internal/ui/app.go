@@ -3,7 +3,7 @@ package ui
 import (
 	"context"
 	"errors"
-	"path/filepath"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -12,20 +12,38 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/lazyvibe/vibemux/internal/app"
+	"github.com/lazyvibe/vibemux/internal/control"
 	"github.com/lazyvibe/vibemux/internal/model"
 	"github.com/lazyvibe/vibemux/internal/notify"
+	"github.com/lazyvibe/vibemux/internal/report"
 	"github.com/lazyvibe/vibemux/internal/runtime"
+	"github.com/lazyvibe/vibemux/internal/secrets"
 	"github.com/lazyvibe/vibemux/internal/store"
+	"github.com/lazyvibe/vibemux/internal/ui/components/approvallist"
+	"github.com/lazyvibe/vibemux/internal/ui/components/diffview"
 	"github.com/lazyvibe/vibemux/internal/ui/components/chaindialog"
+	"github.com/lazyvibe/vibemux/internal/ui/components/chainlist"
+	"github.com/lazyvibe/vibemux/internal/ui/components/notifylist"
+	"github.com/lazyvibe/vibemux/internal/ui/components/resourcelist"
+	"github.com/lazyvibe/vibemux/internal/ui/components/composer"
 	"github.com/lazyvibe/vibemux/internal/ui/components/configdialog"
+	"github.com/lazyvibe/vibemux/internal/ui/components/confirm"
 	"github.com/lazyvibe/vibemux/internal/ui/components/dialog"
+	"github.com/lazyvibe/vibemux/internal/ui/components/filepicker"
 	"github.com/lazyvibe/vibemux/internal/ui/components/filepreview"
 	profilelist "github.com/lazyvibe/vibemux/internal/ui/components/profile_list"
 	projectlist "github.com/lazyvibe/vibemux/internal/ui/components/project_list"
+	"github.com/lazyvibe/vibemux/internal/ui/components/quickopen"
+	"github.com/lazyvibe/vibemux/internal/ui/components/search"
 	"github.com/lazyvibe/vibemux/internal/ui/components/sessiontabs"
+	"github.com/lazyvibe/vibemux/internal/ui/components/setup"
 	"github.com/lazyvibe/vibemux/internal/ui/components/statusbar"
+	"github.com/lazyvibe/vibemux/internal/ui/components/templates"
 	"github.com/lazyvibe/vibemux/internal/ui/components/terminal"
+	"github.com/lazyvibe/vibemux/internal/ui/components/toast"
 	"github.com/lazyvibe/vibemux/internal/ui/keys"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
+	"github.com/lazyvibe/vibemux/internal/web"
 	"github.com/lazyvibe/vibemux/pkg/utils"
 )
 
@@ -59,6 +77,9 @@ const (
 const (
 	minAppWidth  = 40
 	minAppHeight = 10
+	// Below minApp* the UI falls back to tabs plus a single pane.
+	minCompactWidth  = 16
+	minCompactHeight = 5
 )
 
 // DialogMode represents the current dialog being shown.
@@ -75,12 +96,28 @@ const (
 	DialogAssignRoles
 	DialogAssignRolesFile
 	DialogFilePreview
+	DialogLaunch
+	DialogSearch
+	DialogPaneSearch
+	DialogExport
+	DialogConfirm
+	DialogQuickOpen
+	DialogSetup
+	DialogLayouts
+	DialogComposer
+	DialogTemplates
+	DialogChains
+	DialogUnlock
+	DialogNotifications
+	DialogResources
+	DialogApprovals
+	DialogDiff
 )
 
 // TerminalInstance holds data for a single terminal session.
 type TerminalInstance struct {
-	ProjectID   string
-	ProjectName string
+	SessionID   string
+	ProjectName string // pane label: project name plus session suffix
 	Terminal    terminal.Model
 	Content     strings.Builder
 }
@@ -94,15 +131,32 @@ type App struct {
 	terminals      map[string]*TerminalInstance // Multiple terminal instances
 	activeTermID   string                       // Currently displayed terminal
 	statusBar      statusbar.Model
+	toasts         toast.Model
 	addDialog      dialog.InputDialog
 	profileDialog  dialog.InputDialog
 	settingsDialog dialog.InputDialog
 	commandDialog  dialog.InputDialog
+	launchDialog   dialog.InputDialog
 	roleDialog     dialog.InputDialog
 	organizerDialog configdialog.Model // Separate complex dialog
 
 	chainDialog    chaindialog.Model
+	chainList      chainlist.Model // Saved chain sessions
+	notifyList     notifylist.Model // Notification center
+	resourceList   resourcelist.Model // CPU and memory of sessions
+	approvalList   approvallist.Model // Yes/no prompts waiting for an answer
+	diffView       diffview.Model     // Changes since a pane's session started
 	filePreview    filepreview.Model
+	filePicker     filepicker.Model
+	search         search.Model
+	confirm        confirm.Model
+	quickOpen      quickopen.Model
+	layoutPicker   quickopen.Model // Saved layouts overlay
+	composer       composer.Model  // Multi-line prompt editor
+	composerLoaded bool            // Composer history read from disk
+	templatePicker templates.Model // Prompt template library
+	wizard         setup.Model // Setup wizard re-run from :setup
+	wizardFrom     string      // Claude path before the wizard ran
 
 	// State
 	focus      FocusArea
@@ -112,16 +166,40 @@ type App struct {
 	ready      bool
 	quitting   bool
 	activePane int
+	// panelMode is the project list width; panelRestore is the mode the
+	// full-screen toggle returns to.
+	panelMode    projectPanelMode
+	panelRestore projectPanelMode
 	gridRows   int
 	gridCols   int
+	gridAuto   bool // Size the grid from the window and session count
+	zoomed     bool // Active pane fills the terminal area
+	resizing   bool // Resize mode: arrows change the active pane's split
 	inputMode    InputMode
 	dispatchMode DispatchMode
 	imeBuffer    *IMEBuffer // IME input buffer for Chinese input support
+	mouse        mouseState // Click/drag tracking for pane text selection
+	spinning     bool       // A launch spinner tick is scheduled
+	injection    *injectionProgress // Prompt batch being written to panes
+	debugOverlay bool               // Show PTY/emulator sizes in pane headers
+	macro        macroState         // Keystroke macro recording
 
 	// Data
-	projects      []model.Project
-	profiles      []model.Profile
-	profileEditID string
+	projects         []model.Project
+	profiles         []model.Profile
+	templates        []model.Template
+	profileEditID    string
+	profileCloneFrom *model.Profile // copied profile the profile form was opened for
+	launchProject    string         // project ID the launch dialog was opened for
+	pendingProject   *model.Project // project waiting for its folder to be created
+	pendingBroadcast *pendingBroadcast // multi-pane write waiting for confirmation
+	broadcastTrusted bool              // "Always send" chosen; skip broadcast previews
+	broadcastLine    string            // typed in broadcast mode since the last Enter
+	pendingStuck     string            // session the stuck pane dialog asks about
+	pendingPaste     *pendingPaste     // large paste waiting for confirmation
+	pendingExtract   *pendingExtract   // conclusion shown in the extraction preview
+	pendingWorktree  *worktreeCleanup  // closed pane's worktree the dialog asks about
+	worktreeCleanups []worktreeCleanup // closed panes' worktrees waiting for the dialog
 
 	tempChainFile string
 
@@ -133,60 +211,150 @@ type App struct {
 	turnTopic         string
 	turnFilename    string
 	currentTurnStartTime time.Time
+	turnWatch            turnWatch // Completion detection for the current turn
+	turnRounds           int               // How often the sequence runs
+	turnRound            int               // Current round, 0-based
+	turnDelay            int               // Seconds from a completed turn to the next
+	turnMessageDefault   string            // "Your turn" message for panes without their own
+	turnMessages         map[string]string // Per-session "your turn" message from the organizer dialog
+	turnRoles            map[string]string // Per-session role name, for {{ROLE}}
 
 	configDir string
 	config    *app.Config
 
 	// Chain Mode
 	chainContext *runtime.ChainContext
+	// chainSaveFailed suppresses repeated autosave error toasts.
+	chainSaveFailed bool
+	// chainResumed sends the chain to sessions started after it was resumed.
+	chainResumed bool
+	// summaryPass is the summarizer prompt awaiting its answer, if any.
+	summaryPass *summaryPass
+	// pipeline hands the chain from pane to pane, if one is running.
+	pipeline *chainPipeline
+	// batch is the startall run in progress, if any.
+	batch *batchLaunch
+	// lastCtrlC is the previous terminal-mode Ctrl+C, for two-press policies.
+	lastCtrlC ctrlCPress
+	// sessionRecords are the history records of running sessions.
+	sessionRecords map[string]store.SessionRecord
+	// exportPaneID is the pane the export file picker writes out.
+	exportPaneID string
+	// sessionLogs record the output of sessions with logging enabled.
+	sessionLogs map[string]*runtime.SessionLog
 
 	// Dependencies
-	store          *store.JSONStore
+	store          store.Store
 	engine         *runtime.DefaultEngine
 	keys           keys.KeyMap
 	ctx            context.Context
 	notifier       *notify.Dispatcher
 	outputWatchers map[string]*outputWatcher
+	stats          *report.Recorder
+	dashboard      *web.Server
+	control        *control.Server
+	secrets        *secrets.Resolver
+
+	// Activity tracking
+	lastOutput     map[string]time.Time
+	allQuietFired  bool
+	// notifications is the notification center's history, oldest first;
+	// notificationsSeen is when the center was last opened.
+	notifications     []notifylist.Entry
+	notificationsSeen time.Time
+	// approvals are the prompts of the approval queue, oldest first.
+	approvals []approvallist.Entry
+	// resources samples the sessions' process trees; usage and usagePIDs
+	// hold the last sample, taken at resourcesAt.
+	resources     *runtime.ResourceMonitor
+	usage         map[string]runtime.ProcessUsage
+	usagePIDs     map[string]int
+	resourcesAt   time.Time
+	resourcesBusy bool   // A sample is being taken
+	resourceErr   string // Why the last sample failed
+	// diffBases are the snapshots the panes' diffs are taken against;
+	// diffPane is the pane shown in the diff viewer.
+	diffBases map[string]*diffBase
+	diffPane  string
+	// gitAt is when the git status of the projects was last read.
+	gitAt   time.Time
+	gitBusy bool // A refresh is running
+	// rehomed maps old session IDs to new ones until the output reader
+	// started under the old ID delivers its last message.
+	rehomed map[string]string
+	// bindings maps session IDs to their project and launch options.
+	bindings map[string]sessionBinding
+	// pendingPrompts holds initial prompts waiting for their CLI to be ready.
+	pendingPrompts map[string]*pendingPrompt
+	// restarts tracks the automatic restarts of each pane.
+	restarts map[string]*restartState
 }
 
 // New creates a new application instance.
-func New(s *store.JSONStore, e *runtime.DefaultEngine, cfg *app.Config, configDir string) App {
+func New(s store.Store, e *runtime.DefaultEngine, cfg *app.Config, configDir string) App {
 	rows, cols := sanitizeGridSize(cfg)
+	applyConfigTheme(cfg)
 	status := statusbar.New()
 	status.SetModeLabel("CTRL")
+	tabs := sessiontabs.New()
+	tabs.SetActivityOrder(cfg != nil && cfg.TabOrder == tabOrderActivity)
 	return App{
 		projectList:    projectlist.New(),
 		profileList:    profilelist.New(),
-		sessionTabs:    sessiontabs.New(),
+		sessionTabs:    tabs,
 		filePreview:    filepreview.New(),
+		filePicker:     filepicker.New(),
+		search:         search.New(),
+		confirm:        confirm.New(),
+		quickOpen:      quickopen.New(),
+		layoutPicker:   quickopen.New(),
+		composer:       composer.New(),
+		templatePicker: templates.New(),
+		chainList:      chainlist.New(),
+		notifyList:     notifylist.New(),
+		resourceList:   resourcelist.New(),
+		approvalList:   approvallist.New(),
+		diffView:       diffview.New(),
+		resources:      runtime.NewResourceMonitor(),
 		terminals:      make(map[string]*TerminalInstance),
 		outputWatchers: make(map[string]*outputWatcher),
+		lastOutput:     make(map[string]time.Time),
+		rehomed:        make(map[string]string),
+		pendingPrompts: make(map[string]*pendingPrompt),
+		restarts:       make(map[string]*restartState),
+		diffBases:      make(map[string]*diffBase),
+		bindings:       make(map[string]sessionBinding),
 		statusBar:      status,
+		toasts:         toast.New(),
 		addDialog: dialog.NewInputDialog("Add Project", []dialog.InputField{
 			{Label: "Project Name", Placeholder: "my-awesome-project"},
 			{Label: "Project Path", Placeholder: "~/projects/my-project", EnablePathComp: true},
 			{Label: "Profile", Placeholder: "default (optional)"},
+			{Label: "Initial Prompt", Placeholder: "sent once the agent is ready (optional)"},
 		}),
-		focus:      FocusProjects,
-		dialogMode: DialogNone,
-		store:      s,
-		engine:     e,
-		keys:       keys.DefaultKeyMap(),
-		ctx:        context.Background(),
-		notifier:   notify.NewDispatcher(),
-		gridRows:   rows,
-		gridCols:   cols,
-		inputMode:  InputModeControl,
-		imeBuffer:  NewIMEBuffer(),
-		configDir:  configDir,
-		config:     cfg,
-		// Initialize with a default chain session
-		chainContext: func() *runtime.ChainContext {
-			id := fmt.Sprintf("%d", time.Now().Unix())
-			dir := filepath.Join(configDir, "chain")
-			ctx, _ := runtime.NewChainContext(id, "Chain Session "+id, dir)
-			return ctx
-		}(),
+		focus:        FocusProjects,
+		dialogMode:   DialogNone,
+		store:        s,
+		engine:       e,
+		keys:         keys.DefaultKeyMap(),
+		ctx:          context.Background(),
+		notifier:     notify.NewDispatcher(),
+		stats:        report.NewRecorder(configDir),
+		dashboard:    newDashboard(e, s, cfg, configDir),
+		control:      control.NewServer(e, s),
+		secrets:      newSecrets(e, cfg, configDir),
+		gridRows:     rows,
+		gridCols:     cols,
+		gridAuto:     cfg != nil && cfg.GridAuto,
+		panelMode:    parseProjectPanelMode(cfg),
+		panelRestore: panelWide,
+		inputMode:    InputModeControl,
+		turnDelay:    defaultTurnDelay,
+		imeBuffer:    NewIMEBuffer(),
+		configDir:    configDir,
+		config:       cfg,
+		// Continue the latest chain session, if any
+		chainContext: recoverChainContext(configDir),
 	}
 }
 
@@ -211,6 +379,19 @@ func sanitizeGridSize(cfg *app.Config) (int, int) {
 	return rows, cols
 }
 
+// parseOnOff parses an on/off form field; empty input yields def.
+func parseOnOff(input string, def bool) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "":
+		return def, nil
+	case "on", "yes", "y", "true", "1":
+		return true, nil
+	case "off", "no", "n", "false", "0":
+		return false, nil
+	}
+	return false, errors.New("use on or off")
+}
+
 func parseGridSetting(input string) (int, int, error) {
 	value := strings.ToLower(strings.TrimSpace(input))
 	if value == "" {
@@ -267,6 +448,12 @@ func (a App) Init() tea.Cmd {
 	return tea.Batch(
 		a.loadProjects(),
 		a.loadProfiles(),
+		a.loadTemplates(),
+		a.loadShared(),
+		housekeepingTick(),
+		a.autoStartDashboard(),
+		a.autoStartControl(),
+		a.themeWarning(),
 	)
 }
 
@@ -291,45 +478,78 @@ func (a *App) updateAddDialogProfiles() {
 		a.addDialog.SetFieldOptions(2, nil)
 		return
 	}
+	a.addDialog.SetFieldOptions(2, a.profileOptions())
+}
+
+// profileOptions lists profiles as "Name (id)" suggestions for dialogs.
+func (a *App) profileOptions() []string {
 	options := make([]string, 0, len(a.profiles))
-	for _, p := range a.profiles {
-		label := p.Name
-		if p.IsDefault {
-			label = p.Name + " (default)"
-		}
-		options = append(options, label+" ("+p.ID+")")
+	for i := range a.profiles {
+		options = append(options, profileLabel(&a.profiles[i]))
 	}
-	a.addDialog.SetFieldOptions(2, options)
+	return options
+}
+
+func profileLabel(p *model.Profile) string {
+	label := p.Name
+	if p.IsDefault {
+		label = p.Name + " (default)"
+	}
+	return label + " (" + p.ID + ")"
 }
 
 // startSession starts a PTY session for the selected project.
 func (a *App) startSession(project *model.Project) tea.Cmd {
-	return func() tea.Msg {
+	return a.launchSession(project, project.ID, launchOptions{})
+}
+
+// launchSession starts a PTY session for project under sessionID.
+func (a *App) launchSession(project *model.Project, sessionID string, opts launchOptions) tea.Cmd {
+	if project.Worktree && opts.Worktree.Root == "" {
+		return a.createWorktree(project, sessionID, opts)
+	}
+	a.bindings[sessionID] = sessionBinding{ProjectID: project.ID, Opts: opts}
+	profileID := project.ProfileID
+	if profileID == "" {
+		if profile := a.workspaceProfile(project); profile != nil {
+			profileID = profile.ID
+		}
+	}
+	if opts.ProfileID != "" {
+		profileID = opts.ProfileID
+	}
+	spinner := a.markLaunching(sessionID, opts)
+	if a.engine.AtCapacity() {
+		a.toasts.Push(fmt.Sprintf("Session limit (%d) reached; %s will start when a session exits", a.engine.MaxSessions(), sessionID), false)
+	}
+	return tea.Batch(spinner, func() tea.Msg {
 		// Get profile for project
-		profile, err := a.store.GetProfile(a.ctx, project.ProfileID)
+		profile, err := a.store.GetProfile(a.ctx, profileID)
 		if err != nil {
 			// Use default profile
 			profile, _ = a.store.GetDefault(a.ctx)
 		}
+		profile = opts.launchProfile(profile)
 
 		// Create session
         // Get initial dimensions from the terminal instance if it exists
         rows := 24
         cols := 80
-        if inst, ok := a.terminals[project.ID]; ok {
+        if inst, ok := a.terminals[sessionID]; ok {
             w, h := inst.Terminal.PTYSize()
             if w > 0 && h > 0 {
                 cols = w
                 rows = h
             }
         }
-		_, err = a.engine.CreateSession(a.ctx, project, profile, rows, cols)
+		sessionOpts := runtime.SessionOptions{ID: sessionID, WorkDir: opts.workDir(project)}
+		_, err = a.engine.CreateSessionWithOptions(a.ctx, project, profile, sessionOpts, rows, cols)
 		if err != nil {
-			return ErrorMsg{Err: err}
+			return SessionLaunchFailedMsg{SessionID: sessionID, Err: err}
 		}
 
-		return SessionStartedMsg{ProjectID: project.ID}
-	}
+		return SessionStartedMsg{SessionID: sessionID, Profile: profile}
+	})
 }
 
 // waitForOutput waits for session output.
@@ -338,7 +558,7 @@ func (a *App) waitForOutput(projectID string) tea.Cmd {
 	if !ok {
 		return nil
 	}
-	return WaitForOutput(session.Output(), projectID)
+	return WaitForOutput(session, projectID)
 }
 
 // SetSize updates the window dimensions.
@@ -347,22 +567,27 @@ func (a *App) SetSize(width, height int) {
 	a.height = height
 	a.ready = true
 
-	if a.windowTooSmall() {
-		a.statusBar.SetWidth(width)
-		a.addDialog.SetSize(width, height)
-		a.profileDialog.SetSize(width, height)
-		a.settingsDialog.SetSize(width, height)
-		a.commandDialog.SetSize(width, height)
+	if a.compactLayout() {
+		a.setCompactSize(width, height)
 		return
 	}
 
+	if a.gridAuto {
+		// Capacity follows the window, so the active pane may have dropped out.
+		a.normalizeActivePane()
+	}
+
 	leftWidth, rightWidth, contentHeight, colWidths, rowHeights := a.gridLayout()
 	_, cols := a.gridActiveDims()
 
 	// Set component sizes
-	a.projectList.SetSize(leftWidth, contentHeight)
+	if leftWidth > 0 {
+		a.projectList.SetCompact(a.panelMode == panelNarrow)
+		a.projectList.SetSize(leftWidth, contentHeight)
+	}
 	a.sessionTabs.SetWidth(rightWidth)
 	a.statusBar.SetWidth(width)
+	a.toasts.SetWidth(width)
 
 	// Set terminal sizes per grid cell
 	ids := a.gridOrder()
@@ -380,20 +605,13 @@ func (a *App) SetSize(width, height int) {
 		if row >= len(rowHeights) || col >= len(colWidths) {
 			continue
 		}
-		inst.Terminal.SetSize(colWidths[col], rowHeights[row])
-		if session, ok := a.engine.GetSession(id); ok && session.Status() == model.SessionStatusRunning {
-			cols, rows := inst.Terminal.PTYSize()
-			// Enforce minimum PTY size to prevent CLI tool crashes/OOM
-			if cols < 8 {
-				cols = 8
-			}
-			if rows < 2 {
-				rows = 2
-			}
-			if cols > 0 && rows > 0 {
-				_ = session.Resize(uint16(rows), uint16(cols))
-			}
+		if id == a.activeTermID && a.zoomActive() {
+			inst.Terminal.SetSize(rightWidth, contentHeight)
+			a.resizeSessionPTY(id, inst)
+			continue
 		}
+		inst.Terminal.SetSize(colWidths[col], rowHeights[row])
+		a.resizeSessionPTY(id, inst)
 	}
 
 	// Dialog size
@@ -401,6 +619,7 @@ func (a *App) SetSize(width, height int) {
 	a.profileDialog.SetSize(width, height)
 	a.settingsDialog.SetSize(width, height)
 	a.commandDialog.SetSize(width, height)
+	a.wizard.SetSize(width, height)
 
 	// Profile manager size
 	pmWidth, pmHeight := a.profileManagerSize()
@@ -410,21 +629,57 @@ func (a *App) SetSize(width, height int) {
 	a.updateFocusStyles()
 }
 
+// resizeSessionPTY propagates a pane's size to its running session.
+func (a *App) resizeSessionPTY(id string, inst *TerminalInstance) {
+	session, ok := a.engine.GetSession(id)
+	if !ok || session.Status() != model.SessionStatusRunning {
+		return
+	}
+	cols, rows := inst.Terminal.PTYSize()
+	// Enforce minimum PTY size to prevent CLI tool crashes/OOM
+	if cols < 8 {
+		cols = 8
+	}
+	if rows < 2 {
+		rows = 2
+	}
+	if cols > 0 && rows > 0 {
+		err := session.Resize(uint16(rows), uint16(cols))
+		inst.Terminal.NotePTYResize(cols, rows, err == nil)
+	}
+}
+
+// windowTooSmall reports whether even the compact layout cannot fit.
 func (a App) windowTooSmall() bool {
-	return a.width < minAppWidth || a.height < minAppHeight
+	return a.width < minCompactWidth || a.height < minCompactHeight
 }
 
 func (a *App) closeSession(projectID string) {
 	if projectID == "" {
 		return
 	}
+	project := a.projectForSession(projectID)
+	wt, label := a.bindings[projectID].Opts.Worktree, a.paneLabel(projectID)
+	a.engine.CancelPending(projectID)
 	_ = a.engine.CloseSession(projectID)
-	a.projectList.SetRunning(projectID, false)
+	if project != nil {
+		a.syncProjectRunning(project.ID)
+	}
 	a.sessionTabs.RemoveTab(projectID)
 	delete(a.terminals, projectID)
 	delete(a.outputWatchers, projectID)
+	a.dropApprovals(projectID)
+	delete(a.lastOutput, projectID)
+	delete(a.pendingPrompts, projectID)
+	delete(a.restarts, projectID)
+	delete(a.diffBases, projectID)
+	delete(a.bindings, projectID)
+	a.closeSessionLog(projectID)
 	a.normalizeActivePane()
 	a.SetSize(a.width, a.height)
+	if wt.Root != "" && project != nil {
+		a.offerWorktreeCleanup(worktreeCleanup{Label: label, SessionID: projectID, ProjectID: project.ID, Dir: project.Path, Worktree: wt})
+	}
 }
 
 func (a *App) profileManagerSize() (int, int) {
@@ -446,13 +701,17 @@ func (a *App) profileManagerSize() (int, int) {
 }
 
 func (a *App) gridCapacity() int {
-	if a.gridRows < 1 || a.gridCols < 1 {
+	rows, cols := a.gridMaxDims()
+	if rows < 1 || cols < 1 {
 		return 0
 	}
-	return a.gridRows * a.gridCols
+	return rows * cols
 }
 
 func (a *App) gridActiveDims() (int, int) {
+	if a.gridAuto {
+		return a.autoGridDims(len(a.sessionTabs.Tabs()))
+	}
 	return gridDimsForCount(len(a.sessionTabs.Tabs()), a.gridRows, a.gridCols)
 }
 
@@ -529,9 +788,15 @@ func (a *App) setActivePane(index int) {
 	if index >= len(ids) {
 		index = len(ids) - 1
 	}
+	prev := a.activeTermID
 	a.activePane = index
 	a.activeTermID = ids[index]
 	a.sessionTabs.SetActiveTab(ids[index])
+	if a.zoomed && prev != a.activeTermID {
+		// The zoom follows the active pane.
+		a.SetSize(a.width, a.height)
+		return
+	}
 	a.updateFocusStyles()
 }
 
@@ -564,20 +829,14 @@ func (a *App) normalizeActivePane() {
 }
 
 func (a *App) gridLayout() (int, int, int, []int, []int) {
-	// Left panel (project list): 25% width
-	leftWidth := a.width * 25 / 100
-	if leftWidth < 20 {
-		leftWidth = 20
-	}
-	if leftWidth > 40 {
-		leftWidth = 40
-	}
+	leftWidth := a.projectPanelWidth()
 	rightWidth := a.width - leftWidth
 	contentHeight := a.height - 1
 
 	rows, cols := a.gridActiveDims()
-	colWidths := distribute(rightWidth, cols)
-	rowHeights := distribute(contentHeight, rows)
+	colWeights, rowWeights := a.gridSplit(rows, cols)
+	colWidths := distributeWeighted(rightWidth, cols, colWeights)
+	rowHeights := distributeWeighted(contentHeight, rows, rowWeights)
 
 	return leftWidth, rightWidth, contentHeight, colWidths, rowHeights
 }
@@ -670,6 +929,8 @@ func (a *App) cycleFocus() {
 		if index+1 < len(ids) {
 			a.setActivePane(index + 1)
 			a.focus = FocusTerminal
+		} else if !a.projectPanelVisible() {
+			a.setActivePane(0)
 		} else {
 			a.focus = FocusProjects
 		}
@@ -694,6 +955,8 @@ func (a *App) cycleFocusReverse() {
 		if index-1 >= 0 {
 			a.setActivePane(index - 1)
 			a.focus = FocusTerminal
+		} else if !a.projectPanelVisible() {
+			a.setActivePane(len(ids) - 1)
 		} else {
 			a.focus = FocusProjects
 		}
@@ -718,11 +981,25 @@ func (a *App) inputModeLabel() string {
 		dispatchLabel = "BCAST"
 	case DispatchModeChain:
 		dispatchLabel = "CHAIN"
+		if a.chainContext != nil && a.chainContext.Dirty() {
+			// Unsaved chain changes
+			dispatchLabel += "*"
+		}
 	default:
 		dispatchLabel = "SOLO"
 	}
 
-	return inputLabel + "|" + dispatchLabel
+	label := inputLabel + "|" + dispatchLabel
+	if a.zoomActive() {
+		label += "|ZOOM"
+	}
+	if a.resizing {
+		label += "|RESIZE"
+	}
+	if a.macro.recording != "" {
+		label += "|REC@" + a.macro.recording
+	}
+	return label
 }
 
 func (a *App) enterTerminalMode() {
@@ -732,6 +1009,9 @@ func (a *App) enterTerminalMode() {
 	if a.activeTermID == "" {
 		return
 	}
+	if inst, ok := a.terminals[a.activeTermID]; ok {
+		inst.Terminal.ExitCopyMode()
+	}
 	a.inputMode = InputModeTerminal
 	a.focus = FocusTerminal
 	a.updateFocusStyles()
@@ -764,7 +1044,7 @@ func indexOfID(ids []string, id string) int {
 	return -1
 }
 
-// getOrCreateTerminal gets or creates a terminal instance for a project.
+// getOrCreateTerminal gets or creates a terminal instance for a session.
 func (a *App) getOrCreateTerminal(projectID, projectName string) *TerminalInstance {
 	if inst, ok := a.terminals[projectID]; ok {
 		return inst
@@ -790,9 +1070,10 @@ func (a *App) getOrCreateTerminal(projectID, projectName string) *TerminalInstan
 		cellHeight = 10
 	}
 	term.SetSize(cellWidth, cellHeight)
+	term.SetDebug(a.debugOverlay)
 
 	inst := &TerminalInstance{
-		ProjectID:   projectID,
+		SessionID:   projectID,
 		ProjectName: projectName,
 		Terminal:    term,
 	}
@@ -816,9 +1097,14 @@ func (a *App) showProfileManager() {
 func (a *App) showSettingsDialog() {
 	rows := strconv.Itoa(a.gridRows)
 	cols := strconv.Itoa(a.gridCols)
+	value := rows + "x" + cols
+	if a.gridAuto {
+		value = "auto"
+	}
 	
 	a.settingsDialog = dialog.NewInputDialog("Settings", []dialog.InputField{
-		{Label: "Grid Size (e.g. 2x2, 3x3, 4, 6)", Placeholder: "2x2", Value: rows+"x"+cols},
+		{Label: "Grid Size (e.g. 2x2, 3x3, 4, 6, auto)", Placeholder: "2x2", Value: value},
+		{Label: "Theme (Tab to choose)", Placeholder: styles.DefaultTheme, Value: styles.ActiveTheme(), Options: styles.Themes()},
 	})
 	a.settingsDialog.SetSize(a.width, a.height)
 	a.dialogMode = DialogSettings
@@ -827,10 +1113,11 @@ func (a *App) showSettingsDialog() {
 
 func (a *App) showFilePreview() {
 	if a.turnFilename == "" {
-		a.statusBar.SetMessage("No active organizer file to preview", true)
+		a.toasts.Push("No active organizer file to preview", true)
 		return
 	}
 	
+	a.filePreview.SetFilter(nil)
 	a.filePreview.SetFile(a.turnFilename)
 	a.filePreview.SetSize(a.width, a.height)
 	a.dialogMode = DialogFilePreview
@@ -839,33 +1126,63 @@ func (a *App) showFilePreview() {
 
 func (a *App) showCommandDialog() {
 	a.commandDialog = dialog.NewInputDialog("Command", []dialog.InputField{
-		{Label: "Command", Placeholder: "quit"},
+		{Label: "Command", Placeholder: "start <project> | close [pane] | grid 3x3 | profile use <name>", Options: a.commandOptions()},
 	})
 	a.commandDialog.SetSize(a.width, a.height)
 	a.dialogMode = DialogCommand
 }
 
 func (a *App) showProfileDialog(profile *model.Profile) {
+	a.profileCloneFrom = nil
 	a.profileEditID = ""
 	title := "Add Profile"
 	if profile != nil {
 		a.profileEditID = profile.ID
 		title = "Edit Profile"
 	}
+	a.openProfileForm(title, profile)
+}
+
+// showCloneProfileDialog opens the form for a copy of profile named
+// "<name> (copy)". The copy is only saved when the form is submitted.
+func (a *App) showCloneProfileDialog(profile *model.Profile) {
+	a.profileCloneFrom = profile.Clone(profile.Name + " (copy)")
+	a.profileEditID = ""
+	a.openProfileForm("Clone Profile", a.profileCloneFrom)
+}
+
+func (a *App) openProfileForm(title string, profile *model.Profile) {
 
 	commandValue := ""
 	envValue := ""
 	nameValue := ""
+	stepsValue := ""
+	desktopValue := "on"
+	webhookValue := ""
+	slackValue := ""
+	discordValue := ""
 	if profile != nil {
 		nameValue = profile.Name
 		commandValue = strings.TrimSpace(profile.Command)
-		envValue = utils.FormatEnvVars(profile.EnvVars)
+		envValue = utils.FormatEnvVars(maskEnvSecrets(profile.EnvVars))
+		stepsValue = model.FormatStartupSteps(profile.StartupSteps)
+		if !profile.Notification.Desktop {
+			desktopValue = "off"
+		}
+		webhookValue = profile.Notification.WebhookURL
+		slackValue = profile.Notification.SlackWebhookURL
+		discordValue = profile.Notification.DiscordWebhookURL
 	}
 
 	a.profileDialog = dialog.NewInputDialog(title, []dialog.InputField{
 		{Label: "Profile Name", Placeholder: "My Profile", Value: nameValue},
 		{Label: "Command", Placeholder: "claude, codex, or ccr code", Value: commandValue},
-		{Label: "Env Vars", Placeholder: "KEY=VALUE, KEY2=VALUE2", Value: envValue},
+		{Label: "Env Vars", Placeholder: "KEY=VALUE, API_KEY=secret:name", Value: envValue},
+		{Label: "Startup Steps", Placeholder: "/model opus; 2s; /permissions", Value: stepsValue},
+		{Label: "Desktop Notifications (on/off)", Placeholder: "on", Value: desktopValue},
+		{Label: "Webhook URL", Placeholder: "https://hooks.example.com/...", Value: webhookValue},
+		{Label: "Slack Webhook URL", Placeholder: "https://hooks.slack.com/services/...", Value: slackValue},
+		{Label: "Discord Webhook URL", Placeholder: "https://discord.com/api/webhooks/...", Value: discordValue},
 	})
 	a.profileDialog.SetSize(a.width, a.height)
 	a.dialogMode = DialogEditProfile
@@ -890,6 +1207,7 @@ func (a *App) updateGridSettings(rows, cols int) error {
 		updated := *a.config
 		updated.GridRows = rows
 		updated.GridCols = cols
+		updated.GridAuto = false
 		if err := app.SaveConfig(a.configDir, &updated); err != nil {
 			return err
 		}
@@ -898,6 +1216,7 @@ func (a *App) updateGridSettings(rows, cols int) error {
 
 	a.gridRows = rows
 	a.gridCols = cols
+	a.gridAuto = false
 	a.normalizeActivePane()
 	a.SetSize(a.width, a.height)
 	return nil
@@ -911,13 +1230,145 @@ func (a *App) executeCommand(input string) tea.Cmd {
 	if strings.HasPrefix(cmd, ":") {
 		cmd = strings.TrimSpace(strings.TrimPrefix(cmd, ":"))
 	}
-	switch strings.ToLower(cmd) {
+	fields := strings.Fields(cmd)
+	if len(fields) == 0 {
+		return nil
+	}
+	args := fields[1:]
+	switch strings.ToLower(fields[0]) {
 	case "q", "wq", "quit", "exit":
 		a.quitting = true
+		a.flushChain()
+		a.closeHistory()
+		a.closeSessionLogs()
+		a.closeServers()
 		a.engine.CloseAll()
 		return tea.Quit
+	case "report":
+		return a.generateReport(args)
+	case "export-layout":
+		return a.exportLayout(args)
+	case "dashboard":
+		return a.toggleDashboard()
+	case "lock":
+		return a.lockActiveInput()
+	case "unlock":
+		return a.unlockActiveInput()
+	case "initprompt":
+		return a.setInitialPrompt(strings.Join(args, " "))
+	case "alias":
+		return a.setSessionAlias(strings.Join(args, " "))
+	case "clone":
+		return a.cloneSession()
+	case "slot":
+		return a.slotPane(args)
+	case "send":
+		return a.sendToPane(cmd)
+	case "sendall", "broadcast":
+		return a.sendToAll(cmd)
+	case "start":
+		return a.startCommand(args)
+	case "close":
+		return a.closeCommand(args)
+	case "grid":
+		return a.gridCommand(args)
+	case "profile":
+		return a.profileCommand(args)
+	case "startall":
+		return a.startAllCommand(args)
+	case "stopall":
+		return a.stopAllCommand(args)
+	case "setup":
+		a.showSetupWizard()
+		return nil
+	case "workspace", "ws":
+		return a.workspaceCommand(args)
+	case "shared":
+		return a.sharedCommand(args)
+	case "tabs":
+		return a.setTabOrder(args)
+	case "debug":
+		a.toggleDebugOverlay()
+		return nil
+	case "zoom":
+		a.toggleZoom()
+		return nil
+	case "resize":
+		a.startResizeMode()
+		return nil
+	case "layout", "layouts":
+		return a.layoutCommand(args)
+	case "compose":
+		a.showComposer()
+		return nil
+	case "template", "templates":
+		return a.templateCommand(args)
+	case "pipeline":
+		return a.pipelineCommand(args)
+	case "chain", "chains":
+		return a.chainCommand(args)
+	case "notifications", "notif":
+		a.showNotifications()
+		return nil
+	case "top", "resources":
+		return a.showResources()
+	case "approvals", "queue":
+		a.showApprovals()
+		return nil
+	case "diff":
+		return a.showDiff(args)
+	case "worktree", "wt":
+		return a.worktreeCommand(args)
+	case "extract":
+		return a.previewExtraction()
+	case "minutes":
+		return a.minutesCommand(args)
+	case "history":
+		return a.showHistory()
+	case "interrupt":
+		a.interruptPane(args)
+		return nil
+	case "kill":
+		return a.forceKillPane(args)
+	case "log":
+		a.showSessionLog(args)
+		return nil
+	case "export":
+		return a.exportPane(args)
+	case "resync":
+		a.resyncPane(args)
+		return nil
+	case "export-config":
+		return a.exportConfig(args)
+	case "import-config":
+		return a.importConfig(args)
+	case "export-profiles":
+		return a.exportProfilesCommand(args)
+	case "import-profiles":
+		return a.importProfilesCommand(args)
+	case "secrets":
+		return a.secretsCommand(args)
+	case "snippet":
+		return a.sendSnippet(args)
+	case "role":
+		return a.sendRole(args)
+	case "macro":
+		return a.macroCommand(args)
+	case "search":
+		a.showSearch(strings.Join(args, " "))
+		return nil
+	case "rehome":
+		return a.rehomeSession(args)
+	case "tmux":
+		name, ok := a.engine.TmuxSessionName(a.activeTermID)
+		if !ok {
+			a.toasts.Push("Active session is not hosted in tmux (set tmux_mode in config.json)", true)
+			return nil
+		}
+		a.toasts.Push("Attach with: tmux attach -t "+name, false)
+		return nil
 	default:
-		a.statusBar.SetMessage("Unknown command: "+cmd, true)
+		a.toasts.Push("Unknown command: "+cmd, true)
 		return nil
 	}
 }
@@ -931,28 +1382,40 @@ func (a *App) createProject() tea.Cmd {
 	if len(values) > 2 {
 		profileInput = strings.TrimSpace(values[2])
 	}
+	initialPrompt := ""
+	if len(values) > 3 {
+		initialPrompt = strings.TrimSpace(values[3])
+	}
 
 	if name == "" || path == "" {
-		a.statusBar.SetMessage("Name and path are required", true)
+		a.toasts.Push("Name and path are required", true)
 		return nil
 	}
 
-	// Expand and validate path
+	// Expand and validate path; a missing folder can be created
 	path = utils.ExpandPath(path)
-	if !utils.IsValidProjectPath(path) {
-		a.statusBar.SetMessage("Invalid project path: directory does not exist", true)
+	missing := false
+	if info, err := os.Stat(path); os.IsNotExist(err) {
+		missing = true
+	} else if err != nil || !info.IsDir() {
+		a.toasts.Push("Invalid project path: not a directory", true)
 		return nil
 	}
 
 	project := model.NewProject(name, path)
+	project.InitialPrompt = initialPrompt
 	if profileInput != "" {
 		profileID, err := a.resolveProfileID(profileInput)
 		if err != nil {
-			a.statusBar.SetMessage(err.Error(), true)
+			a.toasts.Push(err.Error(), true)
 			return nil
 		}
 		project.ProfileID = profileID
 	}
+	if missing {
+		a.confirmCreateDir(project)
+		return nil
+	}
 
 	return func() tea.Msg {
 		if err := a.store.Create(a.ctx, project); err != nil {
@@ -971,6 +1434,32 @@ func (a *App) buildProfileFromDialog() (*model.Profile, bool, error) {
 	name := strings.TrimSpace(values[0])
 	command := strings.TrimSpace(values[1])
 	envInput := strings.TrimSpace(values[2])
+	var steps []model.StartupStep
+	if len(values) > 3 {
+		steps = model.ParseStartupSteps(values[3])
+	}
+	notification := model.NotificationConfig{Desktop: true}
+	if len(values) > 5 {
+		desktop, err := parseOnOff(values[4], true)
+		if err != nil {
+			return nil, false, fmt.Errorf("desktop notifications: %w", err)
+		}
+		notification.Desktop = desktop
+		notification.WebhookURL = strings.TrimSpace(values[5])
+		if !isWebhookURL(notification.WebhookURL) {
+			return nil, false, errors.New("webhook URL must start with http:// or https://")
+		}
+	}
+	if len(values) > 7 {
+		notification.SlackWebhookURL = strings.TrimSpace(values[6])
+		notification.DiscordWebhookURL = strings.TrimSpace(values[7])
+		if !isWebhookURL(notification.SlackWebhookURL) {
+			return nil, false, errors.New("Slack webhook URL must start with http:// or https://")
+		}
+		if !isWebhookURL(notification.DiscordWebhookURL) {
+			return nil, false, errors.New("Discord webhook URL must start with http:// or https://")
+		}
+	}
 
 	if name == "" {
 		return nil, false, errors.New("profile name is required")
@@ -996,25 +1485,68 @@ func (a *App) buildProfileFromDialog() (*model.Profile, bool, error) {
 	if err != nil {
 		return nil, false, err
 	}
+	if existing != nil {
+		restoreEnvSecrets(envVars, existing.EnvVars)
+	} else if a.profileCloneFrom != nil {
+		restoreEnvSecrets(envVars, a.profileCloneFrom.EnvVars)
+	} else {
+		restoreEnvSecrets(envVars, nil)
+	}
 
 	if existing != nil {
 		updated := *existing
 		updated.Name = name
 		updated.Command = command
 		updated.EnvVars = envVars
-		updated.Driver = model.DriverNative
+		updated.StartupSteps = steps
+		updated.Notification = keepReminders(notification, existing.Notification)
+		// Tool and container drivers chosen in data.json survive edits in
+		// the dialog.
+		if !keepsDriver(updated.Driver) {
+			updated.Driver = model.DriverNative
+		}
 		updated.CommandArgs = nil
 		return &updated, false, nil
 	}
 
 	profile := model.NewProfile(name)
+	if a.profileCloneFrom != nil {
+		profile = a.profileCloneFrom.Clone(name)
+	}
 	profile.Command = command
 	profile.EnvVars = envVars
-	profile.Driver = model.DriverNative
+	profile.StartupSteps = steps
+	profile.Notification = keepReminders(notification, profile.Notification)
+	// A clone of a container profile must stay sandboxed.
+	if !keepsDriver(profile.Driver) {
+		profile.Driver = model.DriverNative
+	}
 	profile.CommandArgs = nil
 	return profile, true, nil
 }
 
+// keepsDriver reports whether the profile dialog leaves a driver as it is.
+func keepsDriver(d model.DriverType) bool {
+	switch d {
+	case model.DriverCodex, model.DriverGemini, model.DriverContainer:
+		return true
+	}
+	return false
+}
+
+// keepReminders returns the notification settings from the profile dialog
+// with the reminder settings of old, which the dialog does not show.
+func keepReminders(n, old model.NotificationConfig) model.NotificationConfig {
+	n.ReminderMinutes = old.ReminderMinutes
+	n.ReminderWebhookURL = old.ReminderWebhookURL
+	return n
+}
+
+// isWebhookURL reports whether u is empty or an http(s) URL.
+func isWebhookURL(u string) bool {
+	return u == "" || strings.HasPrefix(u, "http://") || strings.HasPrefix(u, "https://")
+}
+
 func defaultProfileCommand() string {
 	return "claude"
 }
@@ -1112,6 +1644,9 @@ func (a *App) profileForProject(project *model.Project) *model.Profile {
 			return profile
 		}
 	}
+	if profile := a.workspaceProfile(project); profile != nil {
+		return profile
+	}
 	for i := range a.profiles {
 		if a.profiles[i].IsDefault {
 			return &a.profiles[i]
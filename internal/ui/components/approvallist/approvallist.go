@@ -0,0 +1,227 @@
+// Package approvallist provides the approval queue: the yes/no prompts
+// every pane is waiting on, oldest first, with the output around them, to
+// answer each without switching panes.
+package approvallist
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
+)
+
+// listHeight is how many requests are shown at once.
+const listHeight = 8
+
+// Entry is one pending approval request.
+type Entry struct {
+	SessionID string
+	// Label is the pane label.
+	Label string
+	// Prompt is the line asking for approval.
+	Prompt string
+	// Command is what the prompt asks to run; empty for other yes/no
+	// questions, which cannot be added to the approval rules.
+	Command string
+	// Guessed marks a Command inferred from the output above the prompt;
+	// it may not be all the prompt runs, so it cannot be added to the
+	// approval rules either.
+	Guessed bool
+	// Context is the output leading up to the prompt.
+	Context []string
+	Time    time.Time
+}
+
+// Action is what the last key asked the app to do.
+type Action int
+
+const (
+	// ActionNone needs nothing from the app.
+	ActionNone Action = iota
+	// ActionApprove answers yes to the selected request.
+	ActionApprove
+	// ActionDeny answers no to the selected request.
+	ActionDeny
+	// ActionAlways approves the selected command and adds it to the allow
+	// list.
+	ActionAlways
+	// ActionNever refuses the selected command and adds it to the deny
+	// list.
+	ActionNever
+	// ActionJump focuses the pane of the selected request.
+	ActionJump
+	// ActionClose closes the queue.
+	ActionClose
+)
+
+// Model is the approval queue.
+type Model struct {
+	entries []Entry // Oldest first
+	cursor  int
+	offset  int
+	width   int
+	action  Action
+}
+
+// New creates an empty queue.
+func New() Model {
+	return Model{}
+}
+
+// Open shows entries with the cursor on the oldest.
+func (m *Model) Open(entries []Entry) {
+	m.cursor, m.offset = 0, 0
+	m.action = ActionNone
+	m.SetEntries(entries)
+}
+
+// SetEntries replaces the listed requests. The cursor stays on the same
+// pane when it is still listed.
+func (m *Model) SetEntries(entries []Entry) {
+	selected, hadSelection := m.Selected()
+	m.entries = entries
+	if hadSelection {
+		for i, e := range m.entries {
+			if e.SessionID == selected.SessionID {
+				m.cursor = i
+			}
+		}
+	}
+	m.move(0)
+}
+
+// SetSize sets the queue width from the screen size.
+func (m *Model) SetSize(width, _ int) {
+	m.width = min(100, width-4)
+}
+
+// Action returns what the last key asked for.
+func (m Model) Action() Action { return m.action }
+
+// Selected returns the request under the cursor.
+func (m Model) Selected() (Entry, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.entries) {
+		return Entry{}, false
+	}
+	return m.entries[m.cursor], true
+}
+
+// Update handles key input. Always and never only apply to command
+// prompts whose command was read from the prompt itself.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	m.action = ActionNone
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	e, selected := m.Selected()
+	switch keyMsg.String() {
+	case "esc", "q", "A":
+		m.action = ActionClose
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "enter":
+		if selected {
+			m.action = ActionJump
+		}
+	case "y":
+		if selected {
+			m.action = ActionApprove
+		}
+	case "n":
+		if selected {
+			m.action = ActionDeny
+		}
+	case "a":
+		if selected && e.Command != "" && !e.Guessed {
+			m.action = ActionAlways
+		}
+	case "N":
+		if selected && e.Command != "" && !e.Guessed {
+			m.action = ActionNever
+		}
+	}
+	return m, nil
+}
+
+// move shifts the cursor and keeps it visible.
+func (m *Model) move(delta int) {
+	m.cursor = max(0, min(m.cursor+delta, len(m.entries)-1))
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+listHeight {
+		m.offset = m.cursor - listHeight + 1
+	}
+}
+
+// View renders the queue.
+func (m Model) View() string {
+	width := max(m.width, 50)
+	innerWidth := width - 6
+	lineStyle := lipgloss.NewStyle().Foreground(styles.Subtext1)
+	selectedStyle := lipgloss.NewStyle().Foreground(styles.TextCol).Background(styles.Surface1).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.TextMuted)
+	projectStyle := lipgloss.NewStyle().Foreground(styles.Primary)
+
+	now := time.Now()
+	rows := make([]string, 0, listHeight)
+	end := min(m.offset+listHeight, len(m.entries))
+	for i := m.offset; i < end; i++ {
+		e := m.entries[i]
+		ask := e.Prompt
+		if e.Command != "" {
+			ask = "run " + e.Command
+		}
+		text := fmt.Sprintf("%s %s  %s", mutedStyle.Render(fmt.Sprintf("%5s", waited(now.Sub(e.Time)))),
+			projectStyle.Render(e.Label), strings.Join(strings.Fields(ask), " "))
+		text = ansi.Truncate(text, innerWidth, "…")
+		if i == m.cursor {
+			rows = append(rows, selectedStyle.Width(innerWidth).Render(text))
+		} else {
+			rows = append(rows, lineStyle.Render(text))
+		}
+	}
+	if len(rows) == 0 {
+		rows = append(rows, mutedStyle.Render("No pane is waiting for approval"))
+	}
+
+	parts := []string{
+		styles.DialogTitle.Render(fmt.Sprintf("Approval Queue (%d)", len(m.entries))),
+		lipgloss.JoinVertical(lipgloss.Left, rows...),
+	}
+	if e, ok := m.Selected(); ok {
+		context := make([]string, 0, len(e.Context))
+		for _, line := range e.Context {
+			context = append(context, mutedStyle.Render(ansi.Truncate(line, innerWidth-2, "…")))
+		}
+		box := lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder(), true, false, false, false).
+			BorderForeground(styles.Surface1).
+			Width(innerWidth)
+		parts = append(parts, "", box.Render(lipgloss.JoinVertical(lipgloss.Left, context...)))
+	}
+	help := "y approve • n deny • a always • N never • Enter jump to pane • Esc close"
+	if e, ok := m.Selected(); ok && (e.Command == "" || e.Guessed) {
+		help = "y approve • n deny • Enter jump to pane • Esc close"
+	}
+	parts = append(parts, "", mutedStyle.Render(help))
+	return styles.DialogBox.Width(width - 2).Render(lipgloss.JoinVertical(lipgloss.Left, parts...))
+}
+
+// waited formats how long a request has been pending.
+func waited(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh", int(d.Hours()))
+}
@@ -8,6 +8,8 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/lazyvibe/vibemux/internal/runtime"
 )
 
@@ -17,6 +19,9 @@ type Model struct {
 	width        int
 	height       int
 	scrollOffset int
+	cursor       int
+	expanded     map[int]bool
+	copyText     string
 	closed       bool
 	cleared      bool
 }
@@ -32,17 +37,22 @@ type ChainDialogStyles struct {
 	Help          lipgloss.Style
 	Scrollbar     lipgloss.Style
 	EmptyMessage  lipgloss.Style
+	Cursor        lipgloss.Style
+	Heading       lipgloss.Style
+	Bold          lipgloss.Style
+	Code          lipgloss.Style
 }
 
 // DefaultStyles returns the default styles for the dialog.
 func DefaultStyles() ChainDialogStyles {
-	purple := lipgloss.Color("#7C3AED")
-	cyan := lipgloss.Color("#06B6D4")
-	pink := lipgloss.Color("#EC4899")
-	surface := lipgloss.Color("#1E1E2E")
-	text := lipgloss.Color("#CDD6F4")
-	textMuted := lipgloss.Color("#6C7086")
-	green := lipgloss.Color("#A6E3A1")
+	purple := styles.Primary
+	cyan := styles.Accent
+	pink := styles.Pink
+	surface := styles.Base
+	text := styles.TextCol
+	textMuted := styles.Overlay0
+	green := styles.Green
+	yellow := styles.Yellow
 
 	return ChainDialogStyles{
 		Box: lipgloss.NewStyle().
@@ -84,13 +94,28 @@ func DefaultStyles() ChainDialogStyles {
 			Foreground(textMuted).
 			Italic(true).
 			Align(lipgloss.Center),
+
+		Cursor: lipgloss.NewStyle().
+			Foreground(pink).
+			Bold(true),
+
+		Heading: lipgloss.NewStyle().
+			Foreground(cyan).
+			Bold(true),
+
+		Bold: lipgloss.NewStyle().
+			Bold(true),
+
+		Code: lipgloss.NewStyle().
+			Foreground(yellow),
 	}
 }
 
 // New creates a new Chain Preview dialog.
 func New(ctx *runtime.ChainContext) Model {
 	return Model{
-		context: ctx,
+		context:  ctx,
+		expanded: make(map[int]bool),
 	}
 }
 
@@ -98,6 +123,8 @@ func New(ctx *runtime.ChainContext) Model {
 func (m *Model) SetContext(ctx *runtime.ChainContext) {
 	m.context = ctx
 	m.scrollOffset = 0
+	m.cursor = 0
+	m.expanded = make(map[int]bool)
 }
 
 // SetSize updates the dialog dimensions.
@@ -122,33 +149,46 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			return m, nil
 
 		case "up", "k":
-			if m.scrollOffset > 0 {
-				m.scrollOffset--
-			}
+			m.moveCursor(-1)
 			return m, nil
 
 		case "down", "j":
-			m.scrollOffset++
-			m.clampScroll()
+			m.moveCursor(1)
 			return m, nil
 
-		case "pgup":
-			m.scrollOffset -= 5
-			if m.scrollOffset < 0 {
-				m.scrollOffset = 0
+		case "enter", " ":
+			m.toggle(m.cursor)
+			return m, nil
+
+		case "a":
+			m.toggleAll()
+			return m, nil
+
+		case "y":
+			if entry, ok := m.entry(m.cursor); ok {
+				m.copyText = entry.Conclusion
 			}
 			return m, nil
 
+		case "pgup":
+			m.scrollOffset -= m.viewHeight()
+			m.clampScroll()
+			return m, nil
+
 		case "pgdown":
-			m.scrollOffset += 5
+			m.scrollOffset += m.viewHeight()
 			m.clampScroll()
 			return m, nil
 
 		case "home":
+			m.cursor = 0
 			m.scrollOffset = 0
 			return m, nil
 
 		case "end":
+			if m.context != nil && len(m.context.Chain) > 0 {
+				m.cursor = len(m.context.Chain) - 1
+			}
 			m.scrollOffset = m.maxScroll()
 			return m, nil
 		}
@@ -160,15 +200,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 func (m Model) View() string {
 	styles := DefaultStyles()
 
-	// Calculate inner dimensions
-	innerWidth := m.width - 10
-	if innerWidth < 30 {
-		innerWidth = 30
-	}
-	innerHeight := m.height - 12
-	if innerHeight < 5 {
-		innerHeight = 5
-	}
+	innerWidth, innerHeight := m.innerSize()
 
 	var b strings.Builder
 
@@ -193,32 +225,14 @@ func (m Model) View() string {
 			Render("No chain entries yet.\n\nUse Ctrl+S in Chain Mode to save context.")
 		b.WriteString(emptyMsg)
 	} else {
-		// Build all entry lines
-		var entryLines []string
-		for i, entry := range m.context.Chain {
-			// Header: Agent name + timestamp
-			header := fmt.Sprintf("%d. [%s] %s",
-				i+1,
-				entry.Agent,
-				entry.Timestamp.Format(time.TimeOnly))
-			entryLines = append(entryLines, styles.EntryHeader.Render(header))
-
-			// Content: truncated conclusion
-			content := truncateStr(entry.Conclusion, innerWidth*2)
-			content = strings.ReplaceAll(content, "\n", " ")
-			wrapped := wrapText(content, innerWidth-4)
-			for _, line := range strings.Split(wrapped, "\n") {
-				entryLines = append(entryLines, styles.EntryContent.Render(line))
-			}
-			entryLines = append(entryLines, "") // Empty line between entries
-		}
+		entryLines, _ := m.renderLines(innerWidth, styles)
 
 		// Apply scroll
 		start := m.scrollOffset
 		if start > len(entryLines) {
 			start = len(entryLines)
 		}
-		end := start + innerHeight - 4
+		end := start + m.viewHeight()
 		if end > len(entryLines) {
 			end = len(entryLines)
 		}
@@ -230,8 +244,8 @@ func (m Model) View() string {
 		}
 
 		// Scroll indicator
-		if len(entryLines) > innerHeight-4 {
-			progress := float64(m.scrollOffset) / float64(m.maxScroll())
+		if max := m.maxScroll(); max > 0 {
+			progress := float64(m.scrollOffset) / float64(max)
 			if progress > 1 {
 				progress = 1
 			}
@@ -250,11 +264,14 @@ func (m Model) View() string {
 		entryCount = len(m.context.Chain)
 	}
 	footer := fmt.Sprintf("Entries: %d", entryCount)
+	if m.context != nil && m.context.Dirty() {
+		footer += "  •  unsaved changes"
+	}
 	b.WriteString(styles.Timestamp.Render(footer))
 	b.WriteString("\n\n")
 
 	// Help
-	b.WriteString(styles.Help.Render("[C] Clear  [↑/↓] Scroll  [Esc] Close"))
+	b.WriteString(styles.Help.Render("[↑/↓] Select  [Enter] Expand  [A] All  [Y] Copy  [PgUp/PgDn] Scroll  [C] Clear  [Esc] Close"))
 
 	// Wrap in box
 	content := styles.Box.Width(innerWidth + 4).Render(b.String())
@@ -287,6 +304,174 @@ func (m *Model) Reset() {
 	m.closed = false
 	m.cleared = false
 	m.scrollOffset = 0
+	m.copyText = ""
+}
+
+// TakeCopy returns the conclusion the user asked to copy, if any, and
+// clears the request.
+func (m *Model) TakeCopy() string {
+	text := m.copyText
+	m.copyText = ""
+	return text
+}
+
+// entry returns the chain entry at index i.
+func (m Model) entry(i int) (runtime.ChainEntry, bool) {
+	if m.context == nil || i < 0 || i >= len(m.context.Chain) {
+		return runtime.ChainEntry{}, false
+	}
+	return m.context.Chain[i], true
+}
+
+// toggle expands or collapses entry i.
+func (m *Model) toggle(i int) {
+	if _, ok := m.entry(i); !ok {
+		return
+	}
+	if m.expanded == nil {
+		m.expanded = make(map[int]bool)
+	}
+	m.expanded[i] = !m.expanded[i]
+	m.clampScroll()
+	if !m.expanded[i] {
+		return
+	}
+
+	// Scroll as much of the expanded entry into view as fits while
+	// keeping its header on screen.
+	innerWidth, _ := m.innerSize()
+	lines, headers := m.renderLines(innerWidth, DefaultStyles())
+	last := len(lines) - 1
+	if i+1 < len(headers) {
+		last = headers[i+1] - 1
+	}
+	if last >= m.scrollOffset+m.viewHeight() {
+		m.scrollOffset = last - m.viewHeight() + 1
+		if m.scrollOffset > headers[i] {
+			m.scrollOffset = headers[i]
+		}
+		m.clampScroll()
+	}
+}
+
+// toggleAll expands every entry, or collapses them all when all are
+// already expanded.
+func (m *Model) toggleAll() {
+	if m.context == nil {
+		return
+	}
+	expand := false
+	for i := range m.context.Chain {
+		if !m.expanded[i] {
+			expand = true
+			break
+		}
+	}
+	m.expanded = make(map[int]bool)
+	if expand {
+		for i := range m.context.Chain {
+			m.expanded[i] = true
+		}
+	}
+	m.clampScroll()
+	m.revealCursor()
+}
+
+// moveCursor moves the entry cursor by delta and scrolls it into view.
+func (m *Model) moveCursor(delta int) {
+	if m.context == nil || len(m.context.Chain) == 0 {
+		return
+	}
+	m.cursor += delta
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	if m.cursor >= len(m.context.Chain) {
+		m.cursor = len(m.context.Chain) - 1
+	}
+	m.revealCursor()
+}
+
+// revealCursor scrolls so the cursor entry's header is visible.
+func (m *Model) revealCursor() {
+	innerWidth, _ := m.innerSize()
+	_, headers := m.renderLines(innerWidth, DefaultStyles())
+	if m.cursor >= len(headers) {
+		return
+	}
+	line := headers[m.cursor]
+	if line < m.scrollOffset {
+		m.scrollOffset = line
+	}
+	if line >= m.scrollOffset+m.viewHeight() {
+		m.scrollOffset = line - m.viewHeight() + 1
+	}
+	m.clampScroll()
+}
+
+// renderLines lays out every entry and returns the lines along with the
+// line index of each entry's header.
+func (m Model) renderLines(width int, styles ChainDialogStyles) ([]string, []int) {
+	if m.context == nil {
+		return nil, nil
+	}
+	var lines []string
+	headers := make([]int, 0, len(m.context.Chain))
+	contentWidth := width - 2
+	for i, entry := range m.context.Chain {
+		headers = append(headers, len(lines))
+
+		marker, prefix := "▸", "  "
+		if m.expanded[i] {
+			marker = "▾"
+		}
+		if i == m.cursor {
+			prefix = styles.Cursor.Render("> ")
+		}
+		header := fmt.Sprintf("%s %d. [%s] %s",
+			marker,
+			i+1,
+			entry.Agent,
+			entry.Timestamp.Format(time.TimeOnly))
+		lines = append(lines, prefix+styles.EntryHeader.Render(header))
+
+		if m.expanded[i] {
+			for _, line := range renderMarkdown(entry.Conclusion, contentWidth-2, styles) {
+				lines = append(lines, styles.EntryContent.Render(line))
+			}
+		} else {
+			summary := ansi.Truncate(summaryLine(entry.Conclusion), contentWidth-2, "…")
+			lines = append(lines, styles.EntryContent.Render(summary))
+		}
+		lines = append(lines, "") // Empty line between entries
+	}
+	return lines, headers
+}
+
+// innerSize returns the content dimensions inside the dialog box.
+func (m Model) innerSize() (int, int) {
+	innerWidth := m.width - 10
+	if innerWidth < 30 {
+		innerWidth = 30
+	}
+	innerHeight := m.height - 12
+	if innerHeight < 5 {
+		innerHeight = 5
+	}
+	return innerWidth, innerHeight
+}
+
+// viewHeight returns how many entry lines fit in the dialog.
+func (m Model) viewHeight() int {
+	_, innerHeight := m.innerSize()
+	height := innerHeight - 4
+	if m.context != nil && m.context.Task != "" {
+		height -= 3
+	}
+	if height < 1 {
+		height = 1
+	}
+	return height
 }
 
 // clampScroll ensures scroll offset is within bounds.
@@ -302,16 +487,9 @@ func (m *Model) clampScroll() {
 
 // maxScroll returns the maximum scroll offset.
 func (m Model) maxScroll() int {
-	if m.context == nil {
-		return 0
-	}
-	// Estimate total lines
-	totalLines := len(m.context.Chain) * 4 // rough estimate
-	viewHeight := m.height - 16
-	if viewHeight < 1 {
-		viewHeight = 1
-	}
-	max := totalLines - viewHeight
+	innerWidth, _ := m.innerSize()
+	lines, _ := m.renderLines(innerWidth, DefaultStyles())
+	max := len(lines) - m.viewHeight()
 	if max < 0 {
 		return 0
 	}
@@ -329,29 +507,3 @@ func truncateStr(s string, maxLen int) string {
 	}
 	return s[:maxLen-3] + "..."
 }
-
-func wrapText(s string, width int) string {
-	if width < 1 {
-		return s
-	}
-	var result strings.Builder
-	line := ""
-	for _, word := range strings.Fields(s) {
-		if len(line)+len(word)+1 > width {
-			if line != "" {
-				result.WriteString(line)
-				result.WriteString("\n")
-			}
-			line = word
-		} else {
-			if line != "" {
-				line += " "
-			}
-			line += word
-		}
-	}
-	if line != "" {
-		result.WriteString(line)
-	}
-	return result.String()
-}
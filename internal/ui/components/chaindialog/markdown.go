@@ -0,0 +1,114 @@
+package chaindialog
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+var (
+	reBold       = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	reInlineCode = regexp.MustCompile("`([^`]+)`")
+	reHeading    = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	reBullet     = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	reNumbered   = regexp.MustCompile(`^(\s*)(\d+[.)])\s+(.*)$`)
+	reRule       = regexp.MustCompile(`^\s*(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+)
+
+// renderMarkdown renders the Markdown agents usually write in conclusions
+// (headings, lists, quotes, code fences, bold and inline code) as styled
+// lines wrapped to width.
+func renderMarkdown(text string, width int, st ChainDialogStyles) []string {
+	if width < 10 {
+		width = 10
+	}
+	var lines []string
+	inCode := false
+	for _, raw := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(raw)
+		if strings.HasPrefix(trimmed, "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			code := ansi.Truncate(strings.ReplaceAll(raw, "\t", "    "), width-2, "…")
+			lines = append(lines, st.Code.Render("│ "+code))
+			continue
+		}
+		switch {
+		case trimmed == "":
+			lines = append(lines, "")
+		case reRule.MatchString(trimmed):
+			lines = append(lines, st.Timestamp.Render(strings.Repeat("─", width)))
+		case reHeading.MatchString(trimmed):
+			m := reHeading.FindStringSubmatch(trimmed)
+			lines = append(lines, wrapIndented(st.Heading.Render(m[2]), width, "", "")...)
+		case reBullet.MatchString(raw):
+			m := reBullet.FindStringSubmatch(raw)
+			indent := strings.Repeat(" ", len(m[1]))
+			lines = append(lines, wrapIndented(inline(m[2], st), width, indent+"• ", indent+"  ")...)
+		case reNumbered.MatchString(raw):
+			m := reNumbered.FindStringSubmatch(raw)
+			indent := strings.Repeat(" ", len(m[1]))
+			marker := m[2] + " "
+			lines = append(lines, wrapIndented(inline(m[3], st), width, indent+marker, indent+strings.Repeat(" ", len(marker)))...)
+		case strings.HasPrefix(trimmed, ">"):
+			quote := strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+			for _, l := range wrapIndented(quote, width, "▏ ", "▏ ") {
+				lines = append(lines, st.Timestamp.Render(l))
+			}
+		default:
+			lines = append(lines, wrapIndented(inline(trimmed, st), width, "", "")...)
+		}
+	}
+	return lines
+}
+
+// inline styles **bold** and `code` spans.
+func inline(s string, st ChainDialogStyles) string {
+	s = reInlineCode.ReplaceAllStringFunc(s, func(m string) string {
+		return st.Code.Render(strings.Trim(m, "`"))
+	})
+	return reBold.ReplaceAllStringFunc(s, func(m string) string {
+		return st.Bold.Render(m[2 : len(m)-2])
+	})
+}
+
+// wrapIndented wraps s to width, starting with first and continuing lines
+// with rest.
+func wrapIndented(s string, width int, first, rest string) []string {
+	avail := width - ansi.StringWidth(first)
+	if avail < 5 {
+		avail = 5
+	}
+	wrapped := strings.Split(ansi.Wrap(s, avail, ""), "\n")
+	for i := range wrapped {
+		if i == 0 {
+			wrapped[i] = first + wrapped[i]
+		} else {
+			wrapped[i] = rest + wrapped[i]
+		}
+	}
+	return wrapped
+}
+
+// summaryLine is the first non-empty line with Markdown markers removed,
+// used for collapsed entries.
+func summaryLine(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "```") || reRule.MatchString(line) {
+			continue
+		}
+		if m := reHeading.FindStringSubmatch(line); m != nil {
+			line = m[2]
+		} else if m := reBullet.FindStringSubmatch(line); m != nil {
+			line = m[2]
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, ">"))
+		line = reBold.ReplaceAllString(line, "$1$2")
+		return strings.ReplaceAll(line, "`", "")
+	}
+	return ""
+}
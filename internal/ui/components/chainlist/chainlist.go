@@ -0,0 +1,187 @@
+// Package chainlist provides the chain session browser: the saved chain
+// context files, to resume, start anew or delete.
+package chainlist
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lazyvibe/vibemux/internal/runtime"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
+)
+
+// listHeight is how many chains are shown at once.
+const listHeight = 12
+
+// Action is what the last key asked the app to do.
+type Action int
+
+const (
+	// ActionNone needs nothing from the app.
+	ActionNone Action = iota
+	// ActionResume makes the selected chain the current one.
+	ActionResume
+	// ActionNew starts a fresh chain.
+	ActionNew
+	// ActionDelete removes the selected chain file.
+	ActionDelete
+	// ActionClose closes the browser.
+	ActionClose
+)
+
+// Model is the chain browser.
+type Model struct {
+	chains        []runtime.ChainInfo
+	current       string // Path of the chain in use
+	cursor        int
+	offset        int
+	confirmDelete bool
+	width         int
+	action        Action
+}
+
+// New creates an empty browser.
+func New() Model {
+	return Model{}
+}
+
+// Open shows chains; current is the path of the chain in use.
+func (m *Model) Open(chains []runtime.ChainInfo, current string) {
+	m.cursor, m.offset = 0, 0
+	m.SetChains(chains, current)
+	m.confirmDelete = false
+	m.action = ActionNone
+}
+
+// SetChains replaces the listed chains, keeping the cursor in range.
+func (m *Model) SetChains(chains []runtime.ChainInfo, current string) {
+	m.chains = chains
+	m.current = current
+	m.move(0)
+}
+
+// SetSize sets the browser width from the screen size.
+func (m *Model) SetSize(width, _ int) {
+	m.width = min(90, width-4)
+}
+
+// Action returns what the last key asked for.
+func (m Model) Action() Action { return m.action }
+
+// Selected returns the chain under the cursor.
+func (m Model) Selected() (runtime.ChainInfo, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.chains) {
+		return runtime.ChainInfo{}, false
+	}
+	return m.chains[m.cursor], true
+}
+
+// Update handles key input. Deleting asks for a second d.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	m.action = ActionNone
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	confirmDelete := m.confirmDelete
+	m.confirmDelete = false
+	switch keyMsg.String() {
+	case "esc", "q":
+		m.action = ActionClose
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "enter":
+		if _, ok := m.Selected(); ok {
+			m.action = ActionResume
+		}
+	case "n":
+		m.action = ActionNew
+	case "d", "delete":
+		if _, ok := m.Selected(); !ok {
+			break
+		}
+		if confirmDelete {
+			m.action = ActionDelete
+		} else {
+			m.confirmDelete = true
+		}
+	}
+	return m, nil
+}
+
+// move shifts the cursor and keeps it visible.
+func (m *Model) move(delta int) {
+	m.cursor = max(0, min(m.cursor+delta, len(m.chains)-1))
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+listHeight {
+		m.offset = m.cursor - listHeight + 1
+	}
+}
+
+// View renders the browser.
+func (m Model) View() string {
+	width := max(m.width, 40)
+	innerWidth := width - 6
+	lineStyle := lipgloss.NewStyle().Foreground(styles.Subtext1)
+	selectedStyle := lipgloss.NewStyle().Foreground(styles.TextCol).Background(styles.Surface1).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.TextMuted)
+
+	rows := make([]string, 0, listHeight)
+	end := min(m.offset+listHeight, len(m.chains))
+	for i := m.offset; i < end; i++ {
+		c := m.chains[i]
+		dot := lipgloss.NewStyle().Foreground(styles.StatusIdle).Render("○")
+		if c.Path == m.current {
+			dot = lipgloss.NewStyle().Foreground(styles.StatusRunning).Render("●")
+		}
+		task := strings.Join(strings.Fields(c.Task), " ")
+		detail := fmt.Sprintf("%d entries · %s", c.Entries, formatAge(c.Modified))
+		text := ansi.Truncate(fmt.Sprintf("%s %s  %s", dot, task, mutedStyle.Render(detail)), innerWidth, "…")
+		if i == m.cursor {
+			rows = append(rows, selectedStyle.Width(innerWidth).Render(text))
+		} else {
+			rows = append(rows, lineStyle.Render(text))
+		}
+	}
+	if len(rows) == 0 {
+		rows = append(rows, mutedStyle.Render("No saved chains"))
+	}
+
+	help := mutedStyle.Render("Enter resume • n new chain • d delete • Esc close")
+	if m.confirmDelete {
+		c, _ := m.Selected()
+		help = lipgloss.NewStyle().Foreground(styles.Warning).Render(
+			ansi.Truncate(fmt.Sprintf("Press d again to delete %q", c.Task), innerWidth, "…"))
+	}
+	body := lipgloss.JoinVertical(
+		lipgloss.Left,
+		styles.DialogTitle.Render("Chain Sessions"),
+		lipgloss.JoinVertical(lipgloss.Left, rows...),
+		"",
+		help,
+	)
+	return styles.DialogBox.Width(width - 2).Render(body)
+}
+
+// formatAge describes how long ago t was, e.g. "5m ago".
+func formatAge(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return t.Format("2006-01-02")
+	}
+}
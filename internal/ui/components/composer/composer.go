@@ -0,0 +1,183 @@
+// Package composer provides a multi-line prompt editor whose text is sent
+// to the panes as a whole, so Enter starts a new line instead of
+// submitting.
+package composer
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
+)
+
+// HistoryLimit is how many sent prompts the history keeps.
+const HistoryLimit = 100
+
+// Target is where a composed prompt is sent.
+type Target int
+
+const (
+	// TargetPane sends to the active pane.
+	TargetPane Target = iota
+	// TargetAll sends to every running pane.
+	TargetAll
+	// TargetChain sends the chain context followed by the prompt to the
+	// active pane.
+	TargetChain
+)
+
+// Model is the composer dialog.
+type Model struct {
+	input     textarea.Model
+	target    Target
+	paneLabel string
+	history   []string // Oldest first
+	browse    int      // Index into history while browsing, len(history) otherwise
+	draft     string   // Text being edited before history browsing started
+	width     int
+	submitted bool
+	cancelled bool
+}
+
+// New creates an empty composer.
+func New() Model {
+	ta := textarea.New()
+	ta.Placeholder = "Write a prompt… Enter adds a line, Ctrl+S sends"
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 0
+	return Model{input: ta}
+}
+
+// Open shows the composer for target, keeping any unsent text. paneLabel
+// names the active pane.
+func (m *Model) Open(target Target, paneLabel string) {
+	m.target = target
+	m.paneLabel = paneLabel
+	m.browse = len(m.history)
+	m.submitted = false
+	m.cancelled = false
+	m.input.Focus()
+}
+
+// SetSize sizes the editor from the screen size.
+func (m *Model) SetSize(width, height int) {
+	m.width = min(100, width-4)
+	m.input.SetWidth(max(m.width-8, 10))
+	m.input.SetHeight(max(min(height/2, 20), 3))
+}
+
+// SetHistory replaces the sent-prompt history, oldest first.
+func (m *Model) SetHistory(history []string) {
+	m.history = history
+	m.browse = len(history)
+}
+
+// History returns the sent-prompt history, oldest first.
+func (m Model) History() []string { return m.history }
+
+// IsSubmitted reports whether the prompt should be sent.
+func (m Model) IsSubmitted() bool { return m.submitted }
+
+// IsCancelled reports whether the composer was closed.
+func (m Model) IsCancelled() bool { return m.cancelled }
+
+// Value returns the prompt text.
+func (m Model) Value() string { return m.input.Value() }
+
+// Target returns where the prompt goes.
+func (m Model) Target() Target { return m.target }
+
+// Insert adds text at the cursor.
+func (m *Model) Insert(text string) {
+	m.input.InsertString(text)
+}
+
+// Sent clears the editor and adds text to the history.
+func (m *Model) Sent(text string) {
+	m.input.Reset()
+	m.draft = ""
+	if n := len(m.history); n == 0 || m.history[n-1] != text {
+		m.history = append(m.history, text)
+	}
+	if len(m.history) > HistoryLimit {
+		m.history = m.history[len(m.history)-HistoryLimit:]
+	}
+	m.browse = len(m.history)
+}
+
+// Update handles key input. Ctrl+S sends, Ctrl+T changes the target,
+// Alt+↑/↓ step through sent prompts and Esc closes, keeping the text.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "esc":
+			m.cancelled = true
+			return m, nil
+		case "ctrl+s":
+			m.submitted = m.input.Value() != ""
+			return m, nil
+		case "ctrl+t":
+			m.target = (m.target + 1) % 3
+			return m, nil
+		case "alt+up":
+			m.recall(-1)
+			return m, nil
+		case "alt+down":
+			m.recall(1)
+			return m, nil
+		}
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// recall steps through the history; stepping past the newest entry brings
+// back the draft.
+func (m *Model) recall(delta int) {
+	next := m.browse + delta
+	if next < 0 || next > len(m.history) {
+		return
+	}
+	if m.browse == len(m.history) {
+		m.draft = m.input.Value()
+	}
+	m.browse = next
+	if next == len(m.history) {
+		m.input.SetValue(m.draft)
+	} else {
+		m.input.SetValue(m.history[next])
+	}
+}
+
+// View renders the composer.
+func (m Model) View() string {
+	width := max(m.width, 40)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.TextMuted)
+
+	var target string
+	switch m.target {
+	case TargetAll:
+		target = "all running panes"
+	case TargetChain:
+		target = "chain context + prompt → " + m.paneLabel
+	default:
+		target = m.paneLabel
+	}
+	title := "Compose → " + target
+	if m.browse < len(m.history) {
+		title += fmt.Sprintf(" (history %d/%d)", m.browse+1, len(m.history))
+	}
+
+	body := lipgloss.JoinVertical(
+		lipgloss.Left,
+		styles.DialogTitle.Render(ansi.Truncate(title, width-6, "…")),
+		m.input.View(),
+		"",
+		mutedStyle.Render("Ctrl+S send • Ctrl+T target • Alt+↑/↓ history • Esc close (keeps text)"),
+	)
+	return styles.DialogBox.Width(width - 2).Render(body)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
 )
 
 // InputType defines the type of input field.
@@ -125,11 +126,11 @@ type Styles struct {
 }
 
 func DefaultStyles() Styles {
-	purple := lipgloss.Color("#7C3AED")
-	cyan := lipgloss.Color("#06B6D4")
-	surface := lipgloss.Color("#1E1E2E")
-	surfaceLight := lipgloss.Color("#313244")
-	textMuted := lipgloss.Color("#6C7086")
+	purple := styles.Primary
+	cyan := styles.Accent
+	surface := styles.Base
+	surfaceLight := styles.Surface0
+	textMuted := styles.Overlay0
 
 	return Styles{
 		Box: lipgloss.NewStyle().
@@ -379,7 +380,7 @@ func (m Model) View() string {
 		Width(40).
 		PaddingRight(1).
 		Border(lipgloss.NormalBorder(), false, true, false, false).
-		BorderForeground(lipgloss.Color("240")).
+		BorderForeground(styles.Surface1).
 		Render(leftB.String())
 	
 	// Render Right Grid
@@ -0,0 +1,105 @@
+// Package confirm provides a small multiple-choice confirmation dialog.
+package confirm
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
+)
+
+// Model asks a question with a row of choices.
+type Model struct {
+	title     string
+	message   string
+	choices   []string
+	cursor    int
+	width     int
+	submitted bool
+	cancelled bool
+}
+
+// New creates an empty confirmation dialog.
+func New() Model {
+	return Model{}
+}
+
+// Open resets the dialog with a question. The first choice is the default;
+// each choice can also be picked by its first letter.
+func (m *Model) Open(title, message string, choices ...string) {
+	m.title = title
+	m.message = message
+	m.choices = choices
+	m.cursor = 0
+	m.submitted = false
+	m.cancelled = false
+}
+
+// SetSize sets the dialog width from the screen size.
+func (m *Model) SetSize(width, _ int) {
+	m.width = min(70, width-4)
+}
+
+// IsSubmitted reports whether a choice was made.
+func (m Model) IsSubmitted() bool { return m.submitted }
+
+// IsCancelled reports whether the dialog was dismissed.
+func (m Model) IsCancelled() bool { return m.cancelled }
+
+// Choice returns the index of the selected choice.
+func (m Model) Choice() int { return m.cursor }
+
+// Update handles key input.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.String() {
+	case "esc":
+		m.cancelled = true
+	case "enter":
+		m.submitted = true
+	case "left", "shift+tab", "h":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "right", "tab", "l":
+		if m.cursor < len(m.choices)-1 {
+			m.cursor++
+		}
+	default:
+		for i, choice := range m.choices {
+			if choice != "" && strings.EqualFold(keyMsg.String(), choice[:1]) {
+				m.cursor = i
+				m.submitted = true
+				break
+			}
+		}
+	}
+	return m, nil
+}
+
+// View renders the dialog.
+func (m Model) View() string {
+	width := max(m.width, 30)
+	buttons := make([]string, 0, len(m.choices))
+	for i, choice := range m.choices {
+		style := lipgloss.NewStyle().Padding(0, 1).Foreground(styles.Subtext1)
+		if i == m.cursor {
+			style = style.Foreground(styles.TextCol).Background(styles.Surface1).Bold(true)
+		}
+		buttons = append(buttons, style.Render(choice))
+	}
+	body := lipgloss.JoinVertical(
+		lipgloss.Left,
+		styles.DialogTitle.Render(m.title),
+		lipgloss.NewStyle().Width(width-6).Render(m.message),
+		"",
+		strings.Join(buttons, "  "),
+		"",
+		lipgloss.NewStyle().Foreground(styles.TextMuted).Render("←/→ select • Enter confirm • Esc cancel"),
+	)
+	return styles.DialogBox.Width(width - 2).Render(body)
+}
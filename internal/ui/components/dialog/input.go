@@ -7,6 +7,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
 	"github.com/lazyvibe/vibemux/pkg/utils"
 )
 
@@ -17,6 +18,7 @@ type InputField struct {
 	Value          string
 	EnablePathComp bool // Enable path completion for this field
 	Options        []string
+	Secret         bool // Hide the typed text, e.g. for passphrases
 }
 
 // InputDialog is a modal dialog for text input.
@@ -57,13 +59,13 @@ type InputStyles struct {
 
 // DefaultInputStyles returns beautifully styled dialog styles.
 func DefaultInputStyles() InputStyles {
-	purple := lipgloss.Color("#7C3AED")
-	cyan := lipgloss.Color("#06B6D4")
-	pink := lipgloss.Color("#EC4899")
-	surface := lipgloss.Color("#1E1E2E")
-	surfaceLight := lipgloss.Color("#313244")
-	text := lipgloss.Color("#CDD6F4")
-	textMuted := lipgloss.Color("#6C7086")
+	purple := styles.Primary
+	cyan := styles.Accent
+	pink := styles.Pink
+	surface := styles.Base
+	surfaceLight := styles.Surface0
+	text := styles.TextCol
+	textMuted := styles.Overlay0
 
 	return InputStyles{
 		Overlay: lipgloss.NewStyle().
@@ -136,6 +138,10 @@ func NewInputDialog(title string, fields []InputField) InputDialog {
 		ti.SetValue(f.Value)
 		ti.CharLimit = 256
 		ti.Width = 40
+		if f.Secret {
+			ti.EchoMode = textinput.EchoPassword
+			ti.EchoCharacter = '•'
+		}
 
 		if i == 0 {
 			ti.Focus()
@@ -176,7 +182,10 @@ func (d InputDialog) Update(msg tea.Msg) (InputDialog, tea.Cmd) {
 		case "tab":
 			// If path completion is enabled and we have suggestions, cycle through them
 			if d.isSuggestionEnabled() && d.showSuggestions && len(d.suggestions) > 0 {
-				d.suggestionIndex = (d.suggestionIndex + 1) % len(d.suggestions)
+				// The first Tab takes the highlighted suggestion.
+				if d.inputs[d.focusIndex].Value() == d.suggestions[d.suggestionIndex] {
+					d.suggestionIndex = (d.suggestionIndex + 1) % len(d.suggestions)
+				}
 				d.inputs[d.focusIndex].SetValue(d.suggestions[d.suggestionIndex])
 				d.inputs[d.focusIndex].CursorEnd()
 				return d, nil
@@ -318,10 +327,10 @@ func (d InputDialog) View() string {
 		// Show suggestions for completion fields
 		if i == d.focusIndex && d.isSuggestionEnabled() && d.showSuggestions && len(d.suggestions) > 0 {
 			suggestionStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#6C7086")).
+				Foreground(styles.Overlay0).
 				PaddingLeft(2)
 			selectedStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#06B6D4")).
+				Foreground(styles.Accent).
 				Bold(true).
 				PaddingLeft(2)
 
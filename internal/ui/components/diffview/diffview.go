@@ -0,0 +1,313 @@
+// Package diffview provides the diff viewer: what changed in a pane's
+// repository, file by file, with the changed code highlighted.
+package diffview
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lazyvibe/vibemux/internal/git"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
+)
+
+// Action is what the last key asked the app to do.
+type Action int
+
+const (
+	// ActionNone needs nothing from the app.
+	ActionNone Action = iota
+	// ActionRefresh reads the diff again.
+	ActionRefresh
+	// ActionClose closes the viewer.
+	ActionClose
+)
+
+// minListWidth is the viewer width from which the file list is shown
+// next to the diff.
+const minListWidth = 90
+
+// Model is the diff viewer.
+type Model struct {
+	title  string
+	files  []git.FileDiff
+	file   int // Selected file
+	offset int // First diff line shown
+	width  int
+	height int
+	action Action
+}
+
+// New creates an empty viewer.
+func New() Model {
+	return Model{}
+}
+
+// SetSize makes the viewer fill the screen but for a small margin.
+func (m *Model) SetSize(width, height int) {
+	m.width = max(40, width-4)
+	m.height = max(12, height-2)
+}
+
+// Open shows files under title, starting at the first file.
+func (m *Model) Open(title string, files []git.FileDiff) {
+	m.title = title
+	m.files = files
+	m.file, m.offset = 0, 0
+	m.action = ActionNone
+}
+
+// SetFiles replaces the files after a refresh, staying on the same file
+// when it is still changed.
+func (m *Model) SetFiles(files []git.FileDiff) {
+	current := ""
+	if m.file < len(m.files) {
+		current = m.files[m.file].Path
+	}
+	m.files = files
+	m.file = 0
+	for i, f := range files {
+		if f.Path == current {
+			m.file = i
+		}
+	}
+	m.scroll(0)
+}
+
+// Action returns what the last key asked for.
+func (m Model) Action() Action { return m.action }
+
+// Update handles key input.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	m.action = ActionNone
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	page := max(1, m.bodyHeight()/2)
+	switch keyMsg.String() {
+	case "esc", "q", "D":
+		m.action = ActionClose
+	case "r":
+		m.action = ActionRefresh
+	case "down", "j":
+		m.scroll(1)
+	case "up", "k":
+		m.scroll(-1)
+	case "pgdown", "ctrl+d", " ":
+		m.scroll(page)
+	case "pgup", "ctrl+u":
+		m.scroll(-page)
+	case "home", "g":
+		m.offset = 0
+	case "end", "G":
+		m.scroll(len(m.currentLines()))
+	case "tab", "n", "]", "right", "l":
+		m.selectFile(m.file + 1)
+	case "shift+tab", "p", "[", "left", "h":
+		m.selectFile(m.file - 1)
+	}
+	return m, nil
+}
+
+// selectFile shows file i from its top.
+func (m *Model) selectFile(i int) {
+	if i < 0 || i >= len(m.files) {
+		return
+	}
+	m.file, m.offset = i, 0
+}
+
+// scroll moves the diff by delta lines, keeping a screenful in view.
+func (m *Model) scroll(delta int) {
+	last := max(0, len(m.currentLines())-m.bodyHeight())
+	m.offset = max(0, min(m.offset+delta, last))
+}
+
+// bodyHeight is how many diff lines fit: the box's border and padding,
+// the title and its margin, and the help line with the blank above it
+// take eight rows.
+func (m Model) bodyHeight() int {
+	return max(1, m.height-8)
+}
+
+// currentLines returns the rendered-to-be lines of the selected file:
+// its hunks, without the headers git puts before them.
+func (m Model) currentLines() []string {
+	if m.file >= len(m.files) {
+		return nil
+	}
+	f := m.files[m.file]
+	for i, line := range f.Lines {
+		if strings.HasPrefix(line, "@@") {
+			return f.Lines[i:]
+		}
+	}
+	if f.Binary {
+		return []string{"Binary file changed"}
+	}
+	return []string{"No content changes (mode or rename only)"}
+}
+
+// View renders the viewer.
+func (m Model) View() string {
+	innerWidth := m.width - 6
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.TextMuted)
+
+	added, removed := 0, 0
+	for _, f := range m.files {
+		added += f.Added
+		removed += f.Removed
+	}
+	title := fmt.Sprintf("%s · %d files %s %s", m.title, len(m.files),
+		lipgloss.NewStyle().Foreground(styles.Success).Render(fmt.Sprintf("+%d", added)),
+		lipgloss.NewStyle().Foreground(styles.Danger).Render(fmt.Sprintf("-%d", removed)))
+
+	var body string
+	if len(m.files) == 0 {
+		body = mutedStyle.Render("No changes yet")
+	} else {
+		diffWidth := innerWidth
+		var list string
+		if m.width >= minListWidth {
+			listWidth := min(36, innerWidth/3)
+			diffWidth = innerWidth - listWidth - 3
+			list = m.renderList(listWidth)
+		}
+		diff := m.renderDiff(diffWidth)
+		if list != "" {
+			sep := lipgloss.NewStyle().Foreground(styles.Surface1).
+				Render(strings.TrimSuffix(strings.Repeat(" │ \n", m.bodyHeight()+1), "\n"))
+			body = lipgloss.JoinHorizontal(lipgloss.Top, list, sep, diff)
+		} else {
+			body = diff
+		}
+	}
+
+	help := mutedStyle.Render(ansi.Truncate("j/k scroll • PgUp/PgDn page • n/p next/previous file • r refresh • Esc close", innerWidth, "…"))
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		styles.DialogTitle.Render(ansi.Truncate(title, innerWidth, "…")),
+		lipgloss.NewStyle().Height(m.bodyHeight()+1).MaxHeight(m.bodyHeight()+1).Render(body),
+		help,
+	)
+	return styles.DialogBox.Width(m.width - 2).Render(content)
+}
+
+// renderList renders the changed files with the selected one marked.
+func (m Model) renderList(width int) string {
+	height := m.bodyHeight() + 1
+	start := 0
+	if m.file >= height {
+		start = m.file - height + 1
+	}
+	rows := make([]string, 0, height)
+	for i := start; i < len(m.files) && len(rows) < height; i++ {
+		f := m.files[i]
+		counts := fmt.Sprintf("+%d -%d", f.Added, f.Removed)
+		// Long paths keep their end, where the file name is.
+		name, avail := f.Path, width-len(counts)-3
+		if w := ansi.StringWidth(name); w > avail {
+			name = ansi.TruncateLeft(name, w-avail+1, "…")
+		}
+		name = f.Status + " " + name
+		text := name + strings.Repeat(" ", max(1, width-ansi.StringWidth(name)-len(counts))) + counts
+		style := lipgloss.NewStyle().Foreground(statusColor(f.Status)).Width(width)
+		if i == m.file {
+			style = style.Foreground(styles.TextCol).Background(styles.Surface1).Bold(true)
+		}
+		rows = append(rows, style.Render(text))
+	}
+	return lipgloss.NewStyle().Width(width).Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+// renderDiff renders the header and the visible lines of the selected
+// file.
+func (m Model) renderDiff(width int) string {
+	f := m.files[m.file]
+	name := f.Path
+	if f.OldPath != "" {
+		name = f.OldPath + " → " + f.Path
+	}
+	header := lipgloss.NewStyle().Foreground(styles.Primary).Bold(true).
+		Render(ansi.Truncate(fmt.Sprintf("%d/%d %s", m.file+1, len(m.files), name), width, "…"))
+
+	lang := langFor(path.Ext(f.Path))
+	lines := m.currentLines()
+	// Line numbers of the new file start at the hunk above the first
+	// visible line.
+	newLine := 0
+	for _, line := range lines[:m.offset] {
+		newLine = advanceLine(line, newLine)
+	}
+	rows := []string{header}
+	end := min(m.offset+m.bodyHeight(), len(lines))
+	for _, line := range lines[m.offset:end] {
+		rows = append(rows, ansi.Truncate(renderLine(line, newLine, lang), width, "…"))
+		newLine = advanceLine(line, newLine)
+	}
+	return lipgloss.NewStyle().Width(width).Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+// advanceLine returns the new-file line number of the line after line.
+func advanceLine(line string, n int) int {
+	switch {
+	case strings.HasPrefix(line, "@@"):
+		return hunkStart(line)
+	case strings.HasPrefix(line, "-"), strings.HasPrefix(line, `\`):
+		return n
+	}
+	return n + 1
+}
+
+// hunkStart reads the new-file start line from "@@ -a,b +c,d @@".
+func hunkStart(header string) int {
+	_, rest, ok := strings.Cut(header, " +")
+	if !ok {
+		return 0
+	}
+	field, _, _ := strings.Cut(rest, " ")
+	field, _, _ = strings.Cut(field, ",")
+	n, _ := strconv.Atoi(field)
+	return n
+}
+
+// renderLine renders one diff line with a gutter holding its new-file
+// line number. Added code is highlighted, removed code is red and context
+// is dimmed.
+func renderLine(line string, n int, lang *language) string {
+	gutterStyle := lipgloss.NewStyle().Foreground(styles.Overlay0)
+	gutter := gutterStyle.Render(fmt.Sprintf("%5d ", n))
+	code := ""
+	if line != "" {
+		code = strings.ReplaceAll(line[1:], "\t", "    ")
+	}
+	switch {
+	case strings.HasPrefix(line, "@@"):
+		return lipgloss.NewStyle().Foreground(styles.Sapphire).Render(line)
+	case strings.HasPrefix(line, `\`):
+		return gutterStyle.Render("      " + line)
+	case strings.HasPrefix(line, "+"):
+		return gutter + lipgloss.NewStyle().Foreground(styles.Success).Bold(true).Render("+") +
+			highlight(code, lang, lipgloss.NewStyle().Foreground(styles.TextCol))
+	case strings.HasPrefix(line, "-"):
+		return gutterStyle.Render("      ") + lipgloss.NewStyle().Foreground(styles.Danger).Render("-"+code)
+	}
+	return gutter + lipgloss.NewStyle().Foreground(styles.TextMuted).Render(" "+code)
+}
+
+// statusColor colors a file by how it changed.
+func statusColor(status string) lipgloss.Color {
+	switch status {
+	case "A":
+		return styles.Success
+	case "D":
+		return styles.Danger
+	case "R":
+		return styles.Info
+	}
+	return styles.Subtext1
+}
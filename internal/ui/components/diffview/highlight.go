@@ -0,0 +1,122 @@
+package diffview
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
+)
+
+// language is what the highlighter knows about a file type: its line
+// comment marker and keywords.
+type language struct {
+	comment  string
+	keywords map[string]bool
+}
+
+func words(s string) map[string]bool {
+	m := make(map[string]bool)
+	for _, w := range strings.Fields(s) {
+		m[w] = true
+	}
+	return m
+}
+
+var (
+	langGo = &language{"//", words(`break case chan const continue default defer else fallthrough for func go goto if
+		import interface map package range return select struct switch type var nil true false`)}
+	langJS = &language{"//", words(`async await break case catch class const continue default delete do else export
+		extends false finally for from function if import in instanceof interface let new null return static super
+		switch this throw true try type typeof undefined var void while yield`)}
+	langRust = &language{"//", words(`as async await break const continue crate else enum extern false fn for if impl
+		in let loop match mod move mut pub ref return self Self static struct super trait true type unsafe use where while`)}
+	langC = &language{"//", words(`auto break case catch char class const continue default delete do double else enum
+		extends false final float for if implements import int long namespace new null nullptr package private protected
+		public return short static struct switch template this throw true try typedef union unsigned using virtual void while`)}
+	langPython = &language{"#", words(`and as assert async await break class continue def del elif else except False
+		finally for from global if import in is lambda None nonlocal not or pass raise return True try while with yield`)}
+	langShell  = &language{"#", words(`case do done elif else esac export fi for function if in local return then until while`)}
+	langConfig = &language{"#", words(`true false null yes no on off`)}
+)
+
+// languages maps file extensions to languages.
+var languages = map[string]*language{
+	".go": langGo,
+	".js": langJS, ".jsx": langJS, ".ts": langJS, ".tsx": langJS, ".mjs": langJS, ".cjs": langJS,
+	".rs": langRust,
+	".c":  langC, ".h": langC, ".cc": langC, ".cpp": langC, ".hpp": langC, ".java": langC, ".kt": langC,
+	".cs": langC, ".swift": langC,
+	".py": langPython,
+	".sh": langShell, ".bash": langShell, ".zsh": langShell,
+	".yaml": langConfig, ".yml": langConfig, ".toml": langConfig,
+}
+
+// langFor returns the language of files with extension ext, or nil.
+func langFor(ext string) *language {
+	return languages[strings.ToLower(ext)]
+}
+
+// highlight colors the comments, strings, numbers and keywords of one line
+// of code; everything else gets base. Tokens are found line by line, so a
+// string or comment spanning lines is only colored where it starts.
+func highlight(code string, lang *language, base lipgloss.Style) string {
+	if lang == nil {
+		return base.Render(code)
+	}
+	keyword := lipgloss.NewStyle().Foreground(styles.Mauve)
+	str := lipgloss.NewStyle().Foreground(styles.Green)
+	number := lipgloss.NewStyle().Foreground(styles.Peach)
+	comment := lipgloss.NewStyle().Foreground(styles.Overlay1).Italic(true)
+
+	var b strings.Builder
+	runes := []rune(code)
+	plain := 0 // Start of the uncolored run
+	flush := func(end int) {
+		if end > plain {
+			b.WriteString(base.Render(string(runes[plain:end])))
+		}
+	}
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case strings.HasPrefix(string(runes[i:]), lang.comment):
+			flush(i)
+			b.WriteString(comment.Render(string(runes[i:])))
+			return b.String()
+		case r == '"' || r == '\'' || r == '`':
+			j := i + 1
+			for j < len(runes) && runes[j] != r {
+				if runes[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			j = min(j+1, len(runes))
+			flush(i)
+			b.WriteString(str.Render(string(runes[i:j])))
+			i, plain = j, j
+		case unicode.IsLetter(r) || r == '_' || unicode.IsDigit(r):
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_' || runes[j] == '.' && unicode.IsDigit(r)) {
+				j++
+			}
+			word := string(runes[i:j])
+			switch {
+			case unicode.IsDigit(r):
+				flush(i)
+				b.WriteString(number.Render(word))
+				plain = j
+			case lang.keywords[word]:
+				flush(i)
+				b.WriteString(keyword.Render(word))
+				plain = j
+			}
+			i = j
+		default:
+			i++
+		}
+	}
+	flush(len(runes))
+	return b.String()
+}
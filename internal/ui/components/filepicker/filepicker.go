@@ -0,0 +1,205 @@
+// Package filepicker provides a dialog for choosing where to save a file:
+// a directory browser with a file name field.
+package filepicker
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
+)
+
+// listHeight is how many directory entries are shown at once.
+const listHeight = 10
+
+// Model is the save dialog.
+type Model struct {
+	title     string
+	dir       string
+	entries   []os.DirEntry
+	err       error
+	cursor    int
+	offset    int
+	input     textinput.Model
+	inList    bool // focus is on the directory list rather than the name
+	width     int
+	submitted bool
+	cancelled bool
+}
+
+// New creates an empty dialog.
+func New() Model {
+	ti := textinput.New()
+	ti.Prompt = "Name: "
+	return Model{input: ti}
+}
+
+// Open resets the dialog to browse dir with name proposed as the file name.
+func (m *Model) Open(title, dir, name string) {
+	m.title = title
+	m.input.SetValue(name)
+	m.input.CursorEnd()
+	m.input.Focus()
+	m.inList = false
+	m.submitted = false
+	m.cancelled = false
+	m.chdir(dir)
+}
+
+// SetSize sets the dialog width from the screen size.
+func (m *Model) SetSize(width, _ int) {
+	m.width = min(70, width-4)
+}
+
+// IsSubmitted reports whether a destination was chosen.
+func (m Model) IsSubmitted() bool { return m.submitted }
+
+// IsCancelled reports whether the dialog was dismissed.
+func (m Model) IsCancelled() bool { return m.cancelled }
+
+// Path returns the chosen file path.
+func (m Model) Path() string {
+	return filepath.Join(m.dir, strings.TrimSpace(m.input.Value()))
+}
+
+// Update handles key input. Tab switches between the name and the
+// directory list; Enter saves from the name field and opens a directory
+// from the list.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.String() {
+	case "esc":
+		m.cancelled = true
+		return m, nil
+	case "tab", "shift+tab":
+		m.inList = !m.inList
+		if m.inList {
+			m.input.Blur()
+		} else {
+			m.input.Focus()
+		}
+		return m, nil
+	case "ctrl+t":
+		m.toggleExt()
+		return m, nil
+	}
+	if !m.inList {
+		if keyMsg.String() == "enter" {
+			m.submitted = strings.TrimSpace(m.input.Value()) != ""
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+	switch keyMsg.String() {
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "enter", "right", "l":
+		if m.cursor == 0 {
+			m.chdir(filepath.Dir(m.dir))
+		} else if m.cursor-1 < len(m.entries) {
+			m.chdir(filepath.Join(m.dir, m.entries[m.cursor-1].Name()))
+		}
+	case "left", "h", "backspace":
+		m.chdir(filepath.Dir(m.dir))
+	}
+	return m, nil
+}
+
+// toggleExt swaps the file name between .md and .txt.
+func (m *Model) toggleExt() {
+	name := m.input.Value()
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".md":
+		name = strings.TrimSuffix(name, filepath.Ext(name)) + ".txt"
+	case ".txt":
+		name = strings.TrimSuffix(name, filepath.Ext(name)) + ".md"
+	default:
+		return
+	}
+	m.input.SetValue(name)
+	m.input.CursorEnd()
+}
+
+// move shifts the list cursor; row 0 is the parent directory.
+func (m *Model) move(delta int) {
+	m.cursor = max(0, min(m.cursor+delta, len(m.entries)))
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+listHeight {
+		m.offset = m.cursor - listHeight + 1
+	}
+}
+
+// chdir lists the subdirectories of dir.
+func (m *Model) chdir(dir string) {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		m.err = err
+		return
+	}
+	dirs := entries[:0]
+	for _, e := range entries {
+		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+			dirs = append(dirs, e)
+		}
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		return strings.ToLower(dirs[i].Name()) < strings.ToLower(dirs[j].Name())
+	})
+	m.dir, m.entries, m.err = dir, dirs, nil
+	m.cursor, m.offset = 0, 0
+}
+
+// View renders the dialog.
+func (m Model) View() string {
+	width := max(m.width, 40)
+	innerWidth := width - 6
+	lineStyle := lipgloss.NewStyle().Foreground(styles.Subtext1)
+	selectedStyle := lipgloss.NewStyle().Foreground(styles.TextCol).Background(styles.Surface1).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.TextMuted)
+
+	names := []string{"../"}
+	for _, e := range m.entries {
+		names = append(names, e.Name()+"/")
+	}
+	end := min(m.offset+listHeight, len(names))
+	rows := make([]string, 0, listHeight)
+	for i := m.offset; i < end; i++ {
+		text := ansi.Truncate(names[i], innerWidth, "…")
+		if m.inList && i == m.cursor {
+			rows = append(rows, selectedStyle.Width(innerWidth).Render(text))
+		} else {
+			rows = append(rows, lineStyle.Render(text))
+		}
+	}
+
+	parts := []string{
+		styles.DialogTitle.Render(m.title),
+		mutedStyle.Render(ansi.Truncate(m.dir, innerWidth, "…")),
+		m.input.View(),
+		"",
+		lipgloss.JoinVertical(lipgloss.Left, rows...),
+	}
+	if m.err != nil {
+		parts = append(parts, lipgloss.NewStyle().Foreground(styles.StatusError).Render(m.err.Error()))
+	}
+	parts = append(parts, "", mutedStyle.Render("Enter save • Tab browse folders • Ctrl+T .md/.txt • Esc cancel"))
+	return styles.DialogBox.Width(width - 2).Render(lipgloss.JoinVertical(lipgloss.Left, parts...))
+}
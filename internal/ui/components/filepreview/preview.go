@@ -8,6 +8,7 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
 )
 
 type TickMsg time.Time
@@ -20,13 +21,14 @@ type Model struct {
 	width    int
 	height   int
 	active   bool
+	filter   func(string) string
 }
 
 func New() Model {
 	vp := viewport.New(0, 0)
 	vp.Style = lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("62")).
+		BorderForeground(styles.Primary).
 		Padding(0, 1)
 
 	return Model{
@@ -44,11 +46,18 @@ func (m *Model) SetSize(w, h int) {
 func (m *Model) SetFile(path string) {
 	m.filePath = path
 	m.active = true
+	m.viewport.Style = m.viewport.Style.BorderForeground(styles.Primary) // Follow theme changes
 	m.lastMod = time.Time{} // Reset to force refresh
 	m.content = "" // Clear cached content
 	m.refreshFile()
 }
 
+// SetFilter transforms file content before display, e.g. to strip escape
+// sequences from a session log. Nil shows the file as is.
+func (m *Model) SetFilter(filter func(string) string) {
+	m.filter = filter
+}
+
 func (m *Model) Deactivate() {
 	m.active = false
 }
@@ -82,6 +91,9 @@ func (m *Model) refreshFile() {
 	}
 
 	m.content = string(content)
+	if m.filter != nil {
+		m.content = m.filter(m.content)
+	}
 	m.lastMod = info.ModTime()
 	
 	// Preserve scroll position logic? 
@@ -151,14 +163,14 @@ func (m Model) View() string {
 	// Debug status line
 	status := fmt.Sprintf("Path: %s | Content: %d bytes", m.filePath, len(m.content))
 	statusLine := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("245")).
+		Foreground(styles.Subtext0).
 		Render(status)
 	
 	title := fmt.Sprintf(" Live Preview: %s ", m.filePath)
 	header := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("255")).
-		Background(lipgloss.Color("62")).
+		Foreground(styles.Base).
+		Background(styles.Primary).
 		Render(title)
 	
 	// Display viewport content directly with white text
@@ -166,15 +178,15 @@ func (m Model) View() string {
 	if viewContent == "" {
 		// Fallback: Show a message if viewport is empty
 		viewContent = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("243")).
+			Foreground(styles.Overlay0).
 			Italic(true).
 			Render("(No content to display)")
 	}
 	
 	contentBox := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("62")).
-		Foreground(lipgloss.Color("255")).
+		BorderForeground(styles.Primary).
+		Foreground(styles.TextCol).
 		Width(m.width - 4).
 		Height(m.height - 6).
 		Padding(1, 2).
@@ -0,0 +1,230 @@
+// Package notifylist provides the notification center: the recent
+// notification events of every pane, newest first, to jump to the pane
+// that raised one.
+package notifylist
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lazyvibe/vibemux/internal/notify"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
+)
+
+// listHeight is how many entries are shown at once.
+const listHeight = 12
+
+// Entry is one notification event.
+type Entry struct {
+	// SessionID is the pane that raised the event; empty for app-wide
+	// events such as all-quiet.
+	SessionID string
+	// Project is the pane label, or the project name when the pane is gone.
+	Project string
+	Type    notify.EventType
+	Title   string
+	Message string
+	Time    time.Time
+}
+
+// Action is what the last key asked the app to do.
+type Action int
+
+const (
+	// ActionNone needs nothing from the app.
+	ActionNone Action = iota
+	// ActionJump focuses the pane of the selected entry.
+	ActionJump
+	// ActionClear empties the history.
+	ActionClear
+	// ActionClose closes the panel.
+	ActionClose
+)
+
+// Model is the notification center.
+type Model struct {
+	entries []Entry // Newest first
+	seen    time.Time
+	pane    string // Only show entries of this pane when set
+	cursor  int
+	offset  int
+	width   int
+	action  Action
+}
+
+// New creates an empty panel.
+func New() Model {
+	return Model{}
+}
+
+// Open shows entries, newest first. Entries newer than seen are marked new.
+func (m *Model) Open(entries []Entry, seen time.Time) {
+	m.cursor, m.offset = 0, 0
+	m.pane = ""
+	m.seen = seen
+	m.SetEntries(entries)
+	m.action = ActionNone
+}
+
+// SetEntries replaces the listed entries, keeping the cursor in range.
+func (m *Model) SetEntries(entries []Entry) {
+	m.entries = entries
+	m.move(0)
+}
+
+// SetSize sets the panel width from the screen size.
+func (m *Model) SetSize(width, _ int) {
+	m.width = min(100, width-4)
+}
+
+// Action returns what the last key asked for.
+func (m Model) Action() Action { return m.action }
+
+// Selected returns the entry under the cursor.
+func (m Model) Selected() (Entry, bool) {
+	visible := m.visible()
+	if m.cursor < 0 || m.cursor >= len(visible) {
+		return Entry{}, false
+	}
+	return visible[m.cursor], true
+}
+
+// visible returns the entries passing the pane filter.
+func (m Model) visible() []Entry {
+	if m.pane == "" {
+		return m.entries
+	}
+	var out []Entry
+	for _, e := range m.entries {
+		if e.Project == m.pane {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Update handles key input. p toggles showing only the selected entry's
+// pane.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	m.action = ActionNone
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.String() {
+	case "esc", "q", "b":
+		m.action = ActionClose
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "home", "g":
+		m.move(-len(m.entries))
+	case "end", "G":
+		m.move(len(m.entries))
+	case "enter":
+		if e, ok := m.Selected(); ok && e.SessionID != "" {
+			m.action = ActionJump
+		}
+	case "p":
+		if m.pane != "" {
+			m.pane = ""
+		} else if e, ok := m.Selected(); ok {
+			m.pane = e.Project
+		}
+		m.cursor, m.offset = 0, 0
+	case "c":
+		if len(m.entries) > 0 {
+			m.action = ActionClear
+		}
+	}
+	return m, nil
+}
+
+// move shifts the cursor and keeps it visible.
+func (m *Model) move(delta int) {
+	m.cursor = max(0, min(m.cursor+delta, len(m.visible())-1))
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+listHeight {
+		m.offset = m.cursor - listHeight + 1
+	}
+}
+
+// View renders the panel.
+func (m Model) View() string {
+	width := max(m.width, 40)
+	innerWidth := width - 6
+	lineStyle := lipgloss.NewStyle().Foreground(styles.Subtext1)
+	selectedStyle := lipgloss.NewStyle().Foreground(styles.TextCol).Background(styles.Surface1).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.TextMuted)
+	newStyle := lipgloss.NewStyle().Foreground(styles.Warning)
+	projectStyle := lipgloss.NewStyle().Foreground(styles.Primary)
+
+	visible := m.visible()
+	rows := make([]string, 0, listHeight)
+	end := min(m.offset+listHeight, len(visible))
+	for i := m.offset; i < end; i++ {
+		e := visible[i]
+		mark := " "
+		if e.Time.After(m.seen) {
+			mark = newStyle.Render("●")
+		}
+		message := strings.Join(strings.Fields(e.Message), " ")
+		text := fmt.Sprintf("%s %s %s %s %s  %s", mark, mutedStyle.Render(formatTime(e.Time)),
+			icon(e.Type), projectStyle.Render(e.Project), e.Title, mutedStyle.Render(message))
+		text = ansi.Truncate(text, innerWidth, "…")
+		if i == m.cursor {
+			rows = append(rows, selectedStyle.Width(innerWidth).Render(text))
+		} else {
+			rows = append(rows, lineStyle.Render(text))
+		}
+	}
+	if len(rows) == 0 {
+		rows = append(rows, mutedStyle.Render("No notifications yet"))
+	}
+
+	title := "Notifications"
+	if m.pane != "" {
+		title += " · " + m.pane
+	}
+	help := mutedStyle.Render("Enter jump to pane • p this pane only • c clear • Esc close")
+	body := lipgloss.JoinVertical(
+		lipgloss.Left,
+		styles.DialogTitle.Render(title),
+		lipgloss.JoinVertical(lipgloss.Left, rows...),
+		"",
+		help,
+	)
+	return styles.DialogBox.Width(width - 2).Render(body)
+}
+
+// icon marks an event type, matching the webhook emoji.
+func icon(t notify.EventType) string {
+	switch t {
+	case notify.EventInputRequired, notify.EventInputReminder:
+		return "⏳"
+	case notify.EventTaskCompleted:
+		return "✅"
+	case notify.EventError:
+		return "❌"
+	case notify.EventAllQuiet:
+		return "💤"
+	}
+	return "🔔"
+}
+
+// formatTime shows the clock time of today's events and the date of older
+// ones.
+func formatTime(t time.Time) string {
+	now := time.Now()
+	if y, m, d := t.Date(); y == now.Year() && m == now.Month() && d == now.Day() {
+		return t.Format("15:04:05")
+	}
+	return t.Format("01-02 15:04")
+}
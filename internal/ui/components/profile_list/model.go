@@ -139,7 +139,7 @@ func (m Model) View() string {
 		}
 	}
 
-	help := styles.ListItemDim.Render("Enter: edit - a: add - d: delete - s: default - c: settings - Esc: close")
+	help := styles.ListItemDim.Render("Enter: edit - a: add - c: duplicate - x: export - i: import - d: delete - s: default - g: settings - w: setup wizard - Esc: close")
 	contentRows := append(rows, "", help)
 
 	content := lipgloss.JoinVertical(
@@ -178,6 +178,9 @@ func (m *Model) renderItem(item Item, selected bool, maxWidth int) string {
 		command = "claude"
 	}
 	content := fmt.Sprintf("%s %s - %s", mark, name, command)
+	if item.Profile.Shared {
+		content += " [shared]"
+	}
 	content = styles.TruncateWithEllipsis(content, maxWidth)
 
 	if selected {
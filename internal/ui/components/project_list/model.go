@@ -3,11 +3,16 @@ package projectlist
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lazyvibe/vibemux/internal/git"
 	"github.com/lazyvibe/vibemux/internal/model"
 	"github.com/lazyvibe/vibemux/internal/ui/styles"
+	"github.com/lazyvibe/vibemux/pkg/utils"
 )
 
 // Item represents a project in the list.
@@ -25,6 +30,15 @@ type Model struct {
 	height   int
 	offset   int // For scrolling
 	profiles map[string]string
+	// workspaceDefaults maps a workspace to its default profile name.
+	workspaceDefaults map[string]string
+	// gitStatus holds the work tree status of projects in a git
+	// repository, by project ID.
+	gitStatus map[string]git.Status
+	compact   bool // Narrow mode: status dots and initials only
+	// recent is the length of the "Recent" section at the top of items;
+	// 0 when the list is short enough to show without sections.
+	recent int
 }
 
 // New creates a new project list component.
@@ -41,6 +55,11 @@ func (m *Model) SetSize(width, height int) {
 	m.height = height
 }
 
+// SetCompact switches the narrow rendering with only status dots.
+func (m *Model) SetCompact(compact bool) {
+	m.compact = compact
+}
+
 // SetFocused updates the focus state.
 func (m *Model) SetFocused(focused bool) {
 	m.focused = focused
@@ -51,16 +70,60 @@ func (m Model) IsFocused() bool {
 	return m.focused
 }
 
-// SetProjects updates the project list.
+// SetProjects updates the project list. With more than
+// model.RecentLimit projects, the most recently used come first under a
+// "Recent" heading and the rest follow by name. The cursor stays on the
+// selected project.
 func (m *Model) SetProjects(projects []model.Project, runningIDs map[string]bool) {
-	m.items = make([]Item, len(projects))
-	for i, p := range projects {
+	selectedID := ""
+	if p := m.SelectedProject(); p != nil {
+		selectedID = p.ID
+	}
+
+	ordered := projects
+	m.recent = 0
+	if len(projects) > model.RecentLimit {
+		ordered = model.RecentProjects(projects, model.RecentLimit)
+		m.recent = len(ordered)
+		inRecent := make(map[string]bool, m.recent)
+		for _, p := range ordered {
+			inRecent[p.ID] = true
+		}
+		var rest []model.Project
+		for _, p := range projects {
+			if !inRecent[p.ID] {
+				rest = append(rest, p)
+			}
+		}
+		sort.SliceStable(rest, func(i, j int) bool {
+			return strings.ToLower(rest[i].DisplayName()) < strings.ToLower(rest[j].DisplayName())
+		})
+		ordered = append(ordered, rest...)
+	}
+
+	m.items = make([]Item, len(ordered))
+	for i, p := range ordered {
 		running := false
 		if runningIDs != nil {
 			running = runningIDs[p.ID]
 		}
 		m.items[i] = Item{Project: p, Running: running}
+		if p.ID == selectedID {
+			m.cursor = i
+		}
+	}
+	if m.cursor >= len(m.items) {
+		m.cursor = max(len(m.items)-1, 0)
+	}
+	m.ensureVisible()
+}
+
+// sectionRows is the number of heading rows shown in the list.
+func (m Model) sectionRows() int {
+	if m.recent > 0 {
+		return 2
 	}
+	return 0
 }
 
 // SetProfiles updates profile name lookup for details display.
@@ -76,6 +139,18 @@ func (m *Model) SetProfiles(profiles []model.Profile) {
 	}
 }
 
+// SetWorkspaceDefaults sets the default profile name of each workspace,
+// shown for projects without a profile of their own.
+func (m *Model) SetWorkspaceDefaults(defaults map[string]string) {
+	m.workspaceDefaults = defaults
+}
+
+// SetGitStatus sets the work tree status of projects, by project ID.
+// Projects missing from statuses show no git state.
+func (m *Model) SetGitStatus(statuses map[string]git.Status) {
+	m.gitStatus = statuses
+}
+
 // SetRunning updates the running state for a project.
 func (m *Model) SetRunning(projectID string, running bool) {
 	for i := range m.items {
@@ -123,7 +198,7 @@ func (m *Model) CursorDown() {
 
 // ensureVisible adjusts scroll offset to keep cursor visible.
 func (m *Model) ensureVisible() {
-	visibleRows := m.height - 4 // Account for border, title, and padding
+	visibleRows := m.height - 4 - m.sectionRows() // Account for border, title, and padding
 	if visibleRows < 1 {
 		visibleRows = 1
 	}
@@ -157,8 +232,81 @@ func (m *Model) HandleKey(key string) bool {
 	return false
 }
 
+// listArea splits the panel's inner height between the list and the
+// details box, which is dropped when there is no room for it.
+func (m Model) listArea(innerHeight int) (int, int, bool) {
+	detailHeight := 6
+	showDetails := innerHeight >= detailHeight+2
+	listArea := innerHeight
+	if showDetails {
+		listArea = innerHeight - detailHeight - 1
+		if listArea < 1 {
+			listArea = innerHeight
+			showDetails = false
+		}
+	}
+	return listArea, detailHeight, showDetails
+}
+
+// visibleRows returns how many items fit in listArea rows, leaving room
+// for section headers and the scroll indicator.
+func (m Model) visibleRows(listArea int) int {
+	visibleRows := listArea - m.sectionRows()
+	if len(m.items) > visibleRows {
+		visibleRows--
+		if visibleRows < 1 {
+			visibleRows = 1
+		}
+	}
+	return visibleRows
+}
+
+// ItemAt returns the index of the item shown on row y of the panel
+// (0 is the top border), or false for headers, borders and empty rows.
+func (m Model) ItemAt(y int) (int, bool) {
+	// Border, title and separator come before the first row.
+	row := y - 3
+	visible := max(m.height-4, 1)
+	if !m.compact {
+		listArea, _, _ := m.listArea(max(m.height-4, 1))
+		if row >= listArea {
+			return 0, false
+		}
+		visible = m.visibleRows(listArea)
+	}
+	if row < 0 {
+		return 0, false
+	}
+	end := min(m.offset+visible, len(m.items))
+	for i := m.offset; i < end; i++ {
+		if !m.compact && m.recent > 0 && ((i == m.offset && i < m.recent) || i == m.recent) {
+			row--
+			if row < 0 {
+				return 0, false
+			}
+		}
+		if row == 0 {
+			return i, true
+		}
+		row--
+	}
+	return 0, false
+}
+
+// Select moves the cursor to item index.
+func (m *Model) Select(index int) {
+	if index < 0 || index >= len(m.items) {
+		return
+	}
+	m.cursor = index
+	m.ensureVisible()
+}
+
 // View renders the project list.
 func (m Model) View() string {
+	if m.compact {
+		return m.viewCompact()
+	}
 	// Calculate dimensions
 	innerWidth := m.width - 4 // Border + padding
 	innerHeight := m.height - 4
@@ -182,29 +330,14 @@ func (m Model) View() string {
 
 	// Build list content
 	var rows []string
-	detailHeight := 4
-	showDetails := innerHeight >= detailHeight+2
-	listArea := innerHeight
-	if showDetails {
-		listArea = innerHeight - detailHeight - 1
-		if listArea < 1 {
-			listArea = innerHeight
-			showDetails = false
-		}
-	}
+	listArea, detailHeight, showDetails := m.listArea(innerHeight)
 
 	if len(m.items) == 0 {
 		emptyMsg := styles.TerminalPlaceholder.Render("No projects yet")
 		hint := styles.ListItemDim.Render("Press 'a' to add one")
 		rows = append(rows, "", emptyMsg, hint)
 	} else {
-		visibleRows := listArea
-		if len(m.items) > listArea {
-			visibleRows = listArea - 1
-			if visibleRows < 1 {
-				visibleRows = 1
-			}
-		}
+		visibleRows := m.visibleRows(listArea)
 
 		endIdx := m.offset + visibleRows
 		if endIdx > len(m.items) {
@@ -212,6 +345,12 @@ func (m Model) View() string {
 		}
 
 		for i := m.offset; i < endIdx; i++ {
+			if m.recent > 0 && i == m.offset && i < m.recent {
+				rows = append(rows, styles.ListItemDim.Render("Recent"))
+			}
+			if m.recent > 0 && i == m.recent {
+				rows = append(rows, styles.ListItemDim.Render("All projects"))
+			}
 			item := m.items[i]
 			row := m.renderItem(item, i == m.cursor, innerWidth-2)
 			rows = append(rows, row)
@@ -257,6 +396,56 @@ func (m Model) View() string {
 	return panel
 }
 
+// viewCompact renders one status dot (plus initials) per project.
+func (m Model) viewCompact() string {
+	innerWidth := m.width - 2
+	innerHeight := m.height - 4
+	if innerWidth < 1 {
+		innerWidth = 1
+	}
+	if innerHeight < 1 {
+		innerHeight = 1
+	}
+
+	end := m.offset + innerHeight
+	if end > len(m.items) {
+		end = len(m.items)
+	}
+	rows := make([]string, 0, end-m.offset)
+	for i := m.offset; i < end; i++ {
+		item := m.items[i]
+		dotColor := styles.StatusIdle
+		dot := "○"
+		if item.Running {
+			dotColor = styles.StatusRunning
+			dot = "●"
+		}
+		label := []rune(item.Project.DisplayName())
+		if len(label) > innerWidth-2 {
+			label = label[:max(innerWidth-2, 0)]
+		}
+		style := lipgloss.NewStyle().Foreground(styles.Subtext1).Width(innerWidth)
+		if i == m.cursor {
+			style = style.Foreground(styles.TextCol).Background(styles.Surface1).Bold(m.focused)
+		}
+		rows = append(rows, style.Render(lipgloss.NewStyle().Foreground(dotColor).Render(dot)+" "+string(label)))
+	}
+
+	borderStyle := styles.BorderStyle
+	if m.focused {
+		borderStyle = styles.FocusedBorderStyle
+	}
+	return borderStyle.
+		Width(m.width - 2).
+		Height(m.height - 2).
+		Render(lipgloss.JoinVertical(
+			lipgloss.Left,
+			styles.PanelTitleIcon.Render("📁"),
+			strings.Repeat("─", innerWidth),
+			lipgloss.JoinVertical(lipgloss.Left, rows...),
+		))
+}
+
 // renderItem renders a single project item.
 func (m Model) renderItem(item Item, selected bool, maxWidth int) string {
 	// Status dot
@@ -267,10 +456,28 @@ func (m Model) renderItem(item Item, selected bool, maxWidth int) string {
 		dot = lipgloss.NewStyle().Foreground(styles.StatusIdle).Render("○ ")
 	}
 
-	// Name
+	// Name, with the git state right-aligned when there is room for it
 	name := item.Project.DisplayName()
-	if len(name) > maxWidth-8 {
-		name = name[:maxWidth-11] + "..."
+	badge := ""
+	if status, ok := m.gitStatus[item.Project.ID]; ok {
+		badge = gitBadge(status, !selected)
+		if maxWidth-6-lipgloss.Width(badge) < 10 {
+			status.Branch = ""
+			badge = gitBadge(status, !selected)
+		}
+		if maxWidth-6-lipgloss.Width(badge) < 4 {
+			badge = ""
+		}
+	}
+	nameWidth := maxWidth - 6
+	if badge != "" {
+		nameWidth -= lipgloss.Width(badge) + 1
+	}
+	if lipgloss.Width(name) > nameWidth {
+		name = ansi.Truncate(name, max(nameWidth, 1), "…")
+	}
+	if badge != "" {
+		name += strings.Repeat(" ", max(nameWidth-lipgloss.Width(name), 0)+1) + badge
 	}
 
 	// Build row
@@ -329,19 +536,37 @@ func (m Model) renderDetails(width, height int) string {
 		if profileName == "" {
 			if selected.Project.ProfileID != "" {
 				profileName = selected.Project.ProfileID
+			} else if ws := m.workspaceDefaults[selected.Project.Workspace]; ws != "" {
+				profileName = ws
 			} else {
 				profileName = "default"
 			}
 		}
+		if selected.Project.Workspace != "" {
+			profileName += " [" + selected.Project.Workspace + "]"
+		}
 		status := "IDLE"
 		if selected.Running {
 			status = "RUNNING"
 		}
+		gitLine := "-"
+		if st, ok := m.gitStatus[selected.Project.ID]; ok {
+			gitLine = st.Head()
+			if st.Ahead > 0 {
+				gitLine += fmt.Sprintf(" ↑%d", st.Ahead)
+			}
+			if st.Behind > 0 {
+				gitLine += fmt.Sprintf(" ↓%d", st.Behind)
+			}
+			gitLine += " · " + st.Summary()
+		}
 
 		lines = append(lines,
 			renderDetailLine(labelStyle, valueStyle, "Path: ", path, width),
 			renderDetailLine(labelStyle, valueStyle, "Profile: ", profileName, width),
 			renderDetailLine(labelStyle, valueStyle, "Status: ", status, width),
+			renderDetailLine(labelStyle, valueStyle, "Git: ", gitLine, width),
+			renderDetailLine(labelStyle, valueStyle, "Used: ", utils.RelativeTime(selected.Project.LastUsedTime(), time.Now()), width),
 		)
 	}
 
@@ -351,6 +576,39 @@ func (m Model) renderDetails(width, height int) string {
 		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
 }
 
+// gitBadge shows a work tree's state in a project row: the branch, ● and
+// the number of changed files or ✓ when clean, and the commits ahead of
+// and behind the upstream. Colored is false on the selected row, whose
+// style would be cut short by the badge's own.
+func gitBadge(s git.Status, colored bool) string {
+	branch, state, sync := s.Branch, "✓", ""
+	if s.Dirty() {
+		state = fmt.Sprintf("●%d", s.Changes())
+	}
+	if s.Ahead > 0 {
+		sync += fmt.Sprintf("↑%d", s.Ahead)
+	}
+	if s.Behind > 0 {
+		sync += fmt.Sprintf("↓%d", s.Behind)
+	}
+	if colored {
+		branch = styles.ListItemDim.Render(branch)
+		stateColor := styles.StatusRunning
+		if s.Dirty() {
+			stateColor = styles.Warning
+		}
+		state = lipgloss.NewStyle().Foreground(stateColor).Render(state)
+		sync = lipgloss.NewStyle().Foreground(styles.Primary).Render(sync)
+	}
+	parts := make([]string, 0, 3)
+	for _, p := range []string{branch, state, sync} {
+		if ansi.Strip(p) != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
 func renderDetailLine(labelStyle, valueStyle lipgloss.Style, label, value string, width int) string {
 	if width < 1 {
 		return ""
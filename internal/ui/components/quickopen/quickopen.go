@@ -0,0 +1,131 @@
+// Package quickopen provides the quick-open overlay used for recent
+// projects and saved layouts.
+package quickopen
+
+import (
+	"fmt"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
+)
+
+// Item is one entry in the overlay.
+type Item struct {
+	ID      string
+	Label   string
+	Detail  string // Shown dimmed after the label
+	Running bool
+}
+
+// Model is the quick-open overlay.
+type Model struct {
+	title     string
+	items     []Item
+	cursor    int
+	width     int
+	submitted bool
+	cancelled bool
+}
+
+// New creates an empty overlay.
+func New() Model {
+	return Model{}
+}
+
+// Open resets the overlay with a title and items, most recent first.
+func (m *Model) Open(title string, items []Item) {
+	m.title = title
+	m.items = items
+	m.cursor = 0
+	m.submitted = false
+	m.cancelled = false
+}
+
+// SetSize sets the overlay width from the screen size.
+func (m *Model) SetSize(width, _ int) {
+	m.width = min(60, width-4)
+}
+
+// IsSubmitted reports whether an item was chosen.
+func (m Model) IsSubmitted() bool { return m.submitted }
+
+// IsCancelled reports whether the overlay was dismissed.
+func (m Model) IsCancelled() bool { return m.cancelled }
+
+// Selected returns the item under the cursor.
+func (m Model) Selected() (Item, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.items) {
+		return Item{}, false
+	}
+	return m.items[m.cursor], true
+}
+
+// Update handles key input. Digits pick an item directly.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch key := keyMsg.String(); key {
+	case "esc", "q":
+		m.cancelled = true
+	case "enter":
+		m.submitted = len(m.items) > 0
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.items)-1 {
+			m.cursor++
+		}
+	default:
+		if n, err := strconv.Atoi(key); err == nil && n >= 1 && n <= len(m.items) {
+			m.cursor = n - 1
+			m.submitted = true
+		}
+	}
+	return m, nil
+}
+
+// View renders the overlay.
+func (m Model) View() string {
+	width := max(m.width, 30)
+	innerWidth := width - 6
+	lineStyle := lipgloss.NewStyle().Foreground(styles.Subtext1)
+	selectedStyle := lipgloss.NewStyle().Foreground(styles.TextCol).Background(styles.Surface1).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.TextMuted)
+
+	rows := make([]string, 0, len(m.items))
+	for i, item := range m.items {
+		dot := lipgloss.NewStyle().Foreground(styles.StatusIdle).Render("○")
+		if item.Running {
+			dot = lipgloss.NewStyle().Foreground(styles.StatusRunning).Render("●")
+		}
+		text := fmt.Sprintf("%d %s %s", i+1, dot, item.Label)
+		if item.Detail != "" {
+			text += "  " + mutedStyle.Render(item.Detail)
+		}
+		text = ansi.Truncate(text, innerWidth, "…")
+		if i == m.cursor {
+			rows = append(rows, selectedStyle.Width(innerWidth).Render(text))
+		} else {
+			rows = append(rows, lineStyle.Render(text))
+		}
+	}
+	if len(rows) == 0 {
+		rows = append(rows, mutedStyle.Render("No projects yet"))
+	}
+
+	body := lipgloss.JoinVertical(
+		lipgloss.Left,
+		styles.DialogTitle.Render(m.title),
+		lipgloss.JoinVertical(lipgloss.Left, rows...),
+		"",
+		mutedStyle.Render("1-9/Enter open • ↑/↓ select • Esc close"),
+	)
+	return styles.DialogBox.Width(width - 2).Render(body)
+}
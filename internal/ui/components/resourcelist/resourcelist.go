@@ -0,0 +1,253 @@
+// Package resourcelist provides the resource monitor: the CPU and memory
+// use of every session, sortable, to jump to or stop the heaviest.
+package resourcelist
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lazyvibe/vibemux/internal/runtime"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
+	"github.com/lazyvibe/vibemux/pkg/utils"
+)
+
+// listHeight is how many sessions are shown at once.
+const listHeight = 12
+
+// Row is one session of the monitor.
+type Row struct {
+	SessionID string
+	Label     string
+	PID       int
+	Usage     runtime.ProcessUsage
+	// Sampled is false until the session's usage has been measured.
+	Sampled bool
+}
+
+// SortKey orders the rows.
+type SortKey int
+
+const (
+	// SortCPU puts the busiest session first.
+	SortCPU SortKey = iota
+	// SortMemory puts the largest session first.
+	SortMemory
+	// SortName orders sessions by label.
+	SortName
+)
+
+func (k SortKey) String() string {
+	switch k {
+	case SortMemory:
+		return "memory"
+	case SortName:
+		return "name"
+	}
+	return "CPU"
+}
+
+// Action is what the last key asked the app to do.
+type Action int
+
+const (
+	// ActionNone needs nothing from the app.
+	ActionNone Action = iota
+	// ActionJump focuses the selected session's pane.
+	ActionJump
+	// ActionKill stops the selected session.
+	ActionKill
+	// ActionClose closes the monitor.
+	ActionClose
+)
+
+// Model is the resource monitor.
+type Model struct {
+	rows        []Row
+	sortBy      SortKey
+	cursor      int
+	offset      int
+	confirmKill bool
+	note        string // Shown above the table, e.g. a sampling error
+	width       int
+	action      Action
+}
+
+// New creates an empty monitor sorted by CPU.
+func New() Model {
+	return Model{}
+}
+
+// Open shows rows with the cursor on the first one.
+func (m *Model) Open(rows []Row) {
+	m.cursor, m.offset = 0, 0
+	m.confirmKill = false
+	m.action = ActionNone
+	m.SetRows(rows)
+}
+
+// SetRows replaces the rows with a new sample. The cursor stays on the same
+// session when it is still listed.
+func (m *Model) SetRows(rows []Row) {
+	selected, hadSelection := m.Selected()
+	m.rows = rows
+	m.sort()
+	if hadSelection {
+		for i, r := range m.rows {
+			if r.SessionID == selected.SessionID {
+				m.cursor = i
+			}
+		}
+	}
+	m.move(0)
+}
+
+// SetNote shows a message above the table; empty clears it.
+func (m *Model) SetNote(note string) {
+	m.note = note
+}
+
+// SetSize sets the monitor width from the screen size.
+func (m *Model) SetSize(width, _ int) {
+	m.width = min(90, width-4)
+}
+
+// Action returns what the last key asked for.
+func (m Model) Action() Action { return m.action }
+
+// Selected returns the row under the cursor.
+func (m Model) Selected() (Row, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return Row{}, false
+	}
+	return m.rows[m.cursor], true
+}
+
+// Update handles key input. Killing asks for a second x.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	m.action = ActionNone
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	confirmKill := m.confirmKill
+	m.confirmKill = false
+	switch keyMsg.String() {
+	case "esc", "q", "M":
+		m.action = ActionClose
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "enter":
+		if _, ok := m.Selected(); ok {
+			m.action = ActionJump
+		}
+	case "s", "tab":
+		m.setSort((m.sortBy + 1) % 3)
+	case "c":
+		m.setSort(SortCPU)
+	case "m":
+		m.setSort(SortMemory)
+	case "n":
+		m.setSort(SortName)
+	case "x", "delete":
+		if _, ok := m.Selected(); !ok {
+			break
+		}
+		if confirmKill {
+			m.action = ActionKill
+		} else {
+			m.confirmKill = true
+		}
+	}
+	return m, nil
+}
+
+// setSort reorders the rows, keeping the cursor on its session.
+func (m *Model) setSort(key SortKey) {
+	m.sortBy = key
+	m.SetRows(m.rows)
+}
+
+func (m *Model) sort() {
+	sort.SliceStable(m.rows, func(i, j int) bool {
+		a, b := m.rows[i], m.rows[j]
+		switch m.sortBy {
+		case SortCPU:
+			if a.Usage.CPU != b.Usage.CPU {
+				return a.Usage.CPU > b.Usage.CPU
+			}
+		case SortMemory:
+			if a.Usage.RSS != b.Usage.RSS {
+				return a.Usage.RSS > b.Usage.RSS
+			}
+		}
+		return strings.ToLower(a.Label) < strings.ToLower(b.Label)
+	})
+}
+
+// move shifts the cursor and keeps it visible.
+func (m *Model) move(delta int) {
+	m.cursor = max(0, min(m.cursor+delta, len(m.rows)-1))
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+listHeight {
+		m.offset = m.cursor - listHeight + 1
+	}
+}
+
+// View renders the monitor.
+func (m Model) View() string {
+	width := max(m.width, 50)
+	innerWidth := width - 6
+	lineStyle := lipgloss.NewStyle().Foreground(styles.Subtext1)
+	selectedStyle := lipgloss.NewStyle().Foreground(styles.TextCol).Background(styles.Surface1).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.TextMuted)
+
+	labelWidth := max(10, innerWidth-32)
+	header := mutedStyle.Render(fmt.Sprintf("%-*s %7s %8s %5s %7s", labelWidth, "SESSION", "CPU", "MEM", "PROCS", "PID"))
+	rows := make([]string, 0, listHeight)
+	end := min(m.offset+listHeight, len(m.rows))
+	for i := m.offset; i < end; i++ {
+		r := m.rows[i]
+		label := ansi.Truncate(r.Label, labelWidth, "…")
+		label += strings.Repeat(" ", labelWidth-ansi.StringWidth(label))
+		cpu, mem, procs := "-", "-", "-"
+		if r.Sampled {
+			cpu = fmt.Sprintf("%.0f%%", r.Usage.CPU)
+			mem = utils.FormatBytes(r.Usage.RSS)
+			procs = fmt.Sprintf("%d", r.Usage.Procs)
+		}
+		pid := "-"
+		if r.PID > 0 {
+			pid = fmt.Sprintf("%d", r.PID)
+		}
+		text := fmt.Sprintf("%s %7s %8s %5s %7s", label, cpu, mem, procs, pid)
+		if i == m.cursor {
+			rows = append(rows, selectedStyle.Width(innerWidth).Render(text))
+		} else {
+			rows = append(rows, lineStyle.Render(text))
+		}
+	}
+	if len(rows) == 0 {
+		rows = append(rows, mutedStyle.Render("No running sessions"))
+	}
+
+	help := mutedStyle.Render("Enter jump • s sort (c/m/n) • x stop session • Esc close")
+	if m.confirmKill {
+		r, _ := m.Selected()
+		help = lipgloss.NewStyle().Foreground(styles.Warning).Render(
+			ansi.Truncate(fmt.Sprintf("Press x again to stop %s", r.Label), innerWidth, "…"))
+	}
+	parts := []string{styles.DialogTitle.Render("Resource Monitor · by " + m.sortBy.String())}
+	if m.note != "" {
+		parts = append(parts, lipgloss.NewStyle().Foreground(styles.Warning).Render(ansi.Truncate(m.note, innerWidth, "…")))
+	}
+	parts = append(parts, header, lipgloss.JoinVertical(lipgloss.Left, rows...), "", help)
+	return styles.DialogBox.Width(width - 2).Render(lipgloss.JoinVertical(lipgloss.Left, parts...))
+}
@@ -0,0 +1,257 @@
+// Package search provides the global search overlay across all panes.
+package search
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
+)
+
+// maxMatchesPerPane caps the matches listed for a single pane.
+const maxMatchesPerPane = 50
+
+// Pane is a snapshot of one session's output.
+type Pane struct {
+	ID    string
+	Label string
+	Lines []string
+}
+
+// Match is a single matching line.
+type Match struct {
+	PaneID string
+	Line   string
+}
+
+type group struct {
+	pane    Pane
+	matches []string
+	more    bool // Matches beyond maxMatchesPerPane were dropped
+}
+
+// Model is the search overlay.
+type Model struct {
+	input     textinput.Model
+	panes     []Pane
+	groups    []group
+	cursor    int // Index into the flattened matches
+	offset    int
+	width     int
+	height    int
+	submitted bool
+	cancelled bool
+}
+
+// New creates a search overlay.
+func New() Model {
+	ti := textinput.New()
+	ti.Placeholder = "search all panes…"
+	ti.Prompt = "🔍 "
+	return Model{input: ti}
+}
+
+// Open resets the overlay over fresh pane snapshots.
+func (m *Model) Open(panes []Pane, query string) {
+	m.panes = panes
+	m.submitted = false
+	m.cancelled = false
+	m.input.SetValue(query)
+	m.input.CursorEnd()
+	m.input.Focus()
+	m.refresh()
+}
+
+// SetSize sets the overlay dimensions.
+func (m *Model) SetSize(width, height int) {
+	m.width = width * 80 / 100
+	m.height = height * 80 / 100
+	if m.width < 40 {
+		m.width = min(40, width)
+	}
+	if m.height < 10 {
+		m.height = min(10, height)
+	}
+	m.input.Width = m.width - 10
+}
+
+// IsSubmitted reports whether a match was chosen.
+func (m Model) IsSubmitted() bool { return m.submitted }
+
+// IsCancelled reports whether the overlay was dismissed.
+func (m Model) IsCancelled() bool { return m.cancelled }
+
+// Query returns the current search text.
+func (m Model) Query() string { return m.input.Value() }
+
+// Selected returns the match under the cursor.
+func (m Model) Selected() (Match, bool) {
+	i := 0
+	for _, g := range m.groups {
+		for _, line := range g.matches {
+			if i == m.cursor {
+				return Match{PaneID: g.pane.ID, Line: line}, true
+			}
+			i++
+		}
+	}
+	return Match{}, false
+}
+
+// Update handles key input.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.String() {
+	case "esc":
+		m.cancelled = true
+		return m, nil
+	case "enter":
+		if _, ok := m.Selected(); ok {
+			m.submitted = true
+		}
+		return m, nil
+	case "up", "ctrl+p":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+	case "down", "ctrl+n":
+		if m.cursor < m.matchCount()-1 {
+			m.cursor++
+		}
+		return m, nil
+	}
+	before := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() != before {
+		m.refresh()
+	}
+	return m, cmd
+}
+
+// refresh re-runs the query over the snapshots, newest lines first.
+func (m *Model) refresh() {
+	m.groups = nil
+	m.cursor = 0
+	m.offset = 0
+	query := strings.ToLower(strings.TrimSpace(m.input.Value()))
+	if query == "" {
+		return
+	}
+	for _, pane := range m.panes {
+		g := group{pane: pane}
+		for i := len(pane.Lines) - 1; i >= 0; i-- {
+			if !strings.Contains(strings.ToLower(pane.Lines[i]), query) {
+				continue
+			}
+			if len(g.matches) == maxMatchesPerPane {
+				g.more = true
+				break
+			}
+			g.matches = append(g.matches, pane.Lines[i])
+		}
+		if len(g.matches) > 0 {
+			m.groups = append(m.groups, g)
+		}
+	}
+}
+
+func (m Model) matchCount() int {
+	n := 0
+	for _, g := range m.groups {
+		n += len(g.matches)
+	}
+	return n
+}
+
+// View renders the overlay.
+func (m *Model) View() string {
+	innerWidth := m.width - 6
+	listHeight := m.height - 8
+	if innerWidth < 10 {
+		innerWidth = 10
+	}
+	if listHeight < 1 {
+		listHeight = 1
+	}
+
+	headerStyle := lipgloss.NewStyle().Foreground(styles.Accent).Bold(true)
+	lineStyle := lipgloss.NewStyle().Foreground(styles.Subtext1)
+	selectedStyle := lipgloss.NewStyle().Foreground(styles.TextCol).Background(styles.Surface1).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.TextMuted)
+
+	// Flatten into rows, remembering which row holds the cursor.
+	var rows []string
+	cursorRow := 0
+	i := 0
+	for _, g := range m.groups {
+		title := fmt.Sprintf("%s (%d)", g.pane.Label, len(g.matches))
+		if g.more {
+			title = fmt.Sprintf("%s (%d+)", g.pane.Label, len(g.matches))
+		}
+		rows = append(rows, headerStyle.Render(ansi.Truncate(title, innerWidth, "…")))
+		for _, line := range g.matches {
+			text := ansi.Truncate("  "+strings.TrimSpace(line), innerWidth, "…")
+			if i == m.cursor {
+				cursorRow = len(rows)
+				rows = append(rows, selectedStyle.Width(innerWidth).Render(text))
+			} else {
+				rows = append(rows, lineStyle.Render(text))
+			}
+			i++
+		}
+	}
+
+	if cursorRow < m.offset {
+		m.offset = cursorRow
+	}
+	if cursorRow >= m.offset+listHeight {
+		m.offset = cursorRow - listHeight + 1
+	}
+	end := min(m.offset+listHeight, len(rows))
+	var list string
+	switch {
+	case strings.TrimSpace(m.input.Value()) == "":
+		list = mutedStyle.Render("Type to search the output of every pane")
+	case len(rows) == 0:
+		list = mutedStyle.Render("No matches")
+	default:
+		list = strings.Join(rows[m.offset:end], "\n")
+	}
+
+	body := lipgloss.JoinVertical(
+		lipgloss.Left,
+		styles.DialogTitle.Render("Search All Panes"),
+		m.input.View(),
+		strings.Repeat("─", innerWidth),
+		lipgloss.NewStyle().Height(listHeight).Render(list),
+		mutedStyle.Render("↑/↓ select • Enter jump • Esc close"),
+	)
+	return styles.DialogBox.Width(m.width - 2).Render(body)
+}
+
+// SnapshotLines converts raw PTY output into plain lines. Carriage-return
+// redraws (spinners, progress bars) keep only their final state.
+func SnapshotLines(history []byte) []string {
+	plain := ansi.Strip(string(history))
+	raw := strings.Split(plain, "\n")
+	lines := make([]string, 0, len(raw))
+	for _, line := range raw {
+		line = strings.TrimRight(line, "\r")
+		if i := strings.LastIndex(line, "\r"); i >= 0 {
+			line = line[i+1:]
+		}
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -3,20 +3,36 @@ package sessiontabs
 
 import (
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
 )
 
+// bellIcon marks tabs that rang the bell.
+const bellIcon = "🔔"
+
 // Tab represents a single session tab.
 type Tab struct {
 	ID       string
 	Name     string
 	Status   model.SessionStatus
 	HasNew   bool // Has new unread output
+	Bell     bool // Rang the bell since it was last active
+	Unread   int  // Notifications raised since it was last active
 	IsActive bool
+	// Activity is what the running session appears to be doing.
+	Activity model.ActivityState
+	// LastActivity is when the session last produced output.
+	LastActivity time.Time
 }
 
+// activityBucket groups output times when ordering tabs by activity, so
+// sessions streaming at the same time don't swap places on every frame.
+const activityBucket = 5 * time.Second
+
 // Model is the session tabs component.
 type Model struct {
 	tabs        []Tab
@@ -24,6 +40,7 @@ type Model struct {
 	offset      int
 	width       int
 	focused     bool
+	byActivity  bool // Display tabs most recently active first
 	styles      TabStyles
 }
 
@@ -44,16 +61,16 @@ type TabStyles struct {
 
 // DefaultTabStyles returns beautiful tab styles.
 func DefaultTabStyles() TabStyles {
-	purple := lipgloss.Color("#7C3AED")
-	cyan := lipgloss.Color("#06B6D4")
-	pink := lipgloss.Color("#EC4899")
-	green := lipgloss.Color("#10B981")
-	amber := lipgloss.Color("#F59E0B")
-	red := lipgloss.Color("#EF4444")
-	surface := lipgloss.Color("#1E1E2E")
-	surfaceLight := lipgloss.Color("#313244")
-	text := lipgloss.Color("#CDD6F4")
-	textMuted := lipgloss.Color("#6C7086")
+	purple := styles.Primary
+	cyan := styles.Accent
+	pink := styles.Pink
+	green := styles.StatusRunning
+	amber := styles.StatusStopped
+	red := styles.StatusError
+	surface := styles.Base
+	surfaceLight := styles.Surface0
+	text := styles.TextCol
+	textMuted := styles.Overlay0
 
 	return TabStyles{
 		Container: lipgloss.NewStyle().
@@ -123,6 +140,40 @@ func (m *Model) SetFocused(focused bool) {
 	m.focused = focused
 }
 
+// SetActivityOrder displays tabs most recently active first instead of in
+// the order they were opened. It only affects the strip: tab numbers and
+// the grid keep the opening order.
+func (m *Model) SetActivityOrder(enabled bool) {
+	m.byActivity = enabled
+}
+
+// MarkActivity records output from a tab's session.
+func (m *Model) MarkActivity(id string) {
+	for i, t := range m.tabs {
+		if t.ID == id {
+			m.tabs[i].LastActivity = time.Now()
+			return
+		}
+	}
+}
+
+// displayOrder returns tab indices in the order the strip shows them.
+func (m *Model) displayOrder() []int {
+	order := make([]int, len(m.tabs))
+	for i := range order {
+		order[i] = i
+	}
+	if m.byActivity {
+		bucket := func(t Tab) int64 {
+			return t.LastActivity.UnixNano() / int64(activityBucket)
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return bucket(m.tabs[order[i]]) > bucket(m.tabs[order[j]])
+		})
+	}
+	return order
+}
+
 // AddTab adds a new tab.
 func (m *Model) AddTab(id, name string, status model.SessionStatus) {
 	// Check if tab already exists
@@ -162,6 +213,58 @@ func (m *Model) SetActiveTab(id string) {
 		if t.ID == id {
 			m.activeIndex = i
 			m.tabs[i].HasNew = false
+			m.tabs[i].Bell = false
+			m.tabs[i].Unread = 0
+			return
+		}
+	}
+}
+
+// MoveTab shifts a tab by delta positions, keeping it active if it was.
+// It returns false when the tab is missing or already at the edge.
+func (m *Model) MoveTab(id string, delta int) bool {
+	for i, t := range m.tabs {
+		if t.ID == id {
+			return m.MoveTabTo(id, i+delta)
+		}
+	}
+	return false
+}
+
+// MoveTabTo moves a tab to index, shifting the others. The active tab
+// stays active. It returns false when the tab is missing, the index is out
+// of range or the tab is already there.
+func (m *Model) MoveTabTo(id string, j int) bool {
+	for i, t := range m.tabs {
+		if t.ID != id {
+			continue
+		}
+		if j < 0 || j >= len(m.tabs) || j == i {
+			return false
+		}
+		activeID := ""
+		if m.activeIndex >= 0 && m.activeIndex < len(m.tabs) {
+			activeID = m.tabs[m.activeIndex].ID
+		}
+		tab := m.tabs[i]
+		m.tabs = append(m.tabs[:i], m.tabs[i+1:]...)
+		m.tabs = append(m.tabs[:j], append([]Tab{tab}, m.tabs[j:]...)...)
+		for k := range m.tabs {
+			if m.tabs[k].ID == activeID {
+				m.activeIndex = k
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// RenameTab re-keys a tab and updates its label.
+func (m *Model) RenameTab(oldID, newID, name string) {
+	for i, t := range m.tabs {
+		if t.ID == oldID {
+			m.tabs[i].ID = newID
+			m.tabs[i].Name = name
 			return
 		}
 	}
@@ -187,6 +290,43 @@ func (m *Model) MarkTabHasNew(id string) {
 	}
 }
 
+// MarkTabBell flags a background tab whose session rang the bell.
+func (m *Model) MarkTabBell(id string) {
+	for i, t := range m.tabs {
+		if t.ID == id && i != m.activeIndex {
+			m.tabs[i].Bell = true
+			return
+		}
+	}
+}
+
+// SetTabActivity updates a tab's activity indicator.
+func (m *Model) SetTabActivity(id string, state model.ActivityState) {
+	for i, t := range m.tabs {
+		if t.ID == id {
+			m.tabs[i].Activity = state
+			return
+		}
+	}
+}
+
+// MarkTabUnread counts a notification raised by a background tab.
+func (m *Model) MarkTabUnread(id string) {
+	for i, t := range m.tabs {
+		if t.ID == id && i != m.activeIndex {
+			m.tabs[i].Unread++
+			return
+		}
+	}
+}
+
+// ClearUnread resets the notification counts of all tabs.
+func (m *Model) ClearUnread() {
+	for i := range m.tabs {
+		m.tabs[i].Unread = 0
+	}
+}
+
 // ActiveTab returns the currently active tab.
 func (m Model) ActiveTab() *Tab {
 	if m.activeIndex >= 0 && m.activeIndex < len(m.tabs) {
@@ -216,6 +356,8 @@ func (m *Model) NextTab() {
 	}
 	m.activeIndex = (m.activeIndex + 1) % len(m.tabs)
 	m.tabs[m.activeIndex].HasNew = false
+	m.tabs[m.activeIndex].Bell = false
+	m.tabs[m.activeIndex].Unread = 0
 }
 
 // PrevTab switches to the previous tab.
@@ -228,6 +370,8 @@ func (m *Model) PrevTab() {
 		m.activeIndex = len(m.tabs) - 1
 	}
 	m.tabs[m.activeIndex].HasNew = false
+	m.tabs[m.activeIndex].Bell = false
+	m.tabs[m.activeIndex].Unread = 0
 }
 
 // View renders the session tabs.
@@ -238,8 +382,14 @@ func (m *Model) View() string {
 
 	rendered := make([]string, 0, len(m.tabs))
 	widths := make([]int, 0, len(m.tabs))
+	order := m.displayOrder()
+	active := 0
 
-	for i, t := range m.tabs {
+	for pos, i := range order {
+		t := m.tabs[i]
+		if i == m.activeIndex {
+			active = pos
+		}
 		// Status dot
 		var dotColor lipgloss.Color
 		switch t.Status {
@@ -265,6 +415,15 @@ func (m *Model) View() string {
 
 		// Build tab content
 		content := fmt.Sprintf("%s %s %s", indexStr, dot, name)
+		if icon := m.activityIcon(t.Activity); icon != "" {
+			content += " " + icon
+		}
+		if t.Bell {
+			content += " " + bellIcon
+		}
+		if t.Unread > 0 {
+			content += " " + unreadBadge(t.Unread)
+		}
 
 		// Select style
 		var tabStyle lipgloss.Style
@@ -281,7 +440,7 @@ func (m *Model) View() string {
 		widths = append(widths, lipgloss.Width(tab))
 	}
 
-	start, end := m.visibleRange(widths)
+	start, end := m.visibleRange(widths, active)
 	if start < 0 || end <= start {
 		return m.styles.Container.Width(m.width).Render("")
 	}
@@ -290,6 +449,113 @@ func (m *Model) View() string {
 	return m.styles.Container.Width(m.width).Render(row)
 }
 
+// CompactView renders the tabs as a single line, for small windows.
+func (m *Model) CompactView() string {
+	if len(m.tabs) == 0 {
+		return ""
+	}
+
+	rendered, _, _, start, end := m.compactTabs()
+	if start < 0 || end <= start {
+		return lipgloss.NewStyle().Width(m.width).Render("")
+	}
+	row := lipgloss.JoinHorizontal(lipgloss.Top, rendered[start:end]...)
+	return lipgloss.NewStyle().Width(m.width).MaxWidth(m.width).Render(row)
+}
+
+// TabAt returns the ID of the tab under column x of the compact tab strip.
+func (m *Model) TabAt(x int) (string, bool) {
+	if len(m.tabs) == 0 || x < 0 {
+		return "", false
+	}
+	_, widths, order, start, end := m.compactTabs()
+	for pos := max(start, 0); pos < end; pos++ {
+		if x < widths[pos] {
+			return m.tabs[order[pos]].ID, true
+		}
+		x -= widths[pos]
+	}
+	return "", false
+}
+
+// compactTabs renders each tab of the compact strip in display order and
+// returns the rendered tabs, their widths, the tab index behind each
+// position and the visible range of positions.
+func (m *Model) compactTabs() ([]string, []int, []int, int, int) {
+	rendered := make([]string, 0, len(m.tabs))
+	widths := make([]int, 0, len(m.tabs))
+	order := m.displayOrder()
+	active := 0
+	for pos, i := range order {
+		t := m.tabs[i]
+		if i == m.activeIndex {
+			active = pos
+		}
+		dotColor := m.styles.StatusIdle
+		switch t.Status {
+		case model.SessionStatusRunning:
+			dotColor = m.styles.StatusRunning
+		case model.SessionStatusStopped:
+			dotColor = m.styles.StatusStopped
+		case model.SessionStatusError:
+			dotColor = m.styles.StatusError
+		}
+		dot := lipgloss.NewStyle().Foreground(dotColor).Render("●")
+
+		name := t.Name
+		if len(name) > 12 {
+			name = name[:10] + "…"
+		}
+		style := lipgloss.NewStyle().Foreground(m.styles.Tab.GetForeground()).Padding(0, 1)
+		if i == m.activeIndex {
+			style = style.Foreground(m.styles.TabActive.GetForeground()).Bold(true).Underline(true)
+		} else if t.HasNew {
+			style = style.Foreground(m.styles.TabHasNew.GetForeground())
+		}
+		label := fmt.Sprintf("%d:%s %s", i+1, dot, name)
+		if icon := m.activityIcon(t.Activity); icon != "" {
+			label += " " + icon
+		}
+		if t.Bell {
+			label += " " + bellIcon
+		}
+		if t.Unread > 0 {
+			label += " " + unreadBadge(t.Unread)
+		}
+		tab := style.Render(label)
+		rendered = append(rendered, tab)
+		widths = append(widths, lipgloss.Width(tab))
+	}
+
+	start, end := m.visibleRange(widths, active)
+	return rendered, widths, order, start, end
+}
+
+// activityIcon renders a tab's activity: a spinner while working, ? when
+// waiting for input, ! after an error and a hollow dot when idle.
+func (m *Model) activityIcon(state model.ActivityState) string {
+	switch state {
+	case model.ActivityWorking:
+		frame := time.Now().UnixMilli() / 100 % int64(len(styles.SpinnerFrames))
+		return lipgloss.NewStyle().Foreground(styles.Info).Render(styles.SpinnerFrames[frame])
+	case model.ActivityWaiting:
+		return lipgloss.NewStyle().Foreground(styles.Warning).Bold(true).Render("?")
+	case model.ActivityError:
+		return lipgloss.NewStyle().Foreground(m.styles.StatusError).Bold(true).Render("!")
+	case model.ActivityIdle:
+		return lipgloss.NewStyle().Foreground(m.styles.StatusIdle).Render("○")
+	}
+	return ""
+}
+
+// unreadBadge renders a tab's unread notification count.
+func unreadBadge(n int) string {
+	if n > 99 {
+		return "(99+)"
+	}
+	return fmt.Sprintf("(%d)", n)
+}
+
 // Tabs returns all tabs.
 func (m Model) Tabs() []Tab {
 	return m.tabs
@@ -300,7 +566,7 @@ func (m Model) HasTabs() bool {
 	return len(m.tabs) > 0
 }
 
-func (m *Model) visibleRange(widths []int) (int, int) {
+func (m *Model) visibleRange(widths []int, active int) (int, int) {
 	if len(widths) == 0 {
 		return 0, 0
 	}
@@ -327,11 +593,11 @@ func (m *Model) visibleRange(widths []int) (int, int) {
 
 	end := m.fitFrom(start, widths)
 
-	if m.activeIndex < start {
-		start = m.activeIndex
+	if active < start {
+		start = active
 		end = m.fitFrom(start, widths)
-	} else if m.activeIndex >= end {
-		start = m.shiftLeftToFit(m.activeIndex, widths)
+	} else if active >= end {
+		start = m.shiftLeftToFit(active, widths)
 		end = m.fitFrom(start, widths)
 	}
 
@@ -370,3 +636,8 @@ func (m *Model) shiftLeftToFit(active int, widths []int) int {
 	}
 	return start + 1
 }
+
+// Restyle rebuilds the tab styles from the active theme.
+func (m *Model) Restyle() {
+	m.styles = DefaultTabStyles()
+}
@@ -0,0 +1,197 @@
+package setup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lazyvibe/vibemux/internal/app"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
+)
+
+// enterProfileSteps moves on once the claude path is configured: to the
+// import step when other tools' configs were found, else to profiles.
+func (m *Model) enterProfileSteps() {
+	if m.storeErr != "" {
+		m.step = StepComplete
+		return
+	}
+	m.imports = m.newImports(app.DetectImports())
+	if len(m.imports) == 0 {
+		m.step = StepProfileIntro
+		return
+	}
+	m.importChosen = make([]bool, len(m.imports))
+	for i := range m.importChosen {
+		m.importChosen[i] = true
+	}
+	m.importCursor = 0
+	m.step = StepImport
+}
+
+// newImports drops candidates that already exist: projects by path and
+// profiles by name.
+func (m *Model) newImports(candidates []app.ImportCandidate) []app.ImportCandidate {
+	ctx := context.Background()
+	paths := make(map[string]bool)
+	if projects, err := m.store.List(ctx); err == nil {
+		for _, p := range projects {
+			paths[p.Path] = true
+		}
+	}
+	names := make(map[string]bool)
+	if profiles, err := m.store.ListProfiles(ctx); err == nil {
+		for _, p := range profiles {
+			names[strings.ToLower(p.Name)] = true
+		}
+	}
+	var result []app.ImportCandidate
+	for _, c := range candidates {
+		if c.Kind == app.ImportProject && paths[c.Path] {
+			continue
+		}
+		if c.Kind == app.ImportProfile && names[strings.ToLower(c.Name)] {
+			continue
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
+// updateImport handles keys on the import step.
+func (m Model) updateImport(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.importCursor > 0 {
+			m.importCursor--
+		}
+	case "down", "j":
+		if m.importCursor < len(m.imports)-1 {
+			m.importCursor++
+		}
+	case " ", "x":
+		m.importChosen[m.importCursor] = !m.importChosen[m.importCursor]
+	case "a":
+		all := true
+		for _, chosen := range m.importChosen {
+			all = all && chosen
+		}
+		for i := range m.importChosen {
+			m.importChosen[i] = !all
+		}
+	case "enter":
+		if err := m.applyImports(); err != nil {
+			m.error = err.Error()
+			return m, nil
+		}
+		m.error = ""
+		m.step = StepProfileIntro
+	case "esc":
+		m.importNote = ""
+		m.step = StepProfileIntro
+	}
+	return m, nil
+}
+
+// applyImports creates the chosen projects and profiles.
+func (m *Model) applyImports() error {
+	ctx := context.Background()
+	projects, profiles := 0, 0
+	for i, c := range m.imports {
+		if !m.importChosen[i] {
+			continue
+		}
+		switch c.Kind {
+		case app.ImportProject:
+			if err := m.store.Create(ctx, model.NewProject(c.Name, c.Path)); err != nil {
+				return err
+			}
+			projects++
+		case app.ImportProfile:
+			profile := model.NewProfile(c.Name)
+			profile.Command = c.Command
+			profile.Driver = model.DriverNative
+			for k, v := range c.EnvVars {
+				profile.EnvVars[k] = v
+			}
+			if err := m.store.CreateProfile(ctx, profile); err != nil {
+				return err
+			}
+			profiles++
+		}
+	}
+	m.importNote = fmt.Sprintf("Imported %d project(s) and %d profile(s)", projects, profiles)
+	return nil
+}
+
+func (m Model) viewImport() string {
+	title := lipgloss.NewStyle().
+		Foreground(styles.Primary).
+		Bold(true).
+		Render("📥 Import From Existing Tools")
+
+	desc := lipgloss.NewStyle().
+		Foreground(styles.Text).
+		Render("Found these in your Claude Code, Codex and tmuxinator configs:")
+
+	width := min(80, max(m.width-4, 20))
+	listHeight := max(m.height-14, 3)
+	start := 0
+	if m.importCursor >= listHeight {
+		start = m.importCursor - listHeight + 1
+	}
+	end := min(start+listHeight, len(m.imports))
+
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.TextMuted)
+	rows := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		c := m.imports[i]
+		box := "[ ]"
+		if m.importChosen[i] {
+			box = "[x]"
+		}
+		kind, detail := "project", c.Path
+		if c.Kind == app.ImportProfile {
+			kind, detail = "profile", c.Command
+		}
+		text := fmt.Sprintf("%s %-7s %s  %s", box, kind, c.Name, mutedStyle.Render(detail+" ("+c.Source+")"))
+		text = ansi.Truncate(text, width, "…")
+		style := lipgloss.NewStyle().Foreground(styles.Subtext1)
+		if i == m.importCursor {
+			style = lipgloss.NewStyle().Foreground(styles.TextCol).Background(styles.Surface1).Bold(true)
+		}
+		rows = append(rows, style.Render(text))
+	}
+
+	var errorMsg string
+	if m.error != "" {
+		errorMsg = lipgloss.NewStyle().
+			Foreground(styles.Danger).
+			Bold(true).
+			Render("❌ " + m.error)
+	}
+
+	hint := mutedStyle.Render("Space toggle • a all/none • Enter import selected • Esc skip")
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Center,
+		title,
+		"",
+		desc,
+		"",
+		lipgloss.NewStyle().Width(width).Render(lipgloss.JoinVertical(lipgloss.Left, rows...)),
+		"",
+		errorMsg,
+		hint,
+	)
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height).
+		Align(lipgloss.Center, lipgloss.Center).
+		Render(content)
+}
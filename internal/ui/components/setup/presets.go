@@ -0,0 +1,121 @@
+package setup
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
+)
+
+// Preset pre-fills the profile form.
+type Preset struct {
+	Title       string
+	Description string
+	Name        string
+	Command     string
+	Env         string
+	AutoApprove model.AutoApproveLevel // Empty keeps the profile default
+}
+
+// Presets are offered before the profile form; the last one is blank.
+var Presets = []Preset{
+	{
+		Title:       "Claude (default)",
+		Description: "Claude Code with the usual permission prompts",
+		Name:        "Claude",
+		Command:     "claude",
+	},
+	{
+		Title:       "Claude + yolo",
+		Description: "Skips permission prompts and auto-approves everything",
+		Name:        "Claude YOLO",
+		Command:     "claude --dangerously-skip-permissions",
+		AutoApprove: model.AutoApproveYolo,
+	},
+	{
+		Title:       "Codex",
+		Description: "OpenAI Codex CLI",
+		Name:        "Codex",
+		Command:     "codex",
+	},
+	{
+		Title:       "ccr proxy",
+		Description: "Claude Code routed through claude-code-router",
+		Name:        "CCR",
+		Command:     "ccr code",
+	},
+	{
+		Title:       "Custom",
+		Description: "Start from a blank form",
+	},
+}
+
+// openPresetGallery starts profile creation with the preset list.
+func (m *Model) openPresetGallery() {
+	m.step = StepConfigureProfile
+	m.choosingPreset = true
+	m.error = ""
+}
+
+// updatePresets handles keys while the preset list is shown.
+func (m Model) updatePresets(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.presetCursor > 0 {
+			m.presetCursor--
+		}
+	case "down", "j":
+		if m.presetCursor < len(Presets)-1 {
+			m.presetCursor++
+		}
+	case "enter":
+		m.choosingPreset = false
+		m.initProfileDialog(Presets[m.presetCursor])
+	case "esc":
+		m.choosingPreset = false
+		if m.profilesConfigured > 0 {
+			m.step = StepAddAnotherProfile
+		} else {
+			m.step = StepProfileIntro
+		}
+	}
+	return m, nil
+}
+
+func (m Model) viewPresets() string {
+	title := lipgloss.NewStyle().
+		Foreground(styles.Primary).
+		Bold(true).
+		Render("⚙️  Choose a Starting Point")
+
+	rows := make([]string, 0, len(Presets))
+	for i, p := range Presets {
+		style := lipgloss.NewStyle().Foreground(styles.Subtext1).Width(60)
+		if i == m.presetCursor {
+			style = style.Foreground(styles.TextCol).Background(styles.Surface1).Bold(true)
+		}
+		line := fmt.Sprintf("%-18s %s", p.Title, p.Description)
+		rows = append(rows, style.Render(line))
+	}
+
+	hint := lipgloss.NewStyle().
+		Foreground(styles.TextMuted).
+		Render("↑/↓ select • Enter fill the form • Esc back")
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Center,
+		title,
+		"",
+		lipgloss.JoinVertical(lipgloss.Left, rows...),
+		"",
+		hint,
+	)
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height).
+		Align(lipgloss.Center, lipgloss.Center).
+		Render(content)
+}
@@ -25,6 +25,7 @@ const (
 	StepWelcome Step = iota
 	StepDetectClaude
 	StepConfigureClaude
+	StepImport
 	StepProfileIntro
 	StepConfigureProfile
 	StepAddAnotherProfile
@@ -41,24 +42,54 @@ type Model struct {
 	error              string
 	width              int
 	height             int
-	store              *store.JSONStore
+	store              store.Store
 	storeErr           string
 	profileDialog      dialog.InputDialog
 	profilesConfigured int
+
+	// Projects and profiles found in other tools' configs (StepImport).
+	imports      []app.ImportCandidate
+	importChosen []bool
+	importCursor int
+	importNote   string // Summary of the last import
+
+	// Preset gallery shown before the profile form (StepConfigureProfile).
+	choosingPreset bool
+	presetCursor   int
+
+	// rerun is set when the wizard runs inside the TUI: it then ends with a
+	// DoneMsg instead of quitting, and leaves the default profile alone.
+	rerun bool
+}
+
+// DoneMsg is sent when a wizard started with NewRerun is closed.
+type DoneMsg struct {
+	Completed bool
 }
 
 // New creates a new setup wizard.
 func New(configDir string, config *app.Config) Model {
-	ti := textinput.New()
-	ti.Placeholder = "/path/to/claude"
-	ti.CharLimit = 256
-	ti.Width = 50
-
 	var storeErr string
-	s, err := store.NewJSONStore(configDir)
+	s, err := store.Open(configDir, config.Store)
 	if err != nil {
 		storeErr = err.Error()
 	}
+	return newModel(configDir, config, s, storeErr)
+}
+
+// NewRerun creates a wizard that reconfigures a running VibeMux, sharing its
+// profile store.
+func NewRerun(configDir string, config *app.Config, s store.Store) Model {
+	m := newModel(configDir, config, s, "")
+	m.rerun = true
+	return m
+}
+
+func newModel(configDir string, config *app.Config, s store.Store, storeErr string) Model {
+	ti := textinput.New()
+	ti.Placeholder = "/path/to/claude"
+	ti.CharLimit = 256
+	ti.Width = 50
 
 	return Model{
 		step:        StepWelcome,
@@ -70,6 +101,16 @@ func New(configDir string, config *app.Config) Model {
 	}
 }
 
+// finish ends the wizard: the program quits on first run, while a rerun
+// reports back to the TUI.
+func (m Model) finish() tea.Cmd {
+	if !m.rerun {
+		return tea.Quit
+	}
+	completed := m.step == StepComplete
+	return func() tea.Msg { return DoneMsg{Completed: completed} }
+}
+
 // Init initializes the setup wizard.
 func (m Model) Init() tea.Cmd {
 	return nil
@@ -93,7 +134,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		if msg.String() == "ctrl+c" || msg.String() == "q" {
-			return m, tea.Quit
+			return m, m.finish()
+		}
+
+		if m.step == StepImport {
+			return m.updateImport(msg)
+		}
+
+		if m.step == StepConfigureProfile && m.choosingPreset {
+			return m.updatePresets(msg)
 		}
 
 		if m.step == StepConfigureProfile {
@@ -109,7 +158,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			if m.profileDialog.IsCancelled() {
-				m.step = StepProfileIntro
+				m.openPresetGallery()
 				return m, nil
 			}
 			return m, cmd
@@ -130,8 +179,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case "a":
 			if m.step == StepAddAnotherProfile {
-				m.step = StepConfigureProfile
-				m.initProfileDialog()
+				m.openPresetGallery()
 				return m, nil
 			}
 		}
@@ -165,11 +213,7 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 				m.error = err.Error()
 				return m, nil
 			}
-			if m.storeErr != "" {
-				m.step = StepComplete
-			} else {
-				m.step = StepProfileIntro
-			}
+			m.enterProfileSteps()
 		} else {
 			// Go to manual configuration
 			m.step = StepConfigureClaude
@@ -202,16 +246,11 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 			m.error = err.Error()
 			return m, nil
 		}
-		if m.storeErr != "" {
-			m.step = StepComplete
-		} else {
-			m.step = StepProfileIntro
-		}
+		m.enterProfileSteps()
 		return m, nil
 
 	case StepProfileIntro:
-		m.step = StepConfigureProfile
-		m.initProfileDialog()
+		m.openPresetGallery()
 		return m, nil
 
 	case StepAddAnotherProfile:
@@ -219,17 +258,17 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case StepComplete:
-		return m, tea.Quit
+		return m, m.finish()
 	}
 
 	return m, nil
 }
 
-func (m *Model) initProfileDialog() {
+func (m *Model) initProfileDialog(preset Preset) {
 	m.profileDialog = dialog.NewInputDialog("Create Profile", []dialog.InputField{
-		{Label: "Profile Name", Placeholder: "My Profile"},
-		{Label: "Command", Placeholder: "claude, codex, or ccr code"},
-		{Label: "Env Vars", Placeholder: "KEY=VALUE, KEY2=VALUE2"},
+		{Label: "Profile Name", Placeholder: "My Profile", Value: preset.Name},
+		{Label: "Command", Placeholder: "claude, codex, or ccr code", Value: preset.Command},
+		{Label: "Env Vars", Placeholder: "KEY=VALUE, KEY2=VALUE2", Value: preset.Env},
 	})
 	m.profileDialog.SetSize(m.width, m.height)
 }
@@ -262,12 +301,15 @@ func (m *Model) saveProfileFromDialog() error {
 
 	ctx := context.Background()
 
-	if m.profilesConfigured == 0 {
+	if m.profilesConfigured == 0 && !m.rerun {
 		profiles, err := m.store.ListProfiles(ctx)
 		if err == nil && len(profiles) == 1 && profiles[0].IsDefault {
 			p := profiles[0]
 			p.Name = name
 			p.Command = command
+			if level := Presets[m.presetCursor].AutoApprove; level != "" {
+				p.AutoApprove = level
+			}
 			p.EnvVars = envVars
 			p.IsDefault = true
 			p.Driver = model.DriverNative
@@ -282,10 +324,13 @@ func (m *Model) saveProfileFromDialog() error {
 
 	profile := model.NewProfile(name)
 	profile.Command = command
+	if level := Presets[m.presetCursor].AutoApprove; level != "" {
+		profile.AutoApprove = level
+	}
 	profile.EnvVars = envVars
 	profile.Driver = model.DriverNative
 	profile.CommandArgs = nil
-	if m.profilesConfigured == 0 {
+	if m.profilesConfigured == 0 && !m.rerun {
 		profile.IsDefault = true
 	}
 
@@ -342,9 +387,14 @@ func (m Model) View() string {
 		return m.viewDetect()
 	case StepConfigureClaude:
 		return m.viewConfigure()
+	case StepImport:
+		return m.viewImport()
 	case StepProfileIntro:
 		return m.viewProfileIntro()
 	case StepConfigureProfile:
+		if m.choosingPreset {
+			return m.viewPresets()
+		}
 		return m.profileDialog.View()
 	case StepAddAnotherProfile:
 		return m.viewProfileAddAnother()
@@ -545,10 +595,18 @@ func (m Model) viewProfileIntro() string {
 		Foreground(styles.TextMuted).
 		Render("Press Enter to create your first profile • Esc to skip")
 
+	var note string
+	if m.importNote != "" {
+		note = lipgloss.NewStyle().
+			Foreground(styles.Secondary).
+			Render("✓ " + m.importNote)
+	}
+
 	content := lipgloss.JoinVertical(
 		lipgloss.Center,
 		title,
 		"",
+		note,
 		desc,
 		"",
 		hint,
@@ -617,7 +675,7 @@ func (m Model) viewComplete() string {
 	hint := lipgloss.NewStyle().
 		Foreground(styles.Primary).
 		Bold(true).
-		Render("Press Enter to start VibeMux...")
+		Render(m.completeHint())
 
 	content := lipgloss.JoinVertical(
 		lipgloss.Center,
@@ -639,3 +697,10 @@ func (m Model) viewComplete() string {
 		Align(lipgloss.Center, lipgloss.Center).
 		Render(content)
 }
+
+func (m Model) completeHint() string {
+	if m.rerun {
+		return "Press Enter to return to VibeMux..."
+	}
+	return "Press Enter to start VibeMux..."
+}
@@ -13,12 +13,11 @@ import (
 // Model is the status bar component.
 type Model struct {
 	width        int
-	message      string
-	isError      bool
 	keyMap       keys.KeyMap
 	sessionCount int
 	modeLabel    string
 	turnInfo     string
+	pipelineInfo string
 }
 
 // New creates a new status bar component.
@@ -33,18 +32,6 @@ func (m *Model) SetWidth(width int) {
 	m.width = width
 }
 
-// SetMessage sets a temporary message.
-func (m *Model) SetMessage(msg string, isError bool) {
-	m.message = msg
-	m.isError = isError
-}
-
-// ClearMessage clears the temporary message.
-func (m *Model) ClearMessage() {
-	m.message = ""
-	m.isError = false
-}
-
 // SetSessionCount updates the active session count.
 func (m *Model) SetSessionCount(count int) {
 	m.sessionCount = count
@@ -60,6 +47,11 @@ func (m *Model) SetTurnInfo(info string) {
 	m.turnInfo = info
 }
 
+// SetPipelineInfo sets the chain pipeline status info.
+func (m *Model) SetPipelineInfo(info string) {
+	m.pipelineInfo = info
+}
+
 // View renders the status bar.
 func (m Model) View() string {
 	// Brand
@@ -115,68 +107,58 @@ func (m Model) View() string {
 			Render(fmt.Sprintf(" ● %d sessions ", m.sessionCount))
 	}
 
-	// Message area
-	var msgArea string
-	if m.message != "" {
-		msgStyle := lipgloss.NewStyle().Foreground(styles.TextMuted)
-		if m.isError {
-			msgStyle = lipgloss.NewStyle().Foreground(styles.Danger).Bold(true)
-		}
-		msgArea = msgStyle.Render(" " + m.message + " ")
-	}
-
 	// Calculate spacing
 	leftContent := brand + modeBadge + sessionInfo
 	rightContent := help
-	middleContent := msgArea
 
 	leftWidth := lipgloss.Width(leftContent)
 	rightWidth := lipgloss.Width(rightContent)
-	middleWidth := lipgloss.Width(middleContent)
 
 	// Calculate padding
-	totalUsed := leftWidth + rightWidth + middleWidth
+	totalUsed := leftWidth + rightWidth
 	padding := m.width - totalUsed
 	if padding < 0 {
 		padding = 0
 	}
 
-	leftPad := padding / 2
-	rightPad := padding - leftPad
-
-	// Build status bar
+	// Build status bar (messages are shown as toasts)
 	content := leftContent +
-		strings.Repeat(" ", leftPad) +
-		middleContent +
-		strings.Repeat(" ", rightPad) +
+		strings.Repeat(" ", padding) +
 		rightContent
 	
-	// Overlay Turn Info if present (right aligned before help)
-	if m.turnInfo != "" {
-		turnBadge := lipgloss.NewStyle().
-			Foreground(styles.Base).
-			Background(styles.Secondary).
-			Bold(true).
-			Padding(0, 1).
-			Render(m.turnInfo)
-		// Recalculate to inject it? Or just append to leftContent?
-		// Let's append to leftContent (after session info)
-		leftContent += " " + turnBadge
+	// Overlay Turn and Pipeline Info if present (right aligned before help)
+	if m.turnInfo != "" || m.pipelineInfo != "" {
+		if m.turnInfo != "" {
+			turnBadge := lipgloss.NewStyle().
+				Foreground(styles.Base).
+				Background(styles.Secondary).
+				Bold(true).
+				Padding(0, 1).
+				Render(m.turnInfo)
+			// Recalculate to inject it? Or just append to leftContent?
+			// Let's append to leftContent (after session info)
+			leftContent += " " + turnBadge
+		}
+		if m.pipelineInfo != "" {
+			pipelineBadge := lipgloss.NewStyle().
+				Foreground(styles.Base).
+				Background(styles.Peach).
+				Bold(true).
+				Padding(0, 1).
+				Render(m.pipelineInfo)
+			leftContent += " " + pipelineBadge
+		}
 		
 		// Re-calculate layout
 		leftWidth = lipgloss.Width(leftContent)
-		totalUsed = leftWidth + rightWidth + middleWidth
+		totalUsed = leftWidth + rightWidth
 		padding = m.width - totalUsed
 		if padding < 0 {
 			padding = 0
 		}
-		leftPad = padding / 2
-		rightPad = padding - leftPad
 		
 		content = leftContent +
-			strings.Repeat(" ", leftPad) +
-			middleContent +
-			strings.Repeat(" ", rightPad) +
+			strings.Repeat(" ", padding) +
 			rightContent
 	}
 
@@ -0,0 +1,296 @@
+// Package templates provides the prompt template library dialog: a list
+// of templates to insert or send, and an editor to add and change them.
+package templates
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
+)
+
+// listHeight is how many templates are shown at once.
+const listHeight = 12
+
+// Action is what the last key asked the app to do.
+type Action int
+
+const (
+	// ActionNone needs nothing from the app.
+	ActionNone Action = iota
+	// ActionInsert puts the selected template into the composer.
+	ActionInsert
+	// ActionSend sends the selected template to the active pane.
+	ActionSend
+	// ActionSave stores the edited template.
+	ActionSave
+	// ActionDelete removes the selected template.
+	ActionDelete
+	// ActionClose closes the dialog.
+	ActionClose
+)
+
+// Model is the template library dialog.
+type Model struct {
+	templates     []model.Template
+	cursor        int
+	offset        int
+	editing       bool
+	editID        string // Template being edited; empty for a new one
+	name          textinput.Model
+	body          textarea.Model
+	inBody        bool // Focus is on the body rather than the name
+	confirmDelete bool
+	err           string
+	width         int
+	action        Action
+	result        model.Template
+}
+
+// New creates an empty dialog.
+func New() Model {
+	ti := textinput.New()
+	ti.Prompt = "Name: "
+	ti.CharLimit = 80
+	ta := textarea.New()
+	ta.Placeholder = "Prompt text… variables: {{PROJECT}} {{TOPIC}} {{FILENAME}} {{PANE}} {{DATE}}"
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 0
+	return Model{name: ti, body: ta}
+}
+
+// Open shows the template list.
+func (m *Model) Open(templates []model.Template) {
+	m.SetTemplates(templates)
+	m.editing = false
+	m.confirmDelete = false
+	m.err = ""
+	m.action = ActionNone
+}
+
+// SetTemplates replaces the listed templates, keeping the cursor in range.
+func (m *Model) SetTemplates(templates []model.Template) {
+	m.templates = templates
+	m.move(0)
+}
+
+// SetSize sizes the dialog from the screen size.
+func (m *Model) SetSize(width, height int) {
+	m.width = min(90, width-4)
+	m.name.Width = max(m.width-14, 10)
+	m.body.SetWidth(max(m.width-8, 10))
+	m.body.SetHeight(max(min(height/2, 16), 3))
+}
+
+// Action returns what the last key asked for.
+func (m Model) Action() Action { return m.action }
+
+// Template returns the template the action applies to: the selected one,
+// or the edited one for ActionSave. A new template has an empty ID.
+func (m Model) Template() model.Template { return m.result }
+
+// Update handles key input.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	m.action = ActionNone
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	if m.editing {
+		return m.updateEditor(keyMsg)
+	}
+	confirmDelete := m.confirmDelete
+	m.confirmDelete = false
+	m.err = ""
+	switch key := keyMsg.String(); key {
+	case "esc", "q":
+		m.action = ActionClose
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "enter":
+		m.choose(ActionInsert)
+	case "s":
+		m.choose(ActionSend)
+	case "a", "n":
+		m.startEdit(model.Template{})
+	case "e":
+		if t, ok := m.selected(); ok {
+			m.startEdit(t)
+		}
+	case "d", "delete":
+		if _, ok := m.selected(); !ok {
+			break
+		}
+		if confirmDelete {
+			m.choose(ActionDelete)
+		} else {
+			m.confirmDelete = true
+		}
+	default:
+		if n, err := strconv.Atoi(key); err == nil && n >= 1 && n <= len(m.templates) {
+			m.cursor = n - 1
+			m.move(0)
+			m.choose(ActionInsert)
+		}
+	}
+	return m, nil
+}
+
+// updateEditor handles keys while a template is edited. Tab switches
+// between name and body, Ctrl+S saves and Esc goes back to the list.
+func (m Model) updateEditor(keyMsg tea.KeyMsg) (Model, tea.Cmd) {
+	switch keyMsg.String() {
+	case "esc":
+		m.editing = false
+		m.err = ""
+		return m, nil
+	case "tab", "shift+tab":
+		m.focusBody(!m.inBody)
+		return m, nil
+	case "ctrl+s":
+		name := strings.TrimSpace(m.name.Value())
+		if name == "" {
+			m.err = "Name is required"
+			return m, nil
+		}
+		if strings.TrimSpace(m.body.Value()) == "" {
+			m.err = "Prompt text is required"
+			return m, nil
+		}
+		m.result = model.Template{ID: m.editID, Name: name, Body: m.body.Value()}
+		m.action = ActionSave
+		m.editing = false
+		m.err = ""
+		return m, nil
+	}
+	var cmd tea.Cmd
+	if m.inBody {
+		m.body, cmd = m.body.Update(keyMsg)
+	} else {
+		m.name, cmd = m.name.Update(keyMsg)
+	}
+	return m, cmd
+}
+
+// startEdit opens the editor on t; a zero template adds a new one.
+func (m *Model) startEdit(t model.Template) {
+	m.editing = true
+	m.editID = t.ID
+	m.name.SetValue(t.Name)
+	m.name.CursorEnd()
+	m.body.SetValue(t.Body)
+	m.focusBody(false)
+}
+
+// focusBody moves focus to the body or the name.
+func (m *Model) focusBody(body bool) {
+	m.inBody = body
+	if body {
+		m.name.Blur()
+		m.body.Focus()
+	} else {
+		m.body.Blur()
+		m.name.Focus()
+	}
+}
+
+// choose reports action for the selected template.
+func (m *Model) choose(action Action) {
+	if t, ok := m.selected(); ok {
+		m.result = t
+		m.action = action
+	}
+}
+
+// selected returns the template under the cursor.
+func (m Model) selected() (model.Template, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.templates) {
+		return model.Template{}, false
+	}
+	return m.templates[m.cursor], true
+}
+
+// move shifts the cursor and keeps it visible.
+func (m *Model) move(delta int) {
+	m.cursor = max(0, min(m.cursor+delta, len(m.templates)-1))
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+listHeight {
+		m.offset = m.cursor - listHeight + 1
+	}
+}
+
+// View renders the dialog.
+func (m Model) View() string {
+	width := max(m.width, 40)
+	if m.editing {
+		return m.editorView(width)
+	}
+	innerWidth := width - 6
+	lineStyle := lipgloss.NewStyle().Foreground(styles.Subtext1)
+	selectedStyle := lipgloss.NewStyle().Foreground(styles.TextCol).Background(styles.Surface1).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.TextMuted)
+
+	rows := make([]string, 0, listHeight)
+	end := min(m.offset+listHeight, len(m.templates))
+	for i := m.offset; i < end; i++ {
+		t := m.templates[i]
+		preview := strings.Join(strings.Fields(t.Body), " ")
+		text := fmt.Sprintf("%d %s  %s", i+1, t.Name, mutedStyle.Render(preview))
+		text = ansi.Truncate(text, innerWidth, "…")
+		if i == m.cursor {
+			rows = append(rows, selectedStyle.Width(innerWidth).Render(text))
+		} else {
+			rows = append(rows, lineStyle.Render(text))
+		}
+	}
+	if len(rows) == 0 {
+		rows = append(rows, mutedStyle.Render("No templates yet (a to add)"))
+	}
+
+	help := "Enter insert • s send • a add • e edit • d delete • Esc close"
+	if m.confirmDelete {
+		t, _ := m.selected()
+		help = lipgloss.NewStyle().Foreground(styles.Warning).Render(fmt.Sprintf("Press d again to delete %q", t.Name))
+	} else {
+		help = mutedStyle.Render(help)
+	}
+	body := lipgloss.JoinVertical(
+		lipgloss.Left,
+		styles.DialogTitle.Render("Prompt Templates"),
+		lipgloss.JoinVertical(lipgloss.Left, rows...),
+		"",
+		help,
+	)
+	return styles.DialogBox.Width(width - 2).Render(body)
+}
+
+// editorView renders the template editor.
+func (m Model) editorView(width int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.TextMuted)
+	title := "New Template"
+	if m.editID != "" {
+		title = "Edit Template"
+	}
+	parts := []string{
+		styles.DialogTitle.Render(title),
+		m.name.View(),
+		"",
+		m.body.View(),
+	}
+	if m.err != "" {
+		parts = append(parts, lipgloss.NewStyle().Foreground(styles.StatusError).Render(m.err))
+	}
+	parts = append(parts, "", mutedStyle.Render("Ctrl+S save • Tab name/text • Esc back"))
+	return styles.DialogBox.Width(width - 2).Render(lipgloss.JoinVertical(lipgloss.Left, parts...))
+}
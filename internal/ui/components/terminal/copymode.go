@@ -0,0 +1,174 @@
+package terminal
+
+import "strings"
+
+// copyMode is a keyboard cursor over the visible content for selecting
+// text, in content coordinates. The selection itself is m.sel.
+type copyMode struct {
+	active bool
+	cursor cellPos
+}
+
+// EnterCopyMode starts copy mode with the cursor on the terminal cursor,
+// or at the bottom of the view when scrolled back.
+func (m *Model) EnterCopyMode() {
+	m.sel = selection{}
+	m.copy = copyMode{active: true, cursor: cellPos{0, m.innerHeight - 1}}
+	if m.scrollOffset == 0 && m.term != nil {
+		m.term.Lock()
+		c := m.term.Cursor()
+		m.term.Unlock()
+		m.copy.cursor = m.clampPos(cellPos{c.X, c.Y})
+	}
+}
+
+// ExitCopyMode leaves copy mode and drops its selection.
+func (m *Model) ExitCopyMode() {
+	m.copy = copyMode{}
+	m.sel = selection{}
+}
+
+// InCopyMode reports whether copy mode is active.
+func (m Model) InCopyMode() bool {
+	return m.copy.active
+}
+
+// CopyModeKey handles a key in copy mode. It returns the yanked text on y
+// or Enter, and done when copy mode has ended.
+//
+//	h j k l / arrows  move          v  select characters
+//	0 $               line start/end V  select lines
+//	w b               next/prev word y  Enter  yank and leave
+//	g G               history top/end Esc q  leave
+//	pgup pgdown ctrl+u ctrl+d  scroll
+func (m *Model) CopyModeKey(key string) (text string, done bool) {
+	c := m.copy.cursor
+	switch key {
+	case "h", "left":
+		c.x--
+	case "l", "right":
+		c.x++
+	case "k", "up":
+		if c.y == 0 {
+			m.copyScroll(1)
+		}
+		c.y--
+	case "j", "down":
+		if c.y == m.innerHeight-1 {
+			m.copyScroll(-1)
+		}
+		c.y++
+	case "0", "home":
+		c.x = 0
+	case "$", "end":
+		c.x = max(0, len([]rune(strings.TrimRight(string(lineAt(m.visibleLines(), c.y)), " ")))-1)
+	case "w":
+		c = m.nextWord(c)
+	case "b":
+		c = m.prevWord(c)
+	case "pgup", "ctrl+u":
+		m.copyScroll(m.innerHeight / 2)
+	case "pgdown", "ctrl+d":
+		m.copyScroll(-m.innerHeight / 2)
+	case "g":
+		m.copyScroll(m.maxScrollOffset() - m.scrollOffset)
+		c.y = 0
+	case "G":
+		m.copyScroll(-m.scrollOffset)
+		c.y = m.innerHeight - 1
+	case "v", "V":
+		unit := 1
+		if key == "V" {
+			unit = 3
+		}
+		if m.sel.active && m.sel.unit == unit {
+			m.sel = selection{}
+		} else {
+			m.sel = selection{active: true, unit: unit, anchor: c, head: c}
+		}
+	case "y", "enter":
+		text = m.copyText()
+		m.ExitCopyMode()
+		return text, true
+	case "esc", "q":
+		m.ExitCopyMode()
+		return "", true
+	}
+	m.copy.cursor = m.clampPos(c)
+	if m.sel.active {
+		m.sel.head = m.copy.cursor
+	}
+	return "", false
+}
+
+// copyScroll scrolls the view by delta lines (positive is back in
+// history), moving the selection anchor with the content.
+func (m *Model) copyScroll(delta int) {
+	before := m.scrollOffset
+	m.scrollBy(delta)
+	if m.sel.active {
+		m.sel.anchor.y += m.scrollOffset - before
+		m.sel.anchor = m.clampPos(m.sel.anchor)
+	}
+}
+
+// copyText returns the selection, the character under the cursor for an
+// empty selection, or the cursor line without one.
+func (m *Model) copyText() string {
+	if m.HasSelection() {
+		return m.SelectedText()
+	}
+	row := lineAt(m.visibleLines(), m.copy.cursor.y)
+	if m.sel.active {
+		if m.copy.cursor.x < len(row) {
+			return string(row[m.copy.cursor.x])
+		}
+		return ""
+	}
+	return strings.TrimSpace(string(row))
+}
+
+// nextWord moves to the start of the next word, continuing on later lines.
+func (m *Model) nextWord(c cellPos) cellPos {
+	lines := m.visibleLines()
+	row := lineAt(lines, c.y)
+	x := wordEnd(row, c.x) + 1
+	for {
+		for ; x < len(row); x++ {
+			if isWordRune(row[x]) {
+				return cellPos{x, c.y}
+			}
+		}
+		if c.y+1 >= len(lines) {
+			return c
+		}
+		c.y++
+		row, x = lineAt(lines, c.y), 0
+	}
+}
+
+// prevWord moves to the start of the previous word, continuing on earlier
+// lines.
+func (m *Model) prevWord(c cellPos) cellPos {
+	lines := m.visibleLines()
+	row := lineAt(lines, c.y)
+	x := min(c.x, len(row)) - 1
+	for {
+		for ; x >= 0; x-- {
+			if isWordRune(row[x]) && (x == 0 || !isWordRune(row[x-1])) {
+				return cellPos{x, c.y}
+			}
+		}
+		if c.y == 0 {
+			return c
+		}
+		c.y--
+		row = lineAt(lines, c.y)
+		x = len(row) - 1
+	}
+}
+
+// copyCursorAt reports whether the copy mode cursor is on cell (x, y).
+func (m *Model) copyCursorAt(x, y int) bool {
+	return m.copy.active && m.copy.cursor == cellPos{x, y}
+}
@@ -0,0 +1,98 @@
+package terminal
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
+)
+
+// debugState holds the size diagnostics shown by the debug overlay.
+type debugState struct {
+	enabled  bool
+	ptyCols  int
+	ptyRows  int
+	resized  time.Time
+	resizeOK bool
+}
+
+// SetDropped records the session's total of dropped output bytes. The
+// header warns while some were dropped since the last Resync.
+func (m *Model) SetDropped(total int64) {
+	m.dropped = total
+}
+
+// Dropped reports whether output was dropped since the last Resync.
+func (m Model) Dropped() bool {
+	return m.dropped > m.droppedSync
+}
+
+// Resync rebuilds the screen from the session's output history, repairing
+// the emulator after dropped output. Replies the replay would send (cursor
+// reports and the like) are not written to the PTY.
+func (m *Model) Resync(history []byte, dropped int64) {
+	var w io.Writer
+	if m.responder != nil {
+		m.responder.mu.RLock()
+		w = m.responder.w
+		m.responder.mu.RUnlock()
+		m.responder.SetWriter(nil)
+	}
+	m.resetTerminal()
+	m.AppendOutput(history)
+	m.responder.SetWriter(w)
+	m.dropped = dropped
+	m.droppedSync = dropped
+}
+
+// SetDebug toggles the size overlay, which replaces the header separator so
+// it does not change the layout it is diagnosing.
+func (m *Model) SetDebug(enabled bool) {
+	m.debug.enabled = enabled
+}
+
+// NotePTYResize records the size last sent to the PTY and whether the
+// resize succeeded.
+func (m *Model) NotePTYResize(cols, rows int, ok bool) {
+	m.debug.ptyCols = cols
+	m.debug.ptyRows = rows
+	m.debug.resized = time.Now()
+	m.debug.resizeOK = ok
+}
+
+// renderDebugLine describes the PTY, emulator, inner and outer sizes. It
+// turns to a warning color when the PTY and emulator disagree.
+func (m Model) renderDebugLine(width int) string {
+	d := m.debug
+	vtCols, vtRows := 0, 0
+	if m.term != nil {
+		vtCols, vtRows = m.term.Size()
+	}
+	pty := "pty ?"
+	if !d.resized.IsZero() {
+		pty = fmt.Sprintf("pty %d×%d", d.ptyCols, d.ptyRows)
+		if !d.resizeOK {
+			pty += " (failed)"
+		}
+	}
+	resized := "never resized"
+	if !d.resized.IsZero() {
+		resized = fmt.Sprintf("resized %s ago", time.Since(d.resized).Truncate(time.Second))
+	}
+	text := fmt.Sprintf("─ %s │ vt %d×%d │ inner %d×%d │ box %d×%d │ %s │ dropped %d B ",
+		pty, vtCols, vtRows, m.innerWidth, m.innerHeight, m.width, m.height, resized, m.dropped)
+	text = ansi.Truncate(text, width, "…")
+	if pad := width - ansi.StringWidth(text); pad > 0 {
+		text += strings.Repeat("─", pad)
+	}
+
+	color := styles.TextMuted
+	if d.resized.IsZero() || !d.resizeOK || d.ptyCols != vtCols || d.ptyRows != vtRows || m.Dropped() {
+		color = styles.Warning
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(text)
+}
@@ -0,0 +1,80 @@
+package terminal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
+)
+
+// LaunchHintAfter is how long a launch may stay silent before the pane
+// suggests checking the command.
+const LaunchHintAfter = 10 * time.Second
+
+// launchState tracks a session between launch and its first output.
+type launchState struct {
+	active  bool
+	label   string
+	started time.Time
+	frame   int
+	queued  int // Place in the engine's launch queue, 0 once starting
+}
+
+// SetLaunching shows the launch spinner for the given command until the
+// first output arrives.
+func (m *Model) SetLaunching(label string) {
+	m.launch = launchState{active: true, label: label, started: time.Now()}
+}
+
+// ClearLaunching hides the launch spinner.
+func (m *Model) ClearLaunching() {
+	m.launch = launchState{}
+}
+
+// IsLaunching reports whether the pane is waiting for first output.
+func (m Model) IsLaunching() bool {
+	return m.launch.active
+}
+
+// SetQueued shows the pane's place in the launch queue; 0 means the
+// launch has left the queue and is starting.
+func (m *Model) SetQueued(position int) {
+	if !m.launch.active || m.launch.queued == position {
+		return
+	}
+	if position == 0 {
+		// Time the silent-launch hint from when the process starts.
+		m.launch.started = time.Now()
+	}
+	m.launch.queued = position
+}
+
+// AdvanceSpinner moves the launch spinner to its next frame.
+func (m *Model) AdvanceSpinner() {
+	if m.launch.active {
+		m.launch.frame = (m.launch.frame + 1) % len(styles.SpinnerFrames)
+	}
+}
+
+// renderLaunching renders the spinner, with a hint once the launch has
+// been silent for LaunchHintAfter.
+func (m Model) renderLaunching(width int) string {
+	spinner := lipgloss.NewStyle().Foreground(styles.Accent).Render(styles.SpinnerFrames[m.launch.frame])
+	msg := spinner + " " + styles.TerminalPlaceholder.Render("launching "+m.launch.label+"…")
+	if m.launch.queued > 0 {
+		msg = spinner + " " + styles.TerminalPlaceholder.Render(fmt.Sprintf("queued #%d — waiting for a free session slot", m.launch.queued))
+	} else if elapsed := time.Since(m.launch.started); elapsed >= LaunchHintAfter {
+		hint := fmt.Sprintf("No output after %ds — check the profile command or press x to close", int(elapsed.Seconds()))
+		msg = lipgloss.JoinVertical(lipgloss.Center, msg, "", lipgloss.NewStyle().Foreground(styles.Warning).Render(hint))
+	}
+	height := m.innerHeight
+	if height < 1 {
+		height = 1
+	}
+	return lipgloss.NewStyle().
+		Width(width).
+		Height(height).
+		Align(lipgloss.Center, lipgloss.Center).
+		Render(msg)
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/ansi"
@@ -14,6 +15,9 @@ import (
 	"github.com/lazyvibe/vibemux/internal/ui/styles"
 )
 
+// BellFlash is how long a terminal bell highlights the pane.
+const BellFlash = 3 * time.Second
+
 const (
 	attrReverse = 1 << iota
 	attrUnderline
@@ -57,11 +61,25 @@ type Model struct {
 	projectID    string
 	projectName  string
 	status       model.SessionStatus
+	activity     model.ActivityState
+	usage        string // CPU and memory of the session, e.g. "12% 540M"
+	usageHigh    bool   // usage is over the warning threshold
 	scrollback   []string
 	scrollTail   string
 	scrollOffset int
 	isAltScreen  bool // Track if terminal is in Alt Screen mode (TUI app running)
 	manualScrollbackPause bool // Manual toggle to stop recording history
+	inputLock    string // Holder of the session input lock, if any
+	sel          selection // Mouse selection over the visible content
+	search       searchState // Scrollback search started with /
+	copy         copyMode    // Keyboard selection, entered with [
+	launch       launchState // Spinner shown until the first output
+	badge        string         // Transient header badge, e.g. injection progress
+	badgeColor   lipgloss.Color
+	debug        debugState // Size overlay for diagnosing layout glitches
+	dropped      int64      // Output bytes the session dropped in total
+	droppedSync  int64      // dropped as of the last Resync
+	bellAt       time.Time  // Last terminal bell, shown for BellFlash
 }
 
 // New creates a new terminal component.
@@ -94,6 +112,7 @@ func (m *Model) SetSize(width, height int) {
 		m.term.Resize(m.innerWidth, m.innerHeight)
 	}
 	m.clampScrollOffset()
+	m.copy.cursor = m.clampPos(m.copy.cursor)
 }
 
 // SetFocused updates the focus state.
@@ -115,6 +134,13 @@ func (m *Model) SetProject(id, name string) {
 	m.projectName = name
 }
 
+// Rename re-labels the terminal for another project without clearing
+// its screen, used when a running session is re-homed.
+func (m *Model) Rename(id, name string) {
+	m.projectID = id
+	m.projectName = name
+}
+
 // SetStatus updates the session status.
 func (m *Model) SetStatus(status model.SessionStatus) {
 	m.status = status
@@ -136,6 +162,44 @@ func (m *Model) UnbindWriter() {
 	m.responder.SetWriter(nil)
 }
 
+// SetActivity sets the activity shown in the header of a running pane.
+func (m *Model) SetActivity(state model.ActivityState) {
+	m.activity = state
+}
+
+// Activity returns the pane's activity state.
+func (m Model) Activity() model.ActivityState {
+	return m.activity
+}
+
+// SetUsage shows the session's CPU and memory use in the header; empty
+// clears it. high highlights it.
+func (m *Model) SetUsage(text string, high bool) {
+	m.usage = text
+	m.usageHigh = high
+}
+
+// SetInputLock shows which operator currently holds the session input lock.
+func (m *Model) SetInputLock(owner string) {
+	m.inputLock = owner
+}
+
+// SetBadge shows a short transient status in the header; empty clears it.
+func (m *Model) SetBadge(text string, color lipgloss.Color) {
+	m.badge = text
+	m.badgeColor = color
+}
+
+// Ring flashes the border and shows a bell in the header for BellFlash.
+func (m *Model) Ring() {
+	m.bellAt = time.Now()
+}
+
+// Ringing reports whether the bell indicator is showing.
+func (m Model) Ringing() bool {
+	return !m.bellAt.IsZero() && time.Since(m.bellAt) < BellFlash
+}
+
 // ProjectID returns the current project ID.
 func (m Model) ProjectID() string {
 	return m.projectID
@@ -171,6 +235,7 @@ func (m *Model) AppendOutput(data []byte) {
 		m.isAltScreen = false
 	}
 
+	m.launch = launchState{}
 	_, _ = m.term.Write(data)
 	
 	// Only append to scrollback if NOT in Alt Screen mode AND NOT manually paused
@@ -231,6 +296,14 @@ func (m *Model) HandleKey(key string) bool {
 		return true
     case "esc":
         // Snap to bottom on Escape if scrolled
+        if m.HasSelection() {
+            m.ClearSelection()
+            return true
+        }
+        if m.Searching() {
+            m.ClearSearch()
+            return true
+        }
         if m.scrollOffset > 0 {
             m.scrollOffset = 0
             return true
@@ -262,6 +335,9 @@ func (m Model) View() string {
 	if m.manualScrollbackPause {
 		title += " (HIST PAUSED)"
 	}
+	if m.copy.active {
+		title += " [COPY]"
+	}
 
 	if m.focused {
 		title = styles.PanelTitleFocused.Render(title)
@@ -290,11 +366,39 @@ func (m Model) View() string {
 		"  ",
 		statusInfo,
 	)
+	if activity := m.activityLabel(); activity != "" && m.status == model.SessionStatusRunning {
+		header += "  " + activity
+	}
+	if m.usage != "" && m.status == model.SessionStatusRunning {
+		usageStyle := lipgloss.NewStyle().Foreground(styles.TextMuted)
+		if m.usageHigh {
+			usageStyle = usageStyle.Foreground(styles.Warning).Bold(true)
+		}
+		header += "  " + usageStyle.Render(m.usage)
+	}
+	if m.inputLock != "" {
+		header += "  " + lipgloss.NewStyle().Foreground(styles.Warning).Render("🔒 "+m.inputLock)
+	}
+	if m.badge != "" {
+		header += "  " + lipgloss.NewStyle().Foreground(m.badgeColor).Render(m.badge)
+	}
+	if m.Searching() {
+		header += "  " + lipgloss.NewStyle().Foreground(styles.Yellow).Render(m.SearchStatus())
+	}
+	if m.Dropped() {
+		header += "  " + lipgloss.NewStyle().Foreground(styles.Warning).Render("⚠ output dropped (:resync)")
+	}
+	ringing := m.Ringing()
+	if ringing {
+		header += "  " + lipgloss.NewStyle().Foreground(styles.Warning).Bold(true).Render(styles.IconBell)
+	}
 
 	// Content
 	var content string
 	if m.projectID == "" {
 		content = m.renderPlaceholder("Select a project and press Enter to start", innerWidth)
+	} else if m.launch.active {
+		content = m.renderLaunching(innerWidth)
 	} else if m.status == model.SessionStatusIdle {
 		content = m.renderPlaceholder("Press Enter to start session", innerWidth)
 	} else {
@@ -308,19 +412,27 @@ func (m Model) View() string {
 	} else {
 		borderStyle = styles.BorderStyle
 	}
+	if ringing {
+		borderStyle = borderStyle.BorderForeground(styles.Warning)
+	}
 
 	// Build panel
 	// Build panel
     // Combine content with scrollbar
     mainArea := lipgloss.JoinHorizontal(lipgloss.Top, content, m.renderScrollbar())
 
+	separator := strings.Repeat("─", innerWidth)
+	if m.debug.enabled {
+		separator = m.renderDebugLine(innerWidth)
+	}
+
 	panel := borderStyle.
 		Width(m.width - 2).
 		Height(m.height - 2).
 		Render(lipgloss.JoinVertical(
 			lipgloss.Left,
 			header,
-			strings.Repeat("─", innerWidth),
+			separator,
 			mainArea,
 		))
 
@@ -403,11 +515,13 @@ func (m *Model) renderScreen() string {
 	if m.term == nil || m.innerWidth < 1 || m.innerHeight < 1 {
 		return ""
 	}
+	selected := m.selectedMask()
+	hits := m.searchMask(m.screenLines(), true, 0)
 	m.term.Lock()
 	defer m.term.Unlock()
 
 	cursor := m.term.Cursor()
-	showCursor := m.focused && m.term.CursorVisible()
+	showCursor := m.focused && m.term.CursorVisible() && !m.copy.active
 
 	var b strings.Builder
 	b.Grow((m.innerWidth + 1) * m.innerHeight)
@@ -424,9 +538,18 @@ func (m *Model) renderScreen() string {
 			}
 
 			style := cellStyleFromGlyph(cell)
+			if hits != nil && hits(x, y) != hitNone {
+				applySearchHit(&style, hits(x, y))
+			}
 			if showCursor && cursor.X == x && cursor.Y == y {
 				style.reverse = true
 			}
+			if selected != nil && selected(x, y) {
+				style.reverse = true
+			}
+			if m.copyCursorAt(x, y) {
+				style.reverse = !style.reverse
+			}
 
 			if !hasPrev || !style.equals(prev) {
 				b.WriteString(style.sgr())
@@ -458,6 +581,8 @@ func (m *Model) resetTerminal() {
 	m.scrollback = nil
 	m.scrollTail = ""
 	m.scrollOffset = 0
+	m.search = searchState{}
+	m.copy = copyMode{}
 	if m.innerWidth > 0 && m.innerHeight > 0 {
 		m.term = vt10x.New(vt10x.WithWriter(m.responder), vt10x.WithSize(m.innerWidth, m.innerHeight))
 		return
@@ -481,6 +606,22 @@ func (m Model) statusIcon() string {
 	return lipgloss.NewStyle().Foreground(color).Bold(true).Render("●")
 }
 
+// activityLabel renders the activity state for the header.
+func (m Model) activityLabel() string {
+	switch m.activity {
+	case model.ActivityWorking:
+		frame := time.Now().UnixMilli() / 100 % int64(len(styles.SpinnerFrames))
+		return lipgloss.NewStyle().Foreground(styles.Info).Render(styles.SpinnerFrames[frame] + " thinking")
+	case model.ActivityWaiting:
+		return lipgloss.NewStyle().Foreground(styles.Warning).Bold(true).Render("? needs input")
+	case model.ActivityError:
+		return lipgloss.NewStyle().Foreground(styles.StatusError).Bold(true).Render("! error")
+	case model.ActivityIdle:
+		return lipgloss.NewStyle().Foreground(styles.StatusIdle).Render("○ idle")
+	}
+	return ""
+}
+
 // renderPlaceholder renders a centered placeholder message.
 func (m Model) renderPlaceholder(msg string, width int) string {
 	styled := styles.TerminalPlaceholder.Render(msg)
@@ -568,6 +709,7 @@ func (m *Model) renderScrollback() string {
 		padding := make([]string, m.innerHeight-len(visible))
 		visible = append(visible, padding...)
 	}
+	visible = m.highlightLines(visible, start)
 	return lipgloss.NewStyle().
 		Width(m.innerWidth).
 		Height(m.innerHeight).
@@ -616,6 +758,24 @@ func (m *Model) clampScrollOffset() {
 	}
 }
 
+// ScrollTo scrolls the scrollback so the most recent line containing text
+// is centred in view. It reports whether such a line was found.
+func (m *Model) ScrollTo(text string) bool {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return false
+	}
+	lines := m.renderScrollLines()
+	for i := len(lines) - 1; i >= 0; i-- {
+		if strings.Contains(lines[i], text) {
+			m.scrollOffset = len(lines) - i - (m.innerHeight+1)/2
+			m.clampScrollOffset()
+			return true
+		}
+	}
+	return false
+}
+
 func (m *Model) scrollBy(delta int) {
 	m.scrollOffset += delta
 	m.clampScrollOffset()
@@ -0,0 +1,222 @@
+package terminal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/hinshun/vt10x"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
+)
+
+// searchMatch is where a search match starts: a scrollback line, or a row
+// of the emulator screen, and the rune column within it.
+type searchMatch struct {
+	screen bool
+	line   int
+	col    int
+}
+
+// searchState is the pane's scrollback search. Matches are found again on
+// every step, so they follow new output.
+type searchState struct {
+	query   []rune
+	fold    bool // case-insensitive: the query has no upper-case letters
+	current searchMatch
+	found   bool
+	index   int // 1-based position of current, for the header
+	total   int
+}
+
+// Search highlights query in the scrollback and on the screen and jumps to
+// the newest match. It returns the number of matches; an empty query
+// clears the search.
+func (m *Model) Search(query string) int {
+	if query == "" {
+		m.ClearSearch()
+		return 0
+	}
+	fold := strings.ToLower(query) == query
+	m.search = searchState{query: []rune(query), fold: fold}
+	matches := m.searchMatches()
+	if len(matches) == 0 {
+		return 0
+	}
+	m.showMatch(matches, len(matches)-1)
+	return len(matches)
+}
+
+// SearchNext jumps to the next older match, or the next newer one when
+// older is false, wrapping around at either end. It reports false when no
+// search is active or nothing matches any more.
+func (m *Model) SearchNext(older bool) bool {
+	if !m.Searching() {
+		return false
+	}
+	matches := m.searchMatches()
+	if len(matches) == 0 {
+		m.search.found = false
+		m.search.total = 0
+		return false
+	}
+	i := len(matches)
+	for j, match := range matches {
+		if m.search.found && match == m.search.current {
+			i = j
+			break
+		}
+	}
+	if older {
+		i--
+	} else {
+		i++
+	}
+	m.showMatch(matches, (i+len(matches))%len(matches))
+	return true
+}
+
+// Searching reports whether a search is active.
+func (m Model) Searching() bool {
+	return len(m.search.query) > 0
+}
+
+// SearchStatus describes the search for the pane header, e.g. "/error 2/5".
+func (m Model) SearchStatus() string {
+	if !m.Searching() {
+		return ""
+	}
+	if !m.search.found {
+		return fmt.Sprintf("/%s 0/0", string(m.search.query))
+	}
+	return fmt.Sprintf("/%s %d/%d", string(m.search.query), m.search.index, m.search.total)
+}
+
+// ClearSearch drops the search and its highlights.
+func (m *Model) ClearSearch() {
+	m.search = searchState{}
+}
+
+func (m *Model) showMatch(matches []searchMatch, i int) {
+	match := matches[i]
+	m.search.current = match
+	m.search.found = true
+	m.search.index = len(matches) - i
+	m.search.total = len(matches)
+	if match.screen {
+		m.scrollOffset = 0
+		return
+	}
+	m.scrollOffset = len(m.renderScrollLines()) - match.line - (m.innerHeight+1)/2
+	m.clampScrollOffset()
+	if m.scrollOffset == 0 && m.maxScrollOffset() > 0 {
+		// Offset 0 shows the screen; stay in the scrollback holding the match.
+		m.scrollOffset = 1
+	}
+}
+
+// searchMatches lists all matches from oldest to newest: the scrollback,
+// then the screen.
+func (m *Model) searchMatches() []searchMatch {
+	var matches []searchMatch
+	for i, line := range m.renderScrollLines() {
+		for _, col := range m.matchCols(line) {
+			matches = append(matches, searchMatch{line: i, col: col})
+		}
+	}
+	for y, line := range m.screenLines() {
+		for _, col := range m.matchCols(line) {
+			matches = append(matches, searchMatch{screen: true, line: y, col: col})
+		}
+	}
+	return matches
+}
+
+// matchCols returns the rune columns where the query starts in line.
+func (m *Model) matchCols(line string) []int {
+	q := m.search.query
+	row := []rune(line)
+	var cols []int
+	for x := 0; x+len(q) <= len(row); x++ {
+		if m.matchAt(row, x) {
+			cols = append(cols, x)
+			x += len(q) - 1
+		}
+	}
+	return cols
+}
+
+func (m *Model) matchAt(row []rune, x int) bool {
+	for i, r := range m.search.query {
+		c := row[x+i]
+		if m.search.fold {
+			c = unicode.ToLower(c)
+		}
+		if c != r {
+			return false
+		}
+	}
+	return true
+}
+
+// Search highlight kinds returned by searchMask.
+const (
+	hitNone = iota
+	hitMatch
+	hitCurrent
+)
+
+// searchMask marks the matched cells of lines, which start at scrollback
+// line first, or are the screen when screen is set.
+func (m *Model) searchMask(lines []string, screen bool, first int) func(x, y int) int {
+	if !m.Searching() {
+		return nil
+	}
+	n := len(m.search.query)
+	hits := make(map[cellPos]int)
+	for y, line := range lines {
+		for _, col := range m.matchCols(line) {
+			kind := hitMatch
+			if m.search.found && m.search.current == (searchMatch{screen: screen, line: first + y, col: col}) {
+				kind = hitCurrent
+			}
+			for x := col; x < col+n; x++ {
+				hits[cellPos{x, y}] = kind
+			}
+		}
+	}
+	if len(hits) == 0 {
+		return nil
+	}
+	return func(x, y int) int {
+		return hits[cellPos{x, y}]
+	}
+}
+
+// searchStyle returns the lipgloss style for a highlight kind.
+func searchStyle(kind int) lipgloss.Style {
+	bg := styles.Yellow
+	if kind == hitCurrent {
+		bg = styles.Peach
+	}
+	return lipgloss.NewStyle().Foreground(styles.Base).Background(bg)
+}
+
+// applySearchHit colours a screen cell for a highlight kind.
+func applySearchHit(style *cellStyle, kind int) {
+	style.fg = hexColor(styles.Base)
+	style.bg = hexColor(styles.Yellow)
+	if kind == hitCurrent {
+		style.bg = hexColor(styles.Peach)
+	}
+}
+
+// hexColor converts a "#rrggbb" colour to a vt10x true colour.
+func hexColor(c lipgloss.Color) vt10x.Color {
+	v, err := strconv.ParseUint(strings.TrimPrefix(string(c), "#"), 16, 32)
+	if err != nil {
+		return vt10x.DefaultFG
+	}
+	return vt10x.Color(v)
+}
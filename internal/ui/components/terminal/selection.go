@@ -0,0 +1,244 @@
+package terminal
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Content origin inside the panel: border, header and separator rows above,
+// the border column to the left.
+const (
+	contentOffsetX = 1
+	contentOffsetY = 3
+)
+
+// wordDelimiters end a double-click word selection, besides whitespace.
+const wordDelimiters = "\"'`()[]{}<>|,;"
+
+type cellPos struct{ x, y int }
+
+// before reports whether p comes before q in reading order.
+func (p cellPos) before(q cellPos) bool {
+	return p.y < q.y || (p.y == q.y && p.x < q.x)
+}
+
+// selection is a mouse selection over the visible content, in content
+// coordinates. The unit (char, word or line) is set by the click count.
+type selection struct {
+	active bool
+	unit   int // 1 char, 2 word, 3 line
+	anchor cellPos
+	head   cellPos
+}
+
+// SelectAt starts a selection at panel-relative coordinates. clicks selects
+// the unit: 1 for characters, 2 for a word and 3 for the whole line. It
+// returns false when the position is outside the content area.
+func (m *Model) SelectAt(x, y, clicks int) bool {
+	pos := cellPos{x - contentOffsetX, y - contentOffsetY}
+	if pos.x < 0 || pos.y < 0 || pos.x >= m.innerWidth || pos.y >= m.innerHeight {
+		return false
+	}
+	if clicks < 1 {
+		clicks = 1
+	}
+	if clicks > 3 {
+		clicks = 3
+	}
+	m.sel = selection{active: true, unit: clicks, anchor: pos, head: pos}
+	return true
+}
+
+// ExtendSelection moves the selection end to panel-relative coordinates
+// while dragging; positions past the content edge are clamped.
+func (m *Model) ExtendSelection(x, y int) {
+	if !m.sel.active {
+		return
+	}
+	m.sel.head = m.clampPos(cellPos{x - contentOffsetX, y - contentOffsetY})
+}
+
+// ClearSelection drops the current selection.
+func (m *Model) ClearSelection() {
+	m.sel = selection{}
+}
+
+// HasSelection reports whether a selection covers at least one character.
+func (m Model) HasSelection() bool {
+	return m.sel.active && (m.sel.unit > 1 || m.sel.anchor != m.sel.head)
+}
+
+// SelectedText returns the selected text with trailing blanks trimmed
+// from each line.
+func (m *Model) SelectedText() string {
+	if !m.HasSelection() {
+		return ""
+	}
+	lines := m.visibleLines()
+	start, end := m.selectionBounds(lines)
+	out := make([]string, 0, end.y-start.y+1)
+	for y := start.y; y <= end.y && y < len(lines); y++ {
+		row := []rune(lines[y])
+		from, to := 0, len(row)
+		if y == start.y {
+			from = min(start.x, len(row))
+		}
+		if y == end.y {
+			to = min(end.x+1, len(row))
+		}
+		if from > to {
+			from = to
+		}
+		out = append(out, strings.TrimRight(string(row[from:to]), " "))
+	}
+	return strings.Join(out, "\n")
+}
+
+// selectionBounds orders the selection ends and expands them to the
+// selection unit.
+func (m *Model) selectionBounds(lines []string) (cellPos, cellPos) {
+	start, end := m.sel.anchor, m.sel.head
+	if end.before(start) {
+		start, end = end, start
+	}
+	switch m.sel.unit {
+	case 2:
+		start.x = wordStart(lineAt(lines, start.y), start.x)
+		end.x = wordEnd(lineAt(lines, end.y), end.x)
+	case 3:
+		start.x = 0
+		end.x = m.innerWidth - 1
+	}
+	return start, end
+}
+
+// selectedMask marks the selected cells of the visible content.
+func (m *Model) selectedMask() func(x, y int) bool {
+	if !m.HasSelection() {
+		return nil
+	}
+	start, end := m.selectionBounds(m.visibleLines())
+	return func(x, y int) bool {
+		p := cellPos{x, y}
+		return !p.before(start) && !end.before(p)
+	}
+}
+
+// highlightLines applies the selection and search matches to plain
+// (scrollback) lines; first is the scrollback index of lines[0].
+func (m *Model) highlightLines(lines []string, first int) []string {
+	mask := m.selectedMask()
+	hits := m.searchMask(lines, false, first)
+	if mask == nil && hits == nil && !m.copy.active {
+		return lines
+	}
+	style := lipgloss.NewStyle().Reverse(true)
+	out := make([]string, len(lines))
+	for y, line := range lines {
+		row := []rune(line)
+		for len(row) < m.innerWidth {
+			row = append(row, ' ')
+		}
+		var b strings.Builder
+		for x, r := range row {
+			switch {
+			case m.copyCursorAt(x, y):
+				b.WriteString(lipgloss.NewStyle().Underline(true).Reverse(mask == nil || !mask(x, y)).Render(string(r)))
+			case mask != nil && mask(x, y):
+				b.WriteString(style.Render(string(r)))
+			case hits != nil && hits(x, y) != hitNone:
+				b.WriteString(searchStyle(hits(x, y)).Render(string(r)))
+			default:
+				b.WriteRune(r)
+			}
+		}
+		out[y] = b.String()
+	}
+	return out
+}
+
+// visibleLines returns the displayed content as plain text, one entry per
+// content row.
+func (m *Model) visibleLines() []string {
+	if m.scrollOffset > 0 {
+		return m.visibleScrollback()
+	}
+	return m.screenLines()
+}
+
+// screenLines returns the emulator screen as plain text, one rune per
+// cell.
+func (m *Model) screenLines() []string {
+	if m.term == nil {
+		return nil
+	}
+	m.term.Lock()
+	defer m.term.Unlock()
+	lines := make([]string, m.innerHeight)
+	for y := 0; y < m.innerHeight; y++ {
+		var b strings.Builder
+		for x := 0; x < m.innerWidth; x++ {
+			ch := m.term.Cell(x, y).Char
+			if ch == 0 {
+				ch = ' '
+			}
+			b.WriteRune(ch)
+		}
+		lines[y] = b.String()
+	}
+	return lines
+}
+
+// visibleScrollback returns the scrollback rows currently in view.
+func (m *Model) visibleScrollback() []string {
+	lines := m.renderScrollLines()
+	total := len(lines)
+	start := total - m.innerHeight - m.scrollOffset
+	if start < 0 {
+		start = 0
+	}
+	end := start + m.innerHeight
+	if end > total {
+		end = total
+	}
+	return lines[start:end]
+}
+
+func (m *Model) clampPos(p cellPos) cellPos {
+	p.x = max(0, min(p.x, m.innerWidth-1))
+	p.y = max(0, min(p.y, m.innerHeight-1))
+	return p
+}
+
+func lineAt(lines []string, y int) []rune {
+	if y < 0 || y >= len(lines) {
+		return nil
+	}
+	return []rune(lines[y])
+}
+
+func isWordRune(r rune) bool {
+	return !unicode.IsSpace(r) && !strings.ContainsRune(wordDelimiters, r)
+}
+
+func wordStart(row []rune, x int) int {
+	if x >= len(row) || !isWordRune(row[x]) {
+		return x
+	}
+	for x > 0 && isWordRune(row[x-1]) {
+		x--
+	}
+	return x
+}
+
+func wordEnd(row []rune, x int) int {
+	if x >= len(row) || !isWordRune(row[x]) {
+		return x
+	}
+	for x+1 < len(row) && isWordRune(row[x+1]) {
+		x++
+	}
+	return x
+}
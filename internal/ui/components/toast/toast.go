@@ -0,0 +1,125 @@
+// Package toast provides stacked, auto-dismissing notifications.
+package toast
+
+import (
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
+)
+
+const (
+	// Timeout is how long an info toast stays up. Errors stay until
+	// dismissed.
+	Timeout = 4 * time.Second
+	// maxVisible caps the stack; older toasts are dropped first.
+	maxVisible = 4
+	// maxWidth caps a toast's width on wide screens.
+	maxWidth = 60
+)
+
+// Toast is a single notification.
+type Toast struct {
+	Text    string
+	IsError bool
+	Created time.Time
+}
+
+// Model is the toast stack. Newest toasts are at the end.
+type Model struct {
+	toasts []Toast
+	width  int
+}
+
+// New creates an empty toast stack.
+func New() Model {
+	return Model{}
+}
+
+// SetWidth sets the available screen width.
+func (m *Model) SetWidth(width int) {
+	m.width = width
+}
+
+// Push queues a toast. A repeat of the newest toast only refreshes it.
+func (m *Model) Push(text string, isError bool) {
+	if text == "" {
+		return
+	}
+	if n := len(m.toasts); n > 0 && m.toasts[n-1].Text == text && m.toasts[n-1].IsError == isError {
+		m.toasts[n-1].Created = time.Now()
+		return
+	}
+	m.toasts = append(m.toasts, Toast{Text: text, IsError: isError, Created: time.Now()})
+	if len(m.toasts) > maxVisible {
+		m.toasts = m.toasts[len(m.toasts)-maxVisible:]
+	}
+}
+
+// Expire drops info toasts older than Timeout.
+func (m *Model) Expire() {
+	now := time.Now()
+	kept := m.toasts[:0]
+	for _, t := range m.toasts {
+		if t.IsError || now.Sub(t.Created) < Timeout {
+			kept = append(kept, t)
+		}
+	}
+	m.toasts = kept
+}
+
+// HasErrors reports whether an error toast is waiting to be acknowledged.
+func (m Model) HasErrors() bool {
+	for _, t := range m.toasts {
+		if t.IsError {
+			return true
+		}
+	}
+	return false
+}
+
+// DismissErrors acknowledges all error toasts.
+func (m *Model) DismissErrors() {
+	kept := m.toasts[:0]
+	for _, t := range m.toasts {
+		if !t.IsError {
+			kept = append(kept, t)
+		}
+	}
+	m.toasts = kept
+}
+
+// Empty reports whether no toasts are shown.
+func (m Model) Empty() bool {
+	return len(m.toasts) == 0
+}
+
+// View renders the stack, oldest on top, right-aligned to the screen width.
+func (m Model) View() string {
+	if len(m.toasts) == 0 {
+		return ""
+	}
+	width := min(maxWidth, m.width-2)
+	if width < 10 {
+		width = 10
+	}
+	rows := make([]string, 0, len(m.toasts))
+	for _, t := range m.toasts {
+		style := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			Padding(0, 1).
+			Foreground(styles.TextCol).
+			Background(styles.SurfaceCol)
+		text := t.Text
+		if t.IsError {
+			style = style.BorderForeground(styles.Danger).Foreground(styles.Danger)
+			text = "✗ " + text + "  (Esc to dismiss)"
+		} else {
+			style = style.BorderForeground(styles.Border)
+		}
+		text = ansi.Truncate(text, width-4, "…")
+		rows = append(rows, style.Render(text))
+	}
+	return lipgloss.JoinVertical(lipgloss.Right, rows...)
+}
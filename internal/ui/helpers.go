@@ -3,9 +3,10 @@ package ui
 import (
 	"fmt"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
-	"github.com/lazyvibe/vibemux/internal/model"
 	"github.com/lazyvibe/vibemux/internal/runtime"
 )
 
@@ -36,15 +37,51 @@ func (a *App) cycleDispatchMode() {
 		a.dispatchMode = DispatchModeSolo
 
 	}
+	a.broadcastLine = ""
 	a.updateFocusStyles()
 }
 
 // broadcastInput sends input to all running sessions.
 func (a *App) broadcastInput(data []byte) {
-	sessions := a.engine.ListSessions()
-	for _, s := range sessions {
-		if s.Status() == model.SessionStatusRunning {
-			s.Write(data)
+	ids := a.broadcastTargets()
+	a.engine.Broadcast(ids, data, runtime.DefaultBroadcastOptions)
+	if len(data) == 1 && data[0] == '\r' {
+		for _, id := range ids {
+			a.recordPrompt(id)
 		}
 	}
 }
+
+// submitPrompt types text into a session and submits it with Enter.
+// The short pause gives the CLI time to process the pasted text first.
+func submitPrompt(session runtime.Session, text string) {
+	_ = runtime.Paste(session, []byte(text), runtime.DefaultPasteOptions)
+	time.Sleep(200 * time.Millisecond)
+	session.Write([]byte("\r"))
+}
+
+// resolvePane resolves a pane reference to a session ID.
+// A reference is a 1-based grid index, a session alias or a project name.
+func (a *App) resolvePane(ref string) (string, bool) {
+	ids := a.gridOrder()
+	if n, err := strconv.Atoi(ref); err == nil {
+		if n >= 1 && n <= len(ids) {
+			return ids[n-1], true
+		}
+		return "", false
+	}
+	for _, id := range ids {
+		if alias := a.sessionAlias(id); alias != "" && strings.EqualFold(alias, ref) {
+			return id, true
+		}
+	}
+	for _, id := range ids {
+		if project := a.projectForSession(id); project != nil && strings.EqualFold(project.DisplayName(), ref) {
+			return id, true
+		}
+		if inst, ok := a.terminals[id]; ok && strings.EqualFold(inst.ProjectName, ref) {
+			return id, true
+		}
+	}
+	return "", false
+}
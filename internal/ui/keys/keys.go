@@ -14,6 +14,8 @@ type KeyMap struct {
 	// Actions
 	Enter      key.Binding
 	Delete     key.Binding
+	Duplicate  key.Binding
+	Launch     key.Binding
 	Add        key.Binding
 	Profiles   key.Binding
 	Help           key.Binding
@@ -21,12 +23,25 @@ type KeyMap struct {
 	DispatchToggle key.Binding
 	Quit           key.Binding
 	Close          key.Binding
+	ForceKill      key.Binding
 
 	// Terminal
 	PaneLeft  key.Binding
 	PaneRight key.Binding
 	PaneUp    key.Binding
 	PaneDown  key.Binding
+	MovePaneLeft  key.Binding
+	MovePaneRight key.Binding
+	JumpPane      key.Binding
+	Clone         key.Binding
+	Interrupt     key.Binding
+	SessionLog    key.Binding
+	CopyMode      key.Binding
+	ExportPane    key.Binding
+	FullScreen    key.Binding
+	Zoom          key.Binding
+	Resize        key.Binding
+	PanelWidth    key.Binding
 	
 	// Chain Mode
 	AssignRoles     key.Binding
@@ -36,6 +51,20 @@ type KeyMap struct {
 	NextTurn       key.Binding
 	AutoTurnToggle key.Binding
 	FilePreview    key.Binding
+	Command        key.Binding
+	Search         key.Binding
+	SearchOlder    key.Binding
+	SearchNewer    key.Binding
+	Recent         key.Binding
+	Layouts        key.Binding
+	Compose        key.Binding
+	Templates      key.Binding
+	Chains         key.Binding
+	Notifications  key.Binding
+	Resources      key.Binding
+	Approvals      key.Binding
+	Diff           key.Binding
+	PlayMacro      key.Binding
 }
 
 // DefaultKeyMap returns the default keyboard shortcuts.
@@ -65,6 +94,14 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("d", "delete"),
 			key.WithHelp("d", "delete"),
 		),
+		Duplicate: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "duplicate project"),
+		),
+		Launch: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "launch with..."),
+		),
 		Add: key.NewBinding(
 			key.WithKeys("a"),
 			key.WithHelp("a", "add project"),
@@ -93,6 +130,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("x"),
 			key.WithHelp("x", "close"),
 		),
+		ForceKill: key.NewBinding(
+			key.WithKeys("X"),
+			key.WithHelp("X", "force kill"),
+		),
 		PaneLeft: key.NewBinding(
 			key.WithKeys("left", "h"),
 			key.WithHelp("←/h", "pane left"),
@@ -109,6 +150,54 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("down", "j"),
 			key.WithHelp("↓/j", "pane down"),
 		),
+		MovePaneLeft: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "move pane left"),
+		),
+		MovePaneRight: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "move pane right"),
+		),
+		Clone: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "clone session"),
+		),
+		Interrupt: key.NewBinding(
+			key.WithKeys("ctrl+c"),
+			key.WithHelp("ctrl+c", "send SIGINT"),
+		),
+		SessionLog: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "open session log"),
+		),
+		CopyMode: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", "copy mode"),
+		),
+		ExportPane: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "export pane output"),
+		),
+		JumpPane: key.NewBinding(
+			key.WithKeys("alt+1", "alt+2", "alt+3", "alt+4", "alt+5", "alt+6", "alt+7", "alt+8", "alt+9"),
+			key.WithHelp("Alt+1-9", "jump to pane"),
+		),
+		FullScreen: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "full-screen grid"),
+		),
+		Zoom: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "zoom pane"),
+		),
+		Resize: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "resize panes"),
+		),
+		PanelWidth: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "project list width"),
+		),
 		AssignRoles: key.NewBinding(
 			key.WithKeys("ctrl+r"),
 			key.WithHelp("Ctrl+R", "assign roles"),
@@ -129,6 +218,62 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("alt+v"),
 			key.WithHelp("Alt+V", "file preview"),
 		),
+		Command: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", "command"),
+		),
+		Search: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "search pane (all panes from list)"),
+		),
+		SearchOlder: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "older match"),
+		),
+		SearchNewer: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "newer match"),
+		),
+		Recent: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "recent projects"),
+		),
+		Layouts: key.NewBinding(
+			key.WithKeys("alt+l"),
+			key.WithHelp("Alt+L", "layouts"),
+		),
+		Compose: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "compose prompt"),
+		),
+		Templates: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "prompt templates"),
+		),
+		Chains: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "chain sessions"),
+		),
+		Notifications: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "notifications"),
+		),
+		Resources: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "resource monitor"),
+		),
+		Approvals: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "approval queue"),
+		),
+		Diff: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "diff since start"),
+		),
+		PlayMacro: key.NewBinding(
+			key.WithKeys("@"),
+			key.WithHelp("@", "replay last macro"),
+		),
 	}
 }
 
@@ -150,8 +295,8 @@ func (k KeyMap) ShortHelp() []key.Binding {
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Tab, k.ShiftTab},
-		{k.Enter, k.Add, k.Delete, k.Close, k.Profiles},
-		{k.ModeToggle, k.Quit, k.PaneLeft, k.PaneRight, k.PaneUp, k.PaneDown},
-		{k.Help},
+		{k.Enter, k.Launch, k.Add, k.Duplicate, k.Delete, k.Close, k.ForceKill, k.Profiles},
+		{k.ModeToggle, k.Quit, k.PaneLeft, k.PaneRight, k.PaneUp, k.PaneDown, k.MovePaneLeft, k.MovePaneRight, k.JumpPane, k.Clone, k.Interrupt, k.SessionLog, k.CopyMode, k.ExportPane, k.FullScreen, k.Zoom, k.Resize, k.PanelWidth},
+		{k.Command, k.Search, k.SearchOlder, k.SearchNewer, k.Recent, k.Layouts, k.Compose, k.Templates, k.Chains, k.Notifications, k.Resources, k.Approvals, k.Diff, k.PlayMacro, k.Help},
 	}
 }
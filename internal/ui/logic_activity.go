@@ -0,0 +1,32 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/lazyvibe/vibemux/internal/model"
+)
+
+// syncActivity refreshes the activity indicators of every pane, so panes
+// that went quiet turn idle.
+func (a *App) syncActivity() {
+	now := time.Now()
+	for id := range a.terminals {
+		a.syncPaneActivity(id, now)
+	}
+}
+
+// syncPaneActivity shows a pane's activity, derived by its output watcher,
+// in its header and tab. Panes without a running session show none.
+func (a *App) syncPaneActivity(id string, now time.Time) {
+	inst, ok := a.terminals[id]
+	if !ok {
+		return
+	}
+	state := model.ActivityNone
+	w := a.outputWatchers[id]
+	if w != nil && inst.Terminal.Status() == model.SessionStatusRunning && !inst.Terminal.IsLaunching() {
+		state = w.Activity(a.lastOutput[id], now)
+	}
+	inst.Terminal.SetActivity(state)
+	a.sessionTabs.SetTabActivity(id, state)
+}
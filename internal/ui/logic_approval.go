@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/ui/components/approvallist"
+)
+
+// handleApproval answers a yes/no prompt the way the profile's rules
+// decided, or queues it when they did not. The queue opens on its own for
+// command prompts when no dialog is open and keys are not going to a pane,
+// so typing cannot answer by accident; otherwise a toast points at it.
+func (a *App) handleApproval(id string, req *approvalRequest) {
+	label := a.paneLabel(id)
+	switch req.decision {
+	case model.ApprovalAllow:
+		a.answerApproval(id, true)
+		return
+	case model.ApprovalDeny:
+		if a.answerApproval(id, false) {
+			a.toasts.Push("Refused "+req.command+" in "+label+": matches "+req.rule, true)
+		}
+		return
+	}
+	a.queueApproval(approvallist.Entry{
+		SessionID: id,
+		Label:     label,
+		Prompt:    req.prompt,
+		Command:   req.command,
+		Guessed:   req.guessed,
+		Context:   req.context,
+		Time:      time.Now(),
+	})
+	if req.command != "" && a.dialogMode == DialogNone && a.inputMode != InputModeTerminal {
+		a.showApprovals()
+		return
+	}
+	if a.dialogMode != DialogApprovals {
+		ask := req.prompt
+		if req.command != "" {
+			ask = "to run " + req.command
+		}
+		a.toasts.Push(label+" asks "+ask+" (A to review)", false)
+	}
+}
+
+// queueApproval adds a request to the approval queue. A pane waits on one
+// prompt at a time, so a new one replaces the pane's previous request.
+func (a *App) queueApproval(e approvallist.Entry) {
+	a.dropApprovals(e.SessionID)
+	a.approvals = append(a.approvals, e)
+	a.syncApprovalList()
+}
+
+// dropApprovals removes the pane's requests from the queue, once they are
+// answered or the pane's session is gone.
+func (a *App) dropApprovals(sessionID string) {
+	kept := a.approvals[:0]
+	for _, e := range a.approvals {
+		if e.SessionID != sessionID {
+			kept = append(kept, e)
+		}
+	}
+	if len(kept) == len(a.approvals) {
+		return
+	}
+	a.approvals = kept
+	a.syncApprovalList()
+}
+
+// rekeyApprovals points the requests of a moved session at its new ID.
+func (a *App) rekeyApprovals(oldID, newID, label string) {
+	for i, e := range a.approvals {
+		if e.SessionID == oldID {
+			a.approvals[i].SessionID = newID
+			a.approvals[i].Label = label
+		}
+	}
+	a.syncApprovalList()
+}
+
+// syncApprovalList refreshes the open approval queue.
+func (a *App) syncApprovalList() {
+	if a.dialogMode == DialogApprovals {
+		a.approvalList.SetEntries(append([]approvallist.Entry(nil), a.approvals...))
+	}
+}
+
+// showApprovals opens the approval queue.
+func (a *App) showApprovals() {
+	a.approvalList.SetSize(a.width, a.height)
+	a.approvalList.Open(append([]approvallist.Entry(nil), a.approvals...))
+	a.dialogMode = DialogApprovals
+}
+
+// approvalListAction carries out what the approval queue asked for.
+func (a *App) approvalListAction() tea.Cmd {
+	e, ok := a.approvalList.Selected()
+	switch a.approvalList.Action() {
+	case approvallist.ActionClose:
+		a.hideDialog()
+	case approvallist.ActionJump:
+		if !a.hasPane(e.SessionID) {
+			a.toasts.Push("That pane is closed", true)
+			break
+		}
+		a.hideDialog()
+		a.focusPane(e.SessionID)
+	case approvallist.ActionApprove, approvallist.ActionDeny, approvallist.ActionAlways, approvallist.ActionNever:
+		if !ok {
+			break
+		}
+		action := a.approvalList.Action()
+		approve := action == approvallist.ActionApprove || action == approvallist.ActionAlways
+		if !a.answerApproval(e.SessionID, approve) {
+			a.toasts.Push(e.Label+" is no longer running", true)
+			a.dropApprovals(e.SessionID)
+		}
+		if len(a.approvals) == 0 {
+			a.hideDialog()
+		}
+		switch action {
+		case approvallist.ActionAlways:
+			return a.addApprovalRule(e.SessionID, e.Command, true)
+		case approvallist.ActionNever:
+			return a.addApprovalRule(e.SessionID, e.Command, false)
+		}
+	}
+	return nil
+}
+
+// answerApproval types yes or no into the pane's prompt. It reports
+// whether the session was still there to answer.
+func (a *App) answerApproval(id string, approve bool) bool {
+	session, ok := a.engine.GetSession(id)
+	if !ok || session.Status() != model.SessionStatusRunning {
+		return false
+	}
+	reply := "n\r"
+	if approve {
+		reply = "y\r"
+	}
+	session.Write([]byte(reply))
+	a.acknowledgeInput(id)
+	return true
+}
+
+// addApprovalRule adds a rule matching exactly command to the allow or
+// deny list of the session's profile and saves it. Running sessions keep
+// going; the rule applies to their next prompt.
+func (a *App) addApprovalRule(sessionID, command string, allow bool) tea.Cmd {
+	profile := a.profileForSession(sessionID)
+	if profile == nil {
+		return nil
+	}
+	updated := *profile
+	updated.Approval = profile.Approval.Clone()
+	list, verb := &updated.Approval.Deny, "refuses"
+	if allow {
+		list, verb = &updated.Approval.Allow, "approves"
+	}
+	*list = append(*list, model.ApprovalPattern(command))
+	a.upsertProfileInMemory(updated)
+	if updated.Shared {
+		a.toasts.Push(updated.Name+" "+verb+" "+command+" until restart (shared profiles are read-only)", false)
+		return nil
+	}
+	a.toasts.Push(updated.Name+" now "+verb+" "+command, false)
+	return func() tea.Msg {
+		if err := a.store.UpdateProfile(a.ctx, &updated); err != nil {
+			return ErrorMsg{Err: err}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/model"
+)
+
+// batchLaunch starts a list of projects one after another and reports a
+// summary once every launch has started or failed.
+type batchLaunch struct {
+	scope    string   // workspace name, or "" for all projects
+	layout   string   // saved layout being restored, if any
+	queue    []string // project IDs still to launch
+	total    int
+	waiting  map[string]bool // launched sessions without a result yet
+	started  int
+	running  int // skipped: already running
+	noPane   int // skipped: the grid is full
+	failures []string
+}
+
+// startAllCommand handles "startall [workspace]": it launches the sessions
+// of every project (or a workspace's projects) that is not running yet,
+// batchStagger apart.
+func (a *App) startAllCommand(args []string) tea.Cmd {
+	if a.batch != nil {
+		a.toasts.Push(fmt.Sprintf("Batch start in progress (%d left); stopall cancels it", len(a.batch.queue)), true)
+		return nil
+	}
+	scope := strings.Join(args, " ")
+	var ids []string
+	for _, p := range a.projects {
+		if scope == "" || strings.EqualFold(p.Workspace, scope) {
+			ids = append(ids, p.ID)
+		}
+	}
+	if len(ids) == 0 {
+		if scope == "" {
+			a.toasts.Push("No projects to start", true)
+		} else {
+			a.toasts.Push("No projects in workspace "+scope, true)
+		}
+		return nil
+	}
+	a.batch = &batchLaunch{scope: scope, queue: ids, total: len(ids), waiting: make(map[string]bool)}
+	return a.advanceBatch()
+}
+
+// advanceBatch launches the next queued project and schedules the one
+// after it. Projects that already run, or have no free pane, are skipped
+// without waiting.
+func (a *App) advanceBatch() tea.Cmd {
+	b := a.batch
+	if b == nil {
+		return nil
+	}
+	for len(b.queue) > 0 {
+		project := a.findProjectByID(b.queue[0])
+		b.queue = b.queue[1:]
+		if project == nil {
+			continue
+		}
+		if session, ok := a.engine.GetSession(project.ID); ok {
+			if session.Status() == model.SessionStatusRunning {
+				b.running++
+				continue
+			}
+			// Drop the exited session so openProject launches a new one.
+			_ = a.engine.CloseSession(project.ID)
+		}
+		if !a.canOpenPane(project.ID) {
+			b.noPane++
+			continue
+		}
+		b.waiting[project.ID] = true
+		launched := b.total - len(b.queue)
+		a.toasts.Push(fmt.Sprintf("Starting %d/%d: %s", launched, b.total, project.DisplayName()), false)
+		cmd := a.openProject(project, launchOptions{})
+		if len(b.queue) == 0 {
+			return cmd
+		}
+		return tea.Batch(cmd, tea.Tick(batchStagger, func(time.Time) tea.Msg {
+			return batchLaunchTickMsg{batch: b}
+		}))
+	}
+	a.finishBatch()
+	return nil
+}
+
+// noteBatchResult records a launch result for the running batch.
+func (a *App) noteBatchResult(sessionID string, err error) {
+	b := a.batch
+	if b == nil || !b.waiting[sessionID] {
+		return
+	}
+	delete(b.waiting, sessionID)
+	if err != nil {
+		name := sessionID
+		if project := a.findProjectByID(sessionID); project != nil {
+			name = project.DisplayName()
+		}
+		b.failures = append(b.failures, name+": "+err.Error())
+	} else {
+		b.started++
+	}
+	a.finishBatch()
+}
+
+// finishBatch reports the summary once nothing is queued or pending.
+func (a *App) finishBatch() {
+	b := a.batch
+	if b == nil || len(b.queue) > 0 || len(b.waiting) > 0 {
+		return
+	}
+	a.batch = nil
+	a.toasts.Push(b.summary(), len(b.failures) > 0)
+}
+
+// summary is the one-line result of a batch start.
+func (b *batchLaunch) summary() string {
+	scope := "all projects"
+	if b.layout != "" {
+		scope = "layout " + b.layout
+	} else if b.scope != "" {
+		scope = "workspace " + b.scope
+	}
+	parts := []string{fmt.Sprintf("Started %d of %d (%s)", b.started, b.total, scope)}
+	if b.running > 0 {
+		parts = append(parts, fmt.Sprintf("%d already running", b.running))
+	}
+	if b.noPane > 0 {
+		parts = append(parts, fmt.Sprintf("%d skipped, grid full", b.noPane))
+	}
+	if len(b.failures) > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed — %s", len(b.failures), strings.Join(b.failures, "; ")))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// stopAllCommand handles "stopall [workspace]": it cancels a batch start
+// and closes every running session (or a workspace's sessions) with its
+// pane.
+func (a *App) stopAllCommand(args []string) tea.Cmd {
+	scope := strings.Join(args, " ")
+	cancelled := 0
+	if a.batch != nil && (scope == "" || strings.EqualFold(a.batch.scope, scope)) {
+		cancelled = len(a.batch.queue)
+		a.batch = nil
+	}
+	stopped := 0
+	seen := make(map[string]bool)
+	for _, p := range a.projects {
+		if scope != "" && !strings.EqualFold(p.Workspace, scope) {
+			continue
+		}
+		for _, id := range a.sessionsOfProject(p.ID) {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			if session, ok := a.engine.GetSession(id); ok && session.Status() == model.SessionStatusRunning {
+				stopped++
+			}
+		}
+		a.closeProjectSessions(p.ID)
+	}
+	msg := fmt.Sprintf("Stopped %d session(s)", stopped)
+	if scope != "" {
+		msg += " in workspace " + scope
+	}
+	if cancelled > 0 {
+		msg += fmt.Sprintf("; %d queued launch(es) cancelled", cancelled)
+	}
+	a.toasts.Push(msg, false)
+	return nil
+}
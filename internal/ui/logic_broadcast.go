@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lazyvibe/vibemux/internal/model"
+)
+
+// Choices of the broadcast confirmation, in display order.
+const (
+	broadcastSend = iota
+	broadcastSendAlways
+)
+
+// broadcastPreviewLimit caps how much of the payload the preview quotes.
+const broadcastPreviewLimit = 300
+
+// pendingBroadcast is a write to several panes waiting for confirmation.
+type pendingBroadcast struct {
+	send func(a *App) tea.Cmd
+}
+
+// broadcastTargets returns the running panes a broadcast can write to.
+func (a *App) broadcastTargets() []string {
+	var ids []string
+	for _, s := range a.engine.ListSessions() {
+		if s.Status() == model.SessionStatusRunning && !lockedByRemote(s) {
+			ids = append(ids, s.ID())
+		}
+	}
+	return ids
+}
+
+// confirmBroadcast shows the target panes and the exact bytes before send
+// writes them to more than one pane. Single-pane writes, and all writes
+// after "Send, don't ask again", go through directly.
+func (a *App) confirmBroadcast(label string, targets []string, data string, send func(a *App) tea.Cmd) tea.Cmd {
+	if len(targets) <= 1 || a.broadcastTrusted {
+		return send(a)
+	}
+	names := make([]string, 0, len(targets))
+	for _, id := range targets {
+		names = append(names, a.paneLabel(id))
+	}
+	quoted := strconv.QuoteToGraphic(data)
+	if len(quoted) > broadcastPreviewLimit {
+		quoted = ansi.Truncate(quoted, broadcastPreviewLimit, "…")
+	}
+	msg := fmt.Sprintf("%s to %d panes: %s\n\n%s (%d bytes)",
+		capitalize(label), len(targets), strings.Join(names, ", "), quoted, len(data))
+
+	a.pendingBroadcast = &pendingBroadcast{send: send}
+	a.confirm.SetSize(a.width, a.height)
+	a.confirm.Open("Confirm Broadcast", msg, "Send", "Always send", "Cancel")
+	a.dialogMode = DialogConfirm
+	return nil
+}
+
+// resolveBroadcast acts on the broadcast confirmation choice.
+func (a *App) resolveBroadcast(choice int) tea.Cmd {
+	pending := a.pendingBroadcast
+	a.pendingBroadcast = nil
+	switch choice {
+	case broadcastSendAlways:
+		a.broadcastTrusted = true
+		a.toasts.Push("Broadcasts will no longer ask until restart", false)
+		fallthrough
+	case broadcastSend:
+		return pending.send(a)
+	}
+	return nil
+}
+
+// submitBroadcastLine asks before Enter submits what was typed in
+// broadcast mode to every pane.
+func (a *App) submitBroadcastLine(enter []byte) tea.Cmd {
+	line := a.broadcastLine
+	a.broadcastLine = ""
+	return a.confirmBroadcast("submit", a.broadcastTargets(), line+string(enter), func(a *App) tea.Cmd {
+		a.broadcastInput(enter)
+		return nil
+	})
+}
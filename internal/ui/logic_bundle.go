@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/app"
+	"github.com/lazyvibe/vibemux/pkg/utils"
+)
+
+// exportConfig writes the configuration bundle with secrets masked.
+// Usage: export-config [file]
+func (a *App) exportConfig(args []string) tea.Cmd {
+	if a.configDir == "" {
+		a.toasts.Push("Export needs a config directory", true)
+		return nil
+	}
+	file := filepath.Join(a.configDir, "exports", "vibemux-config.tar.gz")
+	if len(args) > 0 {
+		file = utils.ExpandPath(strings.Join(args, " "))
+	}
+	configDir := a.configDir
+	return func() tea.Msg {
+		if err := a.store.Snapshot(); err != nil {
+			return ErrorMsg{Err: err}
+		}
+		result, err := app.ExportBundle(configDir, file)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+		text := fmt.Sprintf("Config exported to %s (%d files", file, result.Files)
+		if result.Secrets > 0 {
+			text += fmt.Sprintf(", %d secrets masked", result.Secrets)
+		}
+		return StatusMsg{Text: text + ")"}
+	}
+}
+
+// importConfig replaces the configuration with a bundle and reloads it.
+// Usage: import-config <file>
+func (a *App) importConfig(args []string) tea.Cmd {
+	if len(args) == 0 {
+		a.toasts.Push("Usage: import-config <file>", true)
+		return nil
+	}
+	if a.configDir == "" || a.config == nil {
+		a.toasts.Push("Import needs a config directory", true)
+		return nil
+	}
+	file := utils.ExpandPath(strings.Join(args, " "))
+	result, err := app.ImportBundle(a.configDir, file)
+	if err != nil {
+		a.toasts.Push("Import failed: "+err.Error(), true)
+		return nil
+	}
+	if err := a.store.Reload(); err != nil {
+		a.toasts.Push("Import failed to reload projects: "+err.Error(), true)
+		return nil
+	}
+	if cfg, err := app.LoadConfig(a.configDir); err == nil {
+		*a.config = *cfg
+	}
+
+	text := fmt.Sprintf("Imported %d files; previous config kept as .bak. Grid and dashboard settings apply after restart", result.Files)
+	a.toasts.Push(text, false)
+	if result.Secrets > 0 {
+		a.toasts.Push(fmt.Sprintf("%d masked secrets were not restored; re-enter them in the profile dialog", result.Secrets), true)
+	}
+	return tea.Batch(a.loadProjects(), a.loadProfiles(), a.loadTemplates(), a.loadShared())
+}
@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/runtime"
+)
+
+// recoverChainContext reopens the most recent chain file so a restart
+// continues the previous chain, creating a fresh one when there is none.
+func recoverChainContext(configDir string) *runtime.ChainContext {
+	dir := filepath.Join(configDir, "chain")
+	if ctx, err := runtime.LatestChainContext(dir); err == nil {
+		return ctx
+	}
+	id := fmt.Sprintf("%d", time.Now().Unix())
+	ctx, _ := runtime.NewChainContext(id, "Chain Session "+id, dir)
+	return ctx
+}
+
+// autosaveChain writes the chain context once its oldest unsaved change is
+// older than the configured interval.
+func (a *App) autosaveChain() {
+	if a.chainContext == nil || a.config == nil || a.config.ChainAutosaveSeconds <= 0 {
+		return
+	}
+	since := a.chainContext.DirtySince()
+	if since.IsZero() || time.Since(since) < time.Duration(a.config.ChainAutosaveSeconds)*time.Second {
+		return
+	}
+	if err := a.chainContext.Save(); err != nil && !a.chainSaveFailed {
+		// Report once; the next tick retries silently.
+		a.chainSaveFailed = true
+		a.toasts.Push("Chain autosave failed: "+err.Error(), true)
+		return
+	}
+	a.chainSaveFailed = false
+}
+
+// flushChain saves unsaved chain changes before quitting.
+func (a *App) flushChain() {
+	if a.chainContext != nil && a.chainContext.Dirty() {
+		_ = a.chainContext.Save()
+	}
+}
+
+// chainCompactOptions returns the compaction settings from the config.
+func (a *App) chainCompactOptions() runtime.CompactOptions {
+	if a.config == nil {
+		return runtime.CompactOptions{}
+	}
+	return runtime.CompactOptions{
+		MaxChars:   a.config.ChainMaxChars,
+		KeepLast:   a.config.ChainKeepLast,
+		EntryChars: a.config.ChainEntryChars,
+	}
+}
+
+// injectChain writes the chain context to a pane, submitting it when submit
+// is set. When the chain is too long and a summarizer pane is configured,
+// older entries that no summary covers yet are summarized there first.
+func (a *App) injectChain(targetID string, submit bool) tea.Cmd {
+	ctx := a.chainContext
+	opts := a.chainCompactOptions()
+	if _, compacted := ctx.FormatCompact(opts); compacted && a.config.ChainSummarizer != "" {
+		older, covers := ctx.OlderEntries(opts.KeepLast)
+		if covers > ctx.SummaryCovers {
+			id, ok := a.resolvePane(a.config.ChainSummarizer)
+			if !ok {
+				a.toasts.Push("Chain summarizer not found: "+a.config.ChainSummarizer+" (truncating instead)", true)
+			} else if cmd := a.startSummaryPass(id, fmt.Sprintf("%d older chain entries", covers),
+				runtime.ChainSummaryRequest+"\n\n"+older, func(a *App, summary string) tea.Cmd {
+					if summary != "" && a.chainContext == ctx {
+						ctx.SetSummary(summary, covers)
+					}
+					return a.writeChain(targetID, submit)
+				}); cmd != nil {
+				return cmd
+			}
+		}
+	}
+	return a.writeChain(targetID, submit)
+}
+
+// writeChain pastes the (compacted) chain context into a pane and submits
+// it when submit is set.
+func (a *App) writeChain(targetID string, submit bool) tea.Cmd {
+	if a.chainContext == nil {
+		return nil
+	}
+	prompt, compacted := a.chainContext.FormatCompact(a.chainCompactOptions())
+	sess, ok := a.engine.GetSession(targetID)
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := runtime.Paste(sess, []byte(prompt), runtime.DefaultPasteOptions); err != nil {
+			return StatusMsg{Text: "Chain injection failed: " + err.Error(), IsError: true}
+		}
+		if submit {
+			time.Sleep(200 * time.Millisecond) // Let the agent take the paste
+			sess.Write([]byte("\r"))
+		}
+		if compacted {
+			return StatusMsg{Text: fmt.Sprintf("Chain context injected (compacted to %d chars)", len(prompt))}
+		}
+		return StatusMsg{Text: "Chain context injected"}
+	}
+}
+
+// appendConclusion adds an extracted conclusion to the chain. Conclusions
+// longer than the configured limit are first summarized by the conclusion
+// summarizer pane, if one is set; the original is kept when that fails.
+func (a *App) appendConclusion(agent, conclusion string) tea.Cmd {
+	ctx := a.chainContext
+	add := func(a *App, text string) tea.Cmd {
+		ctx.AppendConclusion(agent, text)
+		a.toasts.Push("Conclusion added to chain", false)
+		return a.recordChainEntry(ctx.SessionID, agent, text)
+	}
+	if a.config == nil || a.config.ConclusionSummarizer == "" || a.config.ConclusionMaxChars <= 0 ||
+		utf8.RuneCountInString(conclusion) <= a.config.ConclusionMaxChars {
+		return add(a, conclusion)
+	}
+	id, ok := a.resolvePane(a.config.ConclusionSummarizer)
+	if !ok {
+		a.toasts.Push("Conclusion summarizer not found: "+a.config.ConclusionSummarizer, true)
+		return add(a, conclusion)
+	}
+	cmd := a.startSummaryPass(id, "the conclusion from "+agent, runtime.ConclusionSummaryRequest+"\n\n"+conclusion,
+		func(a *App, summary string) tea.Cmd {
+			if summary == "" {
+				summary = conclusion
+			}
+			return add(a, summary)
+		})
+	if cmd == nil {
+		return add(a, conclusion)
+	}
+	return cmd
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/runtime"
+	"github.com/lazyvibe/vibemux/internal/ui/components/chainlist"
+)
+
+// chainDir is where chain context files are kept.
+func (a *App) chainDir() string {
+	return filepath.Join(a.configDir, "chain")
+}
+
+// chainCommand handles "chain new [task]"; "chain" and "chains" open the
+// chain browser.
+func (a *App) chainCommand(args []string) tea.Cmd {
+	if len(args) > 0 && strings.EqualFold(args[0], "new") {
+		a.newChain(strings.Join(args[1:], " "))
+		return nil
+	}
+	a.showChains()
+	return nil
+}
+
+// showChains opens the chain browser on the saved chain files.
+func (a *App) showChains() {
+	a.flushChain()
+	chains, err := runtime.ListChainContexts(a.chainDir())
+	if err != nil {
+		a.toasts.Push("Failed to list chains: "+err.Error(), true)
+		return
+	}
+	a.chainList.SetSize(a.width, a.height)
+	a.chainList.Open(chains, a.currentChainPath())
+	a.dialogMode = DialogChains
+}
+
+// currentChainPath returns the file of the chain in use, if any.
+func (a *App) currentChainPath() string {
+	if a.chainContext == nil {
+		return ""
+	}
+	return a.chainContext.Path()
+}
+
+// chainListAction carries out what the chain browser asked for.
+func (a *App) chainListAction() tea.Cmd {
+	switch a.chainList.Action() {
+	case chainlist.ActionClose:
+		a.hideDialog()
+	case chainlist.ActionResume:
+		if info, ok := a.chainList.Selected(); ok {
+			a.hideDialog()
+			a.resumeChain(info)
+		}
+	case chainlist.ActionNew:
+		a.hideDialog()
+		a.newChain("")
+	case chainlist.ActionDelete:
+		if info, ok := a.chainList.Selected(); ok {
+			a.deleteChain(info)
+		}
+	}
+	return nil
+}
+
+// resumeChain makes a saved chain the current one. Sessions started from
+// now on get its context once they are ready.
+func (a *App) resumeChain(info runtime.ChainInfo) {
+	if info.Path == a.currentChainPath() {
+		a.chainResumed = true
+		a.toasts.Push("Already using this chain; new sessions get its context", false)
+		return
+	}
+	ctx, err := runtime.LoadChainContext(info.Path)
+	if err != nil {
+		a.toasts.Push("Failed to load chain: "+err.Error(), true)
+		return
+	}
+	a.flushChain()
+	a.chainContext = ctx
+	a.chainResumed = true
+	a.chainSaveFailed = false
+	a.toasts.Push(fmt.Sprintf("Resumed chain %q (%d entries); new sessions get its context", ctx.Task, info.Entries), false)
+}
+
+// newChain saves the current chain and starts an empty one.
+func (a *App) newChain(task string) {
+	id := fmt.Sprintf("%d", time.Now().Unix())
+	if task == "" {
+		task = "Chain Session " + id
+	}
+	ctx, err := runtime.NewChainContext(id, task, a.chainDir())
+	if err != nil {
+		a.toasts.Push("Failed to create chain: "+err.Error(), true)
+		return
+	}
+	a.flushChain()
+	_ = ctx.Save()
+	a.chainContext = ctx
+	a.chainResumed = false
+	a.chainSaveFailed = false
+	a.toasts.Push("Started a new chain", false)
+}
+
+// deleteChain removes a saved chain file; the chain in use is kept.
+func (a *App) deleteChain(info runtime.ChainInfo) {
+	if info.Path == a.currentChainPath() {
+		a.toasts.Push("Cannot delete the chain in use (start a new one first)", true)
+		return
+	}
+	if err := os.Remove(info.Path); err != nil {
+		a.toasts.Push("Failed to delete chain: "+err.Error(), true)
+		return
+	}
+	chains, _ := runtime.ListChainContexts(a.chainDir())
+	a.chainList.SetChains(chains, a.currentChainPath())
+	a.toasts.Push("Chain deleted", false)
+}
+
+// resumedChainPrompt returns the chain context to send to a new session
+// after a chain was resumed, or "" when there is nothing to send.
+func (a *App) resumedChainPrompt() string {
+	if !a.chainResumed || a.chainContext == nil || len(a.chainContext.Chain) == 0 {
+		return ""
+	}
+	prompt, _ := a.chainContext.FormatCompact(a.chainCompactOptions())
+	return prompt
+}
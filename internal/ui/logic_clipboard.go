@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"unicode/utf8"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// copyToClipboard copies text to the system clipboard. Without one (SSH,
+// headless hosts) it falls back to an OSC 52 request to the outer terminal.
+func copyToClipboard(text string) tea.Cmd {
+	return func() tea.Msg {
+		if err := clipboard.WriteAll(text); err != nil {
+			if _, err := osc52.New(text).WriteTo(os.Stderr); err != nil {
+				return StatusMsg{Text: "Copy failed: " + err.Error(), IsError: true}
+			}
+		}
+		return StatusMsg{Text: fmt.Sprintf("Copied %d characters", utf8.RuneCountInString(text))}
+	}
+}
+
+// enterCopyMode starts keyboard selection in the active pane.
+func (a *App) enterCopyMode() {
+	inst, ok := a.terminals[a.activeTermID]
+	if !ok {
+		return
+	}
+	inst.Terminal.EnterCopyMode()
+	a.toasts.Push("Copy mode: move with hjkl, v/V to select, y to copy, Esc to leave", false)
+}
+
+// copyModeKey feeds a key to the active pane's copy mode and copies what
+// it yanks.
+func (a *App) copyModeKey(inst *TerminalInstance, msg tea.KeyMsg) tea.Cmd {
+	text, done := inst.Terminal.CopyModeKey(msg.String())
+	if !done || text == "" {
+		return nil
+	}
+	return copyToClipboard(text)
+}
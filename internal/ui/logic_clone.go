@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/model"
+)
+
+// cloneSession starts a second, independent session for the active pane's
+// project and profile in a new pane.
+func (a *App) cloneSession() tea.Cmd {
+	project := a.projectForSession(a.activeTermID)
+	if project == nil {
+		a.toasts.Push("No active session to clone", true)
+		return nil
+	}
+	sessionID := a.engine.NextSessionID(project.ID)
+	if !a.canOpenPane(sessionID) {
+		a.toasts.Push("Max panes reached for grid layout", true)
+		return nil
+	}
+	name := sessionLabel(project, sessionID, launchOptions{})
+	a.getOrCreateTerminal(sessionID, name)
+	a.sessionTabs.AddTab(sessionID, name, model.SessionStatusIdle)
+	a.setActivePaneByProject(sessionID)
+	a.SetSize(a.width, a.height)
+	a.toasts.Push("Cloning "+project.DisplayName()+"...", false)
+	return a.launchSession(project, sessionID, launchOptions{})
+}
+
+// duplicateProject saves a copy of project under a new ID with a
+// "(copy)" suffix, e.g. to keep per-branch or per-experiment settings.
+func (a *App) duplicateProject(project *model.Project) tea.Cmd {
+	dup := project.Duplicate(a.duplicateName(project.DisplayName()))
+	return func() tea.Msg {
+		if err := a.store.Create(a.ctx, dup); err != nil {
+			return ErrorMsg{Err: err}
+		}
+		return ProjectCreatedMsg{Project: *dup}
+	}
+}
+
+// duplicateName picks "name (copy)", "name (copy 2)", ... avoiding names
+// already in use.
+func (a *App) duplicateName(name string) string {
+	taken := make(map[string]bool, len(a.projects))
+	for i := range a.projects {
+		taken[a.projects[i].DisplayName()] = true
+	}
+	candidate := name + " (copy)"
+	for n := 2; taken[candidate]; n++ {
+		candidate = fmt.Sprintf("%s (copy %d)", name, n)
+	}
+	return candidate
+}
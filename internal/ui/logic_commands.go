@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/model"
+)
+
+// commandNames are the commands offered by completion in the command
+// dialog. Arguments are completed separately from projects, panes and
+// profiles.
+var commandNames = []string{
+	"alias", "approvals", "broadcast", "chains", "clone", "close", "compose", "dashboard",
+	"debug", "diff", "export", "export-config", "export-layout", "export-profiles",
+	"extract", "grid", "history", "import-config", "import-profiles",
+	"initprompt", "interrupt", "kill", "layouts", "lock", "log", "macro",
+	"minutes", "notifications", "pipeline", "profile", "quit", "rehome",
+	"report", "resize", "resync", "role", "search", "secrets", "send",
+	"sendall", "setup", "shared", "slot", "snippet", "start", "startall",
+	"stopall", "tabs", "templates", "tmux", "top", "unlock", "workspace",
+	"worktree", "zoom",
+}
+
+// commandOptions lists completions for the command dialog: every command,
+// then the commands that take a project, pane or profile with each name
+// filled in.
+func (a *App) commandOptions() []string {
+	options := append([]string(nil), commandNames...)
+	for i := range a.projects {
+		options = append(options, "start "+a.projects[i].DisplayName())
+	}
+	for _, id := range a.gridOrder() {
+		label := a.paneLabel(id)
+		options = append(options, "close "+label, "kill "+label, "interrupt "+label)
+	}
+	names := make([]string, 0, len(a.profiles))
+	for i := range a.profiles {
+		names = append(names, a.profiles[i].Name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		options = append(options, "profile use "+name)
+	}
+	return append(options, "grid 2x2", "grid 2x3", "grid 3x3", "grid auto",
+		"worktree on", "worktree off", "worktree merge")
+}
+
+// startCommand opens a project by name or ID and starts its session.
+// Usage: start <project>
+func (a *App) startCommand(args []string) tea.Cmd {
+	if len(args) == 0 {
+		a.toasts.Push("Usage: start <project>", true)
+		return nil
+	}
+	ref := strings.Join(args, " ")
+	project := a.findProjectByRef(ref)
+	if project == nil {
+		a.toasts.Push("Unknown project: "+ref, true)
+		return nil
+	}
+	if session, ok := a.engine.GetSession(project.ID); ok && session.Status() != model.SessionStatusRunning {
+		// Drop the exited session so openProject launches a new one.
+		_ = a.engine.CloseSession(project.ID)
+	}
+	return a.openProject(project, launchOptions{})
+}
+
+// closeCommand closes a pane and stops its session: the active pane, or
+// the one named in args.
+// Usage: close [pane]
+func (a *App) closeCommand(args []string) tea.Cmd {
+	id := a.activeTermID
+	if len(args) > 0 {
+		var ok bool
+		if id, ok = a.resolvePane(strings.Join(args, " ")); !ok {
+			a.toasts.Push("Unknown pane: "+strings.Join(args, " "), true)
+			return nil
+		}
+	}
+	if id == "" {
+		a.toasts.Push("No pane to close", true)
+		return nil
+	}
+	label := a.paneLabel(id)
+	a.closeSession(id)
+	a.toasts.Push("Closed "+label, false)
+	return nil
+}
+
+// gridCommand sets the grid size like the settings dialog does.
+// Usage: grid <RxC|4|6|9|auto>
+func (a *App) gridCommand(args []string) tea.Cmd {
+	if len(args) == 0 {
+		a.toasts.Push("Usage: grid <2x2|2x3|3x3|auto>", true)
+		return nil
+	}
+	if strings.EqualFold(args[0], "auto") {
+		if err := a.setGridAuto(); err != nil {
+			a.toasts.Push("Error saving config: "+err.Error(), true)
+			return nil
+		}
+		a.toasts.Push("Grid set to auto", false)
+		return nil
+	}
+	rows, cols, err := parseGridSetting(args[0])
+	if err == nil {
+		err = a.updateGridSettings(rows, cols)
+	}
+	if err != nil {
+		a.toasts.Push("Grid not changed: "+err.Error(), true)
+		return nil
+	}
+	a.toasts.Push(fmt.Sprintf("Grid set to %dx%d", rows, cols), false)
+	return nil
+}
+
+// profileCommand manages the profile of the active pane's project (or the
+// selected project when the project list has focus).
+// Usage: profile [use <name>]
+func (a *App) profileCommand(args []string) tea.Cmd {
+	project := a.projectForSession(a.activeTermID)
+	if a.focus == FocusProjects || project == nil {
+		project = a.projectList.SelectedProject()
+	}
+	if project == nil {
+		a.toasts.Push("No project selected", true)
+		return nil
+	}
+	if len(args) == 0 {
+		current := "none"
+		if profile := a.profileForProject(project); profile != nil {
+			current = profile.Name
+		}
+		a.toasts.Push(project.DisplayName()+" uses profile "+current, false)
+		return nil
+	}
+	if !strings.EqualFold(args[0], "use") || len(args) < 2 {
+		a.toasts.Push("Usage: profile use <name>", true)
+		return nil
+	}
+	profileID, err := a.resolveProfileID(strings.Join(args[1:], " "))
+	if err != nil {
+		a.toasts.Push(err.Error(), true)
+		return nil
+	}
+	updated := *project
+	updated.ProfileID = profileID
+	name := profileID
+	if profile := a.findProfileByID(profileID); profile != nil {
+		name = profile.Name
+	}
+	return func() tea.Msg {
+		if err := a.store.Update(a.ctx, &updated); err != nil {
+			return ErrorMsg{Err: err}
+		}
+		status := fmt.Sprintf("%s now uses profile %s; restart its sessions to apply", updated.DisplayName(), name)
+		return ProjectUpdatedMsg{Project: updated, Status: status}
+	}
+}
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// compactLayout reports whether the window is below the grid thresholds.
+// The UI then shows either the project list or a tab strip with the active
+// pane, each using the full window.
+func (a App) compactLayout() bool {
+	return a.width < minAppWidth || a.height < minAppHeight
+}
+
+// compactShowsProjects reports whether the compact layout shows the project
+// list instead of the active pane.
+func (a App) compactShowsProjects() bool {
+	return a.focus == FocusProjects || a.activeTermID == ""
+}
+
+// setCompactSize sizes components for the compact layout. Every pane gets
+// the full area below the tab strip, so switching tabs needs no resize.
+func (a *App) setCompactSize(width, height int) {
+	contentHeight := height - 1
+	a.projectList.SetCompact(false)
+	a.projectList.SetSize(width, contentHeight)
+	a.sessionTabs.SetWidth(width)
+	a.statusBar.SetWidth(width)
+	a.toasts.SetWidth(width)
+
+	for _, id := range a.gridOrder() {
+		inst, ok := a.terminals[id]
+		if !ok {
+			continue
+		}
+		inst.Terminal.SetSize(width, contentHeight-1)
+		a.resizeSessionPTY(id, inst)
+	}
+
+	a.addDialog.SetSize(width, height)
+	a.profileDialog.SetSize(width, height)
+	a.settingsDialog.SetSize(width, height)
+	a.commandDialog.SetSize(width, height)
+	a.wizard.SetSize(width, height)
+	pmWidth, pmHeight := a.profileManagerSize()
+	a.profileList.SetSize(pmWidth, pmHeight)
+	a.updateFocusStyles()
+}
+
+// renderCompact renders the compact layout body (without the status bar).
+func (a App) renderCompact() string {
+	if a.compactShowsProjects() {
+		return a.projectList.View()
+	}
+	contentHeight := a.height - 1
+	pane := a.renderEmptyPane(a.width, contentHeight-1, a.focus == FocusTerminal)
+	if inst, ok := a.terminals[a.activeTermID]; ok {
+		pane = inst.Terminal.View()
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, a.sessionTabs.CompactView(), pane)
+}
+
+// navigateCompact steps through panes as tabs: left/up goes to the previous
+// pane (or the project list from the first), right/down to the next.
+func (a *App) navigateCompact(msg tea.KeyMsg, ids []string) bool {
+	switch {
+	case key.Matches(msg, a.keys.PaneLeft), key.Matches(msg, a.keys.PaneUp):
+		if a.activePane > 0 {
+			a.setActivePane(a.activePane - 1)
+			return true
+		}
+		a.focus = FocusProjects
+		a.updateFocusStyles()
+	case key.Matches(msg, a.keys.PaneRight), key.Matches(msg, a.keys.PaneDown):
+		if a.activePane < len(ids)-1 {
+			a.setActivePane(a.activePane + 1)
+		}
+	}
+	return true
+}
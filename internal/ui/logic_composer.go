@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/ui/components/composer"
+)
+
+// promptHistoryFile keeps the composer's sent prompts across restarts.
+const promptHistoryFile = "prompt_history.json"
+
+// showComposer opens the prompt composer. It targets every pane in
+// broadcast mode, the chain in chain mode and the active pane otherwise.
+func (a *App) showComposer() {
+	if !a.composerLoaded {
+		a.composerLoaded = true
+		a.composer.SetHistory(loadPromptHistory(a.configDir))
+	}
+	target := composer.TargetPane
+	switch a.dispatchMode {
+	case DispatchModeBroadcast:
+		target = composer.TargetAll
+	case DispatchModeChain:
+		target = composer.TargetChain
+	}
+	a.composer.SetSize(a.width, a.height)
+	a.composer.Open(target, a.paneLabel(a.activeTermID))
+	a.dialogMode = DialogComposer
+}
+
+// sendComposed sends the composer's prompt to its target and submits it.
+// The composer stays open with the text when nothing could be sent.
+func (a *App) sendComposed() tea.Cmd {
+	text := a.composer.Value()
+	var cmd tea.Cmd
+	switch a.composer.Target() {
+	case composer.TargetAll:
+		targets := a.broadcastTargets()
+		if len(targets) == 0 {
+			a.toasts.Push("No running panes", true)
+			return nil
+		}
+		a.hideDialog()
+		cmd = a.confirmBroadcast("send prompt", targets, text, func(a *App) tea.Cmd {
+			batch := a.beginInjection("prompt", targets)
+			cmds := make([]tea.Cmd, 0, len(targets))
+			for _, id := range targets {
+				a.recordPrompt(id)
+				cmds = append(cmds, a.injectCmd(batch, id, text, true))
+			}
+			return tea.Batch(cmds...)
+		})
+	case composer.TargetChain:
+		if a.chainContext == nil {
+			a.toasts.Push("No chain context (switch to chain mode first)", true)
+			return nil
+		}
+		chain, _ := a.chainContext.FormatCompact(a.chainCompactOptions())
+		if cmd = a.submitComposed(chain+"\n\n"+text, "chain context and prompt"); cmd == nil {
+			return nil
+		}
+	default:
+		if cmd = a.submitComposed(text, "prompt"); cmd == nil {
+			return nil
+		}
+	}
+	a.composer.Sent(text)
+	if a.dialogMode == DialogComposer {
+		a.hideDialog()
+	}
+	return tea.Batch(cmd, savePromptHistory(a.configDir, a.composer.History()))
+}
+
+// submitComposed pastes text into the active pane and submits it.
+func (a *App) submitComposed(text, what string) tea.Cmd {
+	id := a.activeTermID
+	session, ok := a.engine.GetSession(id)
+	if !ok || session.Status() != model.SessionStatusRunning {
+		a.toasts.Push("Active pane is not running", true)
+		return nil
+	}
+	if lockedByRemote(session) {
+		a.toasts.Push("Input locked by "+session.InputOwner(), true)
+		return nil
+	}
+	a.recordPrompt(id)
+	label := a.paneLabel(id)
+	return func() tea.Msg {
+		submitPrompt(session, text)
+		return StatusMsg{Text: fmt.Sprintf("Sent %s to %s", what, label)}
+	}
+}
+
+// loadPromptHistory reads the composer history; a missing or unreadable
+// file gives an empty one.
+func loadPromptHistory(configDir string) []string {
+	if configDir == "" {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(configDir, promptHistoryFile))
+	if err != nil {
+		return nil
+	}
+	var history []string
+	if json.Unmarshal(data, &history) != nil {
+		return nil
+	}
+	return history
+}
+
+// savePromptHistory writes the composer history in the background.
+func savePromptHistory(configDir string, history []string) tea.Cmd {
+	if configDir == "" {
+		return nil
+	}
+	history = append([]string(nil), history...)
+	return func() tea.Msg {
+		data, err := json.MarshalIndent(history, "", "  ")
+		if err == nil {
+			err = os.WriteFile(filepath.Join(configDir, promptHistoryFile), data, 0644)
+		}
+		if err != nil {
+			return StatusMsg{Text: "Failed to save prompt history: " + err.Error(), IsError: true}
+		}
+		return nil
+	}
+}
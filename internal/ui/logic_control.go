@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"errors"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/control"
+)
+
+// controlAddr returns where the control API listens, or "" when it is off.
+// Relative socket paths are in the config directory.
+func (a App) controlAddr() string {
+	if a.config == nil || a.config.ControlSocket == "" {
+		return ""
+	}
+	addr := a.config.ControlSocket
+	if !control.IsTCPAddr(addr) && !filepath.IsAbs(addr) {
+		addr = filepath.Join(a.configDir, addr)
+	}
+	return addr
+}
+
+// autoStartControl starts the control API at launch when configured and
+// listens for its start and stop requests.
+func (a App) autoStartControl() tea.Cmd {
+	addr := a.controlAddr()
+	if addr == "" || a.control == nil {
+		return nil
+	}
+	srv := a.control
+	srv.SetTokenFile(filepath.Join(a.configDir, control.TokenFile))
+	start := func() tea.Msg {
+		if err := srv.Start(addr); err != nil {
+			return StatusMsg{Text: "Control API failed to start: " + err.Error(), IsError: true}
+		}
+		return nil
+	}
+	return tea.Batch(start, waitControl(srv))
+}
+
+// closeServers stops the control API and the dashboard on quit, which
+// also removes the control socket file.
+func (a *App) closeServers() {
+	if a.control != nil {
+		_ = a.control.Close()
+	}
+	if a.dashboard != nil {
+		_ = a.dashboard.Close()
+	}
+}
+
+// waitControl waits for the next request of a control API client.
+func waitControl(srv *control.Server) tea.Cmd {
+	return func() tea.Msg {
+		return ControlRequestMsg{Request: <-srv.Requests()}
+	}
+}
+
+// handleControlRequest starts or stops a session the way the matching keys
+// would, so panes follow what control API clients do.
+func (a *App) handleControlRequest(req control.Request) tea.Cmd {
+	switch req.Method {
+	case control.MethodStart:
+		project := a.findProjectByRef(req.Target)
+		if project == nil {
+			req.Respond(control.Reply{Err: errors.New("unknown project: " + req.Target)})
+			return nil
+		}
+		sessionID, cmd := a.openProjectSession(project, launchOptions{})
+		if sessionID == "" {
+			req.Respond(control.Reply{Err: errors.New("no free pane in the grid")})
+			return nil
+		}
+		req.Respond(control.Reply{SessionID: sessionID})
+		return cmd
+	case control.MethodStop:
+		a.closeSession(req.Target)
+		req.Respond(control.Reply{SessionID: req.Target})
+		return nil
+	}
+	req.Respond(control.Reply{Err: errors.New("unsupported request: " + req.Method)})
+	return nil
+}
@@ -0,0 +1,101 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/app"
+	"github.com/lazyvibe/vibemux/internal/runtime"
+	"github.com/lazyvibe/vibemux/internal/store"
+	"github.com/lazyvibe/vibemux/internal/web"
+)
+
+func newDashboard(e *runtime.DefaultEngine, s store.Store, cfg *app.Config, configDir string) *web.Server {
+	dash := web.NewServer(e, s, configDir)
+	if cfg != nil {
+		dash.SetAllowInput(cfg.DashboardAllowInput)
+	}
+	return dash
+}
+
+// autoStartDashboard starts the web dashboard at launch when configured.
+func (a App) autoStartDashboard() tea.Cmd {
+	if a.config == nil || a.config.DashboardAddr == "" || a.dashboard == nil {
+		return nil
+	}
+	addr := a.config.DashboardAddr
+	dash := a.dashboard
+	return func() tea.Msg {
+		if err := dash.Start(addr); err != nil {
+			return StatusMsg{Text: "Dashboard failed to start: " + err.Error(), IsError: true}
+		}
+		return StatusMsg{Text: "Dashboard at " + dash.URL()}
+	}
+}
+
+// toggleDashboard starts or stops the web dashboard.
+func (a *App) toggleDashboard() tea.Cmd {
+	if a.dashboard.Addr() != "" {
+		_ = a.dashboard.Close()
+		a.toasts.Push("Dashboard stopped", false)
+		return nil
+	}
+	addr := web.DefaultAddr
+	if a.config != nil && a.config.DashboardAddr != "" {
+		addr = a.config.DashboardAddr
+	}
+	if err := a.dashboard.Start(addr); err != nil {
+		a.toasts.Push("Dashboard failed to start: "+err.Error(), true)
+		return nil
+	}
+	a.toasts.Push("Dashboard at "+a.dashboard.URL(), false)
+	return nil
+}
+
+// lockActiveInput claims the active session's input lock for the local
+// operator so remote operators cannot type into it.
+func (a *App) lockActiveInput() tea.Cmd {
+	session, ok := a.engine.GetSession(a.activeTermID)
+	if !ok {
+		a.toasts.Push("No active session", true)
+		return nil
+	}
+	if !session.AcquireInput(runtime.LocalOperator) {
+		a.toasts.Push("Input is locked by "+session.InputOwner()+" (:unlock to take it back)", true)
+		return nil
+	}
+	a.syncInputLocks()
+	a.toasts.Push("Input locked to this terminal", false)
+	return nil
+}
+
+// unlockActiveInput releases the active session's input lock, whoever
+// holds it. The local operator hosts the session and can always take over.
+func (a *App) unlockActiveInput() tea.Cmd {
+	session, ok := a.engine.GetSession(a.activeTermID)
+	if !ok {
+		a.toasts.Push("No active session", true)
+		return nil
+	}
+	if owner := session.InputOwner(); owner != "" {
+		session.ReleaseInput(owner)
+	}
+	a.syncInputLocks()
+	a.toasts.Push("Input lock released", false)
+	return nil
+}
+
+// syncInputLocks mirrors session input locks into the pane headers.
+func (a *App) syncInputLocks() {
+	for id, inst := range a.terminals {
+		owner := ""
+		if session, ok := a.engine.GetSession(id); ok {
+			owner = session.InputOwner()
+		}
+		inst.Terminal.SetInputLock(owner)
+	}
+}
+
+// lockedByRemote reports whether a remote operator holds the session's input lock.
+func lockedByRemote(session runtime.Session) bool {
+	owner := session.InputOwner()
+	return owner != "" && owner != runtime.LocalOperator
+}
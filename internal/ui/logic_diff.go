@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/git"
+	"github.com/lazyvibe/vibemux/internal/ui/components/diffview"
+)
+
+// diffTimeout caps taking a snapshot or diff of a repository.
+const diffTimeout = 30 * time.Second
+
+// diffBase is the snapshot of a pane's repository that its diff is taken
+// against.
+type diffBase struct {
+	dir  string
+	tree string // Empty while the snapshot is being taken
+	at   time.Time
+	err  error // Why there is no snapshot, e.g. git.ErrNotRepository
+}
+
+// diffSnapshotMsg carries the snapshot taken when a pane's session started.
+type diffSnapshotMsg struct {
+	SessionID string
+	Tree      string
+	Err       error
+}
+
+// diffLoadedMsg carries the changes since a pane's snapshot.
+type diffLoadedMsg struct {
+	SessionID string
+	Files     []git.FileDiff
+	Err       error
+}
+
+// sessionDir returns the working directory of a pane's session.
+func (a *App) sessionDir(id string) string {
+	project := a.projectForSession(id)
+	if project == nil {
+		return ""
+	}
+	if dir := a.bindings[id].Opts.workDir(project); dir != "" {
+		return dir
+	}
+	return project.Path
+}
+
+// snapshotForDiff records the pane's repository in the background when
+// its first session starts, so the diff viewer shows what the agents of
+// the pane changed. Restarts keep the first snapshot.
+func (a *App) snapshotForDiff(id string) tea.Cmd {
+	if _, ok := a.diffBases[id]; ok {
+		return nil
+	}
+	dir := a.sessionDir(id)
+	if dir == "" {
+		return nil
+	}
+	a.diffBases[id] = &diffBase{dir: dir, at: time.Now()}
+	ctx := a.ctx
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, diffTimeout)
+		defer cancel()
+		tree, err := git.Snapshot(ctx, dir)
+		return diffSnapshotMsg{SessionID: id, Tree: tree, Err: err}
+	}
+}
+
+// applyDiffSnapshot stores a pane's snapshot.
+func (a *App) applyDiffSnapshot(msg diffSnapshotMsg) {
+	if base, ok := a.diffBases[msg.SessionID]; ok {
+		base.tree, base.err = msg.Tree, msg.Err
+	}
+}
+
+// showDiff reads what changed in a pane's repository since its snapshot
+// and opens the diff viewer: the active pane, or the one named in args.
+// Usage: diff [pane]
+func (a *App) showDiff(args []string) tea.Cmd {
+	id := a.activeTermID
+	if len(args) > 0 {
+		var ok bool
+		if id, ok = a.resolvePane(strings.Join(args, " ")); !ok {
+			a.toasts.Push("Unknown pane: "+strings.Join(args, " "), true)
+			return nil
+		}
+	}
+	if id == "" {
+		a.toasts.Push("No pane to diff", true)
+		return nil
+	}
+	base, ok := a.diffBases[id]
+	switch {
+	case !ok:
+		a.toasts.Push(a.paneLabel(id)+" has not started a session yet", true)
+		return nil
+	case errors.Is(base.err, git.ErrNotRepository):
+		a.toasts.Push(a.paneLabel(id)+" is not in a git repository", true)
+		return nil
+	case base.err != nil:
+		a.toasts.Push("No snapshot of "+a.paneLabel(id)+": "+base.err.Error(), true)
+		return nil
+	case base.tree == "":
+		a.toasts.Push("The snapshot of "+a.paneLabel(id)+" is still being taken", true)
+		return nil
+	}
+	a.diffView.SetSize(a.width, a.height)
+	a.diffView.Open(a.paneLabel(id)+" since "+base.at.Format("15:04"), nil)
+	a.diffPane = id
+	return a.loadDiff(id)
+}
+
+// loadDiff reads the pane's changes in the background.
+func (a *App) loadDiff(id string) tea.Cmd {
+	base := a.diffBases[id]
+	if base == nil || base.tree == "" {
+		return nil
+	}
+	ctx, dir, tree := a.ctx, base.dir, base.tree
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, diffTimeout)
+		defer cancel()
+		files, err := git.DiffSince(ctx, dir, tree)
+		return diffLoadedMsg{SessionID: id, Files: files, Err: err}
+	}
+}
+
+// applyDiff shows loaded changes, opening the viewer the first time.
+func (a *App) applyDiff(msg diffLoadedMsg) {
+	if msg.SessionID != a.diffPane {
+		return
+	}
+	if msg.Err != nil {
+		a.toasts.Push("Diff failed: "+msg.Err.Error(), true)
+		return
+	}
+	a.diffView.SetFiles(msg.Files)
+	if a.dialogMode == DialogNone {
+		a.dialogMode = DialogDiff
+	}
+}
+
+// diffViewAction carries out what the diff viewer asked for.
+func (a *App) diffViewAction() tea.Cmd {
+	switch a.diffView.Action() {
+	case diffview.ActionClose:
+		a.diffPane = ""
+		a.hideDialog()
+	case diffview.ActionRefresh:
+		return a.loadDiff(a.diffPane)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/runtime"
+	"github.com/lazyvibe/vibemux/pkg/utils"
+)
+
+// exportPane writes the active pane's output history to a file: the path
+// in args, or one chosen in the file picker. A .md file gets a heading and
+// a code block; anything else is plain text.
+// Usage: export [file]
+func (a *App) exportPane(args []string) tea.Cmd {
+	id := a.activeTermID
+	if _, ok := a.engine.GetSession(id); !ok {
+		a.toasts.Push("No session output to export", true)
+		return nil
+	}
+	if len(args) > 0 {
+		return a.writePaneExport(id, utils.ExpandPath(strings.Join(args, " ")))
+	}
+	dir, _ := os.Getwd()
+	if project := a.projectForSession(id); project != nil && project.Path != "" {
+		dir = project.Path
+	}
+	name := unsafeFileChars.ReplaceAllString(a.paneLabel(id), "-") + "-" + time.Now().Format("20060102-150405") + ".md"
+	a.exportPaneID = id
+	a.filePicker.Open("Export "+a.paneLabel(id), dir, name)
+	a.filePicker.SetSize(a.width, a.height)
+	a.dialogMode = DialogExport
+	return nil
+}
+
+// writePaneExport writes pane id's cleaned output history to path.
+func (a *App) writePaneExport(id, path string) tea.Cmd {
+	session, ok := a.engine.GetSession(id)
+	if !ok {
+		a.toasts.Push("Session is gone: "+a.paneLabel(id), true)
+		return nil
+	}
+	label := a.paneLabel(id)
+	history := session.History()
+	return func() tea.Msg {
+		text := strings.TrimSpace(runtime.CleanOutput(string(history))) + "\n"
+		if strings.EqualFold(filepath.Ext(path), ".md") {
+			text = markdownExport(label, text)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return ErrorMsg{Err: err}
+		}
+		if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+			return ErrorMsg{Err: err}
+		}
+		return StatusMsg{Text: fmt.Sprintf("Exported %d lines to %s", strings.Count(text, "\n"), path)}
+	}
+}
+
+// markdownExport wraps output in a heading and a fenced block whose fence
+// is longer than any backtick run in it.
+func markdownExport(label, text string) string {
+	fence := "```"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+	return fmt.Sprintf("# %s\n\nExported %s\n\n%stext\n%s%s\n", label, time.Now().Format("2006-01-02 15:04"), fence, text, fence)
+}
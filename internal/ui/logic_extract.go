@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/runtime"
+)
+
+// extractPreviewLines is how many lines of a conclusion the preview shows.
+const extractPreviewLines = 15
+
+// Choices of the extraction preview dialog.
+const (
+	extractAddToChain = iota
+	extractCopy
+)
+
+// pendingExtract is a conclusion shown in the extraction preview.
+type pendingExtract struct {
+	agent string
+	text  string
+}
+
+// extractionConfig returns the extraction settings of pane id's profile.
+func (a *App) extractionConfig(id string) model.ExtractionConfig {
+	if profile := a.profileForSession(id); profile != nil {
+		return profile.Extraction
+	}
+	return model.ExtractionConfig{}
+}
+
+// extractConclusion reads pane id's conclusion with its profile's strategy.
+func (a *App) extractConclusion(id string) (runtime.ExtractResult, error) {
+	inst, ok := a.terminals[id]
+	if !ok {
+		return runtime.ExtractResult{}, fmt.Errorf("pane not found: %s", a.paneLabel(id))
+	}
+	tool := model.DriverNative
+	if session, ok := a.engine.GetSession(id); ok {
+		tool = session.Tool()
+	}
+	return runtime.ExtractForTool(inst.Terminal.GetPlainText(), a.extractionConfig(id), tool)
+}
+
+// previewExtraction shows what would be extracted from the active pane,
+// with the option to add it to the chain or copy it.
+func (a *App) previewExtraction() tea.Cmd {
+	id := a.activeTermID
+	result, err := a.extractConclusion(id)
+	if err != nil {
+		a.toasts.Push("Extraction failed: "+err.Error(), true)
+		return nil
+	}
+	cfg := a.extractionConfig(id)
+	strategy := string(cfg.Strategy)
+	if strategy == "" {
+		strategy = "auto"
+	}
+	if result.Fallback {
+		strategy += " (nothing found, used auto)"
+	}
+	lines := strings.Split(result.Text, "\n")
+	preview := result.Text
+	if len(lines) > extractPreviewLines {
+		preview = fmt.Sprintf("… %d earlier lines\n%s", len(lines)-extractPreviewLines,
+			strings.Join(lines[len(lines)-extractPreviewLines:], "\n"))
+	}
+	if strings.TrimSpace(preview) == "" {
+		preview = "(empty)"
+	}
+	msg := fmt.Sprintf("Strategy: %s · %d lines\n\n%s", strategy, len(lines), preview)
+	a.pendingExtract = &pendingExtract{agent: a.paneLabel(id), text: result.Text}
+	a.confirm.SetSize(a.width, a.height)
+	a.confirm.Open("Extraction Preview: "+a.paneLabel(id), msg, "Add to chain", "Copy", "Close")
+	a.dialogMode = DialogConfirm
+	return nil
+}
+
+// resolveExtract carries out the choice made in the extraction preview.
+func (a *App) resolveExtract(choice int) tea.Cmd {
+	p := a.pendingExtract
+	a.pendingExtract = nil
+	switch choice {
+	case extractAddToChain:
+		if a.chainContext == nil {
+			a.toasts.Push("No chain context", true)
+			return nil
+		}
+		return a.appendConclusion(p.agent, p.text)
+	case extractCopy:
+		return copyToClipboard(p.text)
+	}
+	return nil
+}
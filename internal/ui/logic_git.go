@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/git"
+)
+
+// gitInterval is how often the git status of the projects is read again.
+const gitInterval = 10 * time.Second
+
+// gitTimeout caps one git status call, for huge or network-mounted repos.
+const gitTimeout = 5 * time.Second
+
+// gitStatusMsg carries the work tree status of the projects that are in a
+// git repository, by project ID.
+type gitStatusMsg struct {
+	statuses map[string]git.Status
+}
+
+// refreshGitStatus reads the git status of every project in the
+// background, at most once per gitInterval. Projects sharing a directory
+// are read once.
+func (a *App) refreshGitStatus() tea.Cmd {
+	if a.gitBusy || len(a.projects) == 0 || time.Since(a.gitAt) < gitInterval {
+		return nil
+	}
+	paths := make(map[string][]string) // directory -> project IDs
+	for _, p := range a.projects {
+		if p.Path != "" {
+			paths[p.Path] = append(paths[p.Path], p.ID)
+		}
+	}
+	a.gitBusy = true
+	a.gitAt = time.Now()
+	ctx := a.ctx
+	return func() tea.Msg {
+		statuses := make(map[string]git.Status, len(paths))
+		for dir, ids := range paths {
+			callCtx, cancel := context.WithTimeout(ctx, gitTimeout)
+			status, err := git.ReadStatus(callCtx, dir)
+			cancel()
+			if err != nil {
+				continue
+			}
+			for _, id := range ids {
+				statuses[id] = status
+			}
+		}
+		return gitStatusMsg{statuses: statuses}
+	}
+}
+
+// applyGitStatus shows the git status in the project list.
+func (a *App) applyGitStatus(msg gitStatusMsg) {
+	a.gitBusy = false
+	a.projectList.SetGitStatus(msg.statuses)
+}
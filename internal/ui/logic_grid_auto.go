@@ -0,0 +1,80 @@
+package ui
+
+import "github.com/lazyvibe/vibemux/internal/app"
+
+// Smallest grid cell (border included) the auto grid will create, so agent
+// CLIs keep a usable PTY.
+const (
+	minAutoPaneWidth  = 60
+	minAutoPaneHeight = 12
+)
+
+// gridMaxDims returns the grid bounds: the configured size, or in auto mode
+// as many cells as fit the window (capped at 3x3 like manual grids).
+func (a *App) gridMaxDims() (int, int) {
+	if !a.gridAuto {
+		return a.gridRows, a.gridCols
+	}
+	width := a.width - a.projectPanelWidth()
+	height := a.height - 1
+	return clampGrid(height / minAutoPaneHeight), clampGrid(width / minAutoPaneWidth)
+}
+
+func clampGrid(n int) int {
+	if n < 1 {
+		return 1
+	}
+	if n > 3 {
+		return 3
+	}
+	return n
+}
+
+// autoGridDims picks the smallest grid within the bounds that holds count
+// panes, preferring cells closest to a 2:1 (roughly square) character shape.
+func (a *App) autoGridDims(count int) (int, int) {
+	maxRows, maxCols := a.gridMaxDims()
+	if count <= 0 {
+		return 0, 0
+	}
+	if count >= maxRows*maxCols {
+		return maxRows, maxCols
+	}
+	width := float64(a.width - a.projectPanelWidth())
+	height := float64(a.height - 1)
+
+	bestRows, bestCols := maxRows, maxCols
+	bestCells, bestSkew := maxRows*maxCols, 0.0
+	for rows := 1; rows <= maxRows; rows++ {
+		for cols := 1; cols <= maxCols; cols++ {
+			cells := rows * cols
+			if cells < count || cells > bestCells {
+				continue
+			}
+			skew := (width / float64(cols)) / (2 * height / float64(rows))
+			if skew < 1 {
+				skew = 1 / skew
+			}
+			if cells < bestCells || skew < bestSkew {
+				bestRows, bestCols, bestCells, bestSkew = rows, cols, cells, skew
+			}
+		}
+	}
+	return bestRows, bestCols
+}
+
+// setGridAuto switches auto grid sizing on and persists it.
+func (a *App) setGridAuto() error {
+	if a.config != nil && a.configDir != "" {
+		updated := *a.config
+		updated.GridAuto = true
+		if err := app.SaveConfig(a.configDir, &updated); err != nil {
+			return err
+		}
+		*a.config = updated
+	}
+	a.gridAuto = true
+	a.normalizeActivePane()
+	a.SetSize(a.width, a.height)
+	return nil
+}
@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/store"
+)
+
+// historyShown is how many sessions :history lists.
+const historyShown = 5
+
+// historyStore returns the store's history, or nil when the backend does
+// not keep one.
+func (a *App) historyStore() store.HistoryStore {
+	hs, _ := a.store.(store.HistoryStore)
+	return hs
+}
+
+// recordSessionStart adds a started session to the history. A record
+// still open under the same ID (a restart) is closed first.
+func (a *App) recordSessionStart(sessionID string, profile *model.Profile) tea.Cmd {
+	hs := a.historyStore()
+	project := a.projectForSession(sessionID)
+	if hs == nil || project == nil {
+		return nil
+	}
+	if a.sessionRecords == nil {
+		a.sessionRecords = make(map[string]store.SessionRecord)
+	}
+	var cmds []tea.Cmd
+	if _, open := a.sessionRecords[sessionID]; open {
+		cmds = append(cmds, a.recordSessionEnd(sessionID, model.SessionStatusStopped))
+	}
+	rec := store.SessionRecord{
+		ID:        sessionID,
+		ProjectID: project.ID,
+		Started:   time.Now(),
+		Status:    model.SessionStatusRunning,
+	}
+	if profile != nil {
+		rec.ProfileID = profile.ID
+	}
+	a.sessionRecords[sessionID] = rec
+	return tea.Batch(append(cmds, a.saveSessionRecord(hs, rec))...)
+}
+
+// recordSessionEnd closes a session's history record.
+func (a *App) recordSessionEnd(sessionID string, status model.SessionStatus) tea.Cmd {
+	hs := a.historyStore()
+	rec, ok := a.sessionRecords[sessionID]
+	if hs == nil || !ok {
+		return nil
+	}
+	delete(a.sessionRecords, sessionID)
+	rec.Ended = time.Now()
+	rec.Status = status
+	return a.saveSessionRecord(hs, rec)
+}
+
+func (a *App) saveSessionRecord(hs store.HistoryStore, rec store.SessionRecord) tea.Cmd {
+	ctx := a.ctx
+	return func() tea.Msg {
+		if err := hs.RecordSession(ctx, rec); err != nil {
+			return StatusMsg{Text: "Session history not saved: " + err.Error(), IsError: true}
+		}
+		return nil
+	}
+}
+
+// closeHistory ends the records of all running sessions on quit.
+func (a *App) closeHistory() {
+	hs := a.historyStore()
+	if hs == nil {
+		return
+	}
+	for id, rec := range a.sessionRecords {
+		rec.Ended = time.Now()
+		rec.Status = model.SessionStatusStopped
+		_ = hs.RecordSession(a.ctx, rec)
+		delete(a.sessionRecords, id)
+	}
+}
+
+// recordChainEntry adds a chain conclusion to the history.
+func (a *App) recordChainEntry(chainID, agent, content string) tea.Cmd {
+	hs := a.historyStore()
+	if hs == nil {
+		return nil
+	}
+	ctx := a.ctx
+	rec := store.ChainRecord{ChainID: chainID, Agent: agent, Content: content, Added: time.Now()}
+	return func() tea.Msg {
+		if err := hs.RecordChainEntry(ctx, rec); err != nil {
+			return StatusMsg{Text: "Chain history not saved: " + err.Error(), IsError: true}
+		}
+		return nil
+	}
+}
+
+// showHistory lists the latest sessions of the active pane's project, or
+// the selected project.
+// Usage: history
+func (a *App) showHistory() tea.Cmd {
+	hs := a.historyStore()
+	if hs == nil {
+		a.toasts.Push(`Session history needs the database store ("store": "bolt" in config.json)`, true)
+		return nil
+	}
+	project := a.projectForSession(a.activeTermID)
+	if a.focus == FocusProjects || project == nil {
+		project = a.projectList.SelectedProject()
+	}
+	if project == nil {
+		a.toasts.Push("No project selected", true)
+		return nil
+	}
+	ctx, name, id := a.ctx, project.DisplayName(), project.ID
+	return func() tea.Msg {
+		recs, err := hs.SessionHistory(ctx, id, historyShown)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+		if len(recs) == 0 {
+			return StatusMsg{Text: "No sessions recorded for " + name}
+		}
+		parts := make([]string, 0, len(recs))
+		for _, r := range recs {
+			span := r.Started.Format("Jan 2 15:04")
+			if !r.Ended.IsZero() {
+				span += fmt.Sprintf(" (%s)", r.Ended.Sub(r.Started).Round(time.Minute))
+			} else {
+				span += " (running)"
+			}
+			parts = append(parts, span)
+		}
+		return StatusMsg{Text: name + " sessions: " + strings.Join(parts, ", ")}
+	}
+}
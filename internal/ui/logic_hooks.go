@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/hooks"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/notify"
+)
+
+// hookResultMsg carries what a hook printed for a session.
+type hookResultMsg struct {
+	SessionID string
+	Hook      hooks.Hook
+	Output    string
+	Err       error
+}
+
+// runHooks starts the configured hooks for event on a session, each in
+// the background. message fills the payload's message; the conclusion is
+// only extracted for task_completed hooks.
+func (a *App) runHooks(event, sessionID, message string) tea.Cmd {
+	project := a.projectForSession(sessionID)
+	if a.config == nil || project == nil {
+		return nil
+	}
+	var matched []hooks.Hook
+	for _, h := range a.config.Hooks {
+		if h.Matches(event, project.ID, project.DisplayName()) {
+			matched = append(matched, h)
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+	payload := hooks.Payload{
+		Event:     event,
+		Project:   project.DisplayName(),
+		ProjectID: project.ID,
+		Session:   sessionID,
+		Path:      project.Path,
+		WorkDir:   a.sessionDir(sessionID),
+		Message:   message,
+		Timestamp: time.Now(),
+	}
+	if event == hooks.TaskCompleted {
+		if result, err := a.extractConclusion(sessionID); err == nil {
+			payload.Text = result.Text
+		}
+	}
+	cmds := make([]tea.Cmd, 0, len(matched))
+	for _, h := range matched {
+		cmds = append(cmds, func() tea.Msg {
+			out, err := hooks.Run(a.ctx, h, payload)
+			return hookResultMsg{SessionID: sessionID, Hook: h, Output: out, Err: err}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// runEventHooks starts the hooks of the notification events a session's
+// output raised.
+func (a *App) runEventHooks(sessionID string, events []notify.Event) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, ev := range events {
+		switch ev.Type {
+		case notify.EventTaskCompleted:
+			cmds = append(cmds, a.runHooks(hooks.TaskCompleted, sessionID, ev.Message))
+		case notify.EventInputRequired:
+			cmds = append(cmds, a.runHooks(hooks.InputRequired, sessionID, ev.Message))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleHookResult types a hook's output into its session and submits it.
+// Panes whose input is held from the dashboard are left alone.
+func (a *App) handleHookResult(msg hookResultMsg) tea.Cmd {
+	name, _, _ := strings.Cut(strings.TrimSpace(msg.Hook.Command), " ")
+	if msg.Err != nil {
+		a.toasts.Push("Hook "+name+" ("+msg.Hook.Event+") failed: "+msg.Err.Error(), true)
+		return nil
+	}
+	if msg.Output == "" {
+		return nil
+	}
+	session, ok := a.engine.GetSession(msg.SessionID)
+	if !ok || session.Status() != model.SessionStatusRunning {
+		return nil
+	}
+	if lockedByRemote(session) {
+		a.toasts.Push("Hook "+name+" not sent: input locked by "+session.InputOwner(), true)
+		return nil
+	}
+	a.acknowledgeInput(msg.SessionID)
+	a.recordPrompt(msg.SessionID)
+	a.toasts.Push("Hook "+name+" replied to "+a.paneLabel(msg.SessionID), false)
+	text := msg.Output
+	return func() tea.Msg {
+		submitPrompt(session, text)
+		return nil
+	}
+}
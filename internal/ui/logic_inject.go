@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/runtime"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
+)
+
+// injectionBadgeTTL is how long per-pane results stay in the pane headers
+// after a batch completes.
+const injectionBadgeTTL = 5 * time.Second
+
+// InjectionResultMsg reports the outcome of writing a prompt to one pane.
+type InjectionResultMsg struct {
+	Batch     int
+	SessionID string
+	Err       error
+}
+
+// injectionProgress tracks a batch of prompts written to several panes.
+type injectionProgress struct {
+	batch   int
+	label   string
+	panes   []string
+	results map[string]error // Present once the pane has finished
+	done    time.Time
+}
+
+// beginInjection starts tracking a batch and marks its panes as pending.
+func (a *App) beginInjection(label string, ids []string) int {
+	batch := 1
+	if a.injection != nil {
+		batch = a.injection.batch + 1
+		a.clearInjectionBadges()
+	}
+	a.injection = &injectionProgress{
+		batch:   batch,
+		label:   label,
+		panes:   ids,
+		results: make(map[string]error),
+	}
+	for _, id := range ids {
+		if inst, ok := a.terminals[id]; ok {
+			inst.Terminal.SetBadge("⏳ sending "+label, styles.Warning)
+		}
+	}
+	if len(ids) == 0 {
+		a.injection.done = time.Now()
+		return batch
+	}
+	a.toasts.Push(fmt.Sprintf("Sending %s to %d panes…", label, len(ids)), false)
+	return batch
+}
+
+// injectCmd writes text to a session, optionally submitting it, and
+// reports the result.
+func (a *App) injectCmd(batch int, sessionID, text string, submit bool) tea.Cmd {
+	return func() tea.Msg {
+		result := InjectionResultMsg{Batch: batch, SessionID: sessionID}
+		session, ok := a.engine.GetSession(sessionID)
+		if !ok || session.Status() != model.SessionStatusRunning {
+			result.Err = errors.New("session not running")
+			return result
+		}
+		if err := runtime.Paste(session, []byte(text), runtime.DefaultPasteOptions); err != nil {
+			result.Err = err
+			return result
+		}
+		if submit {
+			// Give the agent time to take the paste before submitting.
+			time.Sleep(200 * time.Millisecond)
+			if _, err := session.Write([]byte("\r")); err != nil {
+				result.Err = err
+			}
+		}
+		return result
+	}
+}
+
+// handleInjectionResult records one pane's result and reports the batch
+// once every pane has finished.
+func (a *App) handleInjectionResult(msg InjectionResultMsg) {
+	p := a.injection
+	if p == nil || p.batch != msg.Batch {
+		return
+	}
+	p.results[msg.SessionID] = msg.Err
+	if inst, ok := a.terminals[msg.SessionID]; ok {
+		if msg.Err != nil {
+			inst.Terminal.SetBadge("✗ "+p.label+" failed", styles.Danger)
+		} else {
+			inst.Terminal.SetBadge("✓ "+p.label+" sent", styles.Success)
+		}
+	}
+	if len(p.results) < len(p.panes) {
+		return
+	}
+
+	p.done = time.Now()
+	var failed []string
+	for _, id := range p.panes {
+		if err := p.results[id]; err != nil {
+			failed = append(failed, fmt.Sprintf("%s (%v)", a.paneLabel(id), err))
+		}
+	}
+	if len(failed) == 0 {
+		a.toasts.Push(fmt.Sprintf("%s sent to %d/%d panes", capitalize(p.label), len(p.panes), len(p.panes)), false)
+		return
+	}
+	a.toasts.Push(fmt.Sprintf("%s sent to %d/%d panes; failed: %s",
+		capitalize(p.label), len(p.panes)-len(failed), len(p.panes), strings.Join(failed, ", ")), true)
+}
+
+// expireInjection clears the per-pane badges of a finished batch.
+func (a *App) expireInjection() {
+	if a.injection == nil || a.injection.done.IsZero() || time.Since(a.injection.done) < injectionBadgeTTL {
+		return
+	}
+	a.clearInjectionBadges()
+	a.injection = nil
+}
+
+func (a *App) clearInjectionBadges() {
+	for _, id := range a.injection.panes {
+		if inst, ok := a.terminals[id]; ok {
+			inst.Terminal.SetBadge("", "")
+		}
+	}
+}
+
+// paneLabel returns the display label of a pane.
+func (a *App) paneLabel(id string) string {
+	if inst, ok := a.terminals[id]; ok && inst.ProjectName != "" {
+		return inst.ProjectName
+	}
+	return id
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
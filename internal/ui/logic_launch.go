@@ -0,0 +1,278 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/git"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/ui/components/dialog"
+	"github.com/lazyvibe/vibemux/pkg/utils"
+)
+
+// launchOptions overrides project settings for a single launch.
+type launchOptions struct {
+	// ProfileID replaces the project's profile for this session only.
+	ProfileID string
+	// Command replaces the profile command line, e.g.
+	// `claude -p "fix the failing test"`.
+	Command string
+	// Subdir is a project subdirectory used as the session cwd.
+	Subdir string
+	// Alias names the session in tabs, headers and command targets.
+	Alias string
+	// Worktree is the session's own worktree for projects with worktree
+	// sessions; set once it has been created.
+	Worktree git.Worktree
+}
+
+// workDir returns the session cwd for opts, or "" for the project path.
+func (o launchOptions) workDir(project *model.Project) string {
+	root := project.Path
+	if o.Worktree.Dir != "" {
+		root = o.Worktree.Dir
+	}
+	if o.Subdir == "" {
+		return o.Worktree.Dir
+	}
+	return filepath.Join(root, o.Subdir)
+}
+
+// launchProfile returns the profile a session launched with these options
+// runs: profile itself, or a copy running the ad-hoc command, which keeps
+// the profile's env.
+func (o launchOptions) launchProfile(profile *model.Profile) *model.Profile {
+	if o.Command == "" {
+		return profile
+	}
+	override := model.DefaultProfile()
+	if profile != nil {
+		copied := *profile
+		override = &copied
+	}
+	override.Command = o.Command
+	override.CommandArgs = nil
+	return override
+}
+
+// openSelectedProject opens the project under the list cursor, asking for
+// launch options first when the launch picker is enabled.
+func (a *App) openSelectedProject() tea.Cmd {
+	project := a.projectList.SelectedProject()
+	if project == nil {
+		return nil
+	}
+	if a.config != nil && a.config.LaunchPicker && !a.hasPane(project.ID) {
+		a.showLaunchDialog(project)
+		return nil
+	}
+	return a.openProject(project, launchOptions{})
+}
+
+// openProject shows the project's pane and starts its session if needed.
+// Launching with overrides while the project already runs opens an extra
+// session next to the existing one.
+func (a *App) openProject(project *model.Project, opts launchOptions) tea.Cmd {
+	_, cmd := a.openProjectSession(project, opts)
+	return cmd
+}
+
+// openProjectSession is openProject that also returns the ID of the
+// session shown, or "" when no pane could be opened.
+func (a *App) openProjectSession(project *model.Project, opts launchOptions) (string, tea.Cmd) {
+	sessionID := project.ID
+	if session, ok := a.engine.GetSession(project.ID); ok && session.Status() == model.SessionStatusRunning && opts != (launchOptions{}) {
+		sessionID = a.engine.NextSessionID(project.ID)
+	}
+	if !a.canOpenPane(sessionID) {
+		a.toasts.Push("Max panes reached for grid layout", true)
+		return "", nil
+	}
+	if opts.Alias != "" && a.aliasTaken(opts.Alias, sessionID) {
+		a.toasts.Push("Alias already in use: "+opts.Alias, true)
+		return "", nil
+	}
+	name := sessionLabel(project, sessionID, opts)
+	// Get or create terminal instance
+	inst := a.getOrCreateTerminal(sessionID, name)
+
+	// Add to session tabs if not present
+	a.sessionTabs.AddTab(sessionID, name, model.SessionStatusIdle)
+	a.setActivePaneByProject(sessionID)
+	a.SetSize(a.width, a.height)
+
+	// Check if session already exists
+	if session, ok := a.engine.GetSession(sessionID); ok {
+		// Session exists, just update terminal status
+		inst.Terminal.SetStatus(session.Status())
+		if session.Status() == model.SessionStatusRunning {
+			// Resume listening for output
+			return sessionID, a.waitForOutput(sessionID)
+		}
+		return sessionID, nil
+	}
+	return sessionID, a.launchSession(project, sessionID, opts)
+}
+
+// showLaunchDialog opens the per-launch options for a project, defaulting
+// to the project's own profile.
+func (a *App) showLaunchDialog(project *model.Project) {
+	current := ""
+	if profile := a.profileForProject(project); profile != nil {
+		current = profileLabel(profile)
+	}
+	a.launchProject = project.ID
+	a.launchDialog = dialog.NewInputDialog("Launch "+project.DisplayName(), []dialog.InputField{
+		{Label: "Profile", Placeholder: "default", Value: current, Options: a.profileOptions()},
+		{Label: "Command (this launch only)", Placeholder: "profile command"},
+		{Label: "Subdirectory", Placeholder: "project root", Options: subdirSuggestions(project)},
+		{Label: "Alias", Placeholder: "e.g. api-refactor (optional)"},
+	})
+	a.launchDialog.SetSize(a.width, a.height)
+	a.dialogMode = DialogLaunch
+}
+
+// submitLaunchDialog launches the dialog's project with the chosen options.
+func (a *App) submitLaunchDialog() tea.Cmd {
+	project := a.findProjectByID(a.launchProject)
+	a.launchProject = ""
+	if project == nil {
+		return nil
+	}
+	values := a.launchDialog.Values()
+
+	var opts launchOptions
+	if len(values) > 0 && strings.TrimSpace(values[0]) != "" {
+		profileID, err := a.resolveProfileID(values[0])
+		if err != nil {
+			a.toasts.Push(err.Error(), true)
+			return nil
+		}
+		if current := a.profileForProject(project); current == nil || current.ID != profileID {
+			opts.ProfileID = profileID
+		}
+	}
+	if len(values) > 1 {
+		if command := strings.TrimSpace(values[1]); command != "" {
+			if _, err := utils.SplitCommandLine(command); err != nil {
+				a.toasts.Push("Invalid command: "+err.Error(), true)
+				return nil
+			}
+			opts.Command = command
+		}
+	}
+	var saveCmd tea.Cmd
+	if len(values) > 2 && strings.TrimSpace(values[2]) != "" {
+		subdir, err := projectSubdir(project, values[2])
+		if err != nil {
+			a.toasts.Push(err.Error(), true)
+			return nil
+		}
+		if subdir != "" {
+			opts.Subdir = subdir
+			saveCmd = a.rememberSubdir(project, subdir)
+		}
+	}
+	if len(values) > 3 {
+		opts.Alias = strings.TrimSpace(values[3])
+	}
+	return tea.Batch(a.openProject(project, opts), saveCmd)
+}
+
+// projectSubdir validates input as a directory inside the project and
+// returns it relative to the project path ("" for the root).
+func projectSubdir(project *model.Project, input string) (string, error) {
+	dir := utils.ExpandPath(strings.TrimSpace(input))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(project.Path, dir)
+	}
+	rel, err := filepath.Rel(project.Path, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.New("subdirectory must be inside the project")
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return "", errors.New("subdirectory not found: " + input)
+	}
+	if rel == "." {
+		return "", nil
+	}
+	return rel, nil
+}
+
+// subdirSuggestions offers recent subdirectories first, then the
+// project's top-level directories.
+func subdirSuggestions(project *model.Project) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, s := range project.RecentSubdirs {
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	entries, _ := os.ReadDir(project.Path)
+	for _, e := range entries {
+		name := e.Name()
+		if !e.IsDir() || strings.HasPrefix(name, ".") || seen[name] {
+			continue
+		}
+		seen[name] = true
+		out = append(out, name)
+	}
+	return out
+}
+
+// rememberSubdir saves subdir at the front of the project's recent list.
+func (a *App) rememberSubdir(project *model.Project, subdir string) tea.Cmd {
+	updated := *project
+	updated.RecentSubdirs = append([]string(nil), project.RecentSubdirs...)
+	updated.AddRecentSubdir(subdir)
+	return func() tea.Msg {
+		if err := a.store.Update(a.ctx, &updated); err != nil {
+			return ErrorMsg{Err: err}
+		}
+		return ProjectUpdatedMsg{Project: updated}
+	}
+}
+
+// markLaunching shows the launch spinner in a session's pane until its first
+// output, and starts the spinner tick if it is not already running.
+func (a *App) markLaunching(sessionID string, opts launchOptions) tea.Cmd {
+	inst, ok := a.terminals[sessionID]
+	if !ok {
+		return nil
+	}
+	label := "session"
+	if fields := strings.Fields(opts.Command); len(fields) > 0 {
+		label = filepath.Base(fields[0])
+	} else if profile := a.profileForSession(sessionID); profile != nil && profile.Command != "" {
+		label = filepath.Base(profile.Command)
+	}
+	inst.Terminal.SetLaunching(label)
+	if a.spinning {
+		return nil
+	}
+	a.spinning = true
+	return launchSpinnerTick()
+}
+
+// advanceLaunchSpinners steps every launching pane's spinner and keeps
+// ticking while any pane is still waiting for output.
+func (a *App) advanceLaunchSpinners() tea.Cmd {
+	launching := false
+	for id, inst := range a.terminals {
+		if inst.Terminal.IsLaunching() {
+			inst.Terminal.SetQueued(a.engine.QueuePosition(id))
+			inst.Terminal.AdvanceSpinner()
+			launching = true
+		}
+	}
+	if !launching {
+		a.spinning = false
+		return nil
+	}
+	return launchSpinnerTick()
+}
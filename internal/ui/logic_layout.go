@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/layout"
+	"github.com/lazyvibe/vibemux/internal/runtime"
+	"github.com/lazyvibe/vibemux/pkg/utils"
+)
+
+// exportLayout writes the open panes as a tmuxinator or zellij layout.
+// Usage: export-layout <tmux|zellij> [path]
+func (a *App) exportLayout(args []string) tea.Cmd {
+	if len(args) == 0 {
+		a.toasts.Push("Usage: export-layout <tmux|zellij> [path]", true)
+		return nil
+	}
+	format, err := layout.ParseFormat(args[0])
+	if err != nil {
+		a.toasts.Push(err.Error(), true)
+		return nil
+	}
+
+	panes := a.layoutPanes()
+	if len(panes) == 0 {
+		a.toasts.Push("No open panes to export", true)
+		return nil
+	}
+
+	path := filepath.Join(a.configDir, "exports", "vibemux"+format.Extension())
+	if len(args) > 1 {
+		path = utils.ExpandPath(args[1])
+	}
+	rows, cols := a.gridActiveDims()
+	content := layout.Export(format, "vibemux", panes, rows, cols)
+
+	return func() tea.Msg {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return ErrorMsg{Err: err}
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return ErrorMsg{Err: err}
+		}
+		return StatusMsg{Text: "Layout exported to " + path}
+	}
+}
+
+// layoutPanes describes the open panes in grid order, as they were
+// launched: with their profile, command and directory. Secret-looking
+// environment variables are left out so exported files can be shared.
+func (a *App) layoutPanes() []layout.Pane {
+	var panes []layout.Pane
+	for _, id := range a.gridOrder() {
+		project := a.projectForSession(id)
+		if project == nil {
+			continue
+		}
+		opts := a.bindings[id].Opts
+		pane := layout.Pane{
+			Name:    project.DisplayName() + runtime.SessionSuffix(id, project.ID),
+			Dir:     project.Path,
+			Command: []string{defaultProfileCommand()},
+		}
+		if dir := opts.workDir(project); dir != "" {
+			pane.Dir = dir
+		}
+		// Export the argv vibemux itself runs, placeholders, tool flags and
+		// container included. Without a driver that can build it here,
+		// fall back to the command line as written.
+		profile := opts.launchProfile(a.profileForSession(id))
+		if profile != nil {
+			if args, err := a.engine.LaunchArgs(project, profile, opts.workDir(project), id); err == nil {
+				pane.Command = args
+			} else if profile.Command != "" {
+				pane.Command = append(splitCommand(profile.Command), profile.CommandArgs...)
+			}
+			for k, v := range profile.EnvVars {
+				if utils.IsSecretEnvKey(k) {
+					continue
+				}
+				if pane.Env == nil {
+					pane.Env = make(map[string]string)
+				}
+				pane.Env[k] = v
+			}
+		}
+		panes = append(panes, pane)
+	}
+	return panes
+}
+
+// splitCommand splits a command line into its arguments, or at spaces
+// when its quotes do not add up.
+func splitCommand(line string) []string {
+	if args, err := utils.SplitCommandLine(line); err == nil {
+		return args
+	}
+	return strings.Fields(line)
+}
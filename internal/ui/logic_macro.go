@@ -0,0 +1,192 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/app"
+	"github.com/lazyvibe/vibemux/internal/model"
+)
+
+// macroKeyDelay spaces replayed keystrokes so TUIs read them one by one.
+const macroKeyDelay = 20 * time.Millisecond
+
+// macroState tracks keystroke macro recording.
+type macroState struct {
+	recording string   // Register being recorded, empty when idle
+	keys      []string // Keystrokes recorded so far, as PTY input
+	last      string   // Register last recorded or played, for `@`
+}
+
+// recordMacroKey appends PTY input typed in terminal mode to the macro
+// being recorded.
+func (a *App) recordMacroKey(input []byte) {
+	if a.macro.recording == "" || len(input) == 0 {
+		return
+	}
+	a.macro.keys = append(a.macro.keys, string(input))
+}
+
+// macroCommand handles `:macro record|stop|play|list|delete`.
+func (a *App) macroCommand(args []string) tea.Cmd {
+	if len(args) == 0 {
+		a.toasts.Push("Usage: macro record <reg> | stop | play <reg> [pane|all] | list | delete <reg>", true)
+		return nil
+	}
+	switch strings.ToLower(args[0]) {
+	case "record", "rec":
+		if len(args) < 2 || !validRegister(args[1]) {
+			a.toasts.Push("Usage: macro record <reg> (a single letter or digit)", true)
+			return nil
+		}
+		a.macro.recording = strings.ToLower(args[1])
+		a.macro.keys = nil
+		a.updateFocusStyles()
+		a.toasts.Push("Recording macro @"+a.macro.recording+" — type in terminal mode, then :macro stop", false)
+	case "stop":
+		if a.macro.recording == "" {
+			a.toasts.Push("Not recording a macro", true)
+			return nil
+		}
+		reg, keys := a.macro.recording, a.macro.keys
+		a.macro.recording = ""
+		a.macro.keys = nil
+		a.updateFocusStyles()
+		if len(keys) == 0 {
+			a.toasts.Push("Macro @"+reg+" is empty; nothing saved", true)
+			return nil
+		}
+		if err := a.saveMacro(reg, keys); err != nil {
+			a.toasts.Push("Failed to save macro: "+err.Error(), true)
+			return nil
+		}
+		a.macro.last = reg
+		a.toasts.Push(fmt.Sprintf("Macro @%s saved (%d keys)", reg, len(keys)), false)
+	case "play":
+		if len(args) < 2 {
+			a.toasts.Push("Usage: macro play <reg> [pane|all]", true)
+			return nil
+		}
+		return a.playMacro(strings.ToLower(args[1]), strings.Join(args[2:], " "))
+	case "list", "ls":
+		if a.config == nil || len(a.config.Macros) == 0 {
+			a.toasts.Push("No macros recorded", false)
+			return nil
+		}
+		regs := make([]string, 0, len(a.config.Macros))
+		for reg, keys := range a.config.Macros {
+			regs = append(regs, fmt.Sprintf("@%s (%d keys)", reg, len(keys)))
+		}
+		sort.Strings(regs)
+		a.toasts.Push("Macros: "+strings.Join(regs, ", "), false)
+	case "delete", "rm":
+		if len(args) < 2 {
+			a.toasts.Push("Usage: macro delete <reg>", true)
+			return nil
+		}
+		if err := a.saveMacro(strings.ToLower(args[1]), nil); err != nil {
+			a.toasts.Push("Failed to delete macro: "+err.Error(), true)
+			return nil
+		}
+		a.toasts.Push("Macro @"+strings.ToLower(args[1])+" deleted", false)
+	default:
+		a.toasts.Push("Unknown macro action: "+args[0], true)
+	}
+	return nil
+}
+
+// playMacro replays a register into the active pane, a named pane or all
+// running panes.
+func (a *App) playMacro(reg, target string) tea.Cmd {
+	var keys []string
+	if a.config != nil {
+		keys = a.config.Macros[reg]
+	}
+	if len(keys) == 0 {
+		a.toasts.Push("Macro @"+reg+" is empty", true)
+		return nil
+	}
+	if a.macro.recording != "" {
+		a.toasts.Push("Stop recording before playing a macro", true)
+		return nil
+	}
+
+	var ids []string
+	switch {
+	case strings.EqualFold(target, "all"):
+		ids = a.broadcastTargets()
+	case target != "":
+		id, ok := a.resolvePane(target)
+		if !ok {
+			a.toasts.Push("Unknown pane: "+target, true)
+			return nil
+		}
+		ids = []string{id}
+	default:
+		ids = []string{a.activeTermID}
+	}
+
+	return a.confirmBroadcast("macro @"+reg, ids, strings.Join(keys, ""), func(a *App) tea.Cmd {
+		return a.runMacro(reg, keys, ids)
+	})
+}
+
+// runMacro replays a macro's keystrokes into the given panes.
+func (a *App) runMacro(reg string, keys, ids []string) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, id := range ids {
+		session, ok := a.engine.GetSession(id)
+		if !ok || session.Status() != model.SessionStatusRunning {
+			continue
+		}
+		cmds = append(cmds, func() tea.Msg {
+			for _, k := range keys {
+				session.Write([]byte(k))
+				time.Sleep(macroKeyDelay)
+			}
+			return nil
+		})
+	}
+	if len(cmds) == 0 {
+		a.toasts.Push("No running pane to play the macro into", true)
+		return nil
+	}
+	a.macro.last = reg
+	a.toasts.Push(fmt.Sprintf("Playing macro @%s into %d pane(s)", reg, len(cmds)), false)
+	return tea.Batch(cmds...)
+}
+
+// saveMacro stores (or with no keys, removes) a register in the config.
+func (a *App) saveMacro(reg string, keys []string) error {
+	if a.config == nil {
+		return nil
+	}
+	updated := *a.config
+	updated.Macros = make(map[string][]string, len(a.config.Macros)+1)
+	for r, k := range a.config.Macros {
+		updated.Macros[r] = k
+	}
+	if len(keys) == 0 {
+		delete(updated.Macros, reg)
+	} else {
+		updated.Macros[reg] = keys
+	}
+	if a.configDir != "" {
+		if err := app.SaveConfig(a.configDir, &updated); err != nil {
+			return err
+		}
+	}
+	*a.config = updated
+	return nil
+}
+
+func validRegister(reg string) bool {
+	if len(reg) != 1 {
+		return false
+	}
+	c := reg[0] | 0x20
+	return (c >= 'a' && c <= 'z') || (reg[0] >= '0' && reg[0] <= '9')
+}
@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/runtime"
+)
+
+// minutesInlineChars is the transcript size up to which it is pasted into
+// the summarizer; longer transcripts are left for the agent to read.
+const minutesInlineChars = 16000
+
+// minutesAgent is the chain entry name of meeting minutes.
+const minutesAgent = "Meeting Minutes"
+
+// minutesCommand handles "minutes [pane]": it has the summarizer pane write
+// the minutes of the organizer discussion. The pane defaults to the
+// "meeting_summarizer" setting, then to the organizer.
+func (a *App) minutesCommand(args []string) tea.Cmd {
+	if a.turnFilename == "" {
+		a.toasts.Push("No organizer discussion (assign roles with Alt+F first)", true)
+		return nil
+	}
+	transcript, err := os.ReadFile(a.turnFilename)
+	if err != nil {
+		a.toasts.Push("Cannot read the discussion file: "+err.Error(), true)
+		return nil
+	}
+	if strings.TrimSpace(string(transcript)) == "" {
+		a.toasts.Push("The discussion file is empty", true)
+		return nil
+	}
+	ref := strings.Join(args, " ")
+	if ref == "" && a.config != nil {
+		ref = a.config.MeetingSummarizer
+	}
+	var id string
+	if ref != "" {
+		var ok bool
+		if id, ok = a.resolvePane(ref); !ok {
+			a.toasts.Push("No pane "+ref, true)
+			return nil
+		}
+	} else if id = a.organizerID(); id == "" {
+		a.toasts.Push("No organizer pane to summarize in", true)
+		return nil
+	}
+
+	prompt := runtime.MeetingSummaryRequest + "\n\n" + string(transcript)
+	if utf8.RuneCount(transcript) > minutesInlineChars {
+		prompt = runtime.MeetingSummaryRequest + "\nThe transcript is in " + a.turnFilename + "; read all of it first."
+	}
+	return a.startSummaryPass(id, "the discussion", prompt, func(a *App, summary string) tea.Cmd {
+		if summary == "" {
+			return nil
+		}
+		return a.saveMinutes(summary)
+	})
+}
+
+// organizerID returns the pane holding the ORGANIZER role, or the first pane
+// of the turn sequence.
+func (a *App) organizerID() string {
+	for _, id := range a.gridOrder() {
+		if strings.EqualFold(a.turnRoles[id], "ORGANIZER") {
+			return id
+		}
+	}
+	if len(a.turnSequence) > 0 {
+		return a.turnSequence[0]
+	}
+	return ""
+}
+
+// minutesPath returns the minutes file next to the discussion file, e.g.
+// discussion.summary.md for discussion.md.
+func (a *App) minutesPath() string {
+	base := strings.TrimSuffix(a.turnFilename, ".md")
+	return base + ".summary.md"
+}
+
+// saveMinutes writes the minutes file, adds the minutes to the chain and
+// shows them in the file preview.
+func (a *App) saveMinutes(summary string) tea.Cmd {
+	path := a.minutesPath()
+	content := fmt.Sprintf("# %s — Minutes\n\n_%s_\n\n%s\n", a.turnTopic, time.Now().Format("2006-01-02 15:04"), summary)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		a.toasts.Push("Failed to save minutes: "+err.Error(), true)
+	} else if a.dialogMode == DialogNone {
+		a.filePreview.SetFilter(nil)
+		a.filePreview.SetFile(path)
+		a.filePreview.SetSize(a.width, a.height)
+		a.dialogMode = DialogFilePreview
+	} else {
+		a.toasts.Push("Minutes saved to "+path, false)
+	}
+	if a.chainContext == nil {
+		return nil
+	}
+	ctx := a.chainContext
+	ctx.AppendConclusion(minutesAgent, summary)
+	return a.recordChainEntry(ctx.SessionID, minutesAgent, summary)
+}
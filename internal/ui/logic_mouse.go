@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// multiClickInterval is the longest gap between clicks of a double or
+// triple click.
+const multiClickInterval = 400 * time.Millisecond
+
+// mouseState tracks clicks and drags for pane text selection and project
+// double-clicks.
+type mouseState struct {
+	lastClick time.Time
+	lastX     int
+	lastY     int
+	clicks    int
+	dragging  string // Session ID of the pane being selected in
+}
+
+// handleMouseSelection handles left-button press, drag and release over
+// panes. Double-click selects a word, triple-click a line; the selection is
+// copied to the clipboard on release. Presses outside the panes go to the
+// project list and the tab strip.
+func (a *App) handleMouseSelection(msg tea.MouseMsg) tea.Cmd {
+	if msg.Button != tea.MouseButtonLeft && msg.Action != tea.MouseActionRelease {
+		return nil
+	}
+	switch msg.Action {
+	case tea.MouseActionPress:
+		a.countClick(msg)
+		index, x, y, ok := a.paneAt(msg.X, msg.Y)
+		if !ok {
+			return a.handleMouseClick(msg)
+		}
+		id := a.gridOrder()[index]
+		inst, ok := a.terminals[id]
+		if !ok {
+			return nil
+		}
+		for _, other := range a.terminals {
+			other.Terminal.ClearSelection()
+		}
+		a.focus = FocusTerminal
+		a.setActivePane(index)
+		if inst.Terminal.SelectAt(x, y, a.mouse.clicks) {
+			a.mouse.dragging = id
+		}
+
+	case tea.MouseActionMotion:
+		inst, ok := a.terminals[a.mouse.dragging]
+		if !ok {
+			return nil
+		}
+		_, x, y, _ := a.paneAt(msg.X, msg.Y)
+		inst.Terminal.ExtendSelection(x, y)
+
+	case tea.MouseActionRelease:
+		inst, ok := a.terminals[a.mouse.dragging]
+		a.mouse.dragging = ""
+		if !ok {
+			return nil
+		}
+		if text := inst.Terminal.SelectedText(); text != "" {
+			return copyToClipboard(text)
+		}
+		inst.Terminal.ClearSelection()
+	}
+	return nil
+}
+
+// countClick records a press and counts it as a single, double or triple
+// click.
+func (a *App) countClick(msg tea.MouseMsg) {
+	now := time.Now()
+	if now.Sub(a.mouse.lastClick) <= multiClickInterval && msg.X == a.mouse.lastX && msg.Y == a.mouse.lastY {
+		a.mouse.clicks = a.mouse.clicks%3 + 1
+	} else {
+		a.mouse.clicks = 1
+	}
+	a.mouse.lastClick, a.mouse.lastX, a.mouse.lastY = now, msg.X, msg.Y
+}
+
+// handleMouseClick handles a press outside the panes. Clicking a project
+// selects it and a double-click opens it; clicking a tab in the compact
+// layout switches to its pane.
+func (a *App) handleMouseClick(msg tea.MouseMsg) tea.Cmd {
+	if a.overProjectList(msg.X, msg.Y) {
+		index, ok := a.projectList.ItemAt(msg.Y)
+		if !ok {
+			return nil
+		}
+		a.projectList.Select(index)
+		a.focus = FocusProjects
+		a.updateFocusStyles()
+		if a.mouse.clicks >= 2 {
+			a.mouse.clicks = 0
+			return a.openSelectedProject()
+		}
+		return nil
+	}
+	if a.compactLayout() && msg.Y == 0 {
+		if id, ok := a.sessionTabs.TabAt(msg.X); ok {
+			a.focus = FocusTerminal
+			a.setActivePaneByProject(id)
+		}
+	}
+	return nil
+}
+
+// handleMouseWheel scrolls the pane under the pointer by three lines, or
+// moves the project list cursor when the pointer is over the list.
+func (a *App) handleMouseWheel(msg tea.MouseMsg) {
+	up := msg.Button == tea.MouseButtonWheelUp
+	if a.overProjectList(msg.X, msg.Y) {
+		if up {
+			a.projectList.CursorUp()
+		} else {
+			a.projectList.CursorDown()
+		}
+		return
+	}
+	id := a.activeTermID
+	if index, _, _, ok := a.paneAt(msg.X, msg.Y); ok {
+		id = a.gridOrder()[index]
+	}
+	inst, ok := a.terminals[id]
+	if !ok {
+		return
+	}
+	if up {
+		inst.Terminal.HandleKey("shift+up")
+	} else {
+		inst.Terminal.HandleKey("shift+down")
+	}
+}
+
+// overProjectList reports whether screen cell (x, y) is on the project
+// list panel.
+func (a *App) overProjectList(x, y int) bool {
+	if a.compactLayout() {
+		return a.compactShowsProjects()
+	}
+	leftWidth, _, contentHeight, _, _ := a.gridLayout()
+	return x < leftWidth && y < contentHeight
+}
+
+// paneAt maps screen coordinates to a grid pane and pane-relative
+// coordinates. Coordinates are returned even outside any pane, relative to
+// the pane being dragged in, so drags can run past the pane edge.
+func (a *App) paneAt(x, y int) (int, int, int, bool) {
+	if a.compactLayout() {
+		if a.compactShowsProjects() {
+			return 0, x, y, false
+		}
+		// One-line tab strip above the single pane.
+		return a.activePane, x, y - 1, y >= 1 && y < a.height-1
+	}
+	leftWidth, _, contentHeight, colWidths, rowHeights := a.gridLayout()
+	if a.zoomActive() {
+		return a.activePane, x - leftWidth, y, a.mouse.dragging != "" || (x >= leftWidth && y < contentHeight)
+	}
+	ids := a.gridOrder()
+	if id := a.mouse.dragging; id != "" {
+		if i := indexOfID(ids, id); i >= 0 && len(colWidths) > 0 {
+			px, py := a.paneOrigin(i, leftWidth, colWidths, rowHeights)
+			return i, x - px, y - py, true
+		}
+	}
+	if x < leftWidth || y >= contentHeight || len(colWidths) == 0 {
+		return 0, x, y, false
+	}
+	col, px := 0, leftWidth
+	for col < len(colWidths)-1 && x >= px+colWidths[col] {
+		px += colWidths[col]
+		col++
+	}
+	row, py := 0, 0
+	for row < len(rowHeights)-1 && y >= py+rowHeights[row] {
+		py += rowHeights[row]
+		row++
+	}
+	index := row*len(colWidths) + col
+	if index >= len(ids) {
+		return 0, x, y, false
+	}
+	return index, x - px, y - py, true
+}
+
+// paneOrigin returns the top-left screen cell of grid pane i.
+func (a *App) paneOrigin(i, leftWidth int, colWidths, rowHeights []int) (int, int) {
+	cols := len(colWidths)
+	px, py := leftWidth, 0
+	for c := 0; c < i%cols; c++ {
+		px += colWidths[c]
+	}
+	for r := 0; r < i/cols && r < len(rowHeights); r++ {
+		py += rowHeights[r]
+	}
+	return px, py
+}
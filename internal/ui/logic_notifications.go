@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/notify"
+	"github.com/lazyvibe/vibemux/internal/ui/components/notifylist"
+)
+
+// maxNotifications caps the notification center's history.
+const maxNotifications = 200
+
+// recordNotifications adds events raised by a pane (sessionID, or "" for
+// app-wide events) to the notification center and badges the pane's tab
+// when it is not the active one.
+func (a *App) recordNotifications(sessionID string, events []notify.Event) {
+	for _, ev := range events {
+		label := ev.ProjectName
+		if inst, ok := a.terminals[sessionID]; ok && inst.ProjectName != "" {
+			label = inst.ProjectName
+		}
+		at := ev.Timestamp
+		if at.IsZero() {
+			at = time.Now()
+		}
+		a.notifications = append(a.notifications, notifylist.Entry{
+			SessionID: sessionID,
+			Project:   label,
+			Type:      ev.Type,
+			Title:     ev.Title,
+			Message:   ev.Message,
+			Time:      at,
+		})
+		if sessionID != "" {
+			a.sessionTabs.MarkTabUnread(sessionID)
+		}
+	}
+	if n := len(a.notifications) - maxNotifications; n > 0 {
+		a.notifications = append([]notifylist.Entry(nil), a.notifications[n:]...)
+	}
+	if a.dialogMode == DialogNotifications {
+		a.notifyList.SetEntries(a.notificationsNewestFirst())
+	}
+}
+
+// notificationsNewestFirst returns the history in display order.
+func (a *App) notificationsNewestFirst() []notifylist.Entry {
+	out := make([]notifylist.Entry, len(a.notifications))
+	for i, e := range a.notifications {
+		out[len(out)-1-i] = e
+	}
+	return out
+}
+
+// rekeyNotifications points the history of a moved session at its new ID.
+func (a *App) rekeyNotifications(oldID, newID, label string) {
+	for i, e := range a.notifications {
+		if e.SessionID == oldID {
+			a.notifications[i].SessionID = newID
+			a.notifications[i].Project = label
+		}
+	}
+}
+
+// showNotifications opens the notification center. Entries that arrived
+// since it was last opened are marked new.
+func (a *App) showNotifications() {
+	a.notifyList.SetSize(a.width, a.height)
+	a.notifyList.Open(a.notificationsNewestFirst(), a.notificationsSeen)
+	a.notificationsSeen = time.Now()
+	a.dialogMode = DialogNotifications
+}
+
+// notifyListAction carries out what the notification center asked for.
+func (a *App) notifyListAction() tea.Cmd {
+	switch a.notifyList.Action() {
+	case notifylist.ActionClose:
+		a.hideDialog()
+	case notifylist.ActionClear:
+		a.notifications = nil
+		a.sessionTabs.ClearUnread()
+		a.notifyList.SetEntries(nil)
+		a.toasts.Push("Notifications cleared", false)
+	case notifylist.ActionJump:
+		e, ok := a.notifyList.Selected()
+		if !ok {
+			break
+		}
+		if !a.hasPane(e.SessionID) {
+			a.toasts.Push("That pane is closed", true)
+			break
+		}
+		a.hideDialog()
+		a.focusPane(e.SessionID)
+	}
+	return nil
+}
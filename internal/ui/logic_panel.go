@@ -0,0 +1,193 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/app"
+)
+
+// projectPanelMode is the width of the project list panel.
+type projectPanelMode int
+
+const (
+	panelWide projectPanelMode = iota
+	panelNarrow
+	panelHidden
+)
+
+// narrowPanelWidth fits a border, a status dot and a couple of initials.
+const narrowPanelWidth = 8
+
+func (m projectPanelMode) String() string {
+	switch m {
+	case panelNarrow:
+		return "narrow"
+	case panelHidden:
+		return "hidden"
+	default:
+		return "wide"
+	}
+}
+
+// parseProjectPanelMode reads the persisted panel mode; unknown values are wide.
+func parseProjectPanelMode(cfg *app.Config) projectPanelMode {
+	if cfg == nil {
+		return panelWide
+	}
+	switch cfg.ProjectPanel {
+	case "narrow":
+		return panelNarrow
+	case "hidden":
+		return panelHidden
+	default:
+		return panelWide
+	}
+}
+
+// projectPanelVisible reports whether the project list is shown. It is
+// always shown while no panes are open, so there is something to select,
+// and in the compact layout, where it takes the whole window when focused.
+func (a *App) projectPanelVisible() bool {
+	return a.panelMode != panelHidden || len(a.sessionTabs.Tabs()) == 0 || a.compactLayout()
+}
+
+// projectPanelWidth returns the width of the project list panel.
+func (a *App) projectPanelWidth() int {
+	if !a.projectPanelVisible() {
+		return 0
+	}
+	if a.panelMode == panelNarrow {
+		return narrowPanelWidth
+	}
+	// Left panel (project list): 25% width
+	leftWidth := a.width * 25 / 100
+	if leftWidth < 20 {
+		leftWidth = 20
+	}
+	if leftWidth > 40 {
+		leftWidth = 40
+	}
+	return leftWidth
+}
+
+// toggleFullScreen collapses the project list or restores its previous width.
+func (a *App) toggleFullScreen() {
+	if a.panelMode == panelHidden {
+		a.setProjectPanel(a.panelRestore)
+		return
+	}
+	a.panelRestore = a.panelMode
+	a.setProjectPanel(panelHidden)
+}
+
+// cycleProjectPanel steps the project list through wide, narrow and hidden.
+func (a *App) cycleProjectPanel() {
+	next := (a.panelMode + 1) % 3
+	if next != panelHidden {
+		a.panelRestore = next
+	}
+	a.setProjectPanel(next)
+	a.toasts.Push("Project list: "+next.String(), false)
+}
+
+// setProjectPanel applies and persists a panel mode.
+func (a *App) setProjectPanel(mode projectPanelMode) {
+	a.panelMode = mode
+	if a.config != nil && a.configDir != "" {
+		updated := *a.config
+		updated.ProjectPanel = mode.String()
+		if mode == panelWide {
+			updated.ProjectPanel = ""
+		}
+		if err := app.SaveConfig(a.configDir, &updated); err != nil {
+			a.toasts.Push("Failed to save config: "+err.Error(), true)
+		} else {
+			*a.config = updated
+		}
+	}
+	if !a.projectPanelVisible() && a.focus == FocusProjects {
+		a.focus = FocusTerminal
+		if a.activeTermID != "" {
+			a.setActivePaneByProject(a.activeTermID)
+		} else {
+			a.setActivePane(0)
+		}
+	}
+	a.SetSize(a.width, a.height)
+}
+
+// toggleDebugOverlay shows or hides each pane's PTY, emulator and box sizes,
+// for diagnosing layout glitches caused by size mismatches.
+func (a *App) toggleDebugOverlay() {
+	a.debugOverlay = !a.debugOverlay
+	for _, inst := range a.terminals {
+		inst.Terminal.SetDebug(a.debugOverlay)
+	}
+	if a.debugOverlay {
+		a.toasts.Push("Size overlay on (yellow = PTY and emulator disagree)", false)
+	} else {
+		a.toasts.Push("Size overlay off", false)
+	}
+}
+
+// syncDroppedOutput copies each session's dropped-output count to its pane.
+func (a *App) syncDroppedOutput() {
+	for id, inst := range a.terminals {
+		if session, ok := a.engine.GetSession(id); ok {
+			inst.Terminal.SetDropped(session.DroppedBytes())
+		}
+	}
+}
+
+// resyncPane rebuilds a pane's screen from its session's output history
+// after output was dropped.
+// Usage: resync [pane]
+func (a *App) resyncPane(args []string) {
+	id := a.activeTermID
+	if len(args) > 0 {
+		var ok bool
+		if id, ok = a.resolvePane(args[0]); !ok {
+			a.toasts.Push("Unknown pane: "+args[0], true)
+			return
+		}
+	}
+	inst, ok := a.terminals[id]
+	session, running := a.engine.GetSession(id)
+	if !ok || !running {
+		a.toasts.Push("No session to resync", true)
+		return
+	}
+	inst.Terminal.Resync(session.History(), session.DroppedBytes())
+	a.toasts.Push("Resynced "+a.paneLabel(id)+" from its output history", false)
+}
+
+// Session tab strip orders (config tab_order).
+const (
+	tabOrderOpened   = "opened"
+	tabOrderActivity = "activity"
+)
+
+// setTabOrder switches the tab strip between opening and activity order
+// and persists it.
+// Usage: tabs <opened|activity>
+func (a *App) setTabOrder(args []string) tea.Cmd {
+	if len(args) != 1 || (args[0] != tabOrderOpened && args[0] != tabOrderActivity) {
+		a.toasts.Push("Usage: tabs <opened|activity>", true)
+		return nil
+	}
+	order := args[0]
+	if a.config != nil && a.configDir != "" {
+		updated := *a.config
+		updated.TabOrder = order
+		if order == tabOrderOpened {
+			updated.TabOrder = ""
+		}
+		if err := app.SaveConfig(a.configDir, &updated); err != nil {
+			a.toasts.Push("Failed to save config: "+err.Error(), true)
+			return nil
+		}
+		*a.config = updated
+	}
+	a.sessionTabs.SetActivityOrder(order == tabOrderActivity)
+	a.toasts.Push("Tabs ordered by "+order, false)
+	return nil
+}
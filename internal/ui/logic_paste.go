@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/runtime"
+)
+
+// pendingPaste is a large terminal-mode paste waiting for confirmation.
+type pendingPaste struct {
+	targetID  string
+	data      string
+	broadcast bool
+}
+
+// pasteTooLarge reports whether a paste exceeds the configured guard.
+func (a *App) pasteTooLarge(data string) bool {
+	if a.config == nil {
+		return false
+	}
+	if limit := a.config.PasteGuardBytes; limit > 0 && len(data) > limit {
+		return true
+	}
+	if limit := a.config.PasteGuardLines; limit > 0 && strings.Count(data, "\n")+1 > limit {
+		return true
+	}
+	return false
+}
+
+// confirmPaste asks before a large paste reaches the active pane (or, in
+// broadcast mode, every pane).
+func (a *App) confirmPaste(data string) tea.Cmd {
+	target := a.paneLabel(a.activeTermID)
+	broadcast := a.dispatchMode == DispatchModeBroadcast
+	if broadcast {
+		target = "all panes"
+	}
+	msg := fmt.Sprintf("Paste %s (%d lines) into %s?\n\n%s",
+		formatBytes(len(data)), strings.Count(data, "\n")+1, target, firstLine(data))
+	a.pendingPaste = &pendingPaste{targetID: a.activeTermID, data: data, broadcast: broadcast}
+	a.confirm.SetSize(a.width, a.height)
+	a.confirm.Open("Large Paste", msg, "Paste", "Cancel")
+	a.dialogMode = DialogConfirm
+	return nil
+}
+
+// resolvePaste writes a confirmed paste.
+func (a *App) resolvePaste(choice int) tea.Cmd {
+	p := a.pendingPaste
+	a.pendingPaste = nil
+	if choice != 0 {
+		return nil
+	}
+	if p.broadcast {
+		a.broadcastInput([]byte(p.data))
+		a.broadcastLine += p.data
+		return nil
+	}
+	session, ok := a.engine.GetSession(p.targetID)
+	if !ok || session.Status() != model.SessionStatusRunning {
+		a.toasts.Push("Pane is no longer running", true)
+		return nil
+	}
+	return func() tea.Msg {
+		if err := runtime.Paste(session, []byte(p.data), runtime.DefaultPasteOptions); err != nil {
+			return StatusMsg{Text: "Paste failed: " + err.Error(), IsError: true}
+		}
+		return nil
+	}
+}
+
+// formatBytes renders a byte count as B or KB.
+func formatBytes(n int) string {
+	if n < 1024 {
+		return fmt.Sprintf("%d B", n)
+	}
+	return fmt.Sprintf("%.1f KB", float64(n)/1024)
+}
+
+// firstLine returns the first line of s, marking that more follows.
+func firstLine(s string) string {
+	line, _, more := strings.Cut(s, "\n")
+	if short := ansi.Truncate(line, 60, ""); short != line {
+		line, more = short, true
+	}
+	if more {
+		line += "…"
+	}
+	return line
+}
@@ -0,0 +1,286 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/model"
+)
+
+// pipelineSettle is how long an agent must stay quiet before its answer is
+// taken as its conclusion.
+const pipelineSettle = 6 * time.Second
+
+// chainPipeline hands the chain from agent to agent: each step injects the
+// chain context into a pane, waits for the agent to answer and go quiet,
+// adds its conclusion to the chain and moves on to the next pane.
+type chainPipeline struct {
+	steps   []string  // Session IDs in order
+	index   int       // Current step
+	rounds  int       // How often the steps run
+	round   int       // Current round, 0-based
+	started time.Time // When the current step's prompt was sent
+	sent    bool      // The current step's prompt was sent
+	paused  bool
+}
+
+// pipelineCommand handles "pipeline [start] [panes…] [xN]", "pipeline
+// stop|pause|resume|skip" and "pipeline task <text>". Panes are grid
+// numbers, aliases or project names; all panes in grid order by default.
+// Without arguments it shows the pipeline's state.
+func (a *App) pipelineCommand(args []string) tea.Cmd {
+	if len(args) == 0 {
+		a.toasts.Push(a.pipelineSummary(), false)
+		return nil
+	}
+	switch strings.ToLower(args[0]) {
+	case "stop":
+		if a.pipeline == nil {
+			a.toasts.Push("No pipeline running", true)
+			return nil
+		}
+		a.pipeline = nil
+		a.updatePipelineStatus()
+		a.toasts.Push("Pipeline stopped", false)
+		return nil
+	case "pause":
+		if a.pipeline != nil {
+			a.pipeline.paused = true
+			a.updatePipelineStatus()
+		}
+		return nil
+	case "resume":
+		return a.resumePipeline()
+	case "skip":
+		if a.pipeline == nil {
+			a.toasts.Push("No pipeline running", true)
+			return nil
+		}
+		a.pipeline.paused = false
+		return a.advancePipeline()
+	case "task":
+		if a.chainContext == nil {
+			a.toasts.Push("No chain context", true)
+			return nil
+		}
+		task := strings.TrimSpace(strings.Join(args[1:], " "))
+		if task == "" {
+			a.toasts.Push("Task: "+a.chainContext.Task, false)
+			return nil
+		}
+		a.chainContext.SetTask(task)
+		a.toasts.Push("Chain task set", false)
+		return nil
+	case "start":
+		args = args[1:]
+	}
+	return a.startPipeline(args)
+}
+
+// startPipeline builds a pipeline over the given panes and sends the chain
+// to the first one.
+func (a *App) startPipeline(args []string) tea.Cmd {
+	if a.chainContext == nil {
+		a.toasts.Push("No chain context", true)
+		return nil
+	}
+	rounds := 1
+	if n := len(args); n > 0 && strings.HasPrefix(strings.ToLower(args[n-1]), "x") {
+		if r, err := strconv.Atoi(args[n-1][1:]); err == nil && r > 0 {
+			rounds = r
+			args = args[:n-1]
+		}
+	}
+	var steps []string
+	for _, arg := range args {
+		for _, ref := range strings.Split(arg, ",") {
+			if ref = strings.TrimSpace(ref); ref == "" {
+				continue
+			}
+			id, ok := a.resolvePane(ref)
+			if !ok {
+				a.toasts.Push("No pane "+ref, true)
+				return nil
+			}
+			steps = append(steps, id)
+		}
+	}
+	if len(args) == 0 {
+		steps = a.gridOrder()
+	}
+	if len(steps) == 0 {
+		a.toasts.Push("No panes for the pipeline", true)
+		return nil
+	}
+	a.pipeline = &chainPipeline{steps: steps, rounds: rounds}
+	names := make([]string, len(steps))
+	for i, id := range steps {
+		names[i] = a.paneLabel(id)
+	}
+	a.toasts.Push("Pipeline: "+strings.Join(names, " → "), false)
+	return a.sendPipelineStep()
+}
+
+// resumePipeline continues a paused pipeline by sending its current step
+// again.
+func (a *App) resumePipeline() tea.Cmd {
+	p := a.pipeline
+	if p == nil {
+		a.toasts.Push("No pipeline running", true)
+		return nil
+	}
+	p.paused = false
+	return a.sendPipelineStep()
+}
+
+// sendPipelineStep injects the chain into the current step's pane and
+// submits it.
+func (a *App) sendPipelineStep() tea.Cmd {
+	p := a.pipeline
+	id := p.steps[p.index]
+	session, ok := a.engine.GetSession(id)
+	if !ok || session.Status() != model.SessionStatusRunning {
+		return a.pausePipeline("pane is not running: " + a.paneLabel(id))
+	}
+	p.started = time.Now()
+	p.sent = true
+	a.updatePipelineStatus()
+	return a.injectChain(id, true)
+}
+
+// pausePipeline stops the pipeline at its current step until it is resumed.
+func (a *App) pausePipeline(reason string) tea.Cmd {
+	a.pipeline.paused = true
+	a.updatePipelineStatus()
+	a.toasts.Push("Pipeline paused: "+reason+" (:pipeline resume, skip or stop)", true)
+	return nil
+}
+
+// checkPipeline takes the current agent's conclusion once it has answered
+// and gone quiet, then hands the chain to the next pane. Steps that run
+// past the profile's turn timeout pause the pipeline.
+func (a *App) checkPipeline() tea.Cmd {
+	p := a.pipeline
+	if p == nil || p.paused || !p.sent {
+		return nil
+	}
+	now := time.Now()
+	if a.summaryPass != nil {
+		// The chain or a conclusion is being summarized; hold the clock.
+		p.started = now
+		return nil
+	}
+	id := p.steps[p.index]
+	inst, ok := a.terminals[id]
+	if !ok {
+		return a.pausePipeline("pane closed: " + a.paneLabel(id))
+	}
+	var turn model.TurnConfig
+	if profile := a.profileForSession(id); profile != nil {
+		turn = profile.Turn
+	}
+	if now.Sub(p.started) > turn.Timeout() {
+		return a.pausePipeline(a.paneLabel(id) + " did not finish in time")
+	}
+	last := a.lastOutput[id]
+	if !last.After(p.started) || now.Sub(last) < pipelineSettle {
+		return nil
+	}
+	result, err := a.extractConclusion(id)
+	if err != nil {
+		return a.pausePipeline(err.Error())
+	}
+	conclusion := result.Text
+	if conclusion == "" {
+		return a.pausePipeline("no conclusion found in " + a.paneLabel(id))
+	}
+	agent := inst.ProjectName
+	if agent == "" {
+		agent = "Agent"
+	}
+	return tea.Batch(a.appendConclusion(agent, conclusion), a.advancePipeline())
+}
+
+// advancePipeline moves to the next step, or ends the pipeline after the
+// last round. While the new conclusion is being summarized, the next step
+// waits for it.
+func (a *App) advancePipeline() tea.Cmd {
+	p := a.pipeline
+	p.sent = false
+	p.index++
+	if p.index == len(p.steps) {
+		p.index = 0
+		p.round++
+	}
+	if p.round >= p.rounds {
+		a.pipeline = nil
+		a.updatePipelineStatus()
+		a.toasts.Push("Pipeline complete", false)
+		return nil
+	}
+	if a.summaryPass != nil {
+		a.updatePipelineStatus()
+		return waitPipelineSummary(p)
+	}
+	return a.sendPipelineStep()
+}
+
+// pipelineStepMsg asks to send the current step of pipeline p once no
+// summary is running.
+type pipelineStepMsg struct{ p *chainPipeline }
+
+// waitPipelineSummary polls until the summary pass ends.
+func waitPipelineSummary(p *chainPipeline) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg { return pipelineStepMsg{p: p} })
+}
+
+// handlePipelineStep sends the waiting step, or polls again.
+func (a *App) handlePipelineStep(msg pipelineStepMsg) tea.Cmd {
+	if a.pipeline == nil || a.pipeline != msg.p || a.pipeline.paused {
+		return nil
+	}
+	if a.summaryPass != nil {
+		return waitPipelineSummary(msg.p)
+	}
+	return a.sendPipelineStep()
+}
+
+// pipelineSummary describes the pipeline for the :pipeline command.
+func (a *App) pipelineSummary() string {
+	p := a.pipeline
+	if p == nil {
+		return "No pipeline running (:pipeline start [panes…] [xN])"
+	}
+	names := make([]string, len(p.steps))
+	for i, id := range p.steps {
+		names[i] = a.paneLabel(id)
+		if i == p.index {
+			names[i] = "[" + names[i] + "]"
+		}
+	}
+	text := fmt.Sprintf("Pipeline round %d/%d: %s", p.round+1, p.rounds, strings.Join(names, " → "))
+	if p.paused {
+		text += " (paused)"
+	}
+	return text
+}
+
+// updatePipelineStatus shows the pipeline's progress in the status bar.
+func (a *App) updatePipelineStatus() {
+	p := a.pipeline
+	if p == nil {
+		a.statusBar.SetPipelineInfo("")
+		return
+	}
+	info := fmt.Sprintf("PIPE %d/%d ▸ %s", p.index+1, len(p.steps), a.paneLabel(p.steps[p.index]))
+	if p.rounds > 1 {
+		info += fmt.Sprintf(" R%d/%d", p.round+1, p.rounds)
+	}
+	if p.paused {
+		info += " ⏸"
+	}
+	a.statusBar.SetPipelineInfo(info)
+}
@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/app"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/ui/components/dialog"
+	"github.com/lazyvibe/vibemux/pkg/utils"
+)
+
+// exportProfilesCommand writes the local profiles to a JSON file to share
+// with a team, secrets masked.
+// Usage: export-profiles [file]
+func (a *App) exportProfilesCommand(args []string) tea.Cmd {
+	var profiles []model.Profile
+	for _, p := range a.profiles {
+		if !p.Shared {
+			profiles = append(profiles, p)
+		}
+	}
+	if len(profiles) == 0 {
+		a.toasts.Push("No local profiles to export", true)
+		return nil
+	}
+	file := ""
+	if len(args) > 0 {
+		file = utils.ExpandPath(strings.Join(args, " "))
+	}
+	return a.exportProfiles(file, "vibemux-profiles.json", profiles)
+}
+
+// exportSelectedProfile exports the profile selected in the profile manager.
+func (a *App) exportSelectedProfile() tea.Cmd {
+	profile := a.profileList.SelectedProfile()
+	if profile == nil {
+		return nil
+	}
+	name := "profile-" + unsafeFileChars.ReplaceAllString(strings.ToLower(profile.Name), "-") + ".json"
+	return a.exportProfiles("", name, []model.Profile{*profile})
+}
+
+// exportProfiles writes profiles to file, or to name in the exports
+// directory when file is empty.
+func (a *App) exportProfiles(file, name string, profiles []model.Profile) tea.Cmd {
+	if file == "" {
+		if a.configDir == "" {
+			a.toasts.Push("Export needs a config directory or a file", true)
+			return nil
+		}
+		file = filepath.Join(a.configDir, "exports", name)
+	}
+	return func() tea.Msg {
+		masked, err := app.ExportProfiles(file, profiles, false)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+		text := fmt.Sprintf("%d profiles exported to %s", len(profiles), file)
+		if masked > 0 {
+			text += fmt.Sprintf(" (%d secrets masked)", masked)
+		}
+		return StatusMsg{Text: text}
+	}
+}
+
+// importProfilesCommand adds the profiles in a file exported by
+// export-profiles. A profile whose ID exists locally replaces it, keeping
+// its default flag.
+// Usage: import-profiles <file>
+func (a *App) importProfilesCommand(args []string) tea.Cmd {
+	if len(args) == 0 {
+		a.toasts.Push("Usage: import-profiles <file>", true)
+		return nil
+	}
+	file := utils.ExpandPath(strings.Join(args, " "))
+	local := make(map[string]model.Profile)
+	var localList []model.Profile
+	for _, p := range a.profiles {
+		if !p.Shared {
+			local[p.ID] = p
+			localList = append(localList, p)
+		}
+	}
+	profiles, missing, err := app.ImportProfiles(file, localList)
+	if err != nil {
+		a.toasts.Push("Import failed: "+err.Error(), true)
+		return nil
+	}
+	added, updated := 0, 0
+	for i := range profiles {
+		p := &profiles[i]
+		if old, ok := local[p.ID]; ok {
+			p.IsDefault = old.IsDefault
+			err = a.store.UpdateProfile(a.ctx, p)
+			updated++
+		} else {
+			err = a.store.CreateProfile(a.ctx, p)
+			added++
+		}
+		if err != nil {
+			a.toasts.Push("Import failed at "+p.Name+": "+err.Error(), true)
+			return a.loadProfiles()
+		}
+	}
+	a.toasts.Push(fmt.Sprintf("Profiles imported: %d added, %d updated", added, updated), false)
+	if missing > 0 {
+		a.toasts.Push(fmt.Sprintf("%d masked secrets were not restored; re-enter them in the profile dialog", missing), true)
+	}
+	return a.loadProfiles()
+}
+
+// showImportProfilesPrompt opens the command line with import-profiles
+// typed in.
+func (a *App) showImportProfilesPrompt() {
+	a.commandDialog = dialog.NewInputDialog("Command", []dialog.InputField{
+		{Label: "Command", Value: "import-profiles "},
+	})
+	a.commandDialog.SetSize(a.width, a.height)
+	a.dialogMode = DialogCommand
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/model"
+)
+
+// Choices of the create-folder confirmation, in display order.
+const (
+	createDirOnly = iota
+	createDirGit
+)
+
+// confirmCreateDir asks whether to create a missing project directory
+// before adding the project.
+func (a *App) confirmCreateDir(project *model.Project) {
+	a.pendingProject = project
+	a.confirm.SetSize(a.width, a.height)
+	a.confirm.Open(
+		"Create Project Folder?",
+		project.Path+" does not exist.",
+		"Create", "Git init too", "Cancel",
+	)
+	a.dialogMode = DialogConfirm
+}
+
+// resolveConfirm acts on the confirmation choice.
+func (a *App) resolveConfirm(choice int) tea.Cmd {
+	if a.pendingBroadcast != nil {
+		return a.resolveBroadcast(choice)
+	}
+	if a.pendingStuck != "" {
+		return a.resolveStuck(choice)
+	}
+	if a.pendingPaste != nil {
+		return a.resolvePaste(choice)
+	}
+	if a.pendingExtract != nil {
+		return a.resolveExtract(choice)
+	}
+	if a.pendingWorktree != nil {
+		return a.resolveWorktreeCleanup(choice)
+	}
+	project := a.pendingProject
+	a.pendingProject = nil
+	if project == nil {
+		return nil
+	}
+	switch choice {
+	case createDirOnly, createDirGit:
+		return a.createProjectDir(project, choice == createDirGit)
+	}
+	return nil
+}
+
+// createProjectDir creates the project directory, optionally runs git init
+// in it, and then saves the project.
+func (a *App) createProjectDir(project *model.Project, gitInit bool) tea.Cmd {
+	return func() tea.Msg {
+		if err := os.MkdirAll(project.Path, 0o755); err != nil {
+			return ErrorMsg{Err: fmt.Errorf("create project folder: %w", err)}
+		}
+		if gitInit {
+			cmd := exec.Command("git", "init")
+			cmd.Dir = project.Path
+			if out, err := cmd.CombinedOutput(); err != nil {
+				return ErrorMsg{Err: fmt.Errorf("git init: %v: %s", err, out)}
+			}
+		}
+		if err := a.store.Create(a.ctx, project); err != nil {
+			return ErrorMsg{Err: err}
+		}
+		return ProjectCreatedMsg{Project: *project}
+	}
+}
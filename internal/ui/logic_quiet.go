@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/notify"
+)
+
+// checkAllQuiet fires a single notification once every running session has
+// produced no output for the configured number of minutes. It re-arms as soon
+// as any session prints again.
+func (a *App) checkAllQuiet() tea.Cmd {
+	if a.config == nil || a.config.AllQuietMinutes <= 0 || a.allQuietFired {
+		return nil
+	}
+	quietFor := time.Duration(a.config.AllQuietMinutes) * time.Minute
+
+	running := 0
+	for _, s := range a.engine.ListSessions() {
+		if s.Status() != model.SessionStatusRunning {
+			continue
+		}
+		running++
+		// Sessions are stamped when they start; one without a stamp
+		// was never seen printing.
+		if last, ok := a.lastOutput[s.ID()]; ok && time.Since(last) < quietFor {
+			return nil
+		}
+	}
+	if running == 0 {
+		return nil
+	}
+
+	a.allQuietFired = true
+	msg := fmt.Sprintf("All %d sessions have been idle for %d min", running, a.config.AllQuietMinutes)
+	a.toasts.Push(msg, false)
+	events := []notify.Event{{
+		Type:      notify.EventAllQuiet,
+		Title:     "All quiet",
+		Message:   msg,
+		Timestamp: time.Now(),
+	}}
+	a.recordNotifications("", events)
+	return a.dispatchNotifications(a.profileForProject(nil), events)
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/ui/components/quickopen"
+	"github.com/lazyvibe/vibemux/pkg/utils"
+)
+
+// showQuickOpen opens the overlay listing the most recently used projects.
+func (a *App) showQuickOpen() {
+	recent := model.RecentProjects(a.projects, model.RecentLimit)
+	if len(recent) == 0 {
+		a.toasts.Push("No projects yet", true)
+		return
+	}
+	now := time.Now()
+	items := make([]quickopen.Item, 0, len(recent))
+	for _, p := range recent {
+		detail := "never used"
+		if used := p.LastUsedTime(); !used.IsZero() {
+			detail = "used " + utils.RelativeTime(used, now)
+		}
+		items = append(items, quickopen.Item{
+			ID:      p.ID,
+			Label:   p.DisplayName(),
+			Detail:  detail,
+			Running: a.hasPane(p.ID),
+		})
+	}
+	a.quickOpen.SetSize(a.width, a.height)
+	a.quickOpen.Open("Recent Projects", items)
+	a.dialogMode = DialogQuickOpen
+}
+
+// openRecent starts or focuses the project picked in the quick-open overlay.
+func (a *App) openRecent(item quickopen.Item) tea.Cmd {
+	project := a.findProjectByID(item.ID)
+	if project == nil {
+		return nil
+	}
+	if a.config != nil && a.config.LaunchPicker && !a.hasPane(project.ID) {
+		a.showLaunchDialog(project)
+		return nil
+	}
+	return a.openProject(project, launchOptions{})
+}
+
+// touchProject records that a session of the project started, so it moves
+// to the top of the recent list. The stored copy is re-read so concurrent
+// updates (such as the recent subdirectory list) are kept.
+func (a *App) touchProject(projectID string) tea.Cmd {
+	return func() tea.Msg {
+		project, err := a.store.Get(a.ctx, projectID)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+		project.Touch()
+		if err := a.store.Update(a.ctx, project); err != nil {
+			return ErrorMsg{Err: err}
+		}
+		return ProjectUpdatedMsg{Project: *project}
+	}
+}
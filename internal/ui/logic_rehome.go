@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/model"
+)
+
+// movePane shifts the active pane within the grid; grid order follows tabs.
+func (a *App) movePane(delta int) {
+	if a.activeTermID == "" {
+		return
+	}
+	if !a.sessionTabs.MoveTab(a.activeTermID, delta) {
+		return
+	}
+	a.setActivePaneByProject(a.activeTermID)
+	a.SetSize(a.width, a.height)
+}
+
+// slotPane moves a pane to grid cell N (1-based, row-major).
+// Usage: slot <N> [pane]
+func (a *App) slotPane(args []string) tea.Cmd {
+	if len(args) == 0 {
+		a.toasts.Push("Usage: slot <N> [pane]", true)
+		return nil
+	}
+	slot, err := strconv.Atoi(args[0])
+	if err != nil || slot < 1 || slot > len(a.gridOrder()) {
+		a.toasts.Push(fmt.Sprintf("Slot must be between 1 and %d", len(a.gridOrder())), true)
+		return nil
+	}
+	id := a.activeTermID
+	if len(args) > 1 {
+		ref := strings.Join(args[1:], " ")
+		var ok bool
+		if id, ok = a.resolvePane(ref); !ok {
+			a.toasts.Push("Unknown pane: "+ref, true)
+			return nil
+		}
+	}
+	if id == "" {
+		a.toasts.Push("No active pane", true)
+		return nil
+	}
+	a.sessionTabs.MoveTabTo(id, slot-1)
+	a.setActivePaneByProject(id)
+	a.SetSize(a.width, a.height)
+	a.toasts.Push(fmt.Sprintf("Pane moved to slot %d", slot), false)
+	return nil
+}
+
+// rehomeSession re-associates the active session with another project
+// without restarting it. The process keeps its original working directory.
+func (a *App) rehomeSession(args []string) tea.Cmd {
+	if len(args) == 0 {
+		a.toasts.Push("Usage: rehome <project>", true)
+		return nil
+	}
+	oldID := a.activeTermID
+	session, ok := a.engine.GetSession(oldID)
+	if !ok || session.Status() != model.SessionStatusRunning {
+		a.toasts.Push("No running session in the active pane", true)
+		return nil
+	}
+	target := a.findProjectByRef(strings.Join(args, " "))
+	if target == nil {
+		a.toasts.Push("Unknown project: "+strings.Join(args, " "), true)
+		return nil
+	}
+	source := a.projectForSession(oldID)
+	if source != nil && source.ID == target.ID {
+		return nil
+	}
+	// Take the target's primary slot when free, otherwise run alongside
+	// its existing sessions.
+	newID := target.ID
+	if _, ok := a.engine.GetSession(newID); ok || a.hasPane(newID) {
+		newID = a.engine.NextSessionID(target.ID)
+	}
+	if err := a.engine.RekeySession(oldID, newID, target.ID); err != nil {
+		a.toasts.Push("Rehome failed: "+err.Error(), true)
+		return nil
+	}
+
+	// The process keeps its cwd, so its worktree and subdirectory carry
+	// over with the profile, command and alias.
+	opts := a.bindings[oldID].Opts
+	delete(a.bindings, oldID)
+	a.bindings[newID] = sessionBinding{ProjectID: target.ID, Opts: opts}
+	name := sessionLabel(target, newID, opts)
+	if inst, ok := a.terminals[oldID]; ok {
+		delete(a.terminals, oldID)
+		inst.SessionID = newID
+		inst.ProjectName = name
+		inst.Terminal.Rename(newID, name)
+		a.terminals[newID] = inst
+	}
+	if rec, ok := a.sessionRecords[oldID]; ok {
+		// The record keeps its ID, which keys it in the history.
+		delete(a.sessionRecords, oldID)
+		rec.ProjectID = target.ID
+		a.sessionRecords[newID] = rec
+	}
+	rekey(a.outputWatchers, oldID, newID)
+	rekey(a.lastOutput, oldID, newID)
+	rekey(a.restarts, oldID, newID)
+	rekey(a.diffBases, oldID, newID)
+	rekey(a.sessionLogs, oldID, newID)
+	rekey(a.usage, oldID, newID)
+	rekey(a.usagePIDs, oldID, newID)
+	rekey(a.pendingPrompts, oldID, newID)
+	rekey(a.turnMessages, oldID, newID)
+	rekey(a.turnRoles, oldID, newID)
+	a.rehomed[oldID] = newID
+	a.sessionTabs.RenameTab(oldID, newID, name)
+	a.rekeyNotifications(oldID, newID, name)
+	a.rekeyApprovals(oldID, newID, name)
+	if source != nil {
+		a.syncProjectRunning(source.ID)
+	}
+	a.projectList.SetRunning(target.ID, true)
+	a.activeTermID = newID
+	a.updateFocusStyles()
+	a.toasts.Push("Session moved to "+target.DisplayName(), false)
+	return nil
+}
+
+// rekey moves a session's entry in a per-session map to its new ID.
+func rekey[V any](m map[string]V, oldID, newID string) {
+	if v, ok := m[oldID]; ok {
+		delete(m, oldID)
+		m[newID] = v
+	}
+}
+
+// resolveRehomed maps a message's session ID to its current ID after a
+// rehome. The mapping is dropped once consumed, since the next output
+// reader is started under the new ID.
+func (a *App) resolveRehomed(id string) string {
+	if newID, ok := a.rehomed[id]; ok {
+		delete(a.rehomed, id)
+		return newID
+	}
+	return id
+}
+
+// findProjectByRef looks up a project by ID or case-insensitive name.
+func (a *App) findProjectByRef(ref string) *model.Project {
+	if p := a.findProjectByID(ref); p != nil {
+		return p
+	}
+	for i := range a.projects {
+		if strings.EqualFold(a.projects[i].Name, ref) || strings.EqualFold(a.projects[i].DisplayName(), ref) {
+			return &a.projects[i]
+		}
+	}
+	return nil
+}
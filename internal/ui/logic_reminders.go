@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/notify"
+)
+
+// acknowledgeInput stops reminders for a pane the user is typing into and
+// takes its request off the approval queue.
+func (a *App) acknowledgeInput(sessionID string) {
+	a.dropApprovals(sessionID)
+	if w, ok := a.outputWatchers[sessionID]; ok && w != nil {
+		w.Answered()
+		a.syncPaneActivity(sessionID, time.Now())
+	}
+}
+
+// remindUnansweredInput re-notifies about input requests that have waited
+// longer than their profile's reminder interval. Urgent reminders also go to
+// the profile's reminder webhook.
+func (a *App) remindUnansweredInput() tea.Cmd {
+	now := time.Now()
+	var cmds []tea.Cmd
+	for id, w := range a.outputWatchers {
+		profile := a.profileForSession(id)
+		project := a.projectForSession(id)
+		if w == nil || profile == nil || project == nil || profile.Notification.ReminderMinutes <= 0 {
+			continue
+		}
+		every := time.Duration(profile.Notification.ReminderMinutes) * time.Minute
+		ev, level, ok := w.DueReminder(project, every, now)
+		if !ok {
+			continue
+		}
+		a.toasts.Push("⏰ "+project.Name+": "+ev.Title, level >= 2)
+		if inst, ok := a.terminals[id]; ok {
+			inst.Terminal.Ring()
+		}
+		a.recordNotifications(id, []notify.Event{ev})
+		cmds = append(cmds, a.dispatchNotifications(profile, []notify.Event{ev}))
+		if url := profile.Notification.ReminderWebhookURL; url != "" && level >= 2 {
+			cfg := model.NotificationConfig{WebhookURL: url}
+			cmds = append(cmds, func() tea.Msg {
+				a.notifier.Dispatch(a.ctx, cfg, ev)
+				return nil
+			})
+		}
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/report"
+)
+
+// ReportGeneratedMsg is sent when the daily report has been written.
+type ReportGeneratedMsg struct {
+	Path     string
+	Narrator string // Pane asked to write the narrative, if any
+}
+
+// generateReport writes today's report and optionally asks an agent pane
+// to append a narrative summary to it.
+func (a *App) generateReport(args []string) tea.Cmd {
+	narratorID := ""
+	if len(args) > 0 {
+		id, ok := a.resolvePane(args[0])
+		if !ok {
+			a.toasts.Push("Unknown pane: "+args[0], true)
+			return nil
+		}
+		narratorID = id
+	}
+
+	configDir := a.configDir
+	return func() tea.Msg {
+		path, err := report.Generate(configDir, time.Now())
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+		if narratorID != "" {
+			session, ok := a.engine.GetSession(narratorID)
+			if !ok || session.Status() != model.SessionStatusRunning {
+				return ReportGeneratedMsg{Path: path}
+			}
+			submitPrompt(session, report.NarrativePrompt(path))
+		}
+		return ReportGeneratedMsg{Path: path, Narrator: narratorID}
+	}
+}
+
+// recordPrompt counts a prompt submitted to the given session.
+func (a *App) recordPrompt(sessionID string) {
+	if project := a.projectForSession(sessionID); project != nil {
+		a.stats.RecordPrompt(project.ID, project.Name)
+	}
+}
@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/app"
+)
+
+// Split weights: every column and row starts at splitDefault and resize
+// mode steps the active one by splitStep, within splitMin..splitMax.
+const (
+	splitDefault = 10
+	splitStep    = 2
+	splitMin     = 2
+	splitMax     = 40
+)
+
+// gridShapeKey names a grid shape in the config's grid_splits.
+func gridShapeKey(rows, cols int) string {
+	return fmt.Sprintf("%dx%d", rows, cols)
+}
+
+// gridSplit returns the column and row weights saved for a grid shape;
+// a nil list means equal sizes.
+func (a *App) gridSplit(rows, cols int) ([]int, []int) {
+	if a.config == nil {
+		return nil, nil
+	}
+	split, ok := a.config.GridSplits[gridShapeKey(rows, cols)]
+	if !ok {
+		return nil, nil
+	}
+	colWeights, rowWeights := split.Cols, split.Rows
+	if len(colWeights) != cols {
+		colWeights = nil
+	}
+	if len(rowWeights) != rows {
+		rowWeights = nil
+	}
+	return colWeights, rowWeights
+}
+
+// distributeWeighted splits total into parts sized by weights. Without a
+// weight per part it splits evenly, like distribute.
+func distributeWeighted(total, parts int, weights []int) []int {
+	if len(weights) != parts || total < parts {
+		return distribute(total, parts)
+	}
+	sum := 0
+	for _, w := range weights {
+		sum += w
+	}
+	if sum <= 0 {
+		return distribute(total, parts)
+	}
+	out := make([]int, parts)
+	used, acc := 0, 0
+	for i, w := range weights {
+		acc += w
+		end := total * acc / sum
+		out[i] = end - used
+		used = end
+	}
+	// Every part keeps at least one cell, taken from the largest.
+	for i := range out {
+		for out[i] < 1 {
+			largest := 0
+			for j := range out {
+				if out[j] > out[largest] {
+					largest = j
+				}
+			}
+			out[largest]--
+			out[i]++
+		}
+	}
+	return out
+}
+
+// startResizeMode lets the arrow keys change the active pane's column and
+// row sizes until Enter or Esc.
+func (a *App) startResizeMode() {
+	if a.compactLayout() || a.zoomActive() {
+		a.toasts.Push("Resizing needs the grid (press z to leave zoom)", true)
+		return
+	}
+	rows, cols := a.gridActiveDims()
+	if rows*cols < 2 {
+		a.toasts.Push("Only one pane: nothing to resize", true)
+		return
+	}
+	a.resizing = true
+	a.updateFocusStyles()
+	a.toasts.Push("Resize: ←/→ column, ↑/↓ row, = equal sizes, Enter to finish", false)
+}
+
+// resizeModeKey handles a key in resize mode. Right and down grow the
+// active column and row, left and up shrink them.
+func (a *App) resizeModeKey(msg tea.KeyMsg) tea.Cmd {
+	rows, cols := a.gridActiveDims()
+	if cols == 0 {
+		a.finishResizeMode()
+		return nil
+	}
+	row, col := a.activePane/cols, a.activePane%cols
+	switch msg.String() {
+	case "left", "h":
+		a.stepSplit(rows, cols, false, col, -splitStep)
+	case "right", "l":
+		a.stepSplit(rows, cols, false, col, splitStep)
+	case "up", "k":
+		a.stepSplit(rows, cols, true, row, -splitStep)
+	case "down", "j":
+		a.stepSplit(rows, cols, true, row, splitStep)
+	case "=":
+		if a.config != nil {
+			delete(a.config.GridSplits, gridShapeKey(rows, cols))
+		}
+		a.SetSize(a.width, a.height)
+	case "enter", "esc", "q", "R":
+		a.finishResizeMode()
+	}
+	return nil
+}
+
+// stepSplit changes the weight of one column (or row) of a grid shape.
+func (a *App) stepSplit(rows, cols int, isRow bool, index, delta int) {
+	if a.config == nil {
+		return
+	}
+	key := gridShapeKey(rows, cols)
+	split := a.config.GridSplits[key]
+	weights, n := split.Cols, cols
+	if isRow {
+		weights, n = split.Rows, rows
+	}
+	if n < 2 || index >= n {
+		return
+	}
+	if len(weights) != n {
+		weights = make([]int, n)
+		for i := range weights {
+			weights[i] = splitDefault
+		}
+	} else {
+		weights = slices.Clone(weights)
+	}
+	weights[index] = max(splitMin, min(weights[index]+delta, splitMax))
+	if isRow {
+		split.Rows = weights
+	} else {
+		split.Cols = weights
+	}
+	if a.config.GridSplits == nil {
+		a.config.GridSplits = make(map[string]app.GridSplit)
+	}
+	a.config.GridSplits[key] = split
+	a.SetSize(a.width, a.height)
+}
+
+// finishResizeMode leaves resize mode and saves the splits.
+func (a *App) finishResizeMode() {
+	a.resizing = false
+	a.updateFocusStyles()
+	if a.config == nil || a.configDir == "" {
+		return
+	}
+	if err := app.SaveConfig(a.configDir, a.config); err != nil {
+		a.toasts.Push("Failed to save config: "+err.Error(), true)
+		return
+	}
+	rows, cols := a.gridActiveDims()
+	a.toasts.Push("Saved pane sizes for the "+gridShapeKey(rows, cols)+" grid", false)
+}
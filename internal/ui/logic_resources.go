@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/runtime"
+	"github.com/lazyvibe/vibemux/internal/ui/components/resourcelist"
+	"github.com/lazyvibe/vibemux/pkg/utils"
+)
+
+// resourceInterval is how often the CPU and memory use of sessions is
+// sampled.
+const resourceInterval = 2 * time.Second
+
+// Usage above these marks is highlighted in the pane header.
+const (
+	highMemory = 2 << 30 // bytes
+	highCPU    = 90.0    // percent of one core
+)
+
+// resourceUsageMsg carries a sample of the sessions' resource use.
+type resourceUsageMsg struct {
+	pids  map[string]int
+	usage map[string]runtime.ProcessUsage
+	err   error
+}
+
+// sampleResources measures the process trees of the open sessions in the
+// background, at most once per resourceInterval.
+func (a *App) sampleResources() tea.Cmd {
+	if a.resourcesBusy || len(a.terminals) == 0 || time.Since(a.resourcesAt) < resourceInterval {
+		return nil
+	}
+	ids := make([]string, 0, len(a.terminals))
+	for id := range a.terminals {
+		ids = append(ids, id)
+	}
+	a.resourcesBusy = true
+	a.resourcesAt = time.Now()
+	engine, monitor := a.engine, a.resources
+	return func() tea.Msg {
+		pids := make(map[string]int, len(ids))
+		for _, id := range ids {
+			if pid := engine.ProcessID(id); pid > 0 {
+				pids[id] = pid
+			}
+		}
+		usage, err := monitor.Sample(pids)
+		return resourceUsageMsg{pids: pids, usage: usage, err: err}
+	}
+}
+
+// applyResourceUsage shows a sample in the pane headers and the monitor.
+func (a *App) applyResourceUsage(msg resourceUsageMsg) {
+	a.resourcesBusy = false
+	a.resourceErr = ""
+	if msg.err != nil {
+		a.resourceErr = "Resource use unavailable: " + msg.err.Error()
+	}
+	a.usagePIDs, a.usage = msg.pids, msg.usage
+	for id, inst := range a.terminals {
+		u, ok := a.usage[id]
+		if !ok {
+			inst.Terminal.SetUsage("", false)
+			continue
+		}
+		inst.Terminal.SetUsage(formatUsage(u), u.RSS >= highMemory || u.CPU >= highCPU)
+	}
+	if a.dialogMode == DialogResources {
+		a.resourceList.SetNote(a.resourceErr)
+		a.resourceList.SetRows(a.resourceRows())
+	}
+}
+
+// formatUsage renders a pane header's usage, e.g. "12% 540M".
+func formatUsage(u runtime.ProcessUsage) string {
+	return fmt.Sprintf("%.0f%% %s", u.CPU, utils.FormatBytes(u.RSS))
+}
+
+// resourceRows lists the open sessions with their last sample.
+func (a *App) resourceRows() []resourcelist.Row {
+	rows := make([]resourcelist.Row, 0, len(a.terminals))
+	for id, inst := range a.terminals {
+		u, sampled := a.usage[id]
+		rows = append(rows, resourcelist.Row{
+			SessionID: id,
+			Label:     inst.ProjectName,
+			PID:       a.usagePIDs[id],
+			Usage:     u,
+			Sampled:   sampled,
+		})
+	}
+	return rows
+}
+
+// showResources opens the resource monitor and samples right away.
+func (a *App) showResources() tea.Cmd {
+	a.resourceList.SetSize(a.width, a.height)
+	a.resourceList.SetNote(a.resourceErr)
+	a.resourceList.Open(a.resourceRows())
+	a.dialogMode = DialogResources
+	a.resourcesAt = time.Time{}
+	return a.sampleResources()
+}
+
+// resourceListAction carries out what the resource monitor asked for.
+func (a *App) resourceListAction() tea.Cmd {
+	row, ok := a.resourceList.Selected()
+	switch a.resourceList.Action() {
+	case resourcelist.ActionClose:
+		a.hideDialog()
+	case resourcelist.ActionJump:
+		if ok {
+			a.hideDialog()
+			a.focusPane(row.SessionID)
+		}
+	case resourcelist.ActionKill:
+		if ok {
+			a.closeSession(row.SessionID)
+			a.resourceList.SetRows(a.resourceRows())
+			a.toasts.Push("Stopped "+row.Label, false)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/notify"
+)
+
+// restartState tracks the automatic restarts of one pane.
+type restartState struct {
+	count   int       // Restarts in a row
+	started time.Time // When the current run started
+	pending bool      // A restart is waiting out its backoff
+	resume  bool      // The next start is a restart; send the resume prompt
+	hold    bool      // The session was stopped on purpose; do not restart it
+}
+
+// restartDueMsg starts a pane again once its backoff has passed.
+type restartDueMsg struct {
+	SessionID string
+}
+
+// restartFor returns the pane's restart state, creating it.
+func (a *App) restartFor(id string) *restartState {
+	r, ok := a.restarts[id]
+	if !ok {
+		r = &restartState{}
+		a.restarts[id] = r
+	}
+	return r
+}
+
+// holdRestart keeps the restart policy from starting a session that is
+// being stopped on purpose without closing its pane.
+func (a *App) holdRestart(id string) {
+	r := a.restartFor(id)
+	r.hold = true
+	r.pending = false
+}
+
+// restarted reports whether the pane's session is being started by the
+// restart policy.
+func (a *App) restarted(id string) bool {
+	r, ok := a.restarts[id]
+	return ok && r.resume
+}
+
+// noteSessionRun records that the pane's session has started.
+func (a *App) noteSessionRun(id string) {
+	r := a.restartFor(id)
+	r.started = time.Now()
+	r.resume = false
+	r.hold = false
+}
+
+// scheduleRestart applies the profile's restart policy to a session that
+// exited on its own with exitErr: it logs the exit in the pane and the
+// notification center and starts the session again after a backoff that
+// doubles with every restart in a row.
+func (a *App) scheduleRestart(id string, exitErr error) tea.Cmd {
+	r := a.restartFor(id)
+	if r.hold {
+		r.hold = false
+		return nil
+	}
+	profile := a.profileForSession(id)
+	if profile == nil || !profile.Restart.Restarts(exitErr) || !a.hasPane(id) {
+		return nil
+	}
+	if time.Since(r.started) >= model.RestartStableAfter {
+		r.count = 0
+	}
+
+	label := a.paneLabel(id)
+	reason := "exited"
+	if exitErr != nil {
+		reason = "exited: " + exitErr.Error()
+	}
+	ev := notify.Event{Type: notify.EventError, ProjectName: label, Timestamp: time.Now()}
+	var cmd tea.Cmd
+	if limit := profile.Restart.Limit(); limit > 0 && r.count >= limit {
+		ev.Title = "Restart limit reached"
+		ev.Message = fmt.Sprintf("%s; gave up after %d restarts", reason, r.count)
+		a.toasts.Push(fmt.Sprintf("%s %s; not restarting after %d attempts", label, reason, r.count), true)
+	} else {
+		delay := profile.Restart.Backoff(r.count)
+		r.count++
+		r.pending = true
+		ev.Title = "Session restarting"
+		ev.Message = fmt.Sprintf("%s; restart %d in %s", reason, r.count, delay)
+		a.toasts.Push(fmt.Sprintf("%s %s; restarting in %s", label, reason, delay), exitErr != nil)
+		cmd = tea.Tick(delay, func(time.Time) tea.Msg { return restartDueMsg{SessionID: id} })
+	}
+	if inst, ok := a.terminals[id]; ok {
+		inst.Terminal.AppendOutput([]byte("\r\n\x1b[33m[vibemux] " + ev.Message + "\x1b[0m\r\n"))
+	}
+	a.recordNotifications(id, []notify.Event{ev})
+	return tea.Batch(cmd, a.dispatchNotifications(profile, []notify.Event{ev}))
+}
+
+// restartDue starts a pane again after its backoff, unless it was closed
+// or started by hand in the meantime.
+func (a *App) restartDue(id string) tea.Cmd {
+	r, ok := a.restarts[id]
+	if !ok || !r.pending {
+		return nil
+	}
+	r.pending = false
+	project := a.projectForSession(id)
+	if project == nil || !a.hasPane(id) {
+		return nil
+	}
+	if session, ok := a.engine.GetSession(id); ok && session.Status() == model.SessionStatusRunning {
+		return nil
+	}
+	r.resume = true
+	return a.launchSession(project, id, a.bindings[id].Opts)
+}
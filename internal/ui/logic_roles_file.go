@@ -4,10 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/lazyvibe/vibemux/internal/model"
 	"github.com/lazyvibe/vibemux/internal/ui/components/configdialog"
 )
 
@@ -94,7 +94,7 @@ const (
 func (a *App) showRoleDialogFile() {
 	ids := a.gridOrder()
 	if len(ids) == 0 {
-		a.statusBar.SetMessage("No active terminals", true)
+		a.toasts.Push("No active terminals", true)
 		return
 	}
 
@@ -131,12 +131,30 @@ func (a *App) showRoleDialogFile() {
 	}
 	fields = append(fields, configdialog.Field{
 		Label:       "Turn Sequence",
-		Placeholder: "0,1,2",
+		Placeholder: "0,1,2 x3",
 		Value:       defaultSeq,
 		Type:        configdialog.InputText,
 		Column:      0,
 	})
 
+	// Field 3: Turn Delay
+	fields = append(fields, configdialog.Field{
+		Label:       "Turn Delay (s)",
+		Placeholder: fmt.Sprintf("%d", defaultTurnDelay),
+		Value:       fmt.Sprintf("%d", a.turnDelay),
+		Type:        configdialog.InputText,
+		Column:      0,
+	})
+
+	// Field 4: Default Turn Message
+	fields = append(fields, configdialog.Field{
+		Label:       "Turn Message",
+		Placeholder: "empty = profile default; {{FILE}} {{ROLE}} {{ROUND}}",
+		Value:       a.turnMessageDefault,
+		Type:        configdialog.InputText,
+		Column:      0,
+	})
+
 	// --- Right Column: Terminals ---
 	
 	// Get grid dimensions to calculate positions
@@ -186,6 +204,17 @@ func (a *App) showRoleDialogFile() {
 			GridRow:     row,
 			GridCol:     col,
 		})
+
+		// Field: Turn Message (Text, optional override)
+		fields = append(fields, configdialog.Field{
+			Label:       "Turn Message",
+			Placeholder: "empty = Turn Message above",
+			Value:       a.turnMessages[id],
+			Type:        configdialog.InputText,
+			Column:      1,
+			GridRow:     row,
+			GridCol:     col,
+		})
 	}
 
 	a.organizerDialog = configdialog.New("Assign Roles (Organizer Mode)", fields)
@@ -204,10 +233,12 @@ func (a *App) assignRolesToTerminalsFile() []tea.Cmd {
 	// 0: Topic
 	// 1: Filename
 	// 2: Sequence
-	// Then 2 fields per terminal: Role, Prompt.
+	// 3: Turn Delay
+	// 4: Turn Message
+	// Then 3 fields per terminal: Role, Prompt, Turn Message.
 	
-	if len(values) < 3 + len(ids)*2 {
-		a.statusBar.SetMessage("Error: Missing fields", true)
+	if len(values) < 5 + len(ids)*3 {
+		a.toasts.Push("Error: Missing fields", true)
 		return nil
 	}
 
@@ -226,14 +257,14 @@ func (a *App) assignRolesToTerminalsFile() []tea.Cmd {
 	// AI agents run in the project's working directory, so they will create files there
 	var basePath string
 	if len(ids) > 0 {
-		if proj := a.findProjectByID(ids[0]); proj != nil && proj.Path != "" {
+		if proj := a.projectForSession(ids[0]); proj != nil && proj.Path != "" {
 			basePath = proj.Path
 		}
 	}
 	// Fallback: if no project path, try to use first terminal's known path
 	if basePath == "" {
 		for id := range a.terminals {
-			if proj := a.findProjectByID(id); proj != nil && proj.Path != "" {
+			if proj := a.projectForSession(id); proj != nil && proj.Path != "" {
 				basePath = proj.Path
 				break
 			}
@@ -249,42 +280,47 @@ func (a *App) assignRolesToTerminalsFile() []tea.Cmd {
 	seqStr := strings.TrimSpace(values[2])
 	a.turnTopic = topic
 	a.turnFilename = filename
+
+	delay, err := strconv.Atoi(strings.TrimSpace(values[3]))
+	if err != nil || delay < 0 {
+		delay = defaultTurnDelay
+	}
+	a.turnDelay = delay
+	a.turnMessageDefault = strings.TrimSpace(values[4])
+	a.turnMessages = make(map[string]string)
+	a.turnRoles = make(map[string]string)
 	
 	// Initialize Auto-Turn (Paused)
 	a.initAutoTurn(seqStr)
 
 	// 2. Process Terminals
-	baseIdx := 3
+	baseIdx := 5
+	batch := a.beginInjection("role prompts", ids)
 	for i, id := range ids {
-		projectID := id
 		
-		// Extract Role & Prompt
-		// i=0 -> baseIdx + 0, baseIdx + 1
-		// i=1 -> baseIdx + 2, baseIdx + 3
-		roleIdx := baseIdx + (i * 2)
+		// Extract Role, Prompt & Turn Message
+		// i=0 -> baseIdx + 0, baseIdx + 1, baseIdx + 2
+		// i=1 -> baseIdx + 3, baseIdx + 4, baseIdx + 5
+		roleIdx := baseIdx + (i * 3)
 		promptIdx := roleIdx + 1
+		messageIdx := roleIdx + 2
 		
 		roleName := strings.TrimSpace(values[roleIdx])
 		rawPrompt := values[promptIdx]
+		a.turnRoles[id] = roleName
+		if msg := strings.TrimSpace(values[messageIdx]); msg != "" {
+			a.turnMessages[id] = msg
+		}
 		
 		// Template Replacement
 		finalPrompt := strings.ReplaceAll(rawPrompt, "{{TOPIC}}", topic)
 		finalPrompt = strings.ReplaceAll(finalPrompt, "{{FILENAME}}", filename)
 		finalPrompt = strings.ReplaceAll(finalPrompt, "{{ROLE}}", roleName)
 
-		cmds = append(cmds, func() tea.Msg {
-			session, ok := a.engine.GetSession(projectID)
-			if !ok || session.Status() != model.SessionStatusRunning {
-				return nil
-			}
-
-			// Pre-fill prompt ONLY (No execution)
-			// We add newlines to ensure clean separation from previous output
-			injection := fmt.Sprintf("\n\n%s", finalPrompt)
-			session.Write([]byte(injection))
-			// REMOVED: Automatic carriage return (\r)
-			return nil
-		})
+		// Pre-fill prompt ONLY (No execution)
+		// We add newlines to ensure clean separation from previous output
+		injection := fmt.Sprintf("\n\n%s", finalPrompt)
+		cmds = append(cmds, a.injectCmd(batch, id, injection, false))
 	}
 
 	return cmds
@@ -0,0 +1,200 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/app"
+	"github.com/lazyvibe/vibemux/internal/ui/components/quickopen"
+)
+
+// layoutCommand handles "layout [save|delete] <name>" and "layout <name>".
+// Without arguments it lists the saved layouts.
+func (a *App) layoutCommand(args []string) tea.Cmd {
+	if len(args) == 0 {
+		a.showLayouts()
+		return nil
+	}
+	switch strings.ToLower(args[0]) {
+	case "save":
+		if len(args) < 2 {
+			a.toasts.Push("Usage: layout save <name>", true)
+			return nil
+		}
+		return a.saveLayout(strings.Join(args[1:], " "))
+	case "delete", "rm":
+		if len(args) < 2 {
+			a.toasts.Push("Usage: layout delete <name>", true)
+			return nil
+		}
+		return a.deleteLayout(strings.Join(args[1:], " "))
+	case "load":
+		args = args[1:]
+	}
+	return a.restoreLayout(strings.Join(args, " "))
+}
+
+// showLayouts opens the overlay listing the saved layouts.
+func (a *App) showLayouts() {
+	if a.config == nil || len(a.config.Layouts) == 0 {
+		a.toasts.Push("No saved layouts (save one with :layout save <name>)", true)
+		return
+	}
+	names := make([]string, 0, len(a.config.Layouts))
+	for name := range a.config.Layouts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	items := make([]quickopen.Item, 0, len(names))
+	for _, name := range names {
+		l := a.config.Layouts[name]
+		open := 0
+		labels := make([]string, 0, len(l.Projects))
+		for _, id := range l.Projects {
+			if project := a.findProjectByID(id); project != nil {
+				labels = append(labels, project.DisplayName())
+			}
+			if a.hasPane(id) {
+				open++
+			}
+		}
+		items = append(items, quickopen.Item{
+			ID:      name,
+			Label:   name,
+			Detail:  layoutShape(l) + " · " + strings.Join(labels, ", "),
+			Running: open == len(l.Projects),
+		})
+	}
+	a.layoutPicker.SetSize(a.width, a.height)
+	a.layoutPicker.Open("Layouts", items)
+	a.dialogMode = DialogLayouts
+}
+
+// layoutShape describes a layout's grid, e.g. "2x3" or "auto".
+func layoutShape(l app.Layout) string {
+	if l.GridAuto {
+		return "auto"
+	}
+	return gridShapeKey(l.GridRows, l.GridCols)
+}
+
+// saveLayout stores the projects of the open panes, in grid order, and the
+// grid shape under name.
+func (a *App) saveLayout(name string) tea.Cmd {
+	if a.config == nil || a.configDir == "" {
+		a.toasts.Push("No config directory to save layouts in", true)
+		return nil
+	}
+	var ids []string
+	seen := make(map[string]bool)
+	for _, id := range a.gridOrder() {
+		project := a.projectForSession(id)
+		if project == nil || seen[project.ID] {
+			continue
+		}
+		seen[project.ID] = true
+		ids = append(ids, project.ID)
+	}
+	if len(ids) == 0 {
+		a.toasts.Push("No open panes to save", true)
+		return nil
+	}
+	updated := *a.config
+	updated.Layouts = make(map[string]app.Layout, len(a.config.Layouts)+1)
+	for k, v := range a.config.Layouts {
+		updated.Layouts[k] = v
+	}
+	updated.Layouts[name] = app.Layout{
+		Projects: ids,
+		GridRows: a.gridRows,
+		GridCols: a.gridCols,
+		GridAuto: a.gridAuto,
+	}
+	if err := app.SaveConfig(a.configDir, &updated); err != nil {
+		a.toasts.Push("Failed to save config: "+err.Error(), true)
+		return nil
+	}
+	*a.config = updated
+	a.toasts.Push(fmt.Sprintf("Saved layout %q (%d projects)", name, len(ids)), false)
+	return nil
+}
+
+// deleteLayout removes a saved layout.
+func (a *App) deleteLayout(name string) tea.Cmd {
+	if a.config == nil || a.configDir == "" {
+		return nil
+	}
+	if _, ok := a.config.Layouts[name]; !ok {
+		a.toasts.Push("No layout named "+name, true)
+		return nil
+	}
+	updated := *a.config
+	updated.Layouts = make(map[string]app.Layout, len(a.config.Layouts))
+	for k, v := range a.config.Layouts {
+		if k != name {
+			updated.Layouts[k] = v
+		}
+	}
+	if err := app.SaveConfig(a.configDir, &updated); err != nil {
+		a.toasts.Push("Failed to save config: "+err.Error(), true)
+		return nil
+	}
+	*a.config = updated
+	a.toasts.Push("Deleted layout "+name, false)
+	return nil
+}
+
+// restoreLayout switches to a layout's grid shape, moves its open panes to
+// the front in layout order and starts the sessions of the others, like
+// startall. Panes outside the layout stay open after them.
+func (a *App) restoreLayout(name string) tea.Cmd {
+	var l app.Layout
+	ok := false
+	if a.config != nil {
+		l, ok = a.config.Layouts[name]
+	}
+	if !ok {
+		a.toasts.Push("No layout named "+name, true)
+		return nil
+	}
+	if a.batch != nil {
+		a.toasts.Push(fmt.Sprintf("Batch start in progress (%d left); stopall cancels it", len(a.batch.queue)), true)
+		return nil
+	}
+
+	var err error
+	switch {
+	case l.GridAuto && !a.gridAuto:
+		err = a.setGridAuto()
+	case !l.GridAuto && (a.gridAuto || l.GridRows != a.gridRows || l.GridCols != a.gridCols):
+		err = a.updateGridSettings(l.GridRows, l.GridCols)
+	}
+	if err != nil {
+		a.toasts.Push("Layout "+name+": "+err.Error(), true)
+		return nil
+	}
+
+	var queue []string
+	slot := 0
+	for _, id := range l.Projects {
+		if a.findProjectByID(id) == nil {
+			continue
+		}
+		if a.hasPane(id) {
+			a.sessionTabs.MoveTabTo(id, slot)
+			slot++
+			continue
+		}
+		queue = append(queue, id)
+	}
+	a.normalizeActivePane()
+	a.SetSize(a.width, a.height)
+	if len(queue) == 0 {
+		a.toasts.Push("Restored layout "+name, false)
+		return nil
+	}
+	a.batch = &batchLaunch{layout: name, queue: queue, total: len(queue), waiting: make(map[string]bool)}
+	return a.advanceBatch()
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/lazyvibe/vibemux/internal/ui/components/dialog"
+	"github.com/lazyvibe/vibemux/internal/ui/components/search"
+)
+
+// showSearch opens the search overlay over a snapshot of every open pane's
+// output history, prefilled with query.
+func (a *App) showSearch(query string) {
+	panes := make([]search.Pane, 0, len(a.sessionTabs.Tabs()))
+	for _, tab := range a.sessionTabs.Tabs() {
+		session, ok := a.engine.GetSession(tab.ID)
+		if !ok {
+			continue
+		}
+		panes = append(panes, search.Pane{
+			ID:    tab.ID,
+			Label: tab.Name,
+			Lines: search.SnapshotLines(session.History()),
+		})
+	}
+	if len(panes) == 0 {
+		a.toasts.Push("No session output to search", true)
+		return
+	}
+	a.search.SetSize(a.width, a.height)
+	a.search.Open(panes, query)
+	a.dialogMode = DialogSearch
+}
+
+// jumpToMatch focuses the pane holding a search match, bringing it into
+// the grid if needed, and scrolls its history to the matching line.
+func (a *App) jumpToMatch(match search.Match) {
+	if !a.focusPane(match.PaneID) {
+		return
+	}
+	if inst, ok := a.terminals[match.PaneID]; ok && !inst.Terminal.ScrollTo(match.Line) {
+		a.toasts.Push("Match is no longer in the pane's scrollback", false)
+	}
+}
+
+// focusPane focuses a pane, bringing it into the grid if needed. It
+// returns false when there is no such pane.
+func (a *App) focusPane(id string) bool {
+	if !a.hasPane(id) {
+		return false
+	}
+	if ids := a.gridOrder(); indexOfID(ids, id) < 0 && len(ids) > 0 {
+		a.sessionTabs.MoveTabTo(id, len(ids)-1)
+	}
+	a.focus = FocusTerminal
+	a.setActivePaneByProject(id)
+	a.SetSize(a.width, a.height)
+	return true
+}
+
+// showPaneSearch opens the prompt for searching the active pane's
+// scrollback. Without an active pane it opens the cross-pane search.
+func (a *App) showPaneSearch() {
+	if _, ok := a.terminals[a.activeTermID]; !ok {
+		a.showSearch("")
+		return
+	}
+	a.commandDialog = dialog.NewInputDialog("Search Pane", []dialog.InputField{
+		{Label: "Find", Placeholder: "text (n older, N newer, Esc clears)"},
+	})
+	a.commandDialog.SetSize(a.width, a.height)
+	a.dialogMode = DialogPaneSearch
+}
+
+// searchPane highlights query in the active pane and jumps to the newest
+// match.
+func (a *App) searchPane(query string) {
+	inst, ok := a.terminals[a.activeTermID]
+	if !ok {
+		return
+	}
+	if n := inst.Terminal.Search(query); n == 0 && query != "" {
+		a.toasts.Push(fmt.Sprintf("No matches for %q", query), false)
+	}
+}
+
+// stepPaneSearch moves to the next older or newer match in the active
+// pane. It reports false when the pane has no search.
+func (a *App) stepPaneSearch(older bool) bool {
+	inst, ok := a.terminals[a.activeTermID]
+	if !ok || !inst.Terminal.Searching() {
+		return false
+	}
+	if !inst.Terminal.SearchNext(older) {
+		a.toasts.Push("No matches left", false)
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/app"
+	"github.com/lazyvibe/vibemux/internal/runtime"
+	"github.com/lazyvibe/vibemux/internal/secrets"
+	"github.com/lazyvibe/vibemux/internal/ui/components/dialog"
+	"github.com/lazyvibe/vibemux/pkg/utils"
+)
+
+// newSecrets creates the secret resolver and hands it to the engine, so
+// secret references in profile env vars resolve when sessions launch.
+func newSecrets(e *runtime.DefaultEngine, cfg *app.Config, configDir string) *secrets.Resolver {
+	r := app.NewSecretResolver(configDir, cfg)
+	if e != nil {
+		e.SetSecrets(r)
+	}
+	return r
+}
+
+// secretsCommand lists the names in the secrets file, or unlocks or locks
+// it.
+// Usage: secrets [unlock|lock]
+func (a *App) secretsCommand(args []string) tea.Cmd {
+	if a.secrets == nil {
+		a.toasts.Push("Secrets are not available", true)
+		return nil
+	}
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+	switch sub {
+	case "unlock":
+		if a.secrets.Age() {
+			a.toasts.Push("The secrets file is decrypted with age; no passphrase needed", false)
+			return nil
+		}
+		a.commandDialog = dialog.NewInputDialog("Unlock Secrets", []dialog.InputField{
+			{Label: "Passphrase for " + a.secrets.File(), Secret: true},
+		})
+		a.commandDialog.SetSize(a.width, a.height)
+		a.dialogMode = DialogUnlock
+		return nil
+	case "lock":
+		a.secrets.Lock()
+		a.toasts.Push("Secrets locked", false)
+		return nil
+	}
+	if sub != "" {
+		a.toasts.Push("Usage: secrets [unlock|lock]", true)
+		return nil
+	}
+	r := a.secrets
+	return func() tea.Msg {
+		names, err := r.Names()
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			return StatusMsg{Text: "No secrets file yet (vibemux secret set <name>)"}
+		case errors.Is(err, secrets.ErrLocked):
+			return StatusMsg{Text: "Secrets are locked (:secrets unlock)", IsError: true}
+		case err != nil:
+			return ErrorMsg{Err: err}
+		case len(names) == 0:
+			return StatusMsg{Text: "The secrets file is empty"}
+		}
+		return StatusMsg{Text: "Secrets: " + strings.Join(names, ", ")}
+	}
+}
+
+// unlockSecrets checks the passphrase off the UI goroutine, since deriving
+// the key takes a moment.
+func (a *App) unlockSecrets(passphrase string) tea.Cmd {
+	if passphrase == "" {
+		return nil
+	}
+	r := a.secrets
+	return func() tea.Msg {
+		if err := r.Unlock(passphrase); err != nil {
+			return StatusMsg{Text: "Unlock failed: " + err.Error(), IsError: true}
+		}
+		return StatusMsg{Text: "Secrets unlocked"}
+	}
+}
+
+// maskEnvSecrets returns env for display in the profile dialog, with the
+// values of secret-looking keys masked. References are shown as written.
+func maskEnvSecrets(env map[string]string) map[string]string {
+	masked := make(map[string]string, len(env))
+	for k, v := range env {
+		if v != "" && utils.IsSecretEnvKey(k) && !secrets.IsRef(v) {
+			v = app.MaskedSecret
+		}
+		masked[k] = v
+	}
+	return masked
+}
+
+// restoreEnvSecrets puts back the values the profile dialog showed masked
+// and the user left as they were.
+func restoreEnvSecrets(env, original map[string]string) {
+	for k, v := range env {
+		if v != app.MaskedSecret {
+			continue
+		}
+		if old, ok := original[k]; ok {
+			env[k] = old
+		} else {
+			delete(env, k)
+		}
+	}
+}
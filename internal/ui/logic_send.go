@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/runtime"
+)
+
+// sendToPane writes text to one pane without switching input mode.
+// Usage: send <pane> <text>
+func (a *App) sendToPane(cmd string) tea.Cmd {
+	fields := strings.Fields(cmd)
+	if len(fields) < 3 {
+		a.toasts.Push(`Usage: send <pane> <text> (escapes: \r \n \t \e \\)`, true)
+		return nil
+	}
+	id, ok := a.resolvePane(fields[1])
+	if !ok {
+		a.toasts.Push("Unknown pane: "+fields[1], true)
+		return nil
+	}
+	session, ok := a.engine.GetSession(id)
+	if !ok || session.Status() != model.SessionStatusRunning {
+		a.toasts.Push("Pane is not running: "+fields[1], true)
+		return nil
+	}
+	if lockedByRemote(session) {
+		a.toasts.Push("Input locked by "+session.InputOwner(), true)
+		return nil
+	}
+	data := unescapeSendText(restAfterFields(cmd, 2))
+	session.Write([]byte(data))
+	if strings.ContainsRune(data, '\r') {
+		a.recordPrompt(id)
+	}
+	a.toasts.Push("Sent to "+a.paneLabel(id), false)
+	return nil
+}
+
+// sendToAll writes text to every running pane.
+// Usage: sendall|broadcast <text>
+func (a *App) sendToAll(cmd string) tea.Cmd {
+	if fields := strings.Fields(cmd); len(fields) < 2 {
+		a.toasts.Push(`Usage: `+fields[0]+` <text> (escapes: \r \n \t \e \\)`, true)
+		return nil
+	}
+	data := unescapeSendText(restAfterFields(cmd, 1))
+	targets := a.broadcastTargets()
+	if len(targets) == 0 {
+		a.toasts.Push("No running panes", true)
+		return nil
+	}
+	return a.confirmBroadcast("send", targets, data, func(a *App) tea.Cmd {
+		sent := a.engine.Broadcast(targets, []byte(data), runtime.DefaultBroadcastOptions)
+		if strings.ContainsRune(data, '\r') {
+			for _, id := range targets {
+				a.recordPrompt(id)
+			}
+		}
+		a.toasts.Push(fmt.Sprintf("Sent to %d running panes", sent), false)
+		return nil
+	})
+}
+
+// restAfterFields returns s after its first n whitespace-separated fields
+// and the single separator that follows, keeping the rest verbatim.
+func restAfterFields(s string, n int) string {
+	s = strings.TrimLeftFunc(s, unicode.IsSpace)
+	for i := 0; i < n; i++ {
+		end := strings.IndexFunc(s, unicode.IsSpace)
+		if end < 0 {
+			return ""
+		}
+		s = s[end:]
+		if i < n-1 {
+			s = strings.TrimLeftFunc(s, unicode.IsSpace)
+		}
+	}
+	if s != "" {
+		s = s[1:]
+	}
+	return s
+}
+
+// unescapeSendText expands \r, \n, \t, \e (Esc) and \\ in typed text.
+// Unknown escapes are kept as written.
+func unescapeSendText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'r':
+			b.WriteByte('\r')
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'e':
+			b.WriteByte(0x1b)
+		case '\\':
+			b.WriteByte('\\')
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
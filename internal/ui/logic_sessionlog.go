@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"path/filepath"
+	"regexp"
+
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/runtime"
+)
+
+// unsafeFileChars are replaced in log directory names.
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// sessionLogEnabled reports whether sessions of profile are logged: the
+// profile's session_log setting, else the global one.
+func (a *App) sessionLogEnabled(profile *model.Profile) bool {
+	if profile != nil && profile.SessionLog != nil {
+		return *profile.SessionLog
+	}
+	return a.config != nil && a.config.SessionLog.Enabled
+}
+
+// startSessionLog records a started session's output under
+// <configDir>/logs/<project>/ when logging is enabled for it.
+func (a *App) startSessionLog(sessionID string, profile *model.Profile) {
+	if old, ok := a.sessionLogs[sessionID]; ok {
+		old.Close()
+		delete(a.sessionLogs, sessionID)
+	}
+	project := a.projectForSession(sessionID)
+	session, ok := a.engine.GetSession(sessionID)
+	if a.configDir == "" || project == nil || !ok || !a.sessionLogEnabled(profile) {
+		return
+	}
+	name := unsafeFileChars.ReplaceAllString(project.DisplayName(), "-")
+	opts := runtime.LogOptions{
+		Dir:      filepath.Join(a.configDir, "logs", name),
+		MaxBytes: int64(a.config.SessionLog.MaxMB) << 20,
+		Keep:     a.config.SessionLog.Keep,
+	}
+	l, err := runtime.StartLog(session, opts)
+	if err != nil {
+		a.toasts.Push("Session log not started: "+err.Error(), true)
+		return
+	}
+	if a.sessionLogs == nil {
+		a.sessionLogs = make(map[string]*runtime.SessionLog)
+	}
+	a.sessionLogs[sessionID] = l
+}
+
+// closeSessionLog stops recording a session.
+func (a *App) closeSessionLog(sessionID string) {
+	if l, ok := a.sessionLogs[sessionID]; ok {
+		l.Close()
+		delete(a.sessionLogs, sessionID)
+	}
+}
+
+// closeSessionLogs stops all session logs on quit.
+func (a *App) closeSessionLogs() {
+	for id := range a.sessionLogs {
+		a.closeSessionLog(id)
+	}
+}
+
+// showSessionLog opens the log of the active pane, or the one named in
+// args, in the file preview.
+// Usage: log [pane]
+func (a *App) showSessionLog(args []string) {
+	id := a.activeTermID
+	if len(args) > 0 {
+		var ok bool
+		if id, ok = a.resolvePane(args[0]); !ok {
+			a.toasts.Push("Unknown pane: "+args[0], true)
+			return
+		}
+	}
+	l, ok := a.sessionLogs[id]
+	if !ok {
+		a.toasts.Push(`No log for this session (set "session_log": {"enabled": true} in config.json)`, true)
+		return
+	}
+	l.Flush()
+	a.filePreview.SetFilter(runtime.CleanOutput)
+	a.filePreview.SetFile(l.Path())
+	a.filePreview.SetSize(a.width, a.height)
+	a.dialogMode = DialogFilePreview
+}
@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/runtime"
+)
+
+// sessionBinding records which project a session belongs to and how it was
+// launched. A project can host several sessions at once (e.g. a coder and
+// a reviewer), each keyed by its own session ID.
+type sessionBinding struct {
+	ProjectID string
+	Opts      launchOptions
+}
+
+// projectForSession returns the project a session (or pane) belongs to.
+func (a *App) projectForSession(sessionID string) *model.Project {
+	if b, ok := a.bindings[sessionID]; ok {
+		if project := a.findProjectByID(b.ProjectID); project != nil {
+			return project
+		}
+	}
+	if project := a.findProjectByID(sessionID); project != nil {
+		return project
+	}
+	if session, ok := a.engine.GetSession(sessionID); ok {
+		return a.findProjectByID(session.ProjectID())
+	}
+	return nil
+}
+
+// profileForSession returns the profile a session was launched with.
+func (a *App) profileForSession(sessionID string) *model.Profile {
+	if b, ok := a.bindings[sessionID]; ok && b.Opts.ProfileID != "" {
+		if profile := a.findProfileByID(b.Opts.ProfileID); profile != nil {
+			return profile
+		}
+	}
+	return a.profileForProject(a.projectForSession(sessionID))
+}
+
+// sessionsOfProject lists the session IDs (open panes and engine sessions)
+// that belong to a project.
+func (a *App) sessionsOfProject(projectID string) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	add := func(id string) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, t := range a.sessionTabs.Tabs() {
+		if p := a.projectForSession(t.ID); p != nil && p.ID == projectID {
+			add(t.ID)
+		}
+	}
+	for _, s := range a.engine.SessionsForProject(projectID) {
+		add(s.ID())
+	}
+	return ids
+}
+
+// closeProjectSessions closes every session and pane of a project.
+func (a *App) closeProjectSessions(projectID string) {
+	for _, id := range a.sessionsOfProject(projectID) {
+		a.closeSession(id)
+	}
+	a.projectList.SetRunning(projectID, false)
+}
+
+// syncProjectRunning marks a project as running while any of its sessions is.
+func (a *App) syncProjectRunning(projectID string) {
+	running := false
+	for _, session := range a.engine.SessionsForProject(projectID) {
+		if session.Status() == model.SessionStatusRunning {
+			running = true
+			break
+		}
+	}
+	a.projectList.SetRunning(projectID, running)
+}
+
+// sessionLabel is the pane/tab title of a session: its alias if any, then
+// the project name with the session suffix and launch subdirectory.
+func sessionLabel(project *model.Project, sessionID string, opts launchOptions) string {
+	name := project.DisplayName() + runtime.SessionSuffix(sessionID, project.ID)
+	if opts.Subdir != "" {
+		name += " /" + filepath.ToSlash(opts.Subdir)
+	}
+	if opts.Alias != "" {
+		return opts.Alias + " · " + name
+	}
+	return name
+}
+
+// sessionAlias returns the alias of a session, or "".
+func (a *App) sessionAlias(sessionID string) string {
+	return a.bindings[sessionID].Opts.Alias
+}
+
+// aliasTaken reports whether another session already uses alias.
+func (a *App) aliasTaken(alias, sessionID string) bool {
+	for id, b := range a.bindings {
+		if id != sessionID && strings.EqualFold(b.Opts.Alias, alias) {
+			return true
+		}
+	}
+	return false
+}
+
+// setSessionAlias names the active session; an empty alias clears it.
+func (a *App) setSessionAlias(alias string) tea.Cmd {
+	id := a.activeTermID
+	b, ok := a.bindings[id]
+	project := a.projectForSession(id)
+	if !ok || project == nil {
+		a.toasts.Push("No session in the active pane", true)
+		return nil
+	}
+	if alias != "" && a.aliasTaken(alias, id) {
+		a.toasts.Push("Alias already in use: "+alias, true)
+		return nil
+	}
+	b.Opts.Alias = alias
+	a.bindings[id] = b
+
+	label := sessionLabel(project, id, b.Opts)
+	if inst, ok := a.terminals[id]; ok {
+		inst.ProjectName = label
+		inst.Terminal.Rename(id, label)
+	}
+	a.sessionTabs.RenameTab(id, id, label)
+	if alias == "" {
+		a.toasts.Push("Alias cleared", false)
+	} else {
+		a.toasts.Push("Session alias set to "+alias, false)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/ui/components/setup"
+)
+
+// showSetupWizard re-runs the setup wizard inside the TUI. It edits the
+// live config and profile store, so nothing has to be deleted first.
+func (a *App) showSetupWizard() {
+	if a.config == nil || a.configDir == "" {
+		a.toasts.Push("Setup needs a config directory", true)
+		return
+	}
+	a.wizardFrom = a.config.ClaudePath
+	a.wizard = setup.NewRerun(a.configDir, a.config, a.store)
+	a.wizard.SetSize(a.width, a.height)
+	a.dialogMode = DialogSetup
+}
+
+// finishSetupWizard closes the wizard and picks up what it changed.
+func (a *App) finishSetupWizard(msg setup.DoneMsg) tea.Cmd {
+	a.hideDialog()
+	switch {
+	case a.config.ClaudePath != a.wizardFrom:
+		a.toasts.Push("Setup saved; restart VibeMux to use the new claude path", false)
+	case msg.Completed:
+		a.toasts.Push("Setup saved", false)
+	}
+	return tea.Batch(a.loadProjects(), a.loadProfiles())
+}
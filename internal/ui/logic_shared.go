@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/app"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/store"
+	"github.com/lazyvibe/vibemux/pkg/utils"
+)
+
+// loadShared (re)reads the configured shared config source into the store.
+// With no source configured it detaches any previous one.
+func (a App) loadShared() tea.Cmd {
+	dir := ""
+	if a.config != nil {
+		dir = a.config.SharedConfigDir
+	}
+	return func() tea.Msg {
+		if dir == "" {
+			a.store.SetShared(nil)
+			return SharedLoadedMsg{}
+		}
+		shared, err := store.LoadShared(utils.ExpandPath(dir))
+		if err != nil {
+			return SharedLoadedMsg{Err: err}
+		}
+		a.store.SetShared(shared)
+		return SharedLoadedMsg{Shared: shared}
+	}
+}
+
+// sharedCommand handles:
+//
+//	shared              show the shared config source
+//	shared reload       re-read it (e.g. after a git pull)
+//	shared <dir>|-      set or clear the source directory
+func (a *App) sharedCommand(args []string) tea.Cmd {
+	if len(args) == 0 {
+		a.toasts.Push(a.sharedSummary(), false)
+		return nil
+	}
+	if strings.EqualFold(args[0], "reload") {
+		return a.loadShared()
+	}
+	if a.config == nil || a.configDir == "" {
+		a.toasts.Push("Shared config needs a config directory", true)
+		return nil
+	}
+	dir := strings.Join(args, " ")
+	if dir == "-" {
+		dir = ""
+	}
+	updated := *a.config
+	updated.SharedConfigDir = dir
+	if err := app.SaveConfig(a.configDir, &updated); err != nil {
+		a.toasts.Push("Failed to save config: "+err.Error(), true)
+		return nil
+	}
+	*a.config = updated
+	return a.loadShared()
+}
+
+// sharedSummary describes what the shared source provides.
+func (a *App) sharedSummary() string {
+	shared := a.store.Shared()
+	if shared == nil {
+		return "No shared config (set one with :shared <dir>)"
+	}
+	return fmt.Sprintf("Shared config %s: %d profiles, roles: %s, snippets: %s",
+		shared.Dir, len(shared.Profiles),
+		joinOrNone(shared.RoleNames()), joinOrNone(shared.SnippetNames()))
+}
+
+// sendSnippet types a shared snippet into the active pane without
+// submitting it.
+func (a *App) sendSnippet(args []string) tea.Cmd {
+	shared := a.store.Shared()
+	if len(args) != 1 || shared == nil {
+		a.toasts.Push("Usage: snippet <name> (from the shared config)", true)
+		return nil
+	}
+	text, ok := shared.Snippets[args[0]]
+	if !ok {
+		a.toasts.Push("Unknown snippet: "+args[0], true)
+		return nil
+	}
+	return a.injectShared("snippet "+args[0], text)
+}
+
+// sendRole types a shared role prompt into the active pane. {{ROLE}} is
+// replaced by the role name, and {{TOPIC}}/{{FILENAME}} by the current
+// auto-turn topic and file.
+func (a *App) sendRole(args []string) tea.Cmd {
+	shared := a.store.Shared()
+	if len(args) != 1 || shared == nil {
+		a.toasts.Push("Usage: role <name> (from the shared config)", true)
+		return nil
+	}
+	prompt, ok := shared.Roles[args[0]]
+	if !ok {
+		a.toasts.Push("Unknown role: "+args[0], true)
+		return nil
+	}
+	prompt = strings.ReplaceAll(prompt, "{{ROLE}}", args[0])
+	prompt = strings.ReplaceAll(prompt, "{{TOPIC}}", a.turnTopic)
+	prompt = strings.ReplaceAll(prompt, "{{FILENAME}}", a.turnFilename)
+	return a.injectShared("role "+args[0], prompt)
+}
+
+func (a *App) injectShared(label, text string) tea.Cmd {
+	if a.activeTermID == "" {
+		a.toasts.Push("No active pane", true)
+		return nil
+	}
+	batch := a.beginInjection(label, []string{a.activeTermID})
+	return a.injectCmd(batch, a.activeTermID, text, false)
+}
+
+// rejectShared reports whether the profile comes from the shared source,
+// telling the user to clone it instead of changing it.
+func (a *App) rejectShared(profile *model.Profile) bool {
+	if profile == nil || !profile.Shared {
+		return false
+	}
+	a.toasts.Push("Shared profiles are read-only; press c to clone it", true)
+	return true
+}
+
+func joinOrNone(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
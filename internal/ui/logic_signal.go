@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/runtime"
+)
+
+// ctrlCPress remembers the last Ctrl+C typed in terminal mode.
+type ctrlCPress struct {
+	id string
+	at time.Time
+}
+
+// handleCtrlC applies the active profile's Ctrl+C policy to a Ctrl+C typed
+// in terminal mode. It reports false when the key should go to the pane
+// as usual.
+func (a *App) handleCtrlC(id string, session runtime.Session) (tea.Cmd, bool) {
+	var policy model.CtrlCPolicy
+	if profile := a.profileForSession(id); profile != nil {
+		policy = profile.CtrlC
+	}
+	now := time.Now()
+	again := a.lastCtrlC.id == id && now.Sub(a.lastCtrlC.at) <= model.CtrlCWindow
+	a.lastCtrlC = ctrlCPress{id: id, at: now}
+
+	switch policy {
+	case model.CtrlCGuarded:
+		if !again {
+			a.toasts.Push("Press Ctrl+C again to interrupt "+a.paneLabel(id), false)
+			return nil, true
+		}
+		a.lastCtrlC = ctrlCPress{}
+		session.Write([]byte{0x03})
+		return nil, true
+	case model.CtrlCDoubleKill:
+		if !again {
+			return nil, false
+		}
+		a.lastCtrlC = ctrlCPress{}
+		a.holdRestart(id)
+		a.toasts.Push("Stopping "+a.paneLabel(id), false)
+		return func() tea.Msg {
+			_ = session.Stop()
+			return nil
+		}, true
+	}
+	return nil, false
+}
+
+// interruptPane sends SIGINT to a pane's process: the active pane, or the
+// one named in args.
+// Usage: interrupt [pane]
+func (a *App) interruptPane(args []string) {
+	id := a.activeTermID
+	if len(args) > 0 {
+		var ok bool
+		if id, ok = a.resolvePane(args[0]); !ok {
+			a.toasts.Push("Unknown pane: "+args[0], true)
+			return
+		}
+	}
+	session, ok := a.engine.GetSession(id)
+	if !ok || session.Status() != model.SessionStatusRunning {
+		a.toasts.Push("No running session to interrupt", true)
+		return
+	}
+	if err := session.Interrupt(); err != nil {
+		a.toasts.Push("Interrupt failed: "+err.Error(), true)
+		return
+	}
+	a.toasts.Push("Sent SIGINT to "+a.paneLabel(id), false)
+}
+
+// forceKillPane ends a pane's process at once, skipping the profile's stop
+// sequence and grace period: the active pane, or the one named in args.
+// The pane stays open with its output and is not restarted.
+// Usage: kill [pane]
+func (a *App) forceKillPane(args []string) tea.Cmd {
+	id := a.activeTermID
+	if len(args) > 0 {
+		var ok bool
+		if id, ok = a.resolvePane(args[0]); !ok {
+			a.toasts.Push("Unknown pane: "+args[0], true)
+			return nil
+		}
+	}
+	session, ok := a.engine.GetSession(id)
+	if !ok || session.Status() != model.SessionStatusRunning {
+		a.toasts.Push("No running session to kill", true)
+		return nil
+	}
+	a.holdRestart(id)
+	a.toasts.Push("Killed "+a.paneLabel(id), false)
+	return func() tea.Msg {
+		_ = a.engine.KillSession(id)
+		return nil
+	}
+}
@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lazyvibe/vibemux/internal/model"
+)
+
+// defaultReadyPattern matches the idle banners of common agent CLIs.
+var defaultReadyPattern = regexp.MustCompile(model.DefaultReadyPattern)
+
+const (
+	// readyTimeout is how long to wait for the ready banner before sending
+	// the startup input anyway once output has settled.
+	readyTimeout = 30 * time.Second
+	readySettle  = 2 * time.Second
+)
+
+// pendingPrompt is startup input (profile steps, then the project's initial
+// prompt) waiting for its session to be ready.
+type pendingPrompt struct {
+	steps   []model.StartupStep
+	ready   *regexp.Regexp
+	tail    string
+	started time.Time
+}
+
+// queueStartup arms the profile's startup steps, the project's initial
+// prompt and a resumed chain's context for a new session. An automatic
+// restart gets the profile's resume prompt instead of the initial prompt.
+func (a *App) queueStartup(sessionID string, project *model.Project, profile *model.Profile) {
+	var steps []model.StartupStep
+	if profile != nil {
+		steps = append(steps, profile.StartupSteps...)
+	}
+	if a.restarted(sessionID) {
+		// The agent picks up its earlier work rather than starting over.
+		if profile != nil {
+			if text := strings.TrimSpace(profile.Restart.ResumePrompt); text != "" {
+				steps = append(steps, model.StartupStep{Send: text})
+			}
+		}
+	} else {
+		if text := strings.TrimSpace(project.InitialPrompt); text != "" {
+			steps = append(steps, model.StartupStep{Send: text})
+		}
+		if chain := a.resumedChainPrompt(); chain != "" {
+			steps = append(steps, model.StartupStep{Send: chain})
+		}
+	}
+	if len(steps) == 0 {
+		return
+	}
+	ready := defaultReadyPattern
+	if project.ReadyPattern != "" {
+		re, err := regexp.Compile(project.ReadyPattern)
+		if err != nil {
+			a.toasts.Push("Invalid ready_pattern: "+err.Error(), true)
+		} else {
+			ready = re
+		}
+	}
+	a.pendingPrompts[sessionID] = &pendingPrompt{steps: steps, ready: ready, started: time.Now()}
+}
+
+// checkStartup sends the pending input once the ready banner shows up.
+func (a *App) checkStartup(sessionID string, data []byte) tea.Cmd {
+	p, ok := a.pendingPrompts[sessionID]
+	if !ok {
+		return nil
+	}
+	p.tail = trimTail(p.tail+ansi.Strip(string(data)), textTailLimit)
+	if !p.ready.MatchString(p.tail) {
+		return nil
+	}
+	return a.sendStartup(sessionID)
+}
+
+// flushStaleStartups sends input whose banner never matched, once the
+// session has been quiet for a moment after readyTimeout.
+func (a *App) flushStaleStartups() tea.Cmd {
+	var cmds []tea.Cmd
+	now := time.Now()
+	for id, p := range a.pendingPrompts {
+		if now.Sub(p.started) < readyTimeout || now.Sub(a.lastOutput[id]) < readySettle {
+			continue
+		}
+		cmds = append(cmds, a.sendStartup(id))
+	}
+	return tea.Batch(cmds...)
+}
+
+// sendStartup types the pending steps through the session Write path.
+func (a *App) sendStartup(sessionID string) tea.Cmd {
+	p := a.pendingPrompts[sessionID]
+	delete(a.pendingPrompts, sessionID)
+	session, ok := a.engine.GetSession(sessionID)
+	if p == nil || !ok || session.Status() != model.SessionStatusRunning {
+		return nil
+	}
+	for range p.steps {
+		a.recordPrompt(sessionID)
+	}
+	return func() tea.Msg {
+		// Give the CLI a moment to finish drawing its input box.
+		time.Sleep(500 * time.Millisecond)
+		for _, step := range p.steps {
+			time.Sleep(time.Duration(step.DelayMs) * time.Millisecond)
+			if session.Status() != model.SessionStatusRunning {
+				break
+			}
+			submitPrompt(session, step.Send)
+		}
+		return StatusMsg{Text: "Startup input sent"}
+	}
+}
+
+// setInitialPrompt updates the initial prompt of the active pane's project,
+// or of the selected project. An empty text clears it.
+func (a *App) setInitialPrompt(text string) tea.Cmd {
+	project := a.projectForSession(a.activeTermID)
+	if a.focus == FocusProjects || project == nil {
+		project = a.projectList.SelectedProject()
+	}
+	if project == nil {
+		a.toasts.Push("No project selected", true)
+		return nil
+	}
+	updated := *project
+	updated.InitialPrompt = strings.TrimSpace(text)
+	return func() tea.Msg {
+		if err := a.store.Update(a.ctx, &updated); err != nil {
+			return ErrorMsg{Err: err}
+		}
+		status := "Initial prompt set for " + updated.DisplayName()
+		if updated.InitialPrompt == "" {
+			status = "Initial prompt cleared for " + updated.DisplayName()
+		}
+		return ProjectUpdatedMsg{Project: updated, Status: status}
+	}
+}
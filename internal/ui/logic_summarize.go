@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/runtime"
+)
+
+const (
+	// summarySettle is how long the summarizer must stay quiet before its
+	// answer is read back.
+	summarySettle = 4 * time.Second
+	// summaryTimeout abandons a summary pass that never settles.
+	summaryTimeout = 3 * time.Minute
+)
+
+// summaryPass tracks a prompt sent to a pane whose answer is read back
+// once the pane goes quiet.
+type summaryPass struct {
+	targetID string
+	label    string
+	started  time.Time
+	// done receives the extracted answer, or "" when the pass failed.
+	done func(a *App, summary string) tea.Cmd
+}
+
+// startSummaryPass sends prompt to the pane and calls done with its answer.
+// Only one pass runs at a time.
+func (a *App) startSummaryPass(targetID, label, prompt string, done func(a *App, summary string) tea.Cmd) tea.Cmd {
+	if a.summaryPass != nil {
+		a.toasts.Push("A summary is already in progress in "+a.paneLabel(a.summaryPass.targetID), true)
+		return nil
+	}
+	session, ok := a.engine.GetSession(targetID)
+	if !ok || session.Status() != model.SessionStatusRunning {
+		a.toasts.Push("Summarizer pane is not running: "+a.paneLabel(targetID), true)
+		return nil
+	}
+	a.summaryPass = &summaryPass{targetID: targetID, label: label, started: time.Now(), done: done}
+	a.toasts.Push("Summarizing "+label+" in "+a.paneLabel(targetID)+"…", false)
+
+	text := prompt + "\n\n" + runtime.ChainPromptInstruction
+	return func() tea.Msg {
+		_ = runtime.Paste(session, []byte(text), runtime.DefaultPasteOptions)
+		time.Sleep(200 * time.Millisecond) // Let the agent take the paste
+		session.Write([]byte("\r"))
+		return nil
+	}
+}
+
+// checkSummaryPass reads the summarizer's answer once it has settled, or
+// gives up after summaryTimeout.
+func (a *App) checkSummaryPass() tea.Cmd {
+	p := a.summaryPass
+	if p == nil {
+		return nil
+	}
+	now := time.Now()
+	if now.Sub(p.started) > summaryTimeout {
+		a.summaryPass = nil
+		a.toasts.Push("Summary of "+p.label+" timed out", true)
+		return p.done(a, "")
+	}
+	last := a.lastOutput[p.targetID]
+	if !last.After(p.started) || now.Sub(last) < summarySettle {
+		return nil
+	}
+	a.summaryPass = nil
+	if _, ok := a.terminals[p.targetID]; !ok {
+		a.toasts.Push("Summarizer pane closed", true)
+		return p.done(a, "")
+	}
+	result, err := a.extractConclusion(p.targetID)
+	if err != nil {
+		a.toasts.Push("Summary extraction failed: "+err.Error(), true)
+		return p.done(a, "")
+	}
+	return p.done(a, result.Text)
+}
@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/ui/components/templates"
+)
+
+// loadTemplates returns a command to load the prompt templates.
+func (a App) loadTemplates() tea.Cmd {
+	return func() tea.Msg {
+		list, err := a.store.ListTemplates(a.ctx)
+		return TemplatesLoadedMsg{Templates: list, Err: err}
+	}
+}
+
+// templateCommand handles "template [send] <name>": it puts the named
+// template into the composer, or sends it to the active pane. Without
+// arguments it opens the template library.
+func (a *App) templateCommand(args []string) tea.Cmd {
+	if len(args) == 0 {
+		a.showTemplates()
+		return nil
+	}
+	send := strings.EqualFold(args[0], "send")
+	if send {
+		args = args[1:]
+	}
+	name := strings.Join(args, " ")
+	t := a.findTemplate(name)
+	if t == nil {
+		a.toasts.Push("No template named "+name, true)
+		return nil
+	}
+	if send {
+		return a.sendTemplate(*t)
+	}
+	a.insertTemplate(*t)
+	return nil
+}
+
+// showTemplates opens the template library.
+func (a *App) showTemplates() {
+	a.templatePicker.SetSize(a.width, a.height)
+	a.templatePicker.Open(a.templates)
+	a.dialogMode = DialogTemplates
+}
+
+// templateAction carries out what the template library asked for.
+func (a *App) templateAction() tea.Cmd {
+	t := a.templatePicker.Template()
+	switch a.templatePicker.Action() {
+	case templates.ActionClose:
+		a.hideDialog()
+	case templates.ActionInsert:
+		a.hideDialog()
+		a.insertTemplate(t)
+	case templates.ActionSend:
+		cmd := a.sendTemplate(t)
+		if cmd != nil {
+			a.hideDialog()
+		}
+		return cmd
+	case templates.ActionSave:
+		return a.saveTemplate(t)
+	case templates.ActionDelete:
+		return a.deleteTemplate(t)
+	}
+	return nil
+}
+
+// insertTemplate opens the composer with t's expanded text at the cursor.
+func (a *App) insertTemplate(t model.Template) {
+	a.showComposer()
+	a.composer.Insert(a.expandTemplate(t.Body, a.activeTermID))
+}
+
+// sendTemplate pastes t's expanded text into the active pane and submits it.
+func (a *App) sendTemplate(t model.Template) tea.Cmd {
+	return a.submitComposed(a.expandTemplate(t.Body, a.activeTermID), "template "+t.Name)
+}
+
+// saveTemplate stores t, adding it when it has no ID yet.
+func (a *App) saveTemplate(t model.Template) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		if t.ID == "" {
+			t = *model.NewTemplate(t.Name, t.Body)
+			err = a.store.CreateTemplate(a.ctx, &t)
+		} else {
+			err = a.store.UpdateTemplate(a.ctx, &t)
+		}
+		if err != nil {
+			return ErrorMsg{Err: fmt.Errorf("save template: %w", err)}
+		}
+		list, err := a.store.ListTemplates(a.ctx)
+		return TemplatesLoadedMsg{Templates: list, Err: err}
+	}
+}
+
+// deleteTemplate removes t from the library.
+func (a *App) deleteTemplate(t model.Template) tea.Cmd {
+	return func() tea.Msg {
+		if err := a.store.DeleteTemplate(a.ctx, t.ID); err != nil {
+			return ErrorMsg{Err: fmt.Errorf("delete template: %w", err)}
+		}
+		list, err := a.store.ListTemplates(a.ctx)
+		return TemplatesLoadedMsg{Templates: list, Err: err}
+	}
+}
+
+// findTemplate returns the template with the given name or ID, ignoring case.
+func (a *App) findTemplate(name string) *model.Template {
+	for i := range a.templates {
+		if strings.EqualFold(a.templates[i].Name, name) || a.templates[i].ID == name {
+			return &a.templates[i]
+		}
+	}
+	return nil
+}
+
+// templateBody returns the body of the template with the given ID, falling
+// back to the built-in default when the library no longer has it.
+func (a *App) templateBody(id string) string {
+	for _, t := range a.templates {
+		if t.ID == id {
+			return t.Body
+		}
+	}
+	for _, t := range model.DefaultTemplates() {
+		if t.ID == id {
+			return t.Body
+		}
+	}
+	return ""
+}
+
+// expandTemplate fills in the template variables for pane id: {{PROJECT}},
+// {{PANE}}, {{TOPIC}}, {{FILENAME}} and {{DATE}}.
+func (a *App) expandTemplate(body, id string) string {
+	project := ""
+	if p := a.projectForSession(id); p != nil {
+		project = p.DisplayName()
+	} else if p := a.projectList.SelectedProject(); p != nil {
+		project = p.DisplayName()
+	}
+	return model.ExpandTemplate(body, map[string]string{
+		"PROJECT":  project,
+		"PANE":     a.paneLabel(id),
+		"TOPIC":    a.turnTopic,
+		"FILENAME": a.turnFilename,
+		"DATE":     time.Now().Format("2006-01-02"),
+	})
+}
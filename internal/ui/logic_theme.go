@@ -0,0 +1,43 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/app"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
+)
+
+// applyConfigTheme switches to the config's theme before the first frame.
+// An unknown name keeps the default; themeWarning reports it.
+func applyConfigTheme(cfg *app.Config) {
+	if cfg != nil {
+		_ = styles.Apply(cfg.Theme)
+	}
+}
+
+// themeWarning reports a config theme that does not exist.
+func (a App) themeWarning() tea.Cmd {
+	if a.config == nil || a.config.Theme == "" || a.config.Theme == styles.ActiveTheme() {
+		return nil
+	}
+	err := styles.Apply(a.config.Theme)
+	return func() tea.Msg {
+		return StatusMsg{Text: "Theme not applied: " + err.Error(), IsError: true}
+	}
+}
+
+// setTheme switches the theme and saves it to config.json.
+func (a *App) setTheme(name string) error {
+	if err := styles.Apply(name); err != nil {
+		return err
+	}
+	a.sessionTabs.Restyle()
+	if a.config != nil && a.configDir != "" {
+		updated := *a.config
+		updated.Theme = name
+		if err := app.SaveConfig(a.configDir, &updated); err != nil {
+			return err
+		}
+		*a.config = updated
+	}
+	return nil
+}
@@ -2,11 +2,14 @@ package ui
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lazyvibe/vibemux/internal/hooks"
 	"github.com/lazyvibe/vibemux/internal/model"
 )
 
@@ -14,10 +17,12 @@ import (
 
 // parseTurnSequence parses a sequence string like "0,1,2,1,2" or "0-3" into a list of terminal IDs.
 // It maps the indices (0-based) to the actual Project IDs from the grid.
-func (a *App) parseTurnSequence(input string, gridIDs []string) []string {
+// A trailing "xN" (e.g. "0,1,2 x3") runs the sequence N times; rounds is 1 without it.
+func (a *App) parseTurnSequence(input string, gridIDs []string) (ids []string, rounds int) {
+	input, rounds = splitTurnRounds(input)
 	if input == "" {
 		// Default: Round Robin 0..N
-		return gridIDs
+		return gridIDs, rounds
 	}
 
 	parts := strings.Split(input, ",")
@@ -55,20 +60,35 @@ func (a *App) parseTurnSequence(input string, gridIDs []string) []string {
 	}
 
 	if len(resultIDs) == 0 {
-		return gridIDs
+		return gridIDs, rounds
 	}
-	return resultIDs
+	return resultIDs, rounds
+}
+
+// splitTurnRounds strips a trailing "xN" round count from a turn sequence.
+func splitTurnRounds(input string) (string, int) {
+	input = strings.TrimSpace(input)
+	i := strings.LastIndexAny(input, "xX")
+	if i < 0 {
+		return input, 1
+	}
+	rounds, err := strconv.Atoi(strings.TrimSpace(input[i+1:]))
+	if err != nil || rounds < 1 {
+		return input, 1
+	}
+	return strings.TrimSpace(input[:i]), rounds
 }
 
 // initAutoTurn initializes the turn sequence data but does NOT start the turn.
 // This allows for manual confirmation before starting.
 func (a *App) initAutoTurn(sequenceStr string) {
-	a.turnSequence = a.parseTurnSequence(sequenceStr, a.gridOrder())
+	a.turnSequence, a.turnRounds = a.parseTurnSequence(sequenceStr, a.gridOrder())
 	a.currentSeqIndex = 0
+	a.turnRound = 0
 	a.autoTurnEnabled = false // Default to paused/manual start
 	a.autoTurnCountdown = 10 // User requested 10s default
 	a.updateTurnStatus()
-	a.statusBar.SetMessage("Roles assigned. Press Alt+A to start auto-turn.", false)
+	a.toasts.Push("Roles assigned. Press Alt+A to start auto-turn.", false)
 }
 
 // startAutoTurn initializes and STARTS the first turn immediately (Legacy/Manual usage).
@@ -86,11 +106,17 @@ func (a *App) sendNextTurn() tea.Cmd {
 	
 	a.currentSeqIndex++
 	
+	// Start the next round, if any
+	if a.currentSeqIndex >= len(a.turnSequence) && a.turnRound+1 < a.turnRounds {
+		a.turnRound++
+		a.currentSeqIndex = 0
+	}
+
 	// Check if sequence is finished
 	if a.currentSeqIndex >= len(a.turnSequence) {
 		a.autoTurnEnabled = false
 		a.updateTurnStatus()
-		a.statusBar.SetMessage("Auto-Turn Sequence Completed", false)
+		a.toasts.Push("Auto-Turn Sequence Completed (:minutes to summarize)", false)
 		return nil
 	}
 
@@ -111,6 +137,13 @@ func (a *App) sendCurrentTurn() tea.Cmd {
 	// Reset Timeout Tracking
 	a.currentTurnStartTime = time.Now()
 
+	var turn model.TurnConfig
+	if profile := a.profileForSession(targetID); profile != nil {
+		turn = profile.Turn
+	}
+	a.watchTurn(targetID, turn)
+	msg := a.turnMessage(targetID, turn)
+
 	cmd := func() tea.Msg {
 		session, ok := a.engine.GetSession(targetID)
 		if !ok || session.Status() != model.SessionStatusRunning {
@@ -119,7 +152,6 @@ func (a *App) sendCurrentTurn() tea.Cmd {
 
 		// Send "Your Turn" command
 		// Use \r (Carriage Return) to submit the command in PTY
-		msg := fmt.Sprintf("[SYSTEM] 你的回合已到。请读取文件 %s 并执行输出。", a.turnFilename)
 		session.Write([]byte(msg))
 		time.Sleep(200 * time.Millisecond) // Delay for terminal to process
 		session.Write([]byte("\r")) // Submit with Enter
@@ -127,12 +159,82 @@ func (a *App) sendCurrentTurn() tea.Cmd {
 		return nil
 	}
 	
-	// Schedule a timeout check (e.g., 2 minutes)
-	timeoutCmd := tea.Tick(2 * time.Minute, func(t time.Time) tea.Msg {
-		return AutoTurnTimeoutMsg{TargetID: targetID, StartTime: a.currentTurnStartTime}
+	// Schedule a timeout check (profile turn timeout, 2 minutes by default)
+	startTime := a.currentTurnStartTime
+	timeoutCmd := tea.Tick(turn.Timeout(), func(t time.Time) tea.Msg {
+		return AutoTurnTimeoutMsg{TargetID: targetID, StartTime: startTime}
 	})
 	
-	return tea.Batch(cmd, timeoutCmd)
+	return tea.Batch(cmd, timeoutCmd, a.runHooks(hooks.TurnAdvanced, targetID, msg))
+}
+
+// turnMessage returns the "your turn" message for targetID: the message set
+// for it in the organizer dialog, else the dialog's default message, else the
+// profile's. {{FILE}}, {{FILENAME}}, {{ROLE}}, {{TOPIC}} and {{ROUND}} are
+// filled in.
+func (a *App) turnMessage(targetID string, turn model.TurnConfig) string {
+	msg := a.turnMessages[targetID]
+	if msg == "" {
+		msg = a.turnMessageDefault
+	}
+	if msg == "" {
+		return turn.PromptFor(a.turnFilename)
+	}
+	return model.ExpandTemplate(msg, map[string]string{
+		"FILE":     a.turnFilename,
+		"FILENAME": a.turnFilename,
+		"ROLE":     a.turnRoles[targetID],
+		"TOPIC":    a.turnTopic,
+		"ROUND":    strconv.Itoa(a.turnRound + 1),
+	})
+}
+
+// defaultTurnDelay is the pause between a detected turn completion and the
+// next turn, leaving time to cancel with Alt+A.
+const defaultTurnDelay = 5
+
+// turnWatch tracks the current target's output for the profile's
+// completion pattern.
+type turnWatch struct {
+	targetID string
+	pattern  *regexp.Regexp
+	tail     string
+	done     bool
+}
+
+// watchTurn arms completion detection for a new turn.
+func (a *App) watchTurn(targetID string, turn model.TurnConfig) {
+	a.turnWatch = turnWatch{targetID: targetID}
+	if turn.CompletionPattern == "" {
+		return
+	}
+	re, err := regexp.Compile(turn.CompletionPattern)
+	if err != nil {
+		a.toasts.Push("Invalid turn completion pattern: "+err.Error(), true)
+		return
+	}
+	a.turnWatch.pattern = re
+}
+
+// checkTurnCompletion matches output from the current turn's target against
+// its completion pattern and starts the countdown to the next turn once.
+func (a *App) checkTurnCompletion(sessionID string, data []byte) tea.Cmd {
+	w := &a.turnWatch
+	if !a.autoTurnEnabled || w.pattern == nil || w.done || w.targetID != sessionID {
+		return nil
+	}
+	w.tail = trimTail(w.tail+ansi.Strip(string(data)), textTailLimit)
+	if !w.pattern.MatchString(w.tail) {
+		return nil
+	}
+	w.done = true
+	w.tail = ""
+	delay := a.turnDelay
+	a.autoTurnCountdown = delay
+	a.updateTurnStatus()
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return AutoTurnCountdownMsg(delay)
+	})
 }
 
 type AutoTurnTimeoutMsg struct {
@@ -156,7 +258,7 @@ func (a *App) toggleAutoTurn() tea.Cmd {
 	}
 	
 	a.updateTurnStatus()
-	a.statusBar.SetMessage(fmt.Sprintf("Auto-Turn: %s", status), false)
+	a.toasts.Push(fmt.Sprintf("Auto-Turn: %s", status), false)
 	return cmd
 }
 
@@ -179,6 +281,9 @@ func (a *App) updateTurnStatus() {
 	}
 	
 	info := fmt.Sprintf("SEQ: %d/%d (Next: %s)", current, total, a.turnSequence[a.currentSeqIndex])
+	if a.turnRounds > 1 {
+		info += fmt.Sprintf(" R%d/%d", a.turnRound+1, a.turnRounds)
+	}
 	
 	if a.autoTurnCountdown > 0 {
 		info += fmt.Sprintf(" [Auto in %ds]", a.autoTurnCountdown)
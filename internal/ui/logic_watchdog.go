@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/model"
+)
+
+// Choices of the stuck pane dialog, in display order.
+const (
+	stuckInterrupt = iota
+	stuckRestart
+)
+
+// checkStuckPanes asks what to do about a pane that has shown its busy
+// pattern for longer than its profile allows. The dialog waits until no
+// other dialog is open and keys are not going to a pane, so typing cannot
+// pick a choice by accident.
+func (a *App) checkStuckPanes() {
+	if a.pendingStuck != "" {
+		return
+	}
+	now := time.Now()
+	for id, w := range a.outputWatchers {
+		if w == nil {
+			continue
+		}
+		var cfg model.WatchdogConfig
+		if profile := a.profileForSession(id); profile != nil {
+			cfg = profile.Watchdog
+		}
+		busy, stuck := w.Stuck(cfg.StuckAfter(), now)
+		if !stuck {
+			continue
+		}
+		msg := fmt.Sprintf("%s has been working for %s without finishing.", a.paneLabel(id), busy.Round(time.Minute))
+		if a.dialogMode != DialogNone || a.inputMode == InputModeTerminal {
+			if w.NoteStuck() {
+				a.toasts.Push(msg+" Leave terminal mode to interrupt or restart it.", true)
+			}
+			continue
+		}
+		w.OfferedStuck()
+		a.pendingStuck = id
+		a.confirm.SetSize(a.width, a.height)
+		a.confirm.Open("Pane Looks Stuck", msg, "Interrupt", "Restart", "Ignore")
+		a.dialogMode = DialogConfirm
+		return
+	}
+}
+
+// resolveStuck acts on the stuck pane dialog choice.
+func (a *App) resolveStuck(choice int) tea.Cmd {
+	id := a.pendingStuck
+	a.pendingStuck = ""
+	switch choice {
+	case stuckInterrupt:
+		if session, ok := a.engine.GetSession(id); ok && session.Status() == model.SessionStatusRunning {
+			session.Write([]byte{0x1b}) // Esc interrupts Claude Code and Codex
+			a.toasts.Push("Interrupted "+a.paneLabel(id), false)
+		}
+	case stuckRestart:
+		return a.restartSession(id)
+	}
+	return nil
+}
+
+// restartSession closes a session and launches it again with the same
+// project and options.
+func (a *App) restartSession(id string) tea.Cmd {
+	project := a.projectForSession(id)
+	if project == nil {
+		a.toasts.Push("Cannot restart "+a.paneLabel(id)+": project not found", true)
+		return nil
+	}
+	opts := a.bindings[id].Opts
+	_ = a.engine.CloseSession(id)
+	if inst, ok := a.terminals[id]; ok {
+		inst.Terminal.SetStatus(model.SessionStatusStopped)
+		inst.Terminal.Clear()
+	}
+	a.syncProjectRunning(project.ID)
+	a.sessionTabs.SetTabStatus(id, model.SessionStatusStopped)
+	return a.launchSession(project, id, opts)
+}
@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/app"
+	"github.com/lazyvibe/vibemux/internal/model"
+)
+
+// workspaceProfile returns the default profile of the project's workspace,
+// or nil when the workspace has none.
+func (a *App) workspaceProfile(project *model.Project) *model.Profile {
+	if project == nil || project.Workspace == "" || a.config == nil {
+		return nil
+	}
+	id, ok := a.config.WorkspaceProfiles[project.Workspace]
+	if !ok {
+		return nil
+	}
+	return a.findProfileByID(id)
+}
+
+// workspaceCommand handles:
+//
+//	workspace                        list workspaces and their default profiles
+//	workspace set <name>|-           move the selected project into a workspace
+//	workspace profile <name> <profile>|-
+//	                                 set a workspace's default profile
+func (a *App) workspaceCommand(args []string) tea.Cmd {
+	if len(args) == 0 {
+		a.toasts.Push(a.workspaceSummary(), false)
+		return nil
+	}
+	switch strings.ToLower(args[0]) {
+	case "set":
+		if len(args) != 2 {
+			a.toasts.Push("Usage: workspace set <name> (- clears)", true)
+			return nil
+		}
+		return a.setProjectWorkspace(args[1])
+	case "profile":
+		if len(args) < 3 {
+			a.toasts.Push("Usage: workspace profile <name> <profile> (- clears)", true)
+			return nil
+		}
+		if err := a.setWorkspaceProfile(args[1], strings.Join(args[2:], " ")); err != nil {
+			a.toasts.Push(err.Error(), true)
+		}
+		return nil
+	}
+	a.toasts.Push("Usage: workspace [set <name> | profile <name> <profile>]", true)
+	return nil
+}
+
+// setProjectWorkspace moves the active pane's project, or the selected
+// project, into a workspace. "-" removes it from its workspace.
+func (a *App) setProjectWorkspace(name string) tea.Cmd {
+	project := a.projectForSession(a.activeTermID)
+	if a.focus == FocusProjects || project == nil {
+		project = a.projectList.SelectedProject()
+	}
+	if project == nil {
+		a.toasts.Push("No project selected", true)
+		return nil
+	}
+	if name == "-" {
+		name = ""
+	}
+	updated := *project
+	updated.Workspace = name
+	return func() tea.Msg {
+		if err := a.store.Update(a.ctx, &updated); err != nil {
+			return ErrorMsg{Err: err}
+		}
+		status := updated.DisplayName() + " moved to workspace " + name
+		if name == "" {
+			status = updated.DisplayName() + " removed from its workspace"
+		}
+		return ProjectUpdatedMsg{Project: updated, Status: status}
+	}
+}
+
+// setWorkspaceProfile persists a workspace's default profile. "-" removes
+// the override so the global default applies again.
+func (a *App) setWorkspaceProfile(workspace, profileRef string) error {
+	profileID := ""
+	if profileRef != "-" {
+		id, err := a.resolveProfileID(profileRef)
+		if err != nil {
+			return err
+		}
+		profileID = id
+	}
+	if a.config != nil {
+		updated := *a.config
+		updated.WorkspaceProfiles = make(map[string]string, len(a.config.WorkspaceProfiles)+1)
+		for ws, id := range a.config.WorkspaceProfiles {
+			updated.WorkspaceProfiles[ws] = id
+		}
+		if profileID == "" {
+			delete(updated.WorkspaceProfiles, workspace)
+		} else {
+			updated.WorkspaceProfiles[workspace] = profileID
+		}
+		if a.configDir != "" {
+			if err := app.SaveConfig(a.configDir, &updated); err != nil {
+				return err
+			}
+		}
+		*a.config = updated
+	}
+	a.syncWorkspaceDefaults()
+	if profileID == "" {
+		a.toasts.Push("Workspace "+workspace+" uses the global default profile", false)
+	} else {
+		a.toasts.Push("Workspace "+workspace+" defaults to "+a.findProfileByID(profileID).Name, false)
+	}
+	return nil
+}
+
+// workspaceSummary lists known workspaces with their default profiles.
+func (a *App) workspaceSummary() string {
+	names := make(map[string]bool)
+	for _, p := range a.projects {
+		if p.Workspace != "" {
+			names[p.Workspace] = true
+		}
+	}
+	if a.config != nil {
+		for ws := range a.config.WorkspaceProfiles {
+			names[ws] = true
+		}
+	}
+	if len(names) == 0 {
+		return "No workspaces (workspace set <name> on a project)"
+	}
+	sorted := make([]string, 0, len(names))
+	for ws := range names {
+		sorted = append(sorted, ws)
+	}
+	sort.Strings(sorted)
+	parts := make([]string, 0, len(sorted))
+	for _, ws := range sorted {
+		profile := "global default"
+		if p := a.workspaceProfile(&model.Project{Workspace: ws}); p != nil {
+			profile = p.Name
+		}
+		parts = append(parts, ws+" → "+profile)
+	}
+	return "Workspaces: " + strings.Join(parts, ", ")
+}
+
+// syncWorkspaceDefaults shows workspace default profiles in the project
+// details.
+func (a *App) syncWorkspaceDefaults() {
+	defaults := make(map[string]string)
+	if a.config != nil {
+		for ws := range a.config.WorkspaceProfiles {
+			if p := a.workspaceProfile(&model.Project{Workspace: ws}); p != nil {
+				defaults[ws] = p.Name
+			}
+		}
+	}
+	a.projectList.SetWorkspaceDefaults(defaults)
+}
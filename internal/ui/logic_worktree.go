@@ -0,0 +1,282 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/git"
+	"github.com/lazyvibe/vibemux/internal/model"
+)
+
+// worktreeTimeout caps creating, merging or removing a worktree.
+const worktreeTimeout = time.Minute
+
+// Choices of the closed worktree dialog, in display order.
+const (
+	worktreeKeep = iota
+	worktreeMerge
+	worktreeRemove
+)
+
+// worktreeCleanup is the worktree of a closed pane, waiting for the user to
+// keep, merge or remove it.
+type worktreeCleanup struct {
+	Label     string
+	SessionID string // Closed session, waited for before touching the worktree
+	ProjectID string
+	Dir       string // Project directory, which the branch is merged into
+	Worktree  git.Worktree
+}
+
+// worktreeCreatedMsg launches a session in the worktree made for it.
+type worktreeCreatedMsg struct {
+	ProjectID string
+	SessionID string
+	Opts      launchOptions
+}
+
+// worktreeDoneMsg reports a merge or removal.
+type worktreeDoneMsg struct {
+	Status string
+	Err    error
+}
+
+// worktreeBranch names the branch of a new session's worktree after its
+// alias or project and the time, e.g. "vibemux/api-1016-1530".
+func worktreeBranch(project *model.Project, opts launchOptions) string {
+	name := opts.Alias
+	if name == "" {
+		name = project.DisplayName()
+	}
+	slug := strings.Trim(strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '.', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		}
+		return '-'
+	}, name), "-.")
+	if slug == "" {
+		slug = "session"
+	}
+	return "vibemux/" + slug + "-" + time.Now().Format("0102-1504")
+}
+
+// createWorktree gives a session of a worktree project a worktree and
+// branch of its own in the background, then launches it there.
+func (a *App) createWorktree(project *model.Project, sessionID string, opts launchOptions) tea.Cmd {
+	a.bindings[sessionID] = sessionBinding{ProjectID: project.ID, Opts: opts}
+	spinner := a.markLaunching(sessionID, opts)
+	ctx, dir, branch := a.ctx, project.Path, worktreeBranch(project, opts)
+	projectID := project.ID
+	return tea.Batch(spinner, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, worktreeTimeout)
+		defer cancel()
+		wt, err := git.AddWorktree(ctx, dir, branch)
+		if err != nil {
+			return SessionLaunchFailedMsg{SessionID: sessionID, Err: fmt.Errorf("no worktree: %w", err)}
+		}
+		opts.Worktree = wt
+		return worktreeCreatedMsg{ProjectID: projectID, SessionID: sessionID, Opts: opts}
+	})
+}
+
+// launchInWorktree starts a session in its new worktree. A worktree whose
+// pane was closed while it was made is removed again.
+func (a *App) launchInWorktree(msg worktreeCreatedMsg) tea.Cmd {
+	project := a.findProjectByID(msg.ProjectID)
+	if project == nil || !a.hasPane(msg.SessionID) {
+		if project == nil {
+			return nil
+		}
+		return a.removeWorktree(worktreeCleanup{Dir: project.Path, Worktree: msg.Opts.Worktree})
+	}
+	a.toasts.Push(a.paneLabel(msg.SessionID)+" works on branch "+msg.Opts.Worktree.Branch, false)
+	return a.launchSession(project, msg.SessionID, msg.Opts)
+}
+
+// offerWorktreeCleanup queues the worktree of a closed pane for the user to
+// decide on.
+func (a *App) offerWorktreeCleanup(c worktreeCleanup) {
+	a.worktreeCleanups = append(a.worktreeCleanups, c)
+	a.nextWorktreeCleanup()
+}
+
+// nextWorktreeCleanup asks about the oldest queued worktree. Like the stuck
+// pane dialog, it waits until no other dialog is open and keys are not
+// going to a pane.
+func (a *App) nextWorktreeCleanup() {
+	if len(a.worktreeCleanups) == 0 || a.pendingWorktree != nil ||
+		a.dialogMode != DialogNone || a.inputMode == InputModeTerminal {
+		return
+	}
+	c := a.worktreeCleanups[0]
+	a.worktreeCleanups = a.worktreeCleanups[1:]
+	a.pendingWorktree = &c
+	msg := fmt.Sprintf("%s worked on branch %s in %s. Merge the branch into the project and remove the worktree, keep both, or remove them and discard the work?",
+		c.Label, c.Worktree.Branch, c.Worktree.Root)
+	a.confirm.SetSize(a.width, a.height)
+	a.confirm.Open("Worktree Session Closed", msg, "Keep", "Merge", "Remove")
+	a.dialogMode = DialogConfirm
+}
+
+// resolveWorktreeCleanup acts on the closed worktree dialog choice.
+func (a *App) resolveWorktreeCleanup(choice int) tea.Cmd {
+	c := *a.pendingWorktree
+	a.pendingWorktree = nil
+	switch choice {
+	case worktreeMerge:
+		if project := a.findProjectByID(c.ProjectID); project != nil && a.runsInProjectDir(project) {
+			a.toasts.Push("Kept branch "+c.Worktree.Branch+": a session is running in "+project.DisplayName()+"'s directory", true)
+			return nil
+		}
+		return a.mergeWorktree(c, true)
+	case worktreeRemove:
+		return a.removeWorktree(c)
+	}
+	a.toasts.Push("Kept branch "+c.Worktree.Branch+" in "+c.Worktree.Root, false)
+	return nil
+}
+
+// cancelWorktreeCleanup keeps the worktree when its dialog is dismissed.
+func (a *App) cancelWorktreeCleanup() tea.Cmd {
+	if a.pendingWorktree == nil {
+		return nil
+	}
+	return a.resolveWorktreeCleanup(worktreeKeep)
+}
+
+// mergeWorktree commits what is left uncommitted in a worktree and merges
+// its branch into the branch checked out in the project directory, then
+// removes the worktree and branch when remove is set. A closed session's
+// agent is waited for first, so it cannot write while this commits.
+func (a *App) mergeWorktree(c worktreeCleanup, remove bool) tea.Cmd {
+	ctx, engine := a.ctx, a.engine
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, worktreeTimeout)
+		defer cancel()
+		wt := c.Worktree
+		if err := engine.WaitStopped(ctx, c.SessionID); err != nil {
+			return worktreeDoneMsg{Err: fmt.Errorf("%s is still stopping: %w", c.Label, err)}
+		}
+		if _, err := git.CommitAll(ctx, wt.Root, "Uncommitted changes of "+c.Label); err != nil {
+			return worktreeDoneMsg{Err: fmt.Errorf("commit in %s: %w", wt.Branch, err)}
+		}
+		if err := git.Merge(ctx, c.Dir, wt.Branch); err != nil {
+			return worktreeDoneMsg{Err: err}
+		}
+		if !remove {
+			return worktreeDoneMsg{Status: "Merged " + wt.Branch}
+		}
+		if err := git.RemoveWorktree(ctx, c.Dir, wt); err != nil {
+			return worktreeDoneMsg{Err: fmt.Errorf("merged %s, but %w", wt.Branch, err)}
+		}
+		return worktreeDoneMsg{Status: "Merged and removed " + wt.Branch}
+	}
+}
+
+// removeWorktree deletes a worktree and its branch in the background.
+func (a *App) removeWorktree(c worktreeCleanup) tea.Cmd {
+	ctx, engine := a.ctx, a.engine
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, worktreeTimeout)
+		defer cancel()
+		if err := engine.WaitStopped(ctx, c.SessionID); err != nil {
+			return worktreeDoneMsg{Err: fmt.Errorf("%s is still stopping: %w", c.Label, err)}
+		}
+		if err := git.RemoveWorktree(ctx, c.Dir, c.Worktree); err != nil {
+			return worktreeDoneMsg{Err: err}
+		}
+		return worktreeDoneMsg{Status: "Removed " + c.Worktree.Branch}
+	}
+}
+
+// worktreeCommand manages worktree sessions.
+// Usage: worktree [on | off | merge [pane]]
+func (a *App) worktreeCommand(args []string) tea.Cmd {
+	if len(args) == 0 {
+		if wt := a.bindings[a.activeTermID].Opts.Worktree; wt.Root != "" && a.focus != FocusProjects {
+			a.toasts.Push(a.paneLabel(a.activeTermID)+" works on branch "+wt.Branch+" in "+wt.Root, false)
+			return nil
+		}
+	}
+	switch {
+	case len(args) == 0, strings.EqualFold(args[0], "on"), strings.EqualFold(args[0], "off"):
+		return a.setProjectWorktree(args)
+	case strings.EqualFold(args[0], "merge"):
+		id := a.activeTermID
+		if len(args) > 1 {
+			var ok bool
+			if id, ok = a.resolvePane(strings.Join(args[1:], " ")); !ok {
+				a.toasts.Push("Unknown pane: "+strings.Join(args[1:], " "), true)
+				return nil
+			}
+		}
+		project := a.projectForSession(id)
+		wt := a.bindings[id].Opts.Worktree
+		if project == nil || wt.Root == "" {
+			a.toasts.Push("No worktree pane to merge", true)
+			return nil
+		}
+		// The agent would keep writing while its changes are committed.
+		if session, ok := a.engine.GetSession(id); ok && session.Status() == model.SessionStatusRunning {
+			a.toasts.Push("Stop "+a.paneLabel(id)+" before merging its branch", true)
+			return nil
+		}
+		if a.runsInProjectDir(project) {
+			a.toasts.Push("A session is running in "+project.DisplayName()+"'s directory; stop it before merging", true)
+			return nil
+		}
+		return a.mergeWorktree(worktreeCleanup{Label: a.paneLabel(id), Dir: project.Path, Worktree: wt}, false)
+	}
+	a.toasts.Push("Usage: worktree [on | off | merge [pane]]", true)
+	return nil
+}
+
+// runsInProjectDir reports whether a running session of project works in
+// the project directory itself, whose files a merge would change under it.
+func (a *App) runsInProjectDir(project *model.Project) bool {
+	for _, session := range a.engine.SessionsForProject(project.ID) {
+		if session.Status() == model.SessionStatusRunning && a.bindings[session.ID()].Opts.Worktree.Root == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// setProjectWorktree shows or sets whether the active pane's project, or
+// the selected project, starts its sessions in worktrees of their own.
+func (a *App) setProjectWorktree(args []string) tea.Cmd {
+	project := a.projectForSession(a.activeTermID)
+	if a.focus == FocusProjects || project == nil {
+		project = a.projectList.SelectedProject()
+	}
+	if project == nil {
+		a.toasts.Push("No project selected", true)
+		return nil
+	}
+	if len(args) == 0 {
+		state := "off"
+		if project.Worktree {
+			state = "on"
+		}
+		a.toasts.Push("Worktree sessions are "+state+" for "+project.DisplayName(), false)
+		return nil
+	}
+	updated := *project
+	updated.Worktree = strings.EqualFold(args[0], "on")
+	return func() tea.Msg {
+		if err := a.store.Update(a.ctx, &updated); err != nil {
+			return ErrorMsg{Err: err}
+		}
+		status := updated.DisplayName() + " starts new sessions in worktrees of their own"
+		if !updated.Worktree {
+			status = updated.DisplayName() + " starts new sessions in its own directory"
+		}
+		return ProjectUpdatedMsg{Project: updated, Status: status}
+	}
+}
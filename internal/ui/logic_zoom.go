@@ -0,0 +1,26 @@
+package ui
+
+// zoomActive reports whether the active pane is zoomed to fill the terminal
+// area. Zoom has no effect in the compact layout, which shows one pane anyway.
+func (a *App) zoomActive() bool {
+	if !a.zoomed || a.compactLayout() {
+		return false
+	}
+	return indexOfID(a.gridOrder(), a.activeTermID) >= 0
+}
+
+// toggleZoom zooms the active pane to the whole terminal area, resizing its
+// PTY, or restores the grid. Switching panes while zoomed zooms the new one.
+func (a *App) toggleZoom() {
+	if !a.zoomed && indexOfID(a.gridOrder(), a.activeTermID) < 0 {
+		a.toasts.Push("No pane to zoom", true)
+		return
+	}
+	a.zoomed = !a.zoomed
+	a.SetSize(a.width, a.height)
+	if a.zoomed {
+		a.toasts.Push("Zoomed "+a.paneLabel(a.activeTermID)+" (z to restore the grid)", false)
+	} else {
+		a.toasts.Push("Grid restored", false)
+	}
+}
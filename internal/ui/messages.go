@@ -2,8 +2,13 @@
 package ui
 
 import (
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/control"
 	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/runtime"
+	"github.com/lazyvibe/vibemux/internal/store"
 )
 
 // ---------- Projects Messages ----------
@@ -24,6 +29,12 @@ type ProjectCreatedMsg struct {
 	Project model.Project
 }
 
+// ProjectUpdatedMsg is sent when a project's settings were saved.
+type ProjectUpdatedMsg struct {
+	Project model.Project
+	Status  string
+}
+
 // ProjectDeletedMsg is sent when a project is deleted.
 type ProjectDeletedMsg struct {
 	ProjectID string
@@ -37,6 +48,18 @@ type ProfilesLoadedMsg struct {
 	Err      error
 }
 
+// TemplatesLoadedMsg is sent when prompt templates are loaded from store.
+type TemplatesLoadedMsg struct {
+	Templates []model.Template
+	Err       error
+}
+
+// SharedLoadedMsg is sent when the shared config source has been (re)read.
+type SharedLoadedMsg struct {
+	Shared *store.Shared // Nil when no source is configured
+	Err    error
+}
+
 // ProfileSelectedMsg is sent when a profile is selected.
 type ProfileSelectedMsg struct {
 	ProfileID string
@@ -57,24 +80,36 @@ type ProfileDeletedMsg struct {
 
 // SessionStartedMsg is sent when a PTY session starts.
 type SessionStartedMsg struct {
-	ProjectID string
+	SessionID string
+	// Profile is the profile the session was launched with, if any.
+	Profile *model.Profile
+}
+
+// SessionLaunchFailedMsg is sent when a session could not be created.
+type SessionLaunchFailedMsg struct {
+	SessionID string
+	Err       error
 }
 
-// SessionStoppedMsg is sent when a PTY session stops.
+// SessionStoppedMsg is sent when a PTY session stops. Err is how the
+// process exited; nil for a clean exit or a deliberate stop.
 type SessionStoppedMsg struct {
-	ProjectID string
+	SessionID string
 	Err       error
+	// session is the stopped session, to tell it from one that has since
+	// replaced it under the same ID.
+	session runtime.Session
 }
 
 // SessionOutputMsg carries PTY output data.
 type SessionOutputMsg struct {
-	ProjectID string
+	SessionID string
 	Data      []byte
 }
 
 // SessionStatusMsg reports session status changes.
 type SessionStatusMsg struct {
-	ProjectID string
+	SessionID string
 	Status    model.SessionStatus
 }
 
@@ -90,6 +125,51 @@ type ErrorMsg struct {
 	Err error
 }
 
+// ControlRequestMsg asks to start or stop a session for a control API client.
+type ControlRequestMsg struct {
+	Request control.Request
+}
+
+// StatusMsg carries the result of a background command for the status bar.
+type StatusMsg struct {
+	Text    string
+	IsError bool
+}
+
+// housekeepingTickMsg drives periodic background checks.
+type housekeepingTickMsg time.Time
+
+// housekeepingInterval is the period between housekeeping ticks.
+const housekeepingInterval = time.Second
+
+// housekeepingTick schedules the next housekeeping tick.
+func housekeepingTick() tea.Cmd {
+	return tea.Tick(housekeepingInterval, func(t time.Time) tea.Msg {
+		return housekeepingTickMsg(t)
+	})
+}
+
+// launchSpinnerTickMsg advances the spinners of launching panes.
+type launchSpinnerTickMsg time.Time
+
+// launchSpinnerInterval is the spinner frame period.
+const launchSpinnerInterval = 100 * time.Millisecond
+
+// launchSpinnerTick schedules the next spinner frame.
+func launchSpinnerTick() tea.Cmd {
+	return tea.Tick(launchSpinnerInterval, func(t time.Time) tea.Msg {
+		return launchSpinnerTickMsg(t)
+	})
+}
+
+// batchLaunchTickMsg launches the next project of a startall batch.
+type batchLaunchTickMsg struct {
+	batch *batchLaunch
+}
+
+// batchStagger is the pause between launches of a startall batch.
+const batchStagger = time.Second
+
 // ---------- Input Messages ----------
 
 // InputSubmittedMsg is sent when text input is submitted.
@@ -122,12 +202,13 @@ func LoadProfiles(loader func() ([]model.Profile, error)) tea.Cmd {
 // It implements "Dynamic Catch-up" by opportunistic batching:
 // If the channel has more data ready, it bundles it into a single message
 // to reduce Bubble Tea render cycles (which is the main bottleneck).
-func WaitForOutput(outputCh <-chan []byte, projectID string) tea.Cmd {
+func WaitForOutput(session runtime.Session, sessionID string) tea.Cmd {
+	outputCh := session.Output()
 	return func() tea.Msg {
 		// 1. Block wait for the first chunk (latency priority)
 		data, ok := <-outputCh
 		if !ok {
-			return SessionStoppedMsg{ProjectID: projectID}
+			return SessionStoppedMsg{SessionID: sessionID, Err: session.ExitError(), session: session}
 		}
 
 		// 2. Batching loop (throughput priority)
@@ -141,15 +222,15 @@ func WaitForOutput(outputCh <-chan []byte, projectID string) tea.Cmd {
 				if !ok {
 					// Channel closed, return what we have. 
 					// The next call to WaitForOutput (if any) or the logic above handles close state.
-					return SessionOutputMsg{ProjectID: projectID, Data: data}
+					return SessionOutputMsg{SessionID: sessionID, Data: data}
 				}
 				data = append(data, next...)
 			default:
 				// No more data immediately available, send current batch
-				return SessionOutputMsg{ProjectID: projectID, Data: data}
+				return SessionOutputMsg{SessionID: sessionID, Data: data}
 			}
 		}
 
-		return SessionOutputMsg{ProjectID: projectID, Data: data}
+		return SessionOutputMsg{SessionID: sessionID, Data: data}
 	}
 }
@@ -2,6 +2,8 @@ package ui
 
 import (
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,21 +19,74 @@ const (
 
 var (
 	reInputRequired   = regexp.MustCompile(`(?i)(\[[yY]/[nN]\]|\(y/n\)|\bpress enter\b|\brequires your (approval|confirmation)\b|\bneed(s)? your input\b)`)
+	reCost            = regexp.MustCompile(`(?i)\bcost:\s*\$\s*([0-9]+(?:\.[0-9]+)?)`)
 	reCompleted       = regexp.MustCompile(`(?i)(\btask (finished|complete)\b|\bcost:\s*\$)`)
 	reError           = regexp.MustCompile(`(?i)(\berror:\b|context window exceeded|traceback)`)
 	reNotifyLine      = regexp.MustCompile(`(?i)^\s*(?:\[notify\]|notify(?:ication)?)[\s:：-]+(.+)$`)
 	reVibeNotify      = regexp.MustCompile(`(?i)^\s*vibecode(?:\s+notify)?[\s:：-]+(.+)$`)
 	reCommandApproval = regexp.MustCompile(`(?i)(\bdo you want to run\b|\brun (these|the) commands?\b|\bexecute (these|the) commands?\b|\bcommand\b.*\[[yY]/[nN]\])`)
+	reYesNo           = regexp.MustCompile(`\[[yY]/[nN]\]|\([yY]/[nN]\)`)
+	reQuotedCommand   = regexp.MustCompile("`([^`]+)`|\"([^\"]+)\"|'([^']+)'")
+	reInlineCommand   = regexp.MustCompile(`(?i)\bcommand\s*:\s*(.+?)\s*(\?\s*)?(\[[yY]/[nN]\]|\([yY]/[nN]\))`)
+	// toolInputRequired are the approval prompts of CLIs whose wording
+	// reInputRequired does not cover.
+	toolInputRequired = map[model.DriverType]*regexp.Regexp{
+		model.DriverCodex:  regexp.MustCompile(`(?i)(\ballow command\?|\bwould you like to (run|make) the following\b|\bapprove this (command|edit)\b)`),
+		model.DriverGemini: regexp.MustCompile(`(?i)(\ballow execution\b.*\?|\bapply this change\?|\bwaiting for user confirmation\b)`),
+	}
 )
 
 type outputWatcher struct {
 	oscTail          string
 	textTail         string
 	lastEvents       map[string]time.Time
-	pendingAutoReply string
+	pendingApproval  *approvalRequest
 	pendingAutoTurn  bool
+	pendingCost      float64
+	pendingBell      bool
+	waiting          *inputWait
+	busy             busyState
+	errorAt          time.Time // Last error event
+}
+
+// busyState tracks how long the CLI has been showing its busy pattern.
+type busyState struct {
+	expr    string
+	re      *regexp.Regexp
+	since   time.Time // Start of the current busy stretch; zero when idle
+	last    time.Time // Last time the pattern was seen
+	offered bool      // The watchdog already asked about this stretch
+	noted   bool      // The watchdog already toasted about this stretch
+}
+
+// busyGap ends a busy stretch when the pattern has not been redrawn for
+// this long.
+const busyGap = 15 * time.Second
+
+// approvalRequest is a yes/no prompt, usually asking to approve a command.
+type approvalRequest struct {
+	command  string // Empty when the prompt does not ask to run a command
+	guessed  bool   // command was taken from the output above the prompt
+	prompt   string
+	context  []string // Output leading up to the prompt
+	decision model.ApprovalDecision
+	rule     string // Pattern that decided it; "" when the level did
 }
 
+// approvalContextLines is how much output before a prompt is kept to show
+// with it in the approval queue.
+const approvalContextLines = 8
+
+// inputWait is an input request that has not been answered yet.
+type inputWait struct {
+	line     string
+	since    time.Time
+	reminded int
+}
+
+// maxInputReminders caps how often one input request is re-notified.
+const maxInputReminders = 5
+
 func newOutputWatcher() *outputWatcher {
 	return &outputWatcher{
 		lastEvents: make(map[string]time.Time),
@@ -65,20 +120,37 @@ func (w *outputWatcher) Process(project *model.Project, profile *model.Profile,
 		// NOTE: Auto-turn signal detection removed - using manual control now
 
 		w.textTail = trimTail(combined, textTailLimit)
+		w.trackBusy(profile, plain, now)
 		lines := tailLines(combined, 12)
-		for _, line := range lines {
+		for i, line := range lines {
 			line = strings.TrimSpace(line)
 			if line == "" {
 				continue
 			}
-			if shouldAutoApprove(profile) && w.pendingAutoReply == "" {
-				if reInputRequired.MatchString(line) && reCommandApproval.MatchString(line) {
-					if w.shouldAutoReply(line) {
-						w.pendingAutoReply = "y\r"
+			if m := reCost.FindStringSubmatch(line); len(m) == 2 && w.shouldRecordCost(line) {
+				if v, err := strconv.ParseFloat(m[1], 64); err == nil {
+					w.pendingCost += v
+				}
+			}
+			// Only a prompt in this chunk is new; the tail may hold
+			// prompts that were already answered.
+			if w.pendingApproval == nil && strings.Contains(plain, line) && isApprovalPrompt(line) && w.shouldAutoReply(line) {
+				req := &approvalRequest{prompt: line, context: approvalContext(lines, i)}
+				if reCommandApproval.MatchString(line) {
+					req.command, req.guessed = approvalCommand(lines, i)
+					if rulesApply(profile) {
+						block := strings.Join(lines[promptBlockStart(lines, i):i+1], "\n")
+						req.decision, req.rule = profile.Approval.Decide(profile.AutoApprove, req.command, block, req.guessed)
 					}
 				}
+				w.pendingApproval = req
 			}
-			if reInputRequired.MatchString(line) {
+			if reInputRequired.MatchString(line) || toolAsksInput(profile, line) {
+				// Lines from the tail may belong to an answered request;
+				// only a prompt in this chunk starts a new wait.
+				if w.waiting == nil && strings.Contains(plain, line) {
+					w.waiting = &inputWait{line: line, since: now}
+				}
 				events = appendEventIfNew(events, w, notify.Event{
 					Type:    notify.EventInputRequired,
 					Title:   "Input required",
@@ -87,11 +159,15 @@ func (w *outputWatcher) Process(project *model.Project, profile *model.Profile,
 				continue
 			}
 			if reError.MatchString(line) {
+				n := len(events)
 				events = appendEventIfNew(events, w, notify.Event{
 					Type:    notify.EventError,
 					Title:   "Error",
 					Message: line,
 				}, project, now)
+				if len(events) > n {
+					w.errorAt = now
+				}
 				continue
 			}
 			if reCompleted.MatchString(line) {
@@ -120,7 +196,8 @@ func (w *outputWatcher) Process(project *model.Project, profile *model.Profile,
 		}
 	}
 
-	if strings.ContainsRune(input, '\a') {
+	if hasBareBell(input) {
+		w.pendingBell = true
 		events = appendEventIfNew(events, w, notify.Event{
 			Type:    notify.EventNotify,
 			Title:   "Bell",
@@ -131,18 +208,88 @@ func (w *outputWatcher) Process(project *model.Project, profile *model.Profile,
 	return events
 }
 
-func shouldAutoApprove(profile *model.Profile) bool {
+// toolAsksInput reports whether line is an approval prompt of the
+// profile's CLI.
+func toolAsksInput(profile *model.Profile, line string) bool {
+	if profile == nil {
+		return false
+	}
+	re, ok := toolInputRequired[profile.Tool()]
+	return ok && re.MatchString(line)
+}
+
+// rulesApply reports whether the profile's approval rules answer command
+// prompts; at none they are all left to the user.
+func rulesApply(profile *model.Profile) bool {
 	if profile == nil {
 		return false
 	}
 	switch profile.AutoApprove {
-	case model.AutoApproveVibe, model.AutoApproveYolo:
+	case model.AutoApproveSafe, model.AutoApproveVibe, model.AutoApproveYolo:
 		return true
 	default:
 		return false
 	}
 }
 
+// isApprovalPrompt reports whether line asks a yes/no question or to
+// approve a command.
+func isApprovalPrompt(line string) bool {
+	return reInputRequired.MatchString(line) && (reYesNo.MatchString(line) || reCommandApproval.MatchString(line))
+}
+
+// approvalContext returns the non-empty lines up to and including the
+// prompt (lines[i]), at most approvalContextLines of them.
+func approvalContext(lines []string, i int) []string {
+	var context []string
+	for j := i; j >= 0 && len(context) < approvalContextLines; j-- {
+		if line := strings.TrimRight(lines[j], " \t"); strings.TrimSpace(line) != "" {
+			context = append(context, line)
+		}
+	}
+	slices.Reverse(context)
+	return context
+}
+
+// approvalCommand returns the command a prompt (lines[i]) asks to run: a
+// quoted part of the prompt or the text after "command:". Otherwise it is
+// guessed from the prompt's block, the non-empty lines above it, and
+// guessed is true.
+func approvalCommand(lines []string, i int) (command string, guessed bool) {
+	prompt := strings.TrimSpace(lines[i])
+	if m := reQuotedCommand.FindStringSubmatch(prompt); m != nil {
+		return strings.TrimSpace(m[1] + m[2] + m[3]), false
+	}
+	if m := reInlineCommand.FindStringSubmatch(prompt); m != nil {
+		return strings.TrimSpace(m[1]), false
+	}
+	var block []string
+	for _, line := range lines[promptBlockStart(lines, i):i] {
+		line = strings.TrimRight(strings.TrimLeft(strings.TrimSpace(line), "$>❯│ "), "│ ")
+		if line != "" {
+			block = append(block, line)
+		}
+	}
+	if len(block) == 0 {
+		return prompt, true
+	}
+	return strings.Join(block, "\n"), true
+}
+
+// promptBlockStart returns the index of the first line of the block that
+// ends with the prompt at lines[i]: the block starts after the previous
+// prompt or blank line, so output of earlier, answered prompts is left out.
+func promptBlockStart(lines []string, i int) int {
+	j := i - 1
+	for ; j >= 0; j-- {
+		line := strings.TrimSpace(lines[j])
+		if line == "" || isApprovalPrompt(line) {
+			break
+		}
+	}
+	return j + 1
+}
+
 func (w *outputWatcher) shouldAutoReply(line string) bool {
 	if w.lastEvents == nil {
 		w.lastEvents = make(map[string]time.Time)
@@ -156,13 +303,142 @@ func (w *outputWatcher) shouldAutoReply(line string) bool {
 	return true
 }
 
-func (w *outputWatcher) ConsumeAutoReply() string {
-	if w.pendingAutoReply == "" {
-		return ""
+// ConsumeApproval returns the yes/no prompt seen since the last call, if
+// any, with what the profile's rules decided about it.
+func (w *outputWatcher) ConsumeApproval() *approvalRequest {
+	req := w.pendingApproval
+	w.pendingApproval = nil
+	return req
+}
+
+// shouldRecordCost dedupes cost lines that get redrawn by the CLI.
+func (w *outputWatcher) shouldRecordCost(line string) bool {
+	if w.lastEvents == nil {
+		w.lastEvents = make(map[string]time.Time)
+	}
+	key := "cost|" + line
+	const cooldown = 30 * time.Second
+	if last, ok := w.lastEvents[key]; ok && time.Since(last) < cooldown {
+		return false
+	}
+	w.lastEvents[key] = time.Now()
+	return true
+}
+
+// ConsumeCost returns the cost reported since the last call and resets it.
+func (w *outputWatcher) ConsumeCost() float64 {
+	cost := w.pendingCost
+	w.pendingCost = 0
+	return cost
+}
+
+// Answered clears the pending input request once the user (or an auto
+// reply) has typed into the pane.
+func (w *outputWatcher) Answered() {
+	w.waiting = nil
+}
+
+// DueReminder returns a reminder event when the pending input request has
+// gone unanswered for another interval, along with its urgency level
+// (1 for the first reminder).
+func (w *outputWatcher) DueReminder(project *model.Project, every time.Duration, now time.Time) (notify.Event, int, bool) {
+	wait := w.waiting
+	if wait == nil || every <= 0 || wait.reminded >= maxInputReminders {
+		return notify.Event{}, 0, false
+	}
+	if now.Sub(wait.since) < every*time.Duration(wait.reminded+1) {
+		return notify.Event{}, 0, false
+	}
+	wait.reminded++
+	title := "Still waiting for input"
+	switch {
+	case wait.reminded >= 3:
+		title = "URGENT: input needed"
+	case wait.reminded == 2:
+		title = "Input needed"
+	}
+	return notify.Event{
+		ProjectID:   project.ID,
+		ProjectName: project.Name,
+		Type:        notify.EventInputReminder,
+		Title:       title,
+		Message:     wait.line + " (waiting " + now.Sub(wait.since).Round(time.Minute).String() + ")",
+		Timestamp:   now,
+	}, wait.reminded, true
+}
+
+// idleAfter is how long a pane must go without output, and without
+// drawing its busy pattern, to count as idle.
+const idleAfter = 3 * time.Second
+
+// Activity returns the pane's state given when it last produced output. An
+// input request or an error holds until the CLI is seen busy again.
+func (w *outputWatcher) Activity(lastOutput, now time.Time) model.ActivityState {
+	switch {
+	case w.waiting != nil && !w.busy.last.After(w.waiting.since):
+		return model.ActivityWaiting
+	case !w.errorAt.IsZero() && !w.busy.last.After(w.errorAt):
+		return model.ActivityError
+	case now.Sub(w.busy.last) < idleAfter || now.Sub(lastOutput) < idleAfter:
+		return model.ActivityWorking
+	}
+	return model.ActivityIdle
+}
+
+// trackBusy notes whether this chunk shows the profile's busy pattern.
+func (w *outputWatcher) trackBusy(profile *model.Profile, plain string, now time.Time) {
+	var cfg model.WatchdogConfig
+	tool := model.DriverNative
+	if profile != nil {
+		cfg = profile.Watchdog
+		tool = profile.Tool()
+	}
+	expr := cfg.PatternFor(tool)
+	if w.busy.expr != expr {
+		// Invalid patterns leave re nil, which disables tracking.
+		re, _ := regexp.Compile(expr)
+		w.busy = busyState{expr: expr, re: re}
+	}
+	if w.busy.re == nil || !w.busy.re.MatchString(plain) {
+		return
+	}
+	if w.busy.since.IsZero() || now.Sub(w.busy.last) > busyGap {
+		w.busy.since = now
+		w.busy.offered = false
+		w.busy.noted = false
+	}
+	w.busy.last = now
+}
+
+// Stuck reports how long the pane has been busy once that exceeds after
+// and the watchdog has not asked about this stretch yet.
+func (w *outputWatcher) Stuck(after time.Duration, now time.Time) (time.Duration, bool) {
+	b := &w.busy
+	if after <= 0 || b.since.IsZero() || b.offered || now.Sub(b.last) > busyGap {
+		return 0, false
 	}
-	reply := w.pendingAutoReply
-	w.pendingAutoReply = ""
-	return reply
+	busy := now.Sub(b.since)
+	return busy, busy >= after
+}
+
+// NoteStuck reports whether this busy stretch has not been toasted about
+// yet, and marks it as toasted.
+func (w *outputWatcher) NoteStuck() bool {
+	first := !w.busy.noted
+	w.busy.noted = true
+	return first
+}
+
+// OfferedStuck stops Stuck from firing again until the next busy stretch.
+func (w *outputWatcher) OfferedStuck() {
+	w.busy.offered = true
+}
+
+// ConsumeBell reports whether a bell rang since the last call.
+func (w *outputWatcher) ConsumeBell() bool {
+	bell := w.pendingBell
+	w.pendingBell = false
+	return bell
 }
 
 func (w *outputWatcher) ConsumeAutoTurnSignal() bool {
@@ -223,6 +499,24 @@ func extractOscNotifications(input string) ([]notify.Event, string) {
 	return events, input[i:]
 }
 
+// hasBareBell reports whether input rings the bell, ignoring BEL bytes that
+// terminate OSC sequences such as title updates.
+func hasBareBell(input string) bool {
+	for i := 0; i < len(input); i++ {
+		switch {
+		case input[i] == 0x07:
+			return true
+		case input[i] == 0x1b && i+1 < len(input) && input[i+1] == ']':
+			end, termLen := oscTerminator(input, i+2)
+			if end == -1 {
+				return false
+			}
+			i = end + termLen - 1
+		}
+	}
+	return false
+}
+
 func oscTerminator(input string, start int) (int, int) {
 	if start >= len(input) {
 		return -1, 0
@@ -1,238 +1,281 @@
 // Package styles defines the visual appearance for VibeMux TUI.
-// Using Catppuccin Mocha color palette for a modern, aesthetic look.
+// Colors come from the active theme (see Apply); Catppuccin Mocha by default.
 package styles
 
 import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-// Catppuccin Mocha color palette
+// Palette of the active theme, set by Apply.
 var (
 	// Base colors
-	Rosewater = lipgloss.Color("#F5E0DC")
-	Flamingo  = lipgloss.Color("#F2CDCD")
-	Pink      = lipgloss.Color("#F5C2E7")
-	Mauve     = lipgloss.Color("#CBA6F7")
-	Red       = lipgloss.Color("#F38BA8")
-	Maroon    = lipgloss.Color("#EBA0AC")
-	Peach     = lipgloss.Color("#FAB387")
-	Yellow    = lipgloss.Color("#F9E2AF")
-	Green     = lipgloss.Color("#A6E3A1")
-	Teal      = lipgloss.Color("#94E2D5")
-	Sky       = lipgloss.Color("#89DCEB")
-	Sapphire  = lipgloss.Color("#74C7EC")
-	Blue      = lipgloss.Color("#89B4FA")
-	Lavender  = lipgloss.Color("#B4BEFE")
+	Rosewater lipgloss.Color
+	Flamingo  lipgloss.Color
+	Pink      lipgloss.Color
+	Mauve     lipgloss.Color
+	Red       lipgloss.Color
+	Maroon    lipgloss.Color
+	Peach     lipgloss.Color
+	Yellow    lipgloss.Color
+	Green     lipgloss.Color
+	Teal      lipgloss.Color
+	Sky       lipgloss.Color
+	Sapphire  lipgloss.Color
+	Blue      lipgloss.Color
+	Lavender  lipgloss.Color
 
 	// Surface colors
-	Text     = lipgloss.Color("#CDD6F4")
-	Subtext1 = lipgloss.Color("#BAC2DE")
-	Subtext0 = lipgloss.Color("#A6ADC8")
-	Overlay2 = lipgloss.Color("#9399B2")
-	Overlay1 = lipgloss.Color("#7F849C")
-	Overlay0 = lipgloss.Color("#6C7086")
-	Surface2 = lipgloss.Color("#585B70")
-	Surface1 = lipgloss.Color("#45475A")
-	Surface0 = lipgloss.Color("#313244")
-	Base     = lipgloss.Color("#1E1E2E")
-	Mantle   = lipgloss.Color("#181825")
-	Crust    = lipgloss.Color("#11111B")
+	Text     lipgloss.Color
+	Subtext1 lipgloss.Color
+	Subtext0 lipgloss.Color
+	Overlay2 lipgloss.Color
+	Overlay1 lipgloss.Color
+	Overlay0 lipgloss.Color
+	Surface2 lipgloss.Color
+	Surface1 lipgloss.Color
+	Surface0 lipgloss.Color
+	Base     lipgloss.Color
+	Mantle   lipgloss.Color
+	Crust    lipgloss.Color
 )
 
 // Semantic colors (using the palette)
 var (
-	Primary     = Mauve
-	Secondary   = Green
-	Accent      = Sapphire
-	Danger      = Red
-	Warning     = Peach
-	Success     = Green
-	Info        = Blue
-	Muted       = Overlay0
-	Background  = Base
-	SurfaceCol  = Surface0
-	TextCol     = Text
-	TextMuted   = Subtext0
-	Border      = Surface1
-	BorderFocus = Mauve
+	Primary     lipgloss.Color
+	Secondary   lipgloss.Color
+	Accent      lipgloss.Color
+	Danger      lipgloss.Color
+	Warning     lipgloss.Color
+	Success     lipgloss.Color
+	Info        lipgloss.Color
+	Muted       lipgloss.Color
+	Background  lipgloss.Color
+	SurfaceCol  lipgloss.Color
+	TextCol     lipgloss.Color
+	TextMuted   lipgloss.Color
+	Border      lipgloss.Color
+	BorderFocus lipgloss.Color
 )
 
 // Session status colors
 var (
-	StatusRunning = Green
-	StatusIdle    = Overlay0
-	StatusStopped = Yellow
-	StatusError   = Red
+	StatusRunning lipgloss.Color
+	StatusIdle    lipgloss.Color
+	StatusStopped lipgloss.Color
+	StatusError   lipgloss.Color
 )
 
 // Gradient effects (simulated with patterns)
 var (
-	GradientPurple = []lipgloss.Color{Mauve, Pink, Lavender}
-	GradientCyan   = []lipgloss.Color{Teal, Sky, Sapphire}
-	GradientWarm   = []lipgloss.Color{Peach, Yellow, Rosewater}
+	GradientPurple []lipgloss.Color
+	GradientCyan   []lipgloss.Color
+	GradientWarm   []lipgloss.Color
 )
 
 // Base styles
 var (
 	// BaseStyle is applied to the entire application
-	BaseStyle = lipgloss.NewStyle().
-			Background(Background)
-
+	BaseStyle lipgloss.Style
 	// BorderStyle for panels
-	BorderStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(Border)
-
+	BorderStyle lipgloss.Style
 	// FocusedBorderStyle for focused panels
-	FocusedBorderStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(BorderFocus)
-
+	FocusedBorderStyle lipgloss.Style
 	// GlowBorder for highlighted panels
-	GlowBorder = lipgloss.NewStyle().
-			Border(lipgloss.DoubleBorder()).
-			BorderForeground(Sapphire)
+	GlowBorder lipgloss.Style
 )
 
 // Panel styles
 var (
 	// PanelTitle for panel headers
-	PanelTitle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(TextCol).
-			Padding(0, 1)
-
+	PanelTitle lipgloss.Style
 	// PanelTitleFocused for focused panel headers
-	PanelTitleFocused = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(Primary).
-				Padding(0, 1)
-
+	PanelTitleFocused lipgloss.Style
 	// PanelTitleIcon for icon prefix
-	PanelTitleIcon = lipgloss.NewStyle().
-			Foreground(Accent).
-			MarginRight(1)
+	PanelTitleIcon lipgloss.Style
 )
 
 // List item styles
 var (
 	// ListItem for normal list items
-	ListItem = lipgloss.NewStyle().
-			Foreground(TextCol).
-			Padding(0, 1)
-
+	ListItem lipgloss.Style
 	// ListItemSelected for selected list items
-	ListItemSelected = lipgloss.NewStyle().
-				Foreground(TextCol).
-				Background(SurfaceCol).
-				Bold(true).
-				Padding(0, 1)
-
+	ListItemSelected lipgloss.Style
 	// ListItemDim for inactive/dimmed items
-	ListItemDim = lipgloss.NewStyle().
-			Foreground(TextMuted).
-			Padding(0, 1)
-
+	ListItemDim lipgloss.Style
 	// ListItemHighlight for highlighted items
-	ListItemHighlight = lipgloss.NewStyle().
-				Foreground(Accent).
-				Bold(true).
-				Padding(0, 1)
+	ListItemHighlight lipgloss.Style
 )
 
 // Status indicator styles
 var (
-	StatusIndicator = lipgloss.NewStyle().
-			Bold(true).
-			Padding(0, 1)
+	StatusIndicator    lipgloss.Style
+	StatusRunningStyle lipgloss.Style
+	StatusIdleStyle    lipgloss.Style
+	StatusErrorStyle   lipgloss.Style
+)
 
-	StatusRunningStyle = lipgloss.NewStyle().
-				Foreground(StatusRunning).
-				Bold(true)
+// StatusBar styles
+var (
+	StatusBarStyle     lipgloss.Style
+	StatusBarKey       lipgloss.Style
+	StatusBarDesc      lipgloss.Style
+	StatusBarSeparator lipgloss.Style
+	StatusBarBrand     lipgloss.Style
+)
 
-	StatusIdleStyle = lipgloss.NewStyle().
-			Foreground(StatusIdle)
+// Terminal styles
+var (
+	TerminalStyle       lipgloss.Style
+	TerminalPlaceholder lipgloss.Style
+	TerminalHeader      lipgloss.Style
+)
 
-	StatusErrorStyle = lipgloss.NewStyle().
-				Foreground(StatusError).
-				Bold(true)
+// Dialog styles
+var (
+	DialogBox          lipgloss.Style
+	DialogTitle        lipgloss.Style
+	DialogButton       lipgloss.Style
+	DialogButtonActive lipgloss.Style
 )
 
-// StatusBar styles
+// Logo and branding styles
 var (
-	StatusBarStyle = lipgloss.NewStyle().
-			Foreground(TextMuted).
-			Background(Mantle).
-			Padding(0, 1)
+	LogoStyle    lipgloss.Style
+	VersionStyle lipgloss.Style
+)
 
-	StatusBarKey = lipgloss.NewStyle().
-			Foreground(Accent).
-			Bold(true)
+// build derives the semantic colours and styles from the palette.
+func build() {
+	Primary = Mauve
+	Secondary = Green
+	Accent = Sapphire
+	Danger = Red
+	Warning = Peach
+	Success = Green
+	Info = Blue
+	Muted = Overlay0
+	Background = Base
+	SurfaceCol = Surface0
+	TextCol = Text
+	TextMuted = Subtext0
+	Border = Surface1
+	BorderFocus = Mauve
 
-	StatusBarDesc = lipgloss.NewStyle().
-			Foreground(TextMuted)
+	StatusRunning = Green
+	StatusIdle = Overlay0
+	StatusStopped = Yellow
+	StatusError = Red
 
-	StatusBarSeparator = lipgloss.NewStyle().
-				Foreground(Overlay0).
-				SetString(" │ ")
+	GradientPurple = []lipgloss.Color{Mauve, Pink, Lavender}
+	GradientCyan = []lipgloss.Color{Teal, Sky, Sapphire}
+	GradientWarm = []lipgloss.Color{Peach, Yellow, Rosewater}
+
+	BaseStyle = lipgloss.NewStyle().
+		Background(Background)
+	BorderStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(Border)
+	FocusedBorderStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderFocus)
+	GlowBorder = lipgloss.NewStyle().
+		Border(lipgloss.DoubleBorder()).
+		BorderForeground(Sapphire)
 
+	PanelTitle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(TextCol).
+		Padding(0, 1)
+	PanelTitleFocused = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(Primary).
+		Padding(0, 1)
+	PanelTitleIcon = lipgloss.NewStyle().
+		Foreground(Accent).
+		MarginRight(1)
+
+	ListItem = lipgloss.NewStyle().
+		Foreground(TextCol).
+		Padding(0, 1)
+	ListItemSelected = lipgloss.NewStyle().
+		Foreground(TextCol).
+		Background(SurfaceCol).
+		Bold(true).
+		Padding(0, 1)
+	ListItemDim = lipgloss.NewStyle().
+		Foreground(TextMuted).
+		Padding(0, 1)
+	ListItemHighlight = lipgloss.NewStyle().
+		Foreground(Accent).
+		Bold(true).
+		Padding(0, 1)
+
+	StatusIndicator = lipgloss.NewStyle().
+		Bold(true).
+		Padding(0, 1)
+	StatusRunningStyle = lipgloss.NewStyle().
+		Foreground(StatusRunning).
+		Bold(true)
+	StatusIdleStyle = lipgloss.NewStyle().
+		Foreground(StatusIdle)
+	StatusErrorStyle = lipgloss.NewStyle().
+		Foreground(StatusError).
+		Bold(true)
+
+	StatusBarStyle = lipgloss.NewStyle().
+		Foreground(TextMuted).
+		Background(Mantle).
+		Padding(0, 1)
+	StatusBarKey = lipgloss.NewStyle().
+		Foreground(Accent).
+		Bold(true)
+	StatusBarDesc = lipgloss.NewStyle().
+		Foreground(TextMuted)
+	StatusBarSeparator = lipgloss.NewStyle().
+		Foreground(Overlay0).
+		SetString(" │ ")
 	StatusBarBrand = lipgloss.NewStyle().
-			Foreground(Primary).
-			Bold(true)
-)
+		Foreground(Primary).
+		Bold(true)
 
-// Terminal styles
-var (
 	TerminalStyle = lipgloss.NewStyle().
-			Foreground(TextCol)
-
+		Foreground(TextCol)
 	TerminalPlaceholder = lipgloss.NewStyle().
-				Foreground(TextMuted).
-				Italic(true)
-
+		Foreground(TextMuted).
+		Italic(true)
 	TerminalHeader = lipgloss.NewStyle().
-			Background(Surface0).
-			Foreground(TextCol).
-			Bold(true).
-			Padding(0, 1)
-)
+		Background(Surface0).
+		Foreground(TextCol).
+		Bold(true).
+		Padding(0, 1)
 
-// Dialog styles
-var (
 	DialogBox = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(Primary).
-			Padding(1, 2).
-			Background(SurfaceCol)
-
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(Primary).
+		Padding(1, 2).
+		Background(SurfaceCol)
 	DialogTitle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(TextCol).
-			MarginBottom(1)
-
+		Bold(true).
+		Foreground(TextCol).
+		MarginBottom(1)
 	DialogButton = lipgloss.NewStyle().
-			Foreground(TextCol).
-			Background(SurfaceCol).
-			Padding(0, 2).
-			MarginRight(1)
-
+		Foreground(TextCol).
+		Background(SurfaceCol).
+		Padding(0, 2).
+		MarginRight(1)
 	DialogButtonActive = lipgloss.NewStyle().
-				Foreground(TextCol).
-				Background(Primary).
-				Bold(true).
-				Padding(0, 2).
-				MarginRight(1)
-)
+		Foreground(TextCol).
+		Background(Primary).
+		Bold(true).
+		Padding(0, 2).
+		MarginRight(1)
 
-// Logo and branding styles
-var (
 	LogoStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(Primary)
-
+		Bold(true).
+		Foreground(Primary)
 	VersionStyle = lipgloss.NewStyle().
-			Foreground(Overlay0)
-)
+		Foreground(Overlay0)
+}
 
 // Helper functions
 
@@ -299,6 +342,7 @@ var (
 	IconArrowL    = "←"
 	IconStar      = "★"
 	IconStarEmpty = "☆"
+	IconBell      = "🔔"
 )
 
 // Box drawing characters for custom borders
@@ -0,0 +1,115 @@
+package styles
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DefaultTheme is the theme used when the config names none.
+const DefaultTheme = "catppuccin-mocha"
+
+// Theme is a palette in Catppuccin's slot names. Other palettes map their
+// colours onto the same slots, so every style works with every theme.
+type Theme struct {
+	Rosewater, Flamingo, Pink, Mauve, Red, Maroon, Peach   lipgloss.Color
+	Yellow, Green, Teal, Sky, Sapphire, Blue, Lavender     lipgloss.Color
+	Text, Subtext1, Subtext0, Overlay2, Overlay1, Overlay0 lipgloss.Color
+	Surface2, Surface1, Surface0, Base, Mantle, Crust      lipgloss.Color
+}
+
+// themeOrder lists the themes as offered in the Settings dialog.
+var themeOrder = []string{DefaultTheme, "catppuccin-latte", "dracula", "gruvbox", "high-contrast"}
+
+var themes = map[string]Theme{
+	"catppuccin-mocha": {
+		Rosewater: "#F5E0DC", Flamingo: "#F2CDCD", Pink: "#F5C2E7", Mauve: "#CBA6F7",
+		Red: "#F38BA8", Maroon: "#EBA0AC", Peach: "#FAB387", Yellow: "#F9E2AF",
+		Green: "#A6E3A1", Teal: "#94E2D5", Sky: "#89DCEB", Sapphire: "#74C7EC",
+		Blue: "#89B4FA", Lavender: "#B4BEFE",
+		Text: "#CDD6F4", Subtext1: "#BAC2DE", Subtext0: "#A6ADC8",
+		Overlay2: "#9399B2", Overlay1: "#7F849C", Overlay0: "#6C7086",
+		Surface2: "#585B70", Surface1: "#45475A", Surface0: "#313244",
+		Base: "#1E1E2E", Mantle: "#181825", Crust: "#11111B",
+	},
+	"catppuccin-latte": {
+		Rosewater: "#DC8A78", Flamingo: "#DD7878", Pink: "#EA76CB", Mauve: "#8839EF",
+		Red: "#D20F39", Maroon: "#E64553", Peach: "#FE640B", Yellow: "#DF8E1D",
+		Green: "#40A02B", Teal: "#179299", Sky: "#04A5E5", Sapphire: "#209FB5",
+		Blue: "#1E66F5", Lavender: "#7287FD",
+		Text: "#4C4F69", Subtext1: "#5C5F77", Subtext0: "#6C6F85",
+		Overlay2: "#7C7F93", Overlay1: "#8C8FA1", Overlay0: "#9CA0B0",
+		Surface2: "#ACB0BE", Surface1: "#BCC0CC", Surface0: "#CCD0DA",
+		Base: "#EFF1F5", Mantle: "#E6E9EF", Crust: "#DCE0E8",
+	},
+	"dracula": {
+		Rosewater: "#FFCCE6", Flamingo: "#FF92DF", Pink: "#FF79C6", Mauve: "#BD93F9",
+		Red: "#FF5555", Maroon: "#FF6E6E", Peach: "#FFB86C", Yellow: "#F1FA8C",
+		Green: "#50FA7B", Teal: "#8BE9FD", Sky: "#9AEDFE", Sapphire: "#62D6E8",
+		Blue: "#6E9BF5", Lavender: "#D6ACFF",
+		Text: "#F8F8F2", Subtext1: "#E2E2DC", Subtext0: "#BFBFBF",
+		Overlay2: "#A4A9C9", Overlay1: "#8590B8", Overlay0: "#6272A4",
+		Surface2: "#565A6E", Surface1: "#44475A", Surface0: "#383A4A",
+		Base: "#282A36", Mantle: "#21222C", Crust: "#191A21",
+	},
+	"gruvbox": {
+		Rosewater: "#D5C4A1", Flamingo: "#D3869B", Pink: "#D3869B", Mauve: "#D3869B",
+		Red: "#FB4934", Maroon: "#CC241D", Peach: "#FE8019", Yellow: "#FABD2F",
+		Green: "#B8BB26", Teal: "#8EC07C", Sky: "#83A598", Sapphire: "#689D6A",
+		Blue: "#83A598", Lavender: "#B16286",
+		Text: "#EBDBB2", Subtext1: "#D5C4A1", Subtext0: "#BDAE93",
+		Overlay2: "#A89984", Overlay1: "#928374", Overlay0: "#7C6F64",
+		Surface2: "#665C54", Surface1: "#504945", Surface0: "#3C3836",
+		Base: "#282828", Mantle: "#1D2021", Crust: "#161819",
+	},
+	"high-contrast": {
+		Rosewater: "#FFFFFF", Flamingo: "#FF88FF", Pink: "#FF55FF", Mauve: "#FFFF00",
+		Red: "#FF3333", Maroon: "#FF6666", Peach: "#FF9900", Yellow: "#FFFF00",
+		Green: "#00FF00", Teal: "#00FFFF", Sky: "#00FFFF", Sapphire: "#00DDFF",
+		Blue: "#66AAFF", Lavender: "#CCCCFF",
+		Text: "#FFFFFF", Subtext1: "#FFFFFF", Subtext0: "#EEEEEE",
+		Overlay2: "#DDDDDD", Overlay1: "#CCCCCC", Overlay0: "#BBBBBB",
+		Surface2: "#3355CC", Surface1: "#1C3FAA", Surface0: "#222222",
+		Base: "#000000", Mantle: "#000000", Crust: "#000000",
+	},
+}
+
+var activeTheme = DefaultTheme
+
+// Themes returns the names of the available themes.
+func Themes() []string {
+	return append([]string(nil), themeOrder...)
+}
+
+// ActiveTheme returns the name of the theme in use.
+func ActiveTheme() string {
+	return activeTheme
+}
+
+// Apply switches to the named theme ("" is the default) and rebuilds the
+// package styles. Components read the styles when they render, so the next
+// frame uses the new colours.
+func Apply(name string) error {
+	if name == "" {
+		name = DefaultTheme
+	}
+	t, ok := themes[name]
+	if !ok {
+		return fmt.Errorf("unknown theme %q (available: %v)", name, themeOrder)
+	}
+	Rosewater, Flamingo, Pink, Mauve = t.Rosewater, t.Flamingo, t.Pink, t.Mauve
+	Red, Maroon, Peach, Yellow = t.Red, t.Maroon, t.Peach, t.Yellow
+	Green, Teal, Sky, Sapphire = t.Green, t.Teal, t.Sky, t.Sapphire
+	Blue, Lavender = t.Blue, t.Lavender
+	Text, Subtext1, Subtext0 = t.Text, t.Subtext1, t.Subtext0
+	Overlay2, Overlay1, Overlay0 = t.Overlay2, t.Overlay1, t.Overlay0
+	Surface2, Surface1, Surface0 = t.Surface2, t.Surface1, t.Surface0
+	Base, Mantle, Crust = t.Base, t.Mantle, t.Crust
+	activeTheme = name
+	build()
+	return nil
+}
+
+func init() {
+	_ = Apply(DefaultTheme)
+}
@@ -1,15 +1,21 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyvibe/vibemux/internal/hooks"
 	"github.com/lazyvibe/vibemux/internal/model"
 	"github.com/lazyvibe/vibemux/internal/runtime"
 	"github.com/lazyvibe/vibemux/internal/ui/components/chaindialog"
 	"github.com/lazyvibe/vibemux/internal/ui/components/filepreview"
+	"github.com/lazyvibe/vibemux/internal/ui/components/setup"
+	"github.com/lazyvibe/vibemux/internal/ui/styles"
 )
 
 // AutoTurnMsg indicates it's time to rotate to the next agent.
@@ -22,7 +28,7 @@ type AutoTurnCountdownMsg int
 func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	defer func() {
 		if r := recover(); r != nil {
-			a.statusBar.SetMessage(fmt.Sprintf("Panic recovered: %v", r), true)
+			a.toasts.Push(fmt.Sprintf("Panic recovered: %v", r), true)
 			// Log panic to file if possible, or just print to stderr
 		}
 	}()
@@ -72,6 +78,25 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, nil
 		}
 
+		// Alt+1..9 jump straight to a grid pane, in any input mode.
+		if key.Matches(msg, a.keys.JumpPane) {
+			keyStr := msg.String()
+			a.jumpToPane(int(keyStr[len(keyStr)-1] - '1'))
+			return a, nil
+		}
+
+		// Resize mode takes every key until it is left.
+		if a.resizing && a.inputMode != InputModeTerminal {
+			return a, a.resizeModeKey(msg)
+		}
+
+		// Copy mode takes every key until it is left.
+		if a.inputMode != InputModeTerminal && a.focus == FocusTerminal {
+			if inst, ok := a.terminals[a.activeTermID]; ok && inst.Terminal.InCopyMode() {
+				return a, a.copyModeKey(inst, msg)
+			}
+		}
+
 		if a.inputMode != InputModeTerminal {
 			if key.Matches(msg, a.keys.Tab) {
 				a.cycleFocus()
@@ -87,8 +112,18 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a.handleTerminalKeys(msg)
 		}
 
+		// Esc acknowledges error toasts before reaching the panes.
+		if msg.Type == tea.KeyEsc && a.toasts.HasErrors() {
+			a.toasts.DismissErrors()
+			return a, nil
+		}
+
 		if key.Matches(msg, a.keys.Quit) {
 			a.quitting = true
+			a.flushChain()
+			a.closeHistory()
+			a.closeSessionLogs()
+			a.closeServers()
 			a.engine.CloseAll()
 			return a, tea.Quit
 		}
@@ -116,6 +151,75 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return a, a.sendNextTurn()
 			}
 	
+			if key.Matches(msg, a.keys.Command) {
+				a.showCommandDialog()
+				return a, nil
+			}
+
+			if key.Matches(msg, a.keys.Search) {
+				if a.focus == FocusTerminal {
+					a.showPaneSearch()
+				} else {
+					a.showSearch("")
+				}
+				return a, nil
+			}
+
+			if key.Matches(msg, a.keys.Recent) {
+				a.showQuickOpen()
+				return a, nil
+			}
+
+			if key.Matches(msg, a.keys.Layouts) {
+				a.showLayouts()
+				return a, nil
+			}
+
+			if key.Matches(msg, a.keys.Compose) {
+				a.showComposer()
+				return a, nil
+			}
+
+			if key.Matches(msg, a.keys.Templates) {
+				a.showTemplates()
+				return a, nil
+			}
+
+			if key.Matches(msg, a.keys.Chains) {
+				a.showChains()
+				return a, nil
+			}
+
+			if key.Matches(msg, a.keys.Notifications) {
+				a.showNotifications()
+				return a, nil
+			}
+
+			if key.Matches(msg, a.keys.Resources) {
+				return a, a.showResources()
+			}
+
+			if key.Matches(msg, a.keys.Approvals) {
+				a.showApprovals()
+				return a, nil
+			}
+
+			if key.Matches(msg, a.keys.Diff) {
+				return a, a.showDiff(nil)
+			}
+
+			if key.Matches(msg, a.keys.PlayMacro) {
+				if a.macro.last == "" {
+					a.toasts.Push("No macro yet (:macro record <reg>)", true)
+					return a, nil
+				}
+				target := ""
+				if a.dispatchMode == DispatchModeBroadcast {
+					target = "all"
+				}
+				return a, a.playMacro(a.macro.last, target)
+			}
+
 			if key.Matches(msg, a.keys.FilePreview) {
 				// Toggle file preview
 				if a.dialogMode == DialogFilePreview {
@@ -137,16 +241,14 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a.handlePaneKeys(msg)
 
     case tea.MouseMsg:
-        if msg.Type == tea.MouseWheelUp {
-            if inst, ok := a.terminals[a.activeTermID]; ok {
-                inst.Terminal.HandleKey("shift+up")
-            }
-        } else if msg.Type == tea.MouseWheelDown {
-             if inst, ok := a.terminals[a.activeTermID]; ok {
-                inst.Terminal.HandleKey("shift+down")
-            }
+        if a.dialogMode != DialogNone {
+            return a, nil
         }
-        return a, nil
+        if msg.Button == tea.MouseButtonWheelUp || msg.Button == tea.MouseButtonWheelDown {
+            a.handleMouseWheel(msg)
+            return a, nil
+        }
+        return a, a.handleMouseSelection(msg)
 
 	case ProjectsLoadedMsg:
 		if msg.Err == nil {
@@ -159,7 +261,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			a.projectList.SetProjects(a.projects, runningIDs)
 		} else {
-			a.statusBar.SetMessage("Error loading projects: "+msg.Err.Error(), true)
+			a.toasts.Push("Error loading projects: "+msg.Err.Error(), true)
 		}
 		return a, nil
 
@@ -169,43 +271,64 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.updateAddDialogProfiles()
 			a.profileList.SetProfiles(a.profiles)
 			a.projectList.SetProfiles(a.profiles)
+			a.syncWorkspaceDefaults()
+		} else {
+			a.toasts.Push("Error loading profiles: "+msg.Err.Error(), true)
+		}
+		return a, nil
+
+	case pipelineStepMsg:
+		return a, a.handlePipelineStep(msg)
+
+	case TemplatesLoadedMsg:
+		if msg.Err == nil {
+			a.templates = msg.Templates
+			a.templatePicker.SetTemplates(a.templates)
 		} else {
-			a.statusBar.SetMessage("Error loading profiles: "+msg.Err.Error(), true)
+			a.toasts.Push("Error loading templates: "+msg.Err.Error(), true)
 		}
 		return a, nil
 
+	case SharedLoadedMsg:
+		switch {
+		case msg.Err != nil:
+			a.toasts.Push("Shared config: "+msg.Err.Error(), true)
+		case msg.Shared != nil:
+			a.toasts.Push(fmt.Sprintf("Shared config: %d profiles, %d roles, %d snippets",
+				len(msg.Shared.Profiles), len(msg.Shared.Roles), len(msg.Shared.Snippets)), false)
+		}
+		return a, a.loadProfiles()
+
+	case ProjectUpdatedMsg:
+		if msg.Status != "" {
+			a.toasts.Push(msg.Status, false)
+		}
+		return a, a.loadProjects()
+
 	case ProjectCreatedMsg:
-		a.statusBar.SetMessage("Project added: "+msg.Project.Name, false)
+		a.toasts.Push("Project added: "+msg.Project.Name, false)
 		return a, a.loadProjects()
 
 	case ProfileSavedMsg:
 		a.upsertProfileInMemory(msg.Profile)
 		if msg.IsNew {
-			a.statusBar.SetMessage("Profile added: "+msg.Profile.Name, false)
+			a.toasts.Push("Profile added: "+msg.Profile.Name, false)
 		} else {
-			a.statusBar.SetMessage("Profile updated: "+msg.Profile.Name, false)
+			a.toasts.Push("Profile updated: "+msg.Profile.Name, false)
 		}
 		if !msg.IsNew {
 			var restartCmds []tea.Cmd
-			for i := range a.projects {
-				p := a.projects[i]
-				usesProfile := p.ProfileID == msg.Profile.ID
-				if !usesProfile && p.ProfileID == "" && msg.Profile.IsDefault {
-					usesProfile = true
-				}
-				if !usesProfile {
+			for _, session := range a.engine.ListSessions() {
+				id := session.ID()
+				if session.Status() != model.SessionStatusRunning {
 					continue
 				}
-				if session, ok := a.engine.GetSession(p.ID); ok && session.Status() == model.SessionStatusRunning {
-					_ = a.engine.CloseSession(p.ID)
-					if inst, ok := a.terminals[p.ID]; ok {
-						inst.Terminal.SetStatus(model.SessionStatusStopped)
-						inst.Terminal.Clear()
-					}
-					a.projectList.SetRunning(p.ID, false)
-					a.sessionTabs.SetTabStatus(p.ID, model.SessionStatusStopped)
-					restartCmds = append(restartCmds, a.startSession(&p))
+				project := a.projectForSession(id)
+				profile := a.profileForSession(id)
+				if project == nil || profile == nil || profile.ID != msg.Profile.ID {
+					continue
 				}
+				restartCmds = append(restartCmds, a.restartSession(id))
 			}
 			if len(restartCmds) > 0 {
 				return a, tea.Batch(append(restartCmds, a.loadProfiles())...)
@@ -214,16 +337,19 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, a.loadProfiles()
 
 	case ProfileDeletedMsg:
-		a.statusBar.SetMessage("Profile deleted", false)
+		a.toasts.Push("Profile deleted", false)
 		return a, a.loadProfiles()
 
 	case SessionStartedMsg:
-		a.setActivePaneByProject(msg.ProjectID)
-		a.outputWatchers[msg.ProjectID] = newOutputWatcher()
+		var touchCmd tea.Cmd
+		a.setActivePaneByProject(msg.SessionID)
+		a.outputWatchers[msg.SessionID] = newOutputWatcher()
+		// A session that never prints has been quiet since it started.
+		a.lastOutput[msg.SessionID] = time.Now()
 		// Update terminal status
-		if inst, ok := a.terminals[msg.ProjectID]; ok {
+		if inst, ok := a.terminals[msg.SessionID]; ok {
 			inst.Terminal.SetStatus(model.SessionStatusRunning)
-			if session, ok := a.engine.GetSession(msg.ProjectID); ok {
+			if session, ok := a.engine.GetSession(msg.SessionID); ok {
 				inst.Terminal.BindWriter(session)
 				cols, rows := inst.Terminal.PTYSize()
 				if cols > 0 && rows > 0 {
@@ -231,61 +357,137 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 		}
-		// Update project list
-		a.projectList.SetRunning(msg.ProjectID, true)
 		// Update session tabs
-		a.sessionTabs.SetTabStatus(msg.ProjectID, model.SessionStatusRunning)
-		a.statusBar.SetMessage("Session started", false)
+		a.sessionTabs.SetTabStatus(msg.SessionID, model.SessionStatusRunning)
+		a.toasts.Push("Session started", false)
+		a.noteBatchResult(msg.SessionID, nil)
+		if project := a.projectForSession(msg.SessionID); project != nil {
+			// Update project list
+			a.projectList.SetRunning(project.ID, true)
+			a.stats.RecordSession(project.ID, project.Name)
+			a.queueStartup(msg.SessionID, project, msg.Profile)
+			a.noteSessionRun(msg.SessionID)
+			touchCmd = a.touchProject(project.ID)
+		}
 		
 		// Force global resize to update all PTYs with new grid dimensions
 		a.SetSize(a.width, a.height)
 		// Start listening for output
-		return a, a.waitForOutput(msg.ProjectID)
+		a.startSessionLog(msg.SessionID, msg.Profile)
+		return a, tea.Batch(a.waitForOutput(msg.SessionID), touchCmd, a.recordSessionStart(msg.SessionID, msg.Profile),
+			a.runHooks(hooks.SessionStarted, msg.SessionID, ""), a.snapshotForDiff(msg.SessionID))
 
 	case SessionOutputMsg:
+		msg.SessionID = a.resolveRehomed(msg.SessionID)
+		a.lastOutput[msg.SessionID] = time.Now()
+		a.sessionTabs.MarkActivity(msg.SessionID)
+		a.allQuietFired = false
 		// Update the specific terminal instance
-		if inst, ok := a.terminals[msg.ProjectID]; ok {
+		if inst, ok := a.terminals[msg.SessionID]; ok {
 			inst.Terminal.AppendOutput(msg.Data)
 		}
+		promptCmd := a.checkStartup(msg.SessionID, msg.Data)
+		turnCmd := a.checkTurnCompletion(msg.SessionID, msg.Data)
 		var notifyCmd tea.Cmd
-		if project := a.findProjectByID(msg.ProjectID); project != nil {
-			watcher, ok := a.outputWatchers[msg.ProjectID]
+		if project := a.projectForSession(msg.SessionID); project != nil {
+			watcher, ok := a.outputWatchers[msg.SessionID]
 			if !ok || watcher == nil {
 				watcher = newOutputWatcher()
-				a.outputWatchers[msg.ProjectID] = watcher
+				a.outputWatchers[msg.SessionID] = watcher
 			}
-			profile := a.profileForProject(project)
+			profile := a.profileForSession(msg.SessionID)
 			events := watcher.Process(project, profile, msg.Data)
-			notifyCmd = a.dispatchNotifications(profile, events)
-			if reply := watcher.ConsumeAutoReply(); reply != "" {
-				if session, ok := a.engine.GetSession(msg.ProjectID); ok && session.Status() == model.SessionStatusRunning {
-					session.Write([]byte(reply))
+			a.recordNotifications(msg.SessionID, events)
+			notifyCmd = tea.Batch(a.dispatchNotifications(profile, events), a.runEventHooks(msg.SessionID, events))
+			if watcher.ConsumeBell() {
+				if inst, ok := a.terminals[msg.SessionID]; ok {
+					inst.Terminal.Ring()
 				}
+				a.sessionTabs.MarkTabBell(msg.SessionID)
+			}
+			if cost := watcher.ConsumeCost(); cost > 0 {
+				a.stats.RecordCost(project.ID, project.Name, cost)
+			}
+			if req := watcher.ConsumeApproval(); req != nil {
+				a.handleApproval(msg.SessionID, req)
 			}
 			
 			// NOTE: Auto-turn countdown removed - using manual Alt+N control now
 		}
+		a.syncPaneActivity(msg.SessionID, time.Now())
 		// Mark tab as having new content if not active
-		if msg.ProjectID != a.activeTermID {
-			a.sessionTabs.MarkTabHasNew(msg.ProjectID)
+		if msg.SessionID != a.activeTermID {
+			a.sessionTabs.MarkTabHasNew(msg.SessionID)
 		}
 		// Continue listening
-		return a, tea.Batch(a.waitForOutput(msg.ProjectID), notifyCmd)
+		return a, tea.Batch(a.waitForOutput(msg.SessionID), notifyCmd, promptCmd, turnCmd)
+
+	case SessionLaunchFailedMsg:
+		a.noteBatchResult(msg.SessionID, msg.Err)
+		if errors.Is(msg.Err, runtime.ErrLaunchCancelled) {
+			return a, nil
+		}
+		if inst, ok := a.terminals[msg.SessionID]; ok {
+			inst.Terminal.ClearLaunching()
+			inst.Terminal.SetStatus(model.SessionStatusError)
+		}
+		a.sessionTabs.SetTabStatus(msg.SessionID, model.SessionStatusError)
+		a.toasts.Push("Launch failed: "+msg.Err.Error(), true)
+		delete(a.restarts, msg.SessionID)
+		return a, nil
+
+	case restartDueMsg:
+		return a, a.restartDue(msg.SessionID)
+
+	case hookResultMsg:
+		return a, a.handleHookResult(msg)
+
+	case InjectionResultMsg:
+		a.handleInjectionResult(msg)
+		return a, nil
+
+	case launchSpinnerTickMsg:
+		return a, a.advanceLaunchSpinners()
+
+	case batchLaunchTickMsg:
+		if msg.batch != a.batch {
+			// The batch was cancelled or replaced since this tick.
+			return a, nil
+		}
+		return a, a.advanceBatch()
 
 	case SessionStoppedMsg:
-		if inst, ok := a.terminals[msg.ProjectID]; ok {
+		msg.SessionID = a.resolveRehomed(msg.SessionID)
+		current, known := a.engine.GetSession(msg.SessionID)
+		if known && current != msg.session {
+			// The session was restarted; this is the old one ending.
+			return a, nil
+		}
+		if inst, ok := a.terminals[msg.SessionID]; ok {
+			inst.Terminal.ClearLaunching()
 			inst.Terminal.SetStatus(model.SessionStatusStopped)
 			inst.Terminal.UnbindWriter()
 		}
-		delete(a.outputWatchers, msg.ProjectID)
-		a.projectList.SetRunning(msg.ProjectID, false)
-		a.sessionTabs.SetTabStatus(msg.ProjectID, model.SessionStatusStopped)
+		delete(a.outputWatchers, msg.SessionID)
+		a.dropApprovals(msg.SessionID)
+		a.syncPaneActivity(msg.SessionID, time.Now())
+		if project := a.projectForSession(msg.SessionID); project != nil {
+			a.syncProjectRunning(project.ID)
+		}
+		a.sessionTabs.SetTabStatus(msg.SessionID, model.SessionStatusStopped)
+		status := model.SessionStatusStopped
 		if msg.Err != nil {
-			a.statusBar.SetMessage("Session error: "+msg.Err.Error(), true)
+			status = model.SessionStatusError
+			a.toasts.Push("Session error: "+msg.Err.Error(), true)
 		} else {
-			a.statusBar.SetMessage("Session ended", false)
+			a.toasts.Push("Session ended", false)
 		}
-		return a, nil
+		endCmd := a.recordSessionEnd(msg.SessionID, status)
+		if !known {
+			// Closed from VibeMux rather than exited on its own.
+			return a, endCmd
+		}
+		return a, tea.Batch(endCmd, a.scheduleRestart(msg.SessionID, msg.Err))
 
 	case error:
 		return a, nil
@@ -312,6 +514,44 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return AutoTurnCountdownMsg(count - 1)
 		})
 
+	case housekeepingTickMsg:
+		a.syncInputLocks()
+		a.toasts.Expire()
+		a.expireInjection()
+		a.autosaveChain()
+		a.checkStuckPanes()
+		a.nextWorktreeCleanup()
+		a.syncDroppedOutput()
+		a.syncActivity()
+		return a, tea.Batch(a.checkAllQuiet(), a.flushStaleStartups(), a.checkSummaryPass(), a.checkPipeline(), a.remindUnansweredInput(), a.sampleResources(), a.refreshGitStatus(), housekeepingTick())
+
+	case resourceUsageMsg:
+		a.applyResourceUsage(msg)
+		return a, nil
+
+	case gitStatusMsg:
+		a.applyGitStatus(msg)
+		return a, nil
+
+	case diffSnapshotMsg:
+		a.applyDiffSnapshot(msg)
+		return a, nil
+
+	case diffLoadedMsg:
+		a.applyDiff(msg)
+		return a, nil
+
+	case worktreeCreatedMsg:
+		return a, a.launchInWorktree(msg)
+
+	case worktreeDoneMsg:
+		if msg.Err != nil {
+			a.toasts.Push("Worktree: "+msg.Err.Error(), true)
+		} else {
+			a.toasts.Push(msg.Status, false)
+		}
+		return a, nil
+
 	case filepreview.TickMsg:
 		// Forward tick to file preview if active
 		if a.dialogMode == DialogFilePreview {
@@ -321,8 +561,27 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return a, nil
 
+	case setup.DoneMsg:
+		return a, a.finishSetupWizard(msg)
+
 	case ErrorMsg:
-		a.statusBar.SetMessage("Error: "+msg.Err.Error(), true)
+		a.toasts.Push("Error: "+msg.Err.Error(), true)
+		return a, nil
+
+	case StatusMsg:
+		a.toasts.Push(msg.Text, msg.IsError)
+		return a, nil
+
+	case ControlRequestMsg:
+		return a, tea.Batch(a.handleControlRequest(msg.Request), waitControl(a.control))
+
+	case ReportGeneratedMsg:
+		if msg.Narrator != "" {
+			a.recordPrompt(msg.Narrator)
+			a.toasts.Push("Report written to "+msg.Path+" (narrative requested)", false)
+		} else {
+			a.toasts.Push("Report written to "+msg.Path, false)
+		}
 		return a, nil
 
 	case AutoTurnMsg:
@@ -333,10 +592,10 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case AutoTurnTimeoutMsg:
 		// Check if we are still on the same turn (time matches)
-		if a.autoTurnEnabled && a.activeTermID == msg.TargetID && a.currentTurnStartTime.Equal(msg.StartTime) {
+		if a.autoTurnEnabled && !a.turnWatch.done && a.activeTermID == msg.TargetID && a.currentTurnStartTime.Equal(msg.StartTime) {
 			a.autoTurnEnabled = false
 			a.updateTurnStatus()
-			a.statusBar.SetMessage("Auto-Turn timed out. Switched to Manual Mode.", true)
+			a.toasts.Push("Auto-Turn timed out. Switched to Manual Mode.", true)
 		}
 		return a, nil
 
@@ -397,25 +656,38 @@ func (a App) handleDialogUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 				a.showProfileDialog(nil)
 				return a, nil
 			case "c":
+				if profile := a.profileList.SelectedProfile(); profile != nil {
+					a.showCloneProfileDialog(profile)
+				}
+				return a, nil
+			case "x":
+				return a, a.exportSelectedProfile()
+			case "i":
+				a.showImportProfilesPrompt()
+				return a, nil
+			case "g":
 				a.showSettingsDialog()
 				return a, nil
+			case "w":
+				a.showSetupWizard()
+				return a, nil
 			case "enter", "e":
-				if profile := a.profileList.SelectedProfile(); profile != nil {
+				if profile := a.profileList.SelectedProfile(); profile != nil && !a.rejectShared(profile) {
 					a.showProfileDialog(profile)
 				}
 				return a, nil
 			case "d":
-				if profile := a.profileList.SelectedProfile(); profile != nil {
+				if profile := a.profileList.SelectedProfile(); profile != nil && !a.rejectShared(profile) {
 					if profile.IsDefault {
-						a.statusBar.SetMessage("Cannot delete default profile", true)
+						a.toasts.Push("Cannot delete default profile", true)
 						return a, nil
 					}
 					return a, a.deleteProfile(profile.ID)
 				}
 				return a, nil
 			case "s":
-				if profile := a.profileList.SelectedProfile(); profile != nil {
-					a.statusBar.SetMessage("Default profile set", false)
+				if profile := a.profileList.SelectedProfile(); profile != nil && !a.rejectShared(profile) {
+					a.toasts.Push("Default profile set", false)
 					return a, a.setDefaultProfile(profile.ID)
 				}
 				return a, nil
@@ -429,7 +701,7 @@ func (a App) handleDialogUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if a.profileDialog.IsSubmitted() {
 			profile, isNew, err := a.buildProfileFromDialog()
 			if err != nil {
-				a.statusBar.SetMessage(err.Error(), true)
+				a.toasts.Push(err.Error(), true)
 				return a, nil
 			}
 			a.dialogMode = DialogManageProfiles
@@ -451,16 +723,34 @@ func (a App) handleDialogUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if len(values) > 0 {
 				input = values[0]
 			}
+			if len(values) > 1 {
+				if theme := strings.TrimSpace(values[1]); theme != "" && theme != styles.ActiveTheme() {
+					if err := a.setTheme(theme); err != nil {
+						a.toasts.Push(err.Error(), true)
+						return a, nil
+					}
+					a.toasts.Push("Theme set to "+theme, false)
+				}
+			}
+			if strings.EqualFold(strings.TrimSpace(input), "auto") {
+				if err := a.setGridAuto(); err != nil {
+					a.toasts.Push("Error saving config: "+err.Error(), true)
+					return a, nil
+				}
+				a.toasts.Push("Grid set to auto", false)
+				a.dialogMode = DialogManageProfiles
+				return a, nil
+			}
 			rows, cols, err := parseGridSetting(input)
 			if err != nil {
-				a.statusBar.SetMessage(err.Error(), true)
+				a.toasts.Push(err.Error(), true)
 				return a, nil
 			}
 			if err := a.updateGridSettings(rows, cols); err != nil {
-				a.statusBar.SetMessage("Error saving config: "+err.Error(), true)
+				a.toasts.Push("Error saving config: "+err.Error(), true)
 				return a, nil
 			}
-			a.statusBar.SetMessage(fmt.Sprintf("Grid set to %dx%d", rows, cols), false)
+			a.toasts.Push(fmt.Sprintf("Grid set to %dx%d", rows, cols), false)
 			a.dialogMode = DialogManageProfiles
 			return a, nil
 		}
@@ -486,19 +776,74 @@ func (a App) handleDialogUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, nil
 		}
 		return a, cmd
+	case DialogUnlock:
+		var cmd tea.Cmd
+		a.commandDialog, cmd = a.commandDialog.Update(msg)
+		if a.commandDialog.IsSubmitted() {
+			passphrase := a.commandDialog.Value(0)
+			a.hideDialog()
+			return a, a.unlockSecrets(passphrase)
+		}
+		if a.commandDialog.IsCancelled() {
+			a.hideDialog()
+			return a, nil
+		}
+		return a, cmd
+	case DialogExport:
+		var cmd tea.Cmd
+		a.filePicker, cmd = a.filePicker.Update(msg)
+		if a.filePicker.IsSubmitted() {
+			a.hideDialog()
+			return a, a.writePaneExport(a.exportPaneID, a.filePicker.Path())
+		}
+		if a.filePicker.IsCancelled() {
+			a.hideDialog()
+			return a, nil
+		}
+		return a, cmd
+	case DialogPaneSearch:
+		var cmd tea.Cmd
+		a.commandDialog, cmd = a.commandDialog.Update(msg)
+		if a.commandDialog.IsSubmitted() {
+			values := a.commandDialog.Values()
+			a.hideDialog()
+			if len(values) > 0 {
+				a.searchPane(values[0])
+			}
+			return a, nil
+		}
+		if a.commandDialog.IsCancelled() {
+			a.hideDialog()
+			return a, nil
+		}
+		return a, cmd
+	case DialogLaunch:
+		var cmd tea.Cmd
+		a.launchDialog, cmd = a.launchDialog.Update(msg)
+		if a.launchDialog.IsSubmitted() {
+			a.hideDialog()
+			return a, a.submitLaunchDialog()
+		}
+		if a.launchDialog.IsCancelled() {
+			a.hideDialog()
+			return a, nil
+		}
+		return a, cmd
 	case DialogChainPreview:
 		var cmd tea.Cmd
 		a.chainDialog, cmd = a.chainDialog.Update(msg)
 		if a.chainDialog.IsClosed() {
 			if a.chainDialog.IsCleared() && a.chainContext != nil {
 				// Clear the chain context
-				a.chainContext.Chain = nil
-				_ = a.chainContext.Save()
-				a.statusBar.SetMessage("Chain context cleared", false)
+				a.chainContext.Clear()
+				a.toasts.Push("Chain context cleared", false)
 			}
 			a.hideDialog()
 			return a, nil
 		}
+		if text := a.chainDialog.TakeCopy(); text != "" {
+			return a, tea.Batch(cmd, copyToClipboard(text))
+		}
 		return a, cmd
 	case DialogAssignRoles:
 		var cmd tea.Cmd
@@ -506,7 +851,6 @@ func (a App) handleDialogUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if a.roleDialog.IsSubmitted() {
 			cmds := a.assignRolesToTerminals()
 			a.hideDialog()
-			a.statusBar.SetMessage("Roles and prompts sent to terminals", false)
 			return a, tea.Batch(cmds...)
 		}
 		if a.roleDialog.IsCancelled() {
@@ -514,14 +858,12 @@ func (a App) handleDialogUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, nil
 		}
 		return a, cmd
-		return a, cmd
 	case DialogAssignRolesFile:
 		var cmd tea.Cmd
 		a.organizerDialog, cmd = a.organizerDialog.Update(msg)
 		if a.organizerDialog.IsSubmitted() {
 			cmds := a.assignRolesToTerminalsFile()
 			a.hideDialog()
-			a.statusBar.SetMessage("File-based roles initiated", false)
 			return a, tea.Batch(cmds...)
 		}
 		if a.organizerDialog.IsCancelled() {
@@ -529,6 +871,107 @@ func (a App) handleDialogUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, nil
 		}
 		return a, cmd
+	case DialogSearch:
+		var cmd tea.Cmd
+		a.search, cmd = a.search.Update(msg)
+		if a.search.IsSubmitted() {
+			a.hideDialog()
+			if match, ok := a.search.Selected(); ok {
+				a.jumpToMatch(match)
+			}
+			return a, nil
+		}
+		if a.search.IsCancelled() {
+			a.hideDialog()
+			return a, nil
+		}
+		return a, cmd
+	case DialogSetup:
+		wizard, cmd := a.wizard.Update(msg)
+		a.wizard = wizard.(setup.Model)
+		return a, cmd
+	case DialogQuickOpen:
+		var cmd tea.Cmd
+		a.quickOpen, cmd = a.quickOpen.Update(msg)
+		if a.quickOpen.IsSubmitted() {
+			a.hideDialog()
+			if item, ok := a.quickOpen.Selected(); ok {
+				return a, a.openRecent(item)
+			}
+			return a, nil
+		}
+		if a.quickOpen.IsCancelled() {
+			a.hideDialog()
+			return a, nil
+		}
+		return a, cmd
+	case DialogComposer:
+		var cmd tea.Cmd
+		a.composer, cmd = a.composer.Update(msg)
+		if a.composer.IsSubmitted() {
+			return a, a.sendComposed()
+		}
+		if a.composer.IsCancelled() {
+			a.hideDialog()
+			return a, nil
+		}
+		return a, cmd
+	case DialogChains:
+		var cmd tea.Cmd
+		a.chainList, cmd = a.chainList.Update(msg)
+		return a, tea.Batch(cmd, a.chainListAction())
+	case DialogNotifications:
+		var cmd tea.Cmd
+		a.notifyList, cmd = a.notifyList.Update(msg)
+		return a, tea.Batch(cmd, a.notifyListAction())
+	case DialogResources:
+		var cmd tea.Cmd
+		a.resourceList, cmd = a.resourceList.Update(msg)
+		return a, tea.Batch(cmd, a.resourceListAction())
+	case DialogApprovals:
+		var cmd tea.Cmd
+		a.approvalList, cmd = a.approvalList.Update(msg)
+		return a, tea.Batch(cmd, a.approvalListAction())
+	case DialogDiff:
+		var cmd tea.Cmd
+		a.diffView, cmd = a.diffView.Update(msg)
+		return a, tea.Batch(cmd, a.diffViewAction())
+	case DialogTemplates:
+		var cmd tea.Cmd
+		a.templatePicker, cmd = a.templatePicker.Update(msg)
+		return a, tea.Batch(cmd, a.templateAction())
+	case DialogLayouts:
+		var cmd tea.Cmd
+		a.layoutPicker, cmd = a.layoutPicker.Update(msg)
+		if a.layoutPicker.IsSubmitted() {
+			a.hideDialog()
+			if item, ok := a.layoutPicker.Selected(); ok {
+				return a, a.restoreLayout(item.ID)
+			}
+			return a, nil
+		}
+		if a.layoutPicker.IsCancelled() {
+			a.hideDialog()
+			return a, nil
+		}
+		return a, cmd
+	case DialogConfirm:
+		var cmd tea.Cmd
+		a.confirm, cmd = a.confirm.Update(msg)
+		if a.confirm.IsSubmitted() {
+			a.hideDialog()
+			return a, a.resolveConfirm(a.confirm.Choice())
+		}
+		if a.confirm.IsCancelled() {
+			a.hideDialog()
+			a.pendingProject = nil
+			a.pendingBroadcast = nil
+			a.pendingStuck = ""
+			a.pendingPaste = nil
+			a.pendingExtract = nil
+			return a, a.cancelWorktreeCleanup()
+		}
+		return a, cmd
 	case DialogFilePreview:
 		var cmd tea.Cmd
 		a.filePreview, cmd = a.filePreview.Update(msg)
@@ -568,9 +1011,50 @@ func (a App) handleTerminalControlKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, a.keys.Close):
 		if a.activeTermID != "" {
 			a.closeSession(a.activeTermID)
-			a.statusBar.SetMessage("Session closed", false)
+			a.toasts.Push("Session closed", false)
 		}
 		return a, nil
+	case key.Matches(msg, a.keys.ForceKill):
+		return a, a.forceKillPane(nil)
+	case key.Matches(msg, a.keys.FullScreen):
+		a.toggleFullScreen()
+		return a, nil
+	case key.Matches(msg, a.keys.Zoom):
+		a.toggleZoom()
+		return a, nil
+	case key.Matches(msg, a.keys.Resize):
+		a.startResizeMode()
+		return a, nil
+	case key.Matches(msg, a.keys.PanelWidth):
+		a.cycleProjectPanel()
+		return a, nil
+	case key.Matches(msg, a.keys.Clone):
+		return a, a.cloneSession()
+	case key.Matches(msg, a.keys.Interrupt):
+		a.interruptPane(nil)
+		return a, nil
+	case key.Matches(msg, a.keys.SessionLog):
+		a.showSessionLog(nil)
+		return a, nil
+	case key.Matches(msg, a.keys.CopyMode):
+		a.enterCopyMode()
+		return a, nil
+	case key.Matches(msg, a.keys.ExportPane):
+		return a, a.exportPane(nil)
+	case key.Matches(msg, a.keys.SearchOlder):
+		if a.stepPaneSearch(true) {
+			return a, nil
+		}
+	case key.Matches(msg, a.keys.SearchNewer):
+		if a.stepPaneSearch(false) {
+			return a, nil
+		}
+	case key.Matches(msg, a.keys.MovePaneLeft):
+		a.movePane(-1)
+		return a, nil
+	case key.Matches(msg, a.keys.MovePaneRight):
+		a.movePane(1)
+		return a, nil
 	}
 	if inst, ok := a.terminals[a.activeTermID]; ok {
 		if inst.Terminal.HandleKey(msg.String()) {
@@ -580,6 +1064,17 @@ func (a App) handleTerminalControlKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return a, nil
 }
 
+// jumpToPane focuses grid pane index (0-based), as numbered in the tabs.
+func (a *App) jumpToPane(index int) {
+	ids := a.gridOrder()
+	if index < 0 || index >= len(ids) {
+		a.toasts.Push(fmt.Sprintf("No pane %d", index+1), false)
+		return
+	}
+	a.focus = FocusTerminal
+	a.setActivePane(index)
+}
+
 func (a *App) handlePaneNavigation(msg tea.KeyMsg) bool {
 	ids := a.gridOrder()
 	if len(ids) == 0 {
@@ -591,6 +1086,9 @@ func (a *App) handlePaneNavigation(msg tea.KeyMsg) bool {
 		key.Matches(msg, a.keys.PaneDown)) {
 		return false
 	}
+	if a.compactLayout() {
+		return a.navigateCompact(msg, ids)
+	}
 
 	rows, cols := a.gridActiveDims()
 	if rows < 1 || cols < 1 {
@@ -608,7 +1106,7 @@ func (a *App) handlePaneNavigation(msg tea.KeyMsg) bool {
 	case key.Matches(msg, a.keys.PaneLeft):
 		if col > 0 {
 			col--
-		} else {
+		} else if a.projectPanelVisible() {
 			a.focus = FocusProjects
 			a.updateFocusStyles()
 			return true
@@ -652,32 +1150,11 @@ func (a App) handleProjectsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, nil
 	case key.Matches(msg, a.keys.Enter):
 		// Start/switch to selected project
-		project := a.projectList.SelectedProject()
-		if project != nil {
-			if !a.canOpenPane(project.ID) {
-				a.statusBar.SetMessage("Max panes reached for grid layout", true)
-				return a, nil
-			}
-			// Get or create terminal instance
-			inst := a.getOrCreateTerminal(project.ID, project.DisplayName())
-
-			// Add to session tabs if not present
-			a.sessionTabs.AddTab(project.ID, project.DisplayName(), model.SessionStatusIdle)
-			a.setActivePaneByProject(project.ID)
-			a.SetSize(a.width, a.height)
-
-			// Check if session already exists
-			if session, ok := a.engine.GetSession(project.ID); ok {
-				// Session exists, just update terminal status
-				inst.Terminal.SetStatus(session.Status())
-				if session.Status() == model.SessionStatusRunning {
-					// Resume listening for output
-					return a, a.waitForOutput(project.ID)
-				}
-			} else {
-				// Start new session
-				return a, a.startSession(project)
-			}
+		return a, a.openSelectedProject()
+
+	case key.Matches(msg, a.keys.Launch):
+		if project := a.projectList.SelectedProject(); project != nil {
+			a.showLaunchDialog(project)
 		}
 		return a, nil
 
@@ -685,24 +1162,31 @@ func (a App) handleProjectsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		a.showAddDialog()
 		return a, nil
 
+	case key.Matches(msg, a.keys.FullScreen):
+		a.toggleFullScreen()
+		return a, nil
+
+	case key.Matches(msg, a.keys.PanelWidth):
+		a.cycleProjectPanel()
+		return a, nil
+
+	case key.Matches(msg, a.keys.Duplicate):
+		if project := a.projectList.SelectedProject(); project != nil {
+			return a, a.duplicateProject(project)
+		}
+		return a, nil
+
 	case key.Matches(msg, a.keys.Delete):
 		// Delete selected project
 		project := a.projectList.SelectedProject()
 		if project != nil {
-			// Close session if running
-			a.engine.CloseSession(project.ID)
-			// Remove from tabs
-			a.sessionTabs.RemoveTab(project.ID)
-			// Remove terminal instance
-			delete(a.terminals, project.ID)
-			delete(a.outputWatchers, project.ID)
-			a.normalizeActivePane()
-			a.SetSize(a.width, a.height)
+			// Close every session of the project
+			a.closeProjectSessions(project.ID)
 			// Delete from store
 			if err := a.store.Delete(a.ctx, project.ID); err != nil {
-				a.statusBar.SetMessage("Error deleting project: "+err.Error(), true)
+				a.toasts.Push("Error deleting project: "+err.Error(), true)
 			} else {
-				a.statusBar.SetMessage("Project deleted", false)
+				a.toasts.Push("Project deleted", false)
 				// Reload projects
 				return a, a.loadProjects()
 			}
@@ -711,8 +1195,8 @@ func (a App) handleProjectsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, a.keys.Close):
 		project := a.projectList.SelectedProject()
 		if project != nil {
-			a.closeSession(project.ID)
-			a.statusBar.SetMessage("Session closed", false)
+			a.closeProjectSessions(project.ID)
+			a.toasts.Push("Session closed", false)
 		}
 		return a, nil
 	}
@@ -741,7 +1225,7 @@ func (a App) handleTabsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Close current tab
 		if t := a.sessionTabs.ActiveTab(); t != nil {
 			a.closeSession(t.ID)
-			a.statusBar.SetMessage("Session closed", false)
+			a.toasts.Push("Session closed", false)
 		}
 	case "enter":
 		// Focus on terminal
@@ -757,8 +1241,14 @@ func (a App) handleTerminalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if a.activeTermID != "" {
 		session, ok := a.engine.GetSession(a.activeTermID)
 		if ok && session.Status() == model.SessionStatusRunning {
+			if lockedByRemote(session) {
+				a.toasts.Push("Input locked by "+session.InputOwner()+" (:unlock to take it back)", true)
+				return a, nil
+			}
+
 			// Update IME buffer target
 			a.imeBuffer.SetTarget(a.activeTermID)
+			a.acknowledgeInput(a.activeTermID)
 
 			// Chain Mode Shortcuts
 			if a.dispatchMode == DispatchModeChain && a.chainContext != nil {
@@ -774,8 +1264,13 @@ func (a App) handleTerminalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 						if activeInst != nil {
 							// Snapshot strategy: Get what is actually on screen + scrollback
 							// This avoids all the stream noise (spinners, intermediate frames, etc.)
-							rawContent := activeInst.Terminal.GetPlainText()
-							concl := runtime.ExtractConclusion(rawContent)
+							// The profile's extraction strategy decides what is kept.
+							result, err := a.extractConclusion(a.activeTermID)
+							if err != nil {
+								a.toasts.Push("Extraction failed: "+err.Error(), true)
+								return a, nil
+							}
+							concl := result.Text
 							
 							agentName := activeInst.ProjectName
 							// Fallback if needed
@@ -783,24 +1278,15 @@ func (a App) handleTerminalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 								agentName = "Agent"
 							}
 							
-							if err := a.chainContext.AppendConclusion(agentName, concl); err == nil {
-								a.statusBar.SetMessage("Chain context saved", false)
-							} else {
-								a.statusBar.SetMessage("Failed to save chain: "+err.Error(), true)
-							}
+							return a, a.appendConclusion(agentName, concl)
 						} else {
-							a.statusBar.SetMessage("Error: Active terminal not found in UI model", true)
+							a.toasts.Push("Error: Active terminal not found in UI model", true)
 						}
 					return a, nil
 				}
 				// Ctrl+O: Inject Context
 				if msg.String() == "ctrl+o" {
-					prompt := a.chainContext.FormatContext()
-					if sess, ok := a.engine.GetSession(a.activeTermID); ok {
-						sess.Write([]byte(prompt))
-						a.statusBar.SetMessage("Chain context injected", false)
-					}
-					return a, nil
+					return a, a.injectChain(a.activeTermID, false)
 				}
 				// Ctrl+P: Preview Chain Context
 				if msg.String() == "ctrl+p" {
@@ -822,6 +1308,18 @@ func (a App) handleTerminalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				}
 			}
 
+			// Ctrl+C follows the profile's policy outside broadcast mode
+			if msg.Type == tea.KeyCtrlC && a.dispatchMode != DispatchModeBroadcast {
+				if cmd, handled := a.handleCtrlC(a.activeTermID, session); handled {
+					return a, cmd
+				}
+			}
+
+			// Large pastes ask first
+			if msg.Paste && a.pasteTooLarge(string(msg.Runes)) {
+				return a, a.confirmPaste(string(msg.Runes))
+			}
+
 			// Handle KeyRunes with IME buffering
 			if msg.Type == tea.KeyRunes && len(msg.Runes) > 0 {
 				output, cmd, shouldFlushFirst := a.imeBuffer.ProcessRunes(msg.Runes)
@@ -836,6 +1334,8 @@ func (a App) handleTerminalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 
 
+				a.recordMacroKey(keyToBytes(msg))
+
 				// Send immediate output if any
 				if len(output) > 0 {
 					// Apply Alt modifier if needed
@@ -844,6 +1344,7 @@ func (a App) handleTerminalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					}
 					if a.dispatchMode == DispatchModeBroadcast {
 						a.broadcastInput(output)
+						a.broadcastLine += string(output)
 					} else {
 						session.Write(output)
 					}
@@ -857,6 +1358,7 @@ func (a App) handleTerminalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				buffered := a.imeBuffer.Flush()
 				if a.dispatchMode == DispatchModeBroadcast {
 					a.broadcastInput(buffered)
+					a.broadcastLine += string(buffered)
 				} else {
 					session.Write(buffered)
 				}
@@ -910,13 +1412,21 @@ func (a App) handleTerminalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 			// Send key to PTY
 			input := keyToBytes(msg)
+			a.recordMacroKey(input)
 			if len(input) > 0 {
 				if a.dispatchMode == DispatchModeBroadcast {
-					// 广播模式：发送到所有终端
+					// 广播模式：发送到所有终端，回车提交前先确认
+					if msg.Type == tea.KeyEnter {
+						return a, a.submitBroadcastLine(input)
+					}
 					a.broadcastInput(input)
+					a.broadcastLine += string(input)
 				} else {
 					// Solo 模式和 Chain 模式：只发送到当前活动终端
 					session.Write(input)
+					if msg.Type == tea.KeyEnter {
+						a.recordPrompt(a.activeTermID)
+					}
 				}
 				return a, nil
 			}
@@ -1086,5 +1596,5 @@ func modifierCode(alt, shift, ctrl bool) int {
 }
 
 func modString(code int) string {
-	return string('0' + code)
+	return strconv.Itoa(code)
 }
@@ -2,8 +2,10 @@ package ui
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/lazyvibe/vibemux/internal/ui/styles"
 )
 
@@ -46,7 +48,7 @@ func (a App) View() (result string) {
 	}
 
 	if a.windowTooSmall() {
-		msg := fmt.Sprintf("窗口太小，请至少 %dx%d（当前 %dx%d）", minAppWidth, minAppHeight, a.width, a.height)
+		msg := fmt.Sprintf("窗口太小，请至少 %dx%d（当前 %dx%d）", minCompactWidth, minCompactHeight, a.width, a.height)
 		notice := lipgloss.NewStyle().
 			Bold(true).
 			Foreground(styles.Accent).
@@ -58,28 +60,33 @@ func (a App) View() (result string) {
 			Render(notice)
 	}
 
-	// Calculate layout
-	leftWidth := a.width * 25 / 100
-	if leftWidth < 20 {
-		leftWidth = 20
-	}
-	if leftWidth > 40 {
-		leftWidth = 40
+	if a.compactLayout() {
+		fullView := lipgloss.JoinVertical(lipgloss.Left, a.renderCompact(), a.statusBar.View())
+		if a.dialogMode != DialogNone {
+			return a.withToasts(a.renderWithDialog(fullView))
+		}
+		return a.withToasts(fullView)
 	}
-	rightWidth := a.width - leftWidth
 
-	// Left panel: Project list
-	leftPanel := a.projectList.View()
+	// Calculate layout
+	leftWidth := a.projectPanelWidth()
+	rightWidth := a.width - leftWidth
 
 	// Right panel: Terminal grid
 	rightPanel := a.renderTerminalGrid(rightWidth, a.height-1)
 
-	// Combine panels
-	mainContent := lipgloss.JoinHorizontal(
-		lipgloss.Top,
-		leftPanel,
-		rightPanel,
-	)
+	mainContent := rightPanel
+	if leftWidth > 0 {
+		// Left panel: Project list
+		leftPanel := a.projectList.View()
+
+		// Combine panels
+		mainContent = lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			leftPanel,
+			rightPanel,
+		)
+	}
 
 	// Status bar
 	statusBar := a.statusBar.View()
@@ -93,10 +100,41 @@ func (a App) View() (result string) {
 
 	// Overlay dialog if open
 	if a.dialogMode != DialogNone {
-		return a.renderWithDialog(fullView)
+		return a.withToasts(a.renderWithDialog(fullView))
 	}
 
-	return fullView
+	return a.withToasts(fullView)
+}
+
+// withToasts draws the toast stack over the bottom-right corner of the
+// view, just above the status bar.
+func (a App) withToasts(view string) string {
+	stack := a.toasts.View()
+	if stack == "" {
+		return view
+	}
+	lines := strings.Split(view, "\n")
+	toastLines := strings.Split(stack, "\n")
+	top := len(lines) - 1 - len(toastLines)
+	if top < 0 {
+		top = 0
+	}
+	for i, line := range toastLines {
+		y := top + i
+		if y >= len(lines)-1 {
+			break
+		}
+		left := a.width - lipgloss.Width(line)
+		if left < 0 {
+			left = 0
+		}
+		prefix := ansi.Truncate(lines[y], left, "")
+		if w := ansi.StringWidth(prefix); w < left {
+			prefix += strings.Repeat(" ", left-w)
+		}
+		lines[y] = prefix + "\x1b[0m" + line
+	}
+	return strings.Join(lines, "\n")
 }
 
 // renderTerminalPlaceholder renders a placeholder terminal panel.
@@ -123,6 +161,16 @@ func (a App) renderTerminalGrid(width, height int) string {
 		return a.renderEmptyTerminalArea(width, height)
 	}
 
+	if a.zoomActive() {
+		if inst, ok := a.terminals[a.activeTermID]; ok {
+			inst.Terminal.SetFocused(a.focus == FocusTerminal)
+			return lipgloss.NewStyle().
+				Width(width).
+				Height(height).
+				Render(inst.Terminal.View())
+		}
+	}
+
 	_, _, _, colWidths, rowHeights := a.gridLayout()
 	ids := a.gridOrder()
 	cellIndex := 0
@@ -272,8 +320,10 @@ func (a App) renderWithDialog(_ string) string {
 		dialogView = a.profileDialog.View()
 	case DialogSettings:
 		dialogView = a.settingsDialog.View()
-	case DialogCommand:
+	case DialogCommand, DialogPaneSearch, DialogUnlock:
 		dialogView = a.commandDialog.View()
+	case DialogLaunch:
+		dialogView = a.launchDialog.View()
 	case DialogChainPreview:
 		dialogView = a.chainDialog.View()
 	case DialogAssignRoles:
@@ -282,6 +332,32 @@ func (a App) renderWithDialog(_ string) string {
 		dialogView = a.organizerDialog.View()
 	case DialogFilePreview:
 		dialogView = a.filePreview.View()
+	case DialogSearch:
+		dialogView = a.search.View()
+	case DialogExport:
+		dialogView = a.filePicker.View()
+	case DialogConfirm:
+		dialogView = a.confirm.View()
+	case DialogQuickOpen:
+		dialogView = a.quickOpen.View()
+	case DialogLayouts:
+		dialogView = a.layoutPicker.View()
+	case DialogComposer:
+		dialogView = a.composer.View()
+	case DialogTemplates:
+		dialogView = a.templatePicker.View()
+	case DialogChains:
+		dialogView = a.chainList.View()
+	case DialogNotifications:
+		dialogView = a.notifyList.View()
+	case DialogResources:
+		dialogView = a.resourceList.View()
+	case DialogApprovals:
+		dialogView = a.approvalList.View()
+	case DialogDiff:
+		dialogView = a.diffView.View()
+	case DialogSetup:
+		dialogView = a.wizard.View()
 	}
 
 	// Overlay dialog in center
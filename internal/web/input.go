@@ -0,0 +1,122 @@
+package web
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/lazyvibe/vibemux/internal/runtime"
+)
+
+// maxInputBody caps a single input request.
+const maxInputBody = 64 * 1024
+
+func (s *Server) handleConfig(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, map[string]any{"allowInput": s.inputAllowed()})
+}
+
+// inputSession resolves the session and operator of a lock/input request,
+// writing the error response itself when the request is not allowed.
+func (s *Server) inputSession(w http.ResponseWriter, r *http.Request) (runtime.Session, string, bool) {
+	if !s.inputAllowed() {
+		http.Error(w, "dashboard is read-only", http.StatusForbidden)
+		return nil, "", false
+	}
+	if !s.authorized(r) {
+		http.Error(w, "dashboard token required", http.StatusUnauthorized)
+		return nil, "", false
+	}
+	// Browsers name the sending page on every POST and DELETE; a request
+	// without Origin is refused too, so input always comes from a page
+	// of the dashboard or a client that says where it comes from.
+	if r.Header.Get("Origin") == "" || !trustedRequest(r) {
+		http.Error(w, "cross-origin input refused", http.StatusForbidden)
+		return nil, "", false
+	}
+	sess, ok := s.engine.GetSession(r.PathValue("id"))
+	if !ok {
+		http.NotFound(w, r)
+		return nil, "", false
+	}
+	owner := strings.TrimSpace(r.URL.Query().Get("owner"))
+	if owner == "" || owner == runtime.LocalOperator {
+		http.Error(w, "owner is required", http.StatusBadRequest)
+		return nil, "", false
+	}
+	return sess, owner, true
+}
+
+// handleLock claims the session's input lock for the operator.
+func (s *Server) handleLock(w http.ResponseWriter, r *http.Request) {
+	sess, owner, ok := s.inputSession(w, r)
+	if !ok {
+		return
+	}
+	if !sess.AcquireInput(owner) {
+		w.WriteHeader(http.StatusConflict)
+		writeJSON(w, map[string]string{"lock": sess.InputOwner()})
+		return
+	}
+	writeJSON(w, map[string]string{"lock": owner})
+}
+
+// handleUnlock releases the operator's input lock.
+func (s *Server) handleUnlock(w http.ResponseWriter, r *http.Request) {
+	sess, owner, ok := s.inputSession(w, r)
+	if !ok {
+		return
+	}
+	sess.ReleaseInput(owner)
+	writeJSON(w, map[string]string{"lock": sess.InputOwner()})
+}
+
+// handleInput writes the raw request body to the session. The operator
+// must hold the input lock.
+func (s *Server) handleInput(w http.ResponseWriter, r *http.Request) {
+	sess, owner, ok := s.inputSession(w, r)
+	if !ok {
+		return
+	}
+	if sess.InputOwner() != owner {
+		w.WriteHeader(http.StatusConflict)
+		writeJSON(w, map[string]string{"lock": sess.InputOwner()})
+		return
+	}
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxInputBody))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := sess.Write(data); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// trustedRequest reports whether a request comes from the dashboard itself.
+// A browser names the page that sent a request in Origin, which must be
+// the dashboard, so other sites cannot type into or read sessions (CSRF).
+// Host must be an IP address, localhost or this machine's name, so a site
+// whose name was rebound to this machine cannot either (DNS rebinding).
+func trustedRequest(r *http.Request) bool {
+	if origin := r.Header.Get("Origin"); origin != "" {
+		if u, err := url.Parse(origin); err != nil || u.Host != r.Host {
+			return false
+		}
+	}
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.Trim(host, "[]"), ".")
+	if net.ParseIP(host) != nil || strings.EqualFold(host, "localhost") {
+		return true
+	}
+	name, err := os.Hostname()
+	return err == nil && name != "" &&
+		(strings.EqualFold(host, name) || strings.EqualFold(host, name+".local"))
+}
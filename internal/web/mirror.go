@@ -0,0 +1,127 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Mirror copies a session's raw output stream from a running VibeMux
+// dashboard at addr to w until the session ends or ctx is cancelled.
+// token is the dashboard's, from ReadToken.
+func Mirror(ctx context.Context, addr, sessionID, token string, w io.Writer) error {
+	endpoint := "http://" + addr + "/api/sessions/" + url.PathEscape(sessionID) + "/raw?token=" + url.QueryEscape(token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot reach VibeMux dashboard at %s (enable it with :dashboard or dashboard_addr): %w", addr, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return errors.New("session is not running")
+	case http.StatusUnauthorized:
+		return errors.New("dashboard token rejected; is the dashboard running with this config directory?")
+	default:
+		return fmt.Errorf("dashboard returned %s", resp.Status)
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+// DetachKey ends a drive session (Ctrl+]).
+const DetachKey = 0x1d
+
+// Drive claims the session's input lock as owner and forwards everything
+// read from in until DetachKey is pressed, in is exhausted or ctx ends.
+// token is the dashboard's, from ReadToken. The lock is released on
+// return.
+func Drive(ctx context.Context, addr, sessionID, owner, token string, in io.Reader) error {
+	origin := "http://" + addr
+	base := origin + "/api/sessions/" + url.PathEscape(sessionID)
+	query := "?owner=" + url.QueryEscape(owner) + "&token=" + url.QueryEscape(token)
+
+	if err := driveRequest(ctx, http.MethodPost, base+"/lock"+query, origin, nil); err != nil {
+		return err
+	}
+	defer func() {
+		_ = driveRequest(context.Background(), http.MethodDelete, base+"/lock"+query, origin, nil)
+	}()
+
+	buf := make([]byte, 1024)
+	for {
+		n, err := in.Read(buf)
+		if n > 0 {
+			chunk := buf[:n]
+			detach := false
+			if i := bytes.IndexByte(chunk, DetachKey); i >= 0 {
+				chunk = chunk[:i]
+				detach = true
+			}
+			if len(chunk) > 0 {
+				if err := driveRequest(ctx, http.MethodPost, base+"/input"+query, origin, chunk); err != nil {
+					return err
+				}
+			}
+			if detach {
+				return nil
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+func driveRequest(ctx context.Context, method, endpoint, origin string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Origin", origin)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusConflict:
+		var lock struct {
+			Lock string `json:"lock"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&lock)
+		if lock.Lock != "" {
+			return fmt.Errorf("input is locked by %s", lock.Lock)
+		}
+		return errors.New("session rejected input")
+	case http.StatusUnauthorized:
+		return errors.New("dashboard token rejected; is the dashboard running with this config directory?")
+	case http.StatusForbidden:
+		return errors.New("dashboard is read-only (set dashboard_allow_input in config.json)")
+	default:
+		return fmt.Errorf("dashboard returned %s", resp.Status)
+	}
+}
@@ -0,0 +1,372 @@
+// Package web serves a read-only browser dashboard for running sessions.
+package web
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"embed"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lazyvibe/vibemux/internal/runtime"
+	"github.com/lazyvibe/vibemux/internal/store"
+)
+
+//go:embed static
+var staticFiles embed.FS
+
+// DefaultAddr is the listen address used when none is configured.
+const DefaultAddr = "127.0.0.1:7681"
+
+// TokenFile holds the running dashboard's token in the config directory,
+// readable only by the user, so `vibemux mirror` can authenticate.
+const TokenFile = "dashboard.token"
+
+// Server is the local dashboard HTTP server.
+type Server struct {
+	engine    *runtime.DefaultEngine
+	projects  store.ProjectStore
+	configDir string
+
+	mu         sync.Mutex
+	srv        *http.Server
+	addr       string
+	allowInput bool
+	// token authenticates input requests, and all requests when the server
+	// listens beyond loopback; a new one is made on each Start.
+	token  string
+	public bool
+	// cancel ends the requests of the running server, including hijacked
+	// WebSocket connections that Shutdown does not track.
+	cancel context.CancelFunc
+}
+
+// SessionInfo describes a session for the dashboard.
+type SessionInfo struct {
+	ID        string `json:"id"`
+	ProjectID string `json:"projectId"`
+	Name      string `json:"name"`
+	Path      string `json:"path"`
+	Status    string `json:"status"`
+	Lock      string `json:"lock,omitempty"`
+	Rows      uint16 `json:"rows"`
+	Cols      uint16 `json:"cols"`
+}
+
+// NewServer creates a dashboard server backed by the engine and project store.
+func NewServer(e *runtime.DefaultEngine, projects store.ProjectStore, configDir string) *Server {
+	return &Server{
+		engine:    e,
+		projects:  projects,
+		configDir: configDir,
+	}
+}
+
+// Start binds addr and serves in the background. Binding errors are
+// returned synchronously so the caller can report them before the TUI
+// starts.
+func (s *Server) Start(addr string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.srv != nil {
+		return errors.New("dashboard already running")
+	}
+	token, err := newToken()
+	if err != nil {
+		return err
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	if s.configDir != "" {
+		if err := os.WriteFile(filepath.Join(s.configDir, TokenFile), []byte(token+"\n"), 0o600); err != nil {
+			ln.Close()
+			return err
+		}
+	}
+	s.addr = ln.Addr().String()
+	s.token = token
+	s.public = !ln.Addr().(*net.TCPAddr).IP.IsLoopback()
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.srv = &http.Server{
+		Handler:           s.routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+	srv := s.srv
+	go func() { _ = srv.Serve(ln) }()
+	return nil
+}
+
+// SetAllowInput enables the lock and input endpoints used for pair-driving
+// a session. The dashboard is read-only by default.
+func (s *Server) SetAllowInput(allow bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.allowInput = allow
+}
+
+func (s *Server) inputAllowed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.allowInput
+}
+
+// Addr returns the bound address, or "" when not running.
+func (s *Server) Addr() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addr
+}
+
+// URL returns the dashboard URL, or "" when not running. With input
+// allowed, or when reachable beyond loopback, it carries the token, which
+// the page then needs.
+func (s *Server) URL() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.addr == "" {
+		return ""
+	}
+	u := "http://" + s.addr + "/"
+	if s.allowInput || s.public {
+		u += "?token=" + url.QueryEscape(s.token)
+	}
+	return u
+}
+
+// authorized reports whether r carries the running server's token.
+func (s *Server) authorized(r *http.Request) bool {
+	s.mu.Lock()
+	token := s.token
+	s.mu.Unlock()
+	given := r.URL.Query().Get("token")
+	return token != "" && subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+}
+
+// ReadToken returns the token of the dashboard running with configDir, or
+// "" when there is none.
+func ReadToken(configDir string) string {
+	data, err := os.ReadFile(filepath.Join(configDir, TokenFile))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Close stops the server.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	srv := s.srv
+	cancel := s.cancel
+	s.srv = nil
+	s.addr = ""
+	s.token = ""
+	s.public = false
+	s.cancel = nil
+	s.mu.Unlock()
+
+	if srv == nil {
+		return nil
+	}
+	if s.configDir != "" {
+		_ = os.Remove(filepath.Join(s.configDir, TokenFile))
+	}
+	cancel()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return srv.Shutdown(ctx)
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.handleIndex)
+	mux.Handle("GET /static/", http.FileServerFS(staticFiles))
+	mux.HandleFunc("GET /api/sessions", s.guard(s.handleSessions))
+	mux.HandleFunc("GET /api/ws", s.guard(s.handleWebSocket))
+	mux.HandleFunc("GET /api/sessions/{id}/stream", s.guard(s.handleStream))
+	mux.HandleFunc("GET /api/sessions/{id}/raw", s.guard(s.handleRaw))
+	mux.HandleFunc("GET /api/chain", s.guard(s.handleChain))
+	mux.HandleFunc("GET /api/config", s.guard(s.handleConfig))
+	mux.HandleFunc("POST /api/sessions/{id}/lock", s.handleLock)
+	mux.HandleFunc("DELETE /api/sessions/{id}/lock", s.handleUnlock)
+	mux.HandleFunc("POST /api/sessions/{id}/input", s.handleInput)
+	return mux
+}
+
+// guard refuses API requests from other sites or rebound host names (see
+// trustedRequest), which could otherwise read every pane's output. Beyond
+// loopback, where anyone on the network can connect, the token is needed
+// too.
+func (s *Server) guard(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		public := s.public
+		s.mu.Unlock()
+		if public && !s.authorized(r) {
+			http.Error(w, "dashboard token required", http.StatusUnauthorized)
+			return
+		}
+		if !trustedRequest(r) {
+			http.Error(w, "cross-origin request refused", http.StatusForbidden)
+			return
+		}
+		h(w, r)
+	}
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, _ *http.Request) {
+	data, err := staticFiles.ReadFile("static/index.html")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	// The URL may carry the token; keep it out of Referer headers.
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	// The page holds the token and can type into sessions, so it runs no
+	// code from anywhere but this server.
+	w.Header().Set("Content-Security-Policy", "default-src 'self'; script-src 'self' 'unsafe-inline'; style-src 'self' 'unsafe-inline'; connect-src 'self'")
+	_, _ = w.Write(data)
+}
+
+func (s *Server) handleSessions(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.sessionInfos(r.Context()))
+}
+
+func (s *Server) sessionInfos(ctx context.Context) []SessionInfo {
+	infos := make([]SessionInfo, 0)
+	for _, sess := range s.engine.ListSessions() {
+		info := SessionInfo{ID: sess.ID(), ProjectID: sess.ProjectID(), Name: sess.ID(), Status: string(sess.Status()), Lock: sess.InputOwner()}
+		info.Rows, info.Cols = sess.Size()
+		if project, err := s.projects.Get(ctx, sess.ProjectID()); err == nil {
+			info.Name = project.DisplayName() + runtime.SessionSuffix(sess.ID(), project.ID)
+			info.Path = project.Path
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// handleStream sends the session history followed by live output as
+// Server-Sent Events. Payloads are base64 encoded raw PTY bytes.
+func (s *Server) handleStream(w http.ResponseWriter, r *http.Request) {
+	sess, ok := s.engine.GetSession(r.PathValue("id"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	ch, cancel := sess.Subscribe()
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	send := func(event string, data []byte) {
+		fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, base64.StdEncoding.EncodeToString(data))
+		flusher.Flush()
+	}
+	send("output", sess.History())
+
+	keepAlive := time.NewTicker(20 * time.Second)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case data, ok := <-ch:
+			if !ok {
+				send("end", nil)
+				return
+			}
+			send("output", data)
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// handleRaw streams the session history followed by live output as raw PTY
+// bytes, suitable for writing straight to another terminal.
+func (s *Server) handleRaw(w http.ResponseWriter, r *http.Request) {
+	sess, ok := s.engine.GetSession(r.PathValue("id"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	ch, cancel := sess.Subscribe()
+	defer cancel()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	_, _ = w.Write(sess.History())
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case data, ok := <-ch:
+			if !ok {
+				return
+			}
+			if _, err := w.Write(data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func (s *Server) handleChain(w http.ResponseWriter, _ *http.Request) {
+	ctx, err := runtime.LatestChainContext(filepath.Join(s.configDir, "chain"))
+	if err != nil {
+		writeJSON(w, map[string]any{"chain": []any{}})
+		return
+	}
+	writeJSON(w, ctx)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,644 @@
+// term.js is the dashboard's terminal: a small VT100/xterm emulator that
+// draws a session's screen as HTML. It implements the part of the xterm.js
+// API the dashboard uses, so the page loads no third-party code; the page
+// holds the dashboard token and can type into sessions.
+'use strict';
+
+(function () {
+  const GROUND = 0, ESC = 1, CSI = 2, STRING = 3, STRING_ESC = 4, SKIP = 5;
+  const SCROLLBACK = 500;
+
+  const PALETTE = [
+    '#000000', '#cd3131', '#0dbc79', '#e5e510', '#2472c8', '#bc3fbc', '#11a8cd', '#e5e5e5',
+    '#666666', '#f14c4c', '#23d18b', '#f5f543', '#3b8eea', '#d670d6', '#29b8db', '#ffffff',
+  ];
+  const LEVELS = [0, 95, 135, 175, 215, 255];
+  for (let i = 0; i < 216; i++) {
+    PALETTE.push(rgb(LEVELS[Math.floor(i / 36)], LEVELS[Math.floor(i / 6) % 6], LEVELS[i % 6]));
+  }
+  for (let i = 0; i < 24; i++) PALETTE.push(rgb(8 + i * 10, 8 + i * 10, 8 + i * 10));
+
+  function rgb(r, g, b) {
+    return '#' + [r, g, b].map(v => Math.max(0, Math.min(255, v | 0)).toString(16).padStart(2, '0')).join('');
+  }
+
+  function escapeHTML(s) {
+    return s.replace(/[&<>"]/g, c => ({ '&': '&amp;', '<': '&lt;', '>': '&gt;', '"': '&quot;' })[c]);
+  }
+
+  // charWidth returns how many cells a code point takes: 0 for combining
+  // marks, 2 for wide East Asian characters and emoji.
+  function charWidth(cp) {
+    if (cp < 0x300) return 1;
+    if ((cp <= 0x36f) || (cp >= 0x1ab0 && cp <= 0x1aff) || (cp >= 0x1dc0 && cp <= 0x1dff) ||
+        (cp >= 0x200b && cp <= 0x200f) || (cp >= 0x20d0 && cp <= 0x20ff) || (cp >= 0xfe00 && cp <= 0xfe0f)) return 0;
+    if ((cp >= 0x1100 && cp <= 0x115f) || (cp >= 0x2e80 && cp <= 0xa4cf && cp !== 0x303f) ||
+        (cp >= 0xac00 && cp <= 0xd7a3) || (cp >= 0xf900 && cp <= 0xfaff) || (cp >= 0xfe30 && cp <= 0xfe4f) ||
+        (cp >= 0xff00 && cp <= 0xff60) || (cp >= 0xffe0 && cp <= 0xffe6) || (cp >= 0x1f300 && cp <= 0x1f64f) ||
+        (cp >= 0x1f900 && cp <= 0x1f9ff) || (cp >= 0x20000 && cp <= 0x3fffd)) return 2;
+    return 1;
+  }
+
+  // Attributes are immutable so cells can share them; SGR makes new ones.
+  const DEFAULT_ATTR = Object.freeze({ fg: null, bg: null, bold: false, dim: false, italic: false, underline: false, inverse: false, strike: false, hidden: false });
+
+  function withAttr(a, change) {
+    return Object.freeze(Object.assign({}, a, change));
+  }
+
+  const KEYS = {
+    Enter: '\r', Backspace: '\x7f', Tab: '\t', Escape: '\x1b',
+    Home: '\x1b[H', End: '\x1b[F', Insert: '\x1b[2~', Delete: '\x1b[3~', PageUp: '\x1b[5~', PageDown: '\x1b[6~',
+    F1: '\x1bOP', F2: '\x1bOQ', F3: '\x1bOR', F4: '\x1bOS', F5: '\x1b[15~', F6: '\x1b[17~',
+    F7: '\x1b[18~', F8: '\x1b[19~', F9: '\x1b[20~', F10: '\x1b[21~', F11: '\x1b[23~', F12: '\x1b[24~',
+  };
+  const ARROWS = { ArrowUp: 'A', ArrowDown: 'B', ArrowRight: 'C', ArrowLeft: 'D' };
+
+  class Terminal {
+    constructor(opts) {
+      opts = opts || {};
+      const self = this;
+      let fontSize = opts.fontSize || 12;
+      this.options = {
+        disableStdin: !!opts.disableStdin,
+        get fontSize() { return fontSize; },
+        set fontSize(v) { fontSize = v; self._layout(); },
+      };
+      this.cols = Math.max(1, opts.cols || 80);
+      this.rows = Math.max(1, opts.rows || 24);
+      this._theme = Object.assign({ background: '#000000', foreground: '#cdd6f4' }, opts.theme);
+      this._listeners = [];
+      this._el = null;
+      this._frame = 0;
+      this.reset();
+    }
+
+    // open renders the terminal into parent.
+    open(parent) {
+      const el = document.createElement('div');
+      el.className = 'vt';
+      el.tabIndex = 0;
+      el.style.background = this._theme.background;
+      el.style.color = this._theme.foreground;
+      this._history = document.createElement('pre');
+      this._screen = document.createElement('pre');
+      el.append(this._history, this._screen);
+      el.addEventListener('keydown', e => this._onKey(e));
+      el.addEventListener('paste', e => this._onPaste(e));
+      parent.appendChild(el);
+      this._el = el;
+      this._layout();
+      this._render();
+    }
+
+    // onData registers a handler for the bytes typed into the terminal.
+    onData(handler) {
+      this._listeners.push(handler);
+      return { dispose: () => { this._listeners = this._listeners.filter(h => h !== handler); } };
+    }
+
+    write(data) {
+      const text = typeof data === 'string' ? data : this._decoder.decode(data, { stream: true });
+      for (const ch of text) this._feed(ch);
+      this._schedule();
+    }
+
+    resize(cols, rows) {
+      this.cols = Math.max(1, cols);
+      this.rows = Math.max(1, rows);
+      for (const buf of [this._normal, this._alt]) {
+        for (const line of buf.lines) this._fitLine(line);
+        while (buf.lines.length > this.rows) {
+          if (buf.y < buf.lines.length - 1) {
+            buf.lines.pop();
+            continue;
+          }
+          const line = buf.lines.shift();
+          if (buf === this._normal) this._pushHistory(line);
+          buf.y--;
+        }
+        while (buf.lines.length < this.rows) buf.lines.push(this._blankLine());
+        buf.x = Math.min(buf.x, this.cols - 1);
+        buf.y = Math.min(buf.y, this.rows - 1);
+        buf.top = 0;
+        buf.bottom = this.rows - 1;
+      }
+      this._layout();
+      this._schedule();
+    }
+
+    // reset returns to the initial state and drops the scrollback.
+    reset() {
+      this._decoder = new TextDecoder('utf-8');
+      this._state = GROUND;
+      this._seq = '';
+      this._attr = DEFAULT_ATTR;
+      this._last = ' ';
+      this._wrapPending = false;
+      this._autowrap = true;
+      this._cursorVisible = true;
+      this._appCursor = false;
+      this._bracketedPaste = false;
+      this._historyLines = [];
+      this._historyDirty = true;
+      this._normal = this._newBuffer();
+      this._alt = this._newBuffer();
+      this._buf = this._normal;
+      this._schedule();
+    }
+
+    dispose() {
+      if (this._frame) cancelAnimationFrame(this._frame);
+      this._frame = 0;
+      this._listeners = [];
+      if (this._el) this._el.remove();
+      this._el = null;
+    }
+
+    _newBuffer() {
+      const lines = [];
+      for (let y = 0; y < this.rows; y++) lines.push(this._blankLine());
+      return { lines, x: 0, y: 0, top: 0, bottom: this.rows - 1, saved: null };
+    }
+
+    // _eraseAttr is what erased cells get: the current background only.
+    _eraseAttr() {
+      return this._attr.bg === null ? DEFAULT_ATTR : withAttr(DEFAULT_ATTR, { bg: this._attr.bg });
+    }
+
+    _blankLine() {
+      const a = this._attr ? this._eraseAttr() : DEFAULT_ATTR;
+      const line = [];
+      for (let x = 0; x < this.cols; x++) line.push({ ch: ' ', a });
+      return line;
+    }
+
+    _fitLine(line) {
+      if (line.length > this.cols) line.length = this.cols;
+      while (line.length < this.cols) line.push({ ch: ' ', a: DEFAULT_ATTR });
+    }
+
+    _feed(ch) {
+      const c = ch.codePointAt(0);
+      switch (this._state) {
+        case GROUND:
+          if (c === 0x1b) this._state = ESC;
+          else if (c < 0x20 || c === 0x7f) this._control(c);
+          else this._print(ch, c);
+          return;
+        case ESC:
+          this._state = GROUND;
+          this._escape(ch);
+          return;
+        case CSI:
+          if (c >= 0x40 && c <= 0x7e) {
+            this._state = GROUND;
+            this._csi(ch);
+          } else if (c === 0x1b) {
+            this._state = ESC;
+          } else if (c < 0x20) {
+            this._control(c);
+          } else {
+            this._seq += ch;
+          }
+          return;
+        case STRING:
+          if (c === 0x07) this._state = GROUND;
+          else if (c === 0x1b) this._state = STRING_ESC;
+          return;
+        case STRING_ESC:
+          this._state = GROUND;
+          if (ch !== '\\') this._escape(ch);
+          return;
+        case SKIP:
+          this._state = GROUND;
+          return;
+      }
+    }
+
+    _control(c) {
+      const buf = this._buf;
+      switch (c) {
+        case 0x08:
+          if (buf.x > 0) buf.x--;
+          this._wrapPending = false;
+          break;
+        case 0x09:
+          buf.x = Math.min(this.cols - 1, (Math.floor(buf.x / 8) + 1) * 8);
+          break;
+        case 0x0a: case 0x0b: case 0x0c:
+          this._lineFeed();
+          break;
+        case 0x0d:
+          buf.x = 0;
+          this._wrapPending = false;
+          break;
+      }
+    }
+
+    _escape(ch) {
+      const buf = this._buf;
+      switch (ch) {
+        case '[': this._state = CSI; this._seq = ''; break;
+        case ']': case 'P': case '_': case '^': case 'X': this._state = STRING; break;
+        case '(': case ')': case '*': case '+': case '#': case '%': this._state = SKIP; break;
+        case '7': this._saveCursor(); break;
+        case '8': this._restoreCursor(); break;
+        case 'D': this._lineFeed(); break;
+        case 'E': buf.x = 0; this._lineFeed(); break;
+        case 'M': this._reverseIndex(); break;
+        case 'c': this.reset(); break;
+      }
+    }
+
+    _print(ch, cp) {
+      const buf = this._buf;
+      const width = charWidth(cp);
+      if (width === 0) {
+        const x = this._wrapPending ? buf.x : buf.x - 1;
+        if (x >= 0) buf.lines[buf.y][x].ch += ch;
+        return;
+      }
+      if (this._wrapPending && this._autowrap) {
+        buf.x = 0;
+        this._lineFeed();
+      }
+      this._wrapPending = false;
+      if (width === 2 && buf.x === this.cols - 1) {
+        if (!this._autowrap) return;
+        buf.lines[buf.y][buf.x] = { ch: ' ', a: this._attr };
+        buf.x = 0;
+        this._lineFeed();
+      }
+      const line = buf.lines[buf.y];
+      line[buf.x] = { ch, a: this._attr };
+      if (width === 2) line[buf.x + 1] = { ch: '', a: this._attr };
+      this._last = ch;
+      buf.x += width;
+      if (buf.x >= this.cols) {
+        buf.x = this.cols - 1;
+        this._wrapPending = true;
+      }
+    }
+
+    _lineFeed() {
+      const buf = this._buf;
+      this._wrapPending = false;
+      if (buf.y === buf.bottom) this._scrollUp(1);
+      else if (buf.y < this.rows - 1) buf.y++;
+    }
+
+    _reverseIndex() {
+      const buf = this._buf;
+      if (buf.y === buf.top) this._scrollDown(1);
+      else if (buf.y > 0) buf.y--;
+    }
+
+    _scrollUp(n) {
+      const buf = this._buf;
+      for (let i = 0; i < n; i++) {
+        const line = buf.lines.splice(buf.top, 1)[0];
+        if (buf === this._normal && buf.top === 0) this._pushHistory(line);
+        buf.lines.splice(buf.bottom, 0, this._blankLine());
+      }
+    }
+
+    _scrollDown(n) {
+      const buf = this._buf;
+      for (let i = 0; i < n; i++) {
+        buf.lines.splice(buf.bottom, 1);
+        buf.lines.splice(buf.top, 0, this._blankLine());
+      }
+    }
+
+    _pushHistory(line) {
+      this._historyLines.push(this._lineHTML(line, -1));
+      if (this._historyLines.length > SCROLLBACK) this._historyLines.shift();
+      this._historyDirty = true;
+    }
+
+    _saveCursor() {
+      const buf = this._buf;
+      buf.saved = { x: buf.x, y: buf.y, attr: this._attr };
+    }
+
+    _restoreCursor() {
+      const buf = this._buf;
+      const saved = buf.saved || { x: 0, y: 0, attr: DEFAULT_ATTR };
+      buf.x = Math.min(saved.x, this.cols - 1);
+      buf.y = Math.min(saved.y, this.rows - 1);
+      this._attr = saved.attr;
+      this._wrapPending = false;
+    }
+
+    _eraseCells(line, from, to) {
+      const a = this._eraseAttr();
+      for (let x = Math.max(0, from); x < Math.min(to, this.cols); x++) line[x] = { ch: ' ', a };
+    }
+
+    _csi(final) {
+      let seq = this._seq;
+      let prefix = '';
+      if (seq && '?>=<'.includes(seq[0])) {
+        prefix = seq[0];
+        seq = seq.slice(1);
+      }
+      const intermediate = seq.match(/[\x20-\x2f]+$/);
+      if (intermediate) {
+        if (intermediate[0] === '!' && final === 'p') this._softReset();
+        return;
+      }
+      const parts = seq === '' ? [] : seq.split(';');
+      const params = parts.map(p => parseInt(p, 10) || 0);
+      const p = (i, def) => params[i] > 0 ? params[i] : def;
+      const buf = this._buf;
+      if (prefix === '?') {
+        if (final === 'h' || final === 'l') this._setModes(params, final === 'h');
+        return;
+      }
+      if (prefix !== '') return;
+      if (final !== 'm') this._wrapPending = false;
+      switch (final) {
+        case 'A': buf.y = Math.max(buf.y < buf.top ? 0 : buf.top, buf.y - p(0, 1)); break;
+        case 'B': case 'e': buf.y = Math.min(buf.y > buf.bottom ? this.rows - 1 : buf.bottom, buf.y + p(0, 1)); break;
+        case 'C': case 'a': buf.x = Math.min(this.cols - 1, buf.x + p(0, 1)); break;
+        case 'D': buf.x = Math.max(0, buf.x - p(0, 1)); break;
+        case 'E': buf.x = 0; buf.y = Math.min(this.rows - 1, buf.y + p(0, 1)); break;
+        case 'F': buf.x = 0; buf.y = Math.max(0, buf.y - p(0, 1)); break;
+        case 'G': case '`': buf.x = Math.min(this.cols - 1, p(0, 1) - 1); break;
+        case 'd': buf.y = Math.min(this.rows - 1, p(0, 1) - 1); break;
+        case 'H': case 'f':
+          buf.y = Math.min(this.rows - 1, p(0, 1) - 1);
+          buf.x = Math.min(this.cols - 1, p(1, 1) - 1);
+          break;
+        case 'I': for (let i = 0; i < p(0, 1); i++) this._control(0x09); break;
+        case 'Z': buf.x = Math.max(0, (Math.ceil(buf.x / 8) - p(0, 1)) * 8); break;
+        case 'J': this._eraseDisplay(params[0] || 0); break;
+        case 'K': {
+          const line = buf.lines[buf.y];
+          const mode = params[0] || 0;
+          if (mode === 0) this._eraseCells(line, buf.x, this.cols);
+          else if (mode === 1) this._eraseCells(line, 0, buf.x + 1);
+          else if (mode === 2) this._eraseCells(line, 0, this.cols);
+          break;
+        }
+        case 'L': case 'M':
+          if (buf.y >= buf.top && buf.y <= buf.bottom) {
+            const n = Math.min(p(0, 1), buf.bottom - buf.y + 1);
+            for (let i = 0; i < n; i++) {
+              if (final === 'L') {
+                buf.lines.splice(buf.bottom, 1);
+                buf.lines.splice(buf.y, 0, this._blankLine());
+              } else {
+                buf.lines.splice(buf.y, 1);
+                buf.lines.splice(buf.bottom, 0, this._blankLine());
+              }
+            }
+            buf.x = 0;
+          }
+          break;
+        case 'P': {
+          const line = buf.lines[buf.y];
+          const n = Math.min(p(0, 1), this.cols - buf.x);
+          line.splice(buf.x, n);
+          for (let i = 0; i < n; i++) line.push({ ch: ' ', a: this._eraseAttr() });
+          break;
+        }
+        case '@': {
+          const line = buf.lines[buf.y];
+          const n = Math.min(p(0, 1), this.cols - buf.x);
+          for (let i = 0; i < n; i++) line.splice(buf.x, 0, { ch: ' ', a: this._eraseAttr() });
+          line.length = this.cols;
+          break;
+        }
+        case 'X': this._eraseCells(buf.lines[buf.y], buf.x, buf.x + p(0, 1)); break;
+        case 'S': this._scrollUp(p(0, 1)); break;
+        case 'T': this._scrollDown(p(0, 1)); break;
+        case 'b': for (let i = 0; i < p(0, 1); i++) this._print(this._last, this._last.codePointAt(0)); break;
+        case 'r': {
+          const top = p(0, 1) - 1;
+          const bottom = Math.min(this.rows, p(1, this.rows)) - 1;
+          if (top < bottom) {
+            buf.top = top;
+            buf.bottom = bottom;
+            buf.x = 0;
+            buf.y = 0;
+          }
+          break;
+        }
+        case 's': this._saveCursor(); break;
+        case 'u': this._restoreCursor(); break;
+        case 'm': this._sgr(parts); break;
+      }
+    }
+
+    _eraseDisplay(mode) {
+      const buf = this._buf;
+      if (mode === 0) {
+        this._eraseCells(buf.lines[buf.y], buf.x, this.cols);
+        for (let y = buf.y + 1; y < this.rows; y++) buf.lines[y] = this._blankLine();
+      } else if (mode === 1) {
+        this._eraseCells(buf.lines[buf.y], 0, buf.x + 1);
+        for (let y = 0; y < buf.y; y++) buf.lines[y] = this._blankLine();
+      } else if (mode === 2) {
+        for (let y = 0; y < this.rows; y++) buf.lines[y] = this._blankLine();
+      } else if (mode === 3) {
+        this._historyLines = [];
+        this._historyDirty = true;
+      }
+    }
+
+    _setModes(params, on) {
+      for (const mode of params) {
+        switch (mode) {
+          case 1: this._appCursor = on; break;
+          case 7: this._autowrap = on; break;
+          case 25: this._cursorVisible = on; break;
+          case 2004: this._bracketedPaste = on; break;
+          case 47: case 1047: case 1049:
+            if (on === (this._buf === this._alt)) break;
+            if (on) {
+              if (mode === 1049) this._saveCursor();
+              this._alt = this._newBuffer();
+              this._alt.x = this._buf.x;
+              this._alt.y = this._buf.y;
+              this._buf = this._alt;
+            } else {
+              this._buf = this._normal;
+              if (mode === 1049) this._restoreCursor();
+            }
+            this._wrapPending = false;
+            break;
+        }
+      }
+    }
+
+    _softReset() {
+      this._attr = DEFAULT_ATTR;
+      this._autowrap = true;
+      this._cursorVisible = true;
+      this._appCursor = false;
+      this._buf.top = 0;
+      this._buf.bottom = this.rows - 1;
+    }
+
+    _sgr(parts) {
+      if (parts.length === 0) parts = ['0'];
+      let a = Object.assign({}, this._attr);
+      for (let i = 0; i < parts.length; i++) {
+        const sub = parts[i].split(':').map(v => parseInt(v, 10) || 0);
+        const n = sub[0];
+        if (n === 38 || n === 48 || n === 58) {
+          let color;
+          if (sub.length > 1) {
+            // Colon form: 38:5:n or 38:2:[colorspace:]r:g:b.
+            if (sub[1] === 5) color = PALETTE[sub[2] & 255];
+            else if (sub[1] === 2) color = rgb(...sub.slice(sub.length >= 6 ? 3 : 2));
+          } else {
+            const kind = parseInt(parts[i + 1], 10);
+            if (kind === 5) {
+              color = PALETTE[(parseInt(parts[i + 2], 10) || 0) & 255];
+              i += 2;
+            } else if (kind === 2) {
+              color = rgb(parseInt(parts[i + 2], 10) || 0, parseInt(parts[i + 3], 10) || 0, parseInt(parts[i + 4], 10) || 0);
+              i += 4;
+            }
+          }
+          if (color && n === 38) a.fg = color;
+          if (color && n === 48) a.bg = color;
+          continue;
+        }
+        if (n === 0) a = Object.assign({}, DEFAULT_ATTR);
+        else if (n === 1) a.bold = true;
+        else if (n === 2) a.dim = true;
+        else if (n === 3) a.italic = true;
+        else if (n === 4) a.underline = sub[1] !== 0 || sub.length === 1;
+        else if (n === 7) a.inverse = true;
+        else if (n === 8) a.hidden = true;
+        else if (n === 9) a.strike = true;
+        else if (n === 21 || n === 22) { a.bold = false; a.dim = false; }
+        else if (n === 23) a.italic = false;
+        else if (n === 24) a.underline = false;
+        else if (n === 27) a.inverse = false;
+        else if (n === 28) a.hidden = false;
+        else if (n === 29) a.strike = false;
+        else if (n >= 30 && n <= 37) a.fg = PALETTE[n - 30];
+        else if (n === 39) a.fg = null;
+        else if (n >= 40 && n <= 47) a.bg = PALETTE[n - 40];
+        else if (n === 49) a.bg = null;
+        else if (n >= 90 && n <= 97) a.fg = PALETTE[n - 90 + 8];
+        else if (n >= 100 && n <= 107) a.bg = PALETTE[n - 100 + 8];
+      }
+      this._attr = withAttr(a, {});
+    }
+
+    _onKey(e) {
+      if (this.options.disableStdin || e.isComposing || e.metaKey) return;
+      let data = KEYS[e.key];
+      if (e.key in ARROWS) {
+        data = (this._appCursor ? '\x1bO' : '\x1b[') + ARROWS[e.key];
+      } else if (e.key === 'Tab' && e.shiftKey) {
+        data = '\x1b[Z';
+      } else if (e.ctrlKey && e.key.length === 1) {
+        const code = e.key.toUpperCase().charCodeAt(0);
+        if (code >= 64 && code <= 95) data = String.fromCharCode(code - 64);
+        else if (e.key === ' ') data = '\x00';
+        else return;
+      } else if (e.key.length === 1 || [...e.key].length === 1) {
+        data = e.key;
+      }
+      if (data === undefined) return;
+      if (e.altKey) data = '\x1b' + data;
+      e.preventDefault();
+      this._emit(data);
+    }
+
+    _onPaste(e) {
+      if (this.options.disableStdin) return;
+      e.preventDefault();
+      let text = (e.clipboardData && e.clipboardData.getData('text/plain')) || '';
+      text = text.replace(/\r?\n/g, '\r');
+      if (this._bracketedPaste) text = '\x1b[200~' + text + '\x1b[201~';
+      if (text) this._emit(text);
+    }
+
+    _emit(data) {
+      for (const handler of this._listeners) handler(data);
+    }
+
+    _schedule() {
+      if (this._el && !this._frame) this._frame = requestAnimationFrame(() => this._render());
+    }
+
+    _layout() {
+      if (!this._el) return;
+      const size = this.options.fontSize;
+      this._el.style.fontSize = size + 'px';
+      this._el.style.height = Math.ceil(this.rows * size * 1.2) + 'px';
+    }
+
+    _render() {
+      this._frame = 0;
+      const el = this._el;
+      if (!el) return;
+      const atBottom = el.scrollTop + el.clientHeight >= el.scrollHeight - 2;
+      if (this._historyDirty) {
+        this._history.innerHTML = this._historyLines.join('\n');
+        this._history.style.display = this._historyLines.length ? '' : 'none';
+        this._historyDirty = false;
+      }
+      const buf = this._buf;
+      const cursor = this._cursorVisible ? buf.x : -1;
+      this._screen.innerHTML = buf.lines.map((line, y) => this._lineHTML(line, y === buf.y ? cursor : -1)).join('\n');
+      if (atBottom) el.scrollTop = el.scrollHeight;
+    }
+
+    _lineHTML(line, cursorX) {
+      let html = '';
+      let run = '';
+      let attr = null;
+      const flush = () => {
+        if (run) html += this._span(attr, run);
+        run = '';
+      };
+      for (let x = 0; x < line.length; x++) {
+        const cell = line[x];
+        if (cell.ch === '') continue;
+        if (x === cursorX) {
+          flush();
+          attr = null;
+          html += '<span class="cursor">' + escapeHTML(cell.ch) + '</span>';
+          continue;
+        }
+        if (cell.a !== attr) {
+          flush();
+          attr = cell.a;
+        }
+        run += cell.ch;
+      }
+      flush();
+      return html;
+    }
+
+    _span(a, text) {
+      let fg = a.fg;
+      let bg = a.bg;
+      if (a.inverse) {
+        fg = bg || this._theme.background;
+        bg = a.fg || this._theme.foreground;
+      }
+      const style = [];
+      if (fg) style.push('color:' + fg);
+      if (bg) style.push('background:' + bg);
+      if (a.bold) style.push('font-weight:bold');
+      if (a.dim) style.push('opacity:.6');
+      if (a.italic) style.push('font-style:italic');
+      if (a.underline || a.strike) style.push('text-decoration:' + (a.underline ? 'underline ' : '') + (a.strike ? 'line-through' : ''));
+      if (a.hidden) style.push('visibility:hidden');
+      if (!style.length) return escapeHTML(text);
+      return '<span style="' + style.join(';') + '">' + escapeHTML(text) + '</span>';
+    }
+  }
+
+  window.Terminal = Terminal;
+})();
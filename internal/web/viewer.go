@@ -0,0 +1,128 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/lazyvibe/vibemux/internal/runtime"
+)
+
+// viewerRefresh is how often the WebSocket viewer checks for started,
+// stopped and resized sessions.
+const viewerRefresh = time.Second
+
+// viewerMessage is a message of the WebSocket viewer:
+//
+//	sessions  the session list, sent whenever it changes
+//	history   the buffered output of a session; the client resets its
+//	          terminal first
+//	output    live output of a session
+//	end       the session's output stream ended
+type viewerMessage struct {
+	Type     string        `json:"type"`
+	Sessions []SessionInfo `json:"sessions,omitempty"`
+	ID       string        `json:"id,omitempty"`
+	// Data is raw PTY output, base64 encoded in JSON.
+	Data []byte `json:"data,omitempty"`
+}
+
+// viewerStream is the output forwarder of one session.
+type viewerStream struct {
+	sess runtime.Session
+	stop context.CancelFunc
+}
+
+// handleWebSocket streams the whole grid over one WebSocket: the session
+// list and every session's output. Browsers allow only a few EventSource
+// streams per host, so one connection keeps large grids live. The socket is
+// read-only; input still goes through the lock and input endpoints.
+func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	ws, err := upgradeWebSocket(w, r)
+	if err != nil {
+		return
+	}
+	defer ws.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	go func() {
+		_ = ws.readLoop()
+		cancel()
+	}()
+
+	streams := make(map[string]viewerStream)
+	var last []byte
+	ticker := time.NewTicker(viewerRefresh)
+	defer ticker.Stop()
+	for {
+		infos := s.sessionInfos(ctx)
+		if data, _ := json.Marshal(infos); !bytes.Equal(data, last) {
+			last = data
+			if err := ws.WriteJSON(viewerMessage{Type: "sessions", Sessions: infos}); err != nil {
+				return
+			}
+		}
+
+		live := make(map[string]bool, len(infos))
+		for _, info := range infos {
+			live[info.ID] = true
+			sess, ok := s.engine.GetSession(info.ID)
+			if !ok {
+				continue
+			}
+			// A restarted session is a new Session under the same ID.
+			if stream, ok := streams[info.ID]; ok {
+				if stream.sess == sess {
+					continue
+				}
+				stream.stop()
+			}
+			streamCtx, stop := context.WithCancel(ctx)
+			streams[info.ID] = viewerStream{sess: sess, stop: stop}
+			go streamSession(streamCtx, ws, info.ID, sess, cancel)
+		}
+		for id, stream := range streams {
+			if !live[id] {
+				stream.stop()
+				delete(streams, id)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// streamSession sends a session's history and then its live output until
+// ctx ends or the session does. A failed write calls fail, which closes the
+// whole connection.
+func streamSession(ctx context.Context, ws *wsConn, id string, sess runtime.Session, fail context.CancelFunc) {
+	ch, unsubscribe := sess.Subscribe()
+	defer unsubscribe()
+
+	if err := ws.WriteJSON(viewerMessage{Type: "history", ID: id, Data: sess.History()}); err != nil {
+		fail()
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case data, ok := <-ch:
+			if !ok {
+				_ = ws.WriteJSON(viewerMessage{Type: "end", ID: id})
+				return
+			}
+			if err := ws.WriteJSON(viewerMessage{Type: "output", ID: id, Data: data}); err != nil {
+				fail()
+				return
+			}
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/lazyvibe/vibemux/internal/app"
@@ -29,6 +30,10 @@ func main() {
 		os.Exit(1)
 	}
 
+	if runSubcommand(configDir, os.Args[1:]) {
+		return
+	}
+
 	// Load application configuration
 	config, err := app.LoadConfig(configDir)
 	if err != nil {
@@ -51,7 +56,7 @@ func main() {
 	}
 
 	// Initialize store
-	s, err := store.NewJSONStore(configDir)
+	s, err := store.Open(configDir, config.Store)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error initializing store: %v\n", err)
 		os.Exit(1)
@@ -59,11 +64,7 @@ func main() {
 	defer s.Close()
 
 	// Initialize runtime engine with configuration
-	driverCfg := driver.Config{
-		ClaudePath: config.ClaudePath,
-		CodexPath:  config.CodexPath,
-	}
-	engine := runtime.NewEngineWithConfig(driverCfg)
+	engine := newEngine(config)
 	defer engine.CloseAll()
 
 	// Create application
@@ -82,6 +83,23 @@ func main() {
 	}
 }
 
+// newEngine creates the runtime engine configured by config.
+func newEngine(config *app.Config) *runtime.DefaultEngine {
+	engine := runtime.NewEngineWithConfig(driverConfig(config))
+	engine.SetMaxSessions(config.MaxSessions)
+	engine.SetStopGrace(time.Duration(config.StopGraceSeconds) * time.Second)
+	return engine
+}
+
+// driverConfig returns the driver settings of config.
+func driverConfig(config *app.Config) driver.Config {
+	return driver.Config{
+		ClaudePath: config.ClaudePath,
+		CodexPath:  config.CodexPath,
+		Tmux:       config.TmuxMode,
+	}
+}
+
 // runSetupWizard runs the first-run setup wizard.
 func runSetupWizard(configDir string, config *app.Config) error {
 	wizard := setup.New(configDir, config)
@@ -62,3 +62,15 @@ func splitEnvInput(input string) []string {
 		}
 	})
 }
+
+// IsSecretEnvKey reports whether an environment variable name looks like it
+// holds a credential (API keys, tokens, passwords).
+func IsSecretEnvKey(key string) bool {
+	upper := strings.ToUpper(key)
+	for _, marker := range []string{"KEY", "TOKEN", "SECRET", "PASSWORD", "PASSWD", "CREDENTIAL", "AUTH"} {
+		if strings.Contains(upper, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,21 @@
+package utils
+
+import "fmt"
+
+// FormatBytes formats a memory size compactly, e.g. "640K", "512M",
+// "1.5G".
+func FormatBytes(n uint64) string {
+	const (
+		mib = 1 << 20
+		gib = 1 << 30
+	)
+	switch {
+	case n >= 10*gib:
+		return fmt.Sprintf("%.0fG", float64(n)/gib)
+	case n >= gib:
+		return fmt.Sprintf("%.1fG", float64(n)/gib)
+	case n >= mib:
+		return fmt.Sprintf("%dM", n/mib)
+	}
+	return fmt.Sprintf("%dK", n/1024)
+}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+// RelativeTime formats t relative to now, e.g. "just now", "5m ago",
+// "2h ago", "3d ago". Older times show the date.
+func RelativeTime(t, now time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d/time.Hour))
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(d/(24*time.Hour)))
+	}
+	return t.Format("2006-01-02")
+}
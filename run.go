@@ -0,0 +1,361 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lazyvibe/vibemux/internal/app"
+	"github.com/lazyvibe/vibemux/internal/model"
+	"github.com/lazyvibe/vibemux/internal/runtime"
+	"github.com/lazyvibe/vibemux/internal/runtime/driver"
+	"github.com/lazyvibe/vibemux/internal/store"
+	"github.com/lazyvibe/vibemux/internal/ui/components/terminal"
+)
+
+// Exit codes of vibemux run.
+const (
+	runExitError   = 1 // Bad arguments, or the session could not start
+	runExitTimeout = 2 // No conclusion before the timeout
+	runExitExited  = 3 // The agent exited without a conclusion
+)
+
+const (
+	// runRows and runCols are the size of the headless screen.
+	runRows = 40
+	runCols = 120
+	// runReadyTimeout is how long to wait for the ready banner before
+	// sending the prompt anyway once output has settled.
+	runReadyTimeout = 30 * time.Second
+	runReadySettle  = 2 * time.Second
+	// runTailLimit bounds the output kept for banner matching.
+	runTailLimit = 4096
+	// runBusyLines is how many bottom lines are checked for the busy hint.
+	runBusyLines = 10
+)
+
+// runOptions are the flags of vibemux run.
+type runOptions struct {
+	project string
+	prompt  string
+	profile string
+	wait    bool
+	timeout time.Duration
+	settle  time.Duration
+	tui     bool
+}
+
+// runHeadless starts a session without the TUI, sends a prompt and, with
+// --wait, prints the agent's conclusion once it has answered. It returns
+// the process exit code.
+func runHeadless(configDir string, args []string) (int, error) {
+	opts, err := parseRunOptions(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return 0, nil
+	}
+	if err != nil {
+		return runExitError, err
+	}
+	config, err := app.LoadConfig(configDir)
+	if err != nil {
+		return runExitError, err
+	}
+	if !opts.wait && !config.TmuxMode {
+		return runExitError, errors.New("--wait is required unless tmux_mode is on (the session ends with this process)")
+	}
+	project, profile, err := loadRunTarget(configDir, config.Store, opts)
+	if err != nil {
+		return runExitError, err
+	}
+	if opts.timeout <= 0 {
+		opts.timeout = profile.Turn.Timeout()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// secret:<name> references need VIBEMUX_SECRETS_PASSPHRASE here.
+	secrets := app.NewSecretResolver(configDir, config)
+	if opts.wait && !opts.tui {
+		d := driver.NewRegistryWithConfig(driverConfig(config)).ForProfile(profile)
+		if runner, ok := d.(driver.PromptRunner); ok {
+			return runPrompt(ctx, runner, secrets, project, profile, opts)
+		}
+	}
+
+	engine := newEngine(config)
+	defer engine.CloseAll()
+	engine.SetSecrets(secrets)
+	session, err := engine.CreateSession(ctx, project, profile, runRows, runCols)
+	if err != nil {
+		return runExitError, fmt.Errorf("start %s: %w", project.DisplayName(), err)
+	}
+
+	r := &headlessRun{opts: opts, project: project, profile: profile, session: session}
+	return r.run(ctx)
+}
+
+// parseRunOptions parses the flags of vibemux run. The prompt "-" is read
+// from stdin.
+func parseRunOptions(args []string) (runOptions, error) {
+	var opts runOptions
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.StringVar(&opts.project, "project", "", "project name or ID (required)")
+	fs.StringVar(&opts.prompt, "prompt", "", `prompt to send; "-" reads it from stdin (required)`)
+	fs.StringVar(&opts.profile, "profile", "", "profile ID (default: the project's profile)")
+	fs.BoolVar(&opts.wait, "wait", false, "wait for the answer and print its conclusion")
+	fs.DurationVar(&opts.timeout, "timeout", 0, "how long to wait for the answer (default: the profile's turn timeout)")
+	fs.DurationVar(&opts.settle, "settle", 6*time.Second, "how long the agent must stay quiet to be done")
+	fs.BoolVar(&opts.tui, "tui", false, "drive the interactive UI even for CLIs with a non-interactive mode (codex, gemini)")
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+	if opts.prompt == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return opts, err
+		}
+		opts.prompt = string(data)
+	}
+	opts.prompt = strings.TrimSpace(opts.prompt)
+	if opts.project == "" || opts.prompt == "" {
+		return opts, errors.New(`usage: vibemux run --project <name> --prompt <text|-> [--wait] [--profile id] [--timeout 5m] [--settle 6s] [--tui]`)
+	}
+	return opts, nil
+}
+
+// runPrompt answers the prompt with the CLI's non-interactive mode, whose
+// output is the answer itself, and prints it.
+func runPrompt(ctx context.Context, runner driver.PromptRunner, secrets runtime.SecretResolver, project *model.Project, profile *model.Profile, opts runOptions) (int, error) {
+	launch := *profile
+	env, err := secrets.Resolve(profile.EnvVars)
+	if err != nil {
+		return runExitError, fmt.Errorf("resolve secrets: %w", err)
+	}
+	launch.EnvVars = env
+	vars := driver.Vars{ProjectPath: project.Path, ProjectName: project.Name, SessionID: project.ID}
+	cmd, err := runner.BuildPromptCommand(project.Path, &launch, vars, opts.prompt)
+	if err != nil {
+		return runExitError, err
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return runExitError, fmt.Errorf("start %s: %w", project.DisplayName(), err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	timer := time.NewTimer(opts.timeout)
+	defer timer.Stop()
+	select {
+	case err = <-done:
+	case <-timer.C:
+		cmd.Process.Kill()
+		<-done
+		return runExitTimeout, fmt.Errorf("no answer from %s within %s", project.DisplayName(), opts.timeout)
+	case <-ctx.Done():
+		cmd.Process.Kill()
+		<-done
+		return runExitError, ctx.Err()
+	}
+
+	answer := strings.TrimSpace(ansi.Strip(stdout.String()))
+	if err != nil {
+		if msg := strings.TrimSpace(trimRunTail(stderr.String())); msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+		return runExitExited, fmt.Errorf("%s exited: %w", project.DisplayName(), err)
+	}
+	if answer == "" {
+		return runExitExited, fmt.Errorf("%s gave no answer", project.DisplayName())
+	}
+	fmt.Println(answer)
+	return 0, nil
+}
+
+// loadRunTarget looks up the project and its profile. The store is closed
+// again so a running TUI is not kept waiting.
+func loadRunTarget(configDir, backend string, opts runOptions) (*model.Project, *model.Profile, error) {
+	s, err := store.Open(configDir, backend)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer s.Close()
+
+	project, err := lookupProject(s, opts.project)
+	if err != nil {
+		return nil, nil, err
+	}
+	profileID := opts.profile
+	if profileID == "" {
+		profileID = project.ProfileID
+	}
+	profile, err := s.GetProfile(context.Background(), profileID)
+	if err != nil {
+		if opts.profile != "" {
+			return nil, nil, fmt.Errorf("unknown profile: %s", opts.profile)
+		}
+		profile, err = s.GetDefault(context.Background())
+	}
+	if err != nil || profile == nil {
+		profile = model.DefaultProfile()
+	}
+	return project, profile, nil
+}
+
+// headlessRun drives one session through startup, the prompt and the
+// answer, keeping a screen of its output for extraction.
+type headlessRun struct {
+	opts    runOptions
+	project *model.Project
+	profile *model.Profile
+	session runtime.Session
+	screen  terminal.Model
+	tail    string    // Recent plain output, for the ready banner
+	last    time.Time // Last output
+	sent    time.Time // When the prompt was submitted; zero before
+}
+
+// run feeds the session's output to the screen until the agent has
+// answered, the timeout passes or the session exits.
+func (r *headlessRun) run(ctx context.Context) (int, error) {
+	r.screen = terminal.New()
+	// The screen's inner size is what the agent draws into.
+	r.screen.SetSize(runCols+5, runRows+6)
+	r.screen.BindWriter(r.session)
+
+	ready, err := r.readyPattern()
+	if err != nil {
+		return runExitError, err
+	}
+	started := time.Now()
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	output := r.session.Output()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return runExitError, ctx.Err()
+		case data, ok := <-output:
+			if !ok {
+				return r.finish(true)
+			}
+			r.screen.AppendOutput(data)
+			r.last = time.Now()
+			if r.sent.IsZero() {
+				r.tail = trimRunTail(r.tail + ansi.Strip(string(data)))
+				if ready.MatchString(r.tail) {
+					r.sendPrompt()
+					if !r.opts.wait {
+						fmt.Println(r.session.ID())
+						return 0, nil
+					}
+				}
+			}
+		case now := <-ticker.C:
+			if r.sent.IsZero() {
+				if now.Sub(started) > runReadyTimeout && now.Sub(r.last) > runReadySettle {
+					r.sendPrompt()
+					if !r.opts.wait {
+						fmt.Println(r.session.ID())
+						return 0, nil
+					}
+				}
+				continue
+			}
+			if now.Sub(r.sent) > r.opts.timeout {
+				return runExitTimeout, fmt.Errorf("no answer from %s within %s", r.project.DisplayName(), r.opts.timeout)
+			}
+			if r.answered(now) {
+				if code, err := r.finish(false); code == 0 {
+					return code, err
+				}
+			}
+		}
+	}
+}
+
+// readyPattern returns the project's ready banner regex.
+func (r *headlessRun) readyPattern() (*regexp.Regexp, error) {
+	if r.project.ReadyPattern != "" {
+		re, err := regexp.Compile(r.project.ReadyPattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ready_pattern: %w", err)
+		}
+		return re, nil
+	}
+	return regexp.MustCompile(model.DefaultReadyPattern), nil
+}
+
+// sendPrompt types the profile's startup steps, then the prompt with the
+// output marker instruction used for extraction.
+func (r *headlessRun) sendPrompt() {
+	// Give the CLI a moment to finish drawing its input box.
+	time.Sleep(500 * time.Millisecond)
+	for _, step := range r.profile.StartupSteps {
+		time.Sleep(time.Duration(step.DelayMs) * time.Millisecond)
+		r.submit(step.Send)
+	}
+	r.submit(r.opts.prompt + "\n\n" + runtime.ChainPromptInstruction)
+	r.sent = time.Now()
+}
+
+// submit pastes text into the session and presses Enter.
+func (r *headlessRun) submit(text string) {
+	_ = runtime.Paste(r.session, []byte(text), runtime.DefaultPasteOptions)
+	time.Sleep(200 * time.Millisecond)
+	r.session.Write([]byte("\r"))
+}
+
+// answered reports whether the agent has written since the prompt, then
+// stayed quiet for the settle time without showing its busy hint at the
+// bottom of the screen.
+func (r *headlessRun) answered(now time.Time) bool {
+	if !r.last.After(r.sent) || now.Sub(r.last) < r.opts.settle {
+		return false
+	}
+	busy, err := regexp.Compile(r.profile.Watchdog.PatternFor(r.session.Tool()))
+	if err != nil {
+		return true
+	}
+	lines := strings.Split(strings.TrimRight(r.screen.GetPlainText(), "\n"), "\n")
+	bottom := strings.Join(lines[max(0, len(lines)-runBusyLines):], "\n")
+	return !busy.MatchString(bottom)
+}
+
+// finish prints the extracted conclusion. Without one the run goes on,
+// unless the session has exited.
+func (r *headlessRun) finish(exited bool) (int, error) {
+	result, err := runtime.ExtractForTool(r.screen.GetPlainText(), r.profile.Extraction, r.session.Tool())
+	if err == nil && strings.TrimSpace(result.Text) != "" && !r.sent.IsZero() {
+		fmt.Println(result.Text)
+		return 0, nil
+	}
+	if !exited {
+		return runExitTimeout, nil
+	}
+	if err == nil {
+		err = errors.New("no conclusion found")
+	}
+	return runExitExited, fmt.Errorf("%s exited: %w", r.project.DisplayName(), err)
+}
+
+// trimRunTail keeps the end of s within runTailLimit bytes.
+func trimRunTail(s string) string {
+	if len(s) > runTailLimit {
+		return s[len(s)-runTailLimit:]
+	}
+	return s
+}
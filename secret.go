@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/lazyvibe/vibemux/internal/app"
+	"github.com/lazyvibe/vibemux/internal/secrets"
+)
+
+const secretUsage = "usage: vibemux secret set <name> | rm <name> | list"
+
+// runSecret manages the passphrase-encrypted secrets file that secret:<name>
+// env var references read from. Values are read from stdin, without echo on
+// a terminal, so they stay out of the shell history.
+func runSecret(configDir string, args []string) error {
+	if len(args) == 0 {
+		return errors.New(secretUsage)
+	}
+	config, err := app.LoadConfig(configDir)
+	if err != nil {
+		return err
+	}
+	resolver := app.NewSecretResolver(configDir, config)
+	if resolver.Age() {
+		if args[0] == "list" {
+			return printSecretNames(resolver)
+		}
+		return fmt.Errorf("%s is age-encrypted; edit it with age", resolver.File())
+	}
+	file := resolver.File()
+	_, statErr := os.Stat(file)
+	exists := statErr == nil
+
+	passphrase := os.Getenv(secrets.PassphraseEnv)
+	if passphrase == "" {
+		if passphrase, err = readSecretInput("Passphrase for " + file + ": "); err != nil {
+			return err
+		}
+		if !exists && args[0] == "set" {
+			again, err := readSecretInput("Repeat the passphrase: ")
+			if err != nil {
+				return err
+			}
+			if again != passphrase {
+				return errors.New("passphrases do not match")
+			}
+		}
+	}
+	if passphrase == "" {
+		return errors.New("empty passphrase")
+	}
+
+	values := make(map[string]string)
+	if exists {
+		if values, err = secrets.ReadFile(file, passphrase); err != nil {
+			return err
+		}
+	}
+	switch args[0] {
+	case "list":
+		for _, name := range sortedKeys(values) {
+			fmt.Println(name)
+		}
+		return nil
+	case "set":
+		if len(args) != 2 {
+			return errors.New(secretUsage)
+		}
+		value, err := readSecretInput("Value of " + args[1] + ": ")
+		if err != nil {
+			return err
+		}
+		if value == "" {
+			return errors.New("empty value")
+		}
+		values[args[1]] = value
+		if err := secrets.WriteFile(file, values, passphrase); err != nil {
+			return err
+		}
+		fmt.Printf("Saved %s; use %s%s as a profile env var value\n", args[1], secrets.FilePrefix, args[1])
+		return nil
+	case "rm":
+		if len(args) != 2 {
+			return errors.New(secretUsage)
+		}
+		if _, ok := values[args[1]]; !ok {
+			return fmt.Errorf("no secret %q", args[1])
+		}
+		delete(values, args[1])
+		return secrets.WriteFile(file, values, passphrase)
+	}
+	return errors.New(secretUsage)
+}
+
+// printSecretNames lists the secret names, never their values.
+func printSecretNames(resolver *secrets.Resolver) error {
+	names, err := resolver.Names()
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		fmt.Println(name)
+	}
+	return nil
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// stdinLines reads piped input line by line across prompts.
+var stdinLines = bufio.NewReader(os.Stdin)
+
+// readSecretInput reads a line from stdin, hiding it when stdin is a
+// terminal.
+func readSecretInput(prompt string) (string, error) {
+	if term.IsTerminal(os.Stdin.Fd()) {
+		fmt.Fprint(os.Stderr, prompt)
+		data, err := term.ReadPassword(os.Stdin.Fd())
+		fmt.Fprintln(os.Stderr)
+		return string(data), err
+	}
+	line, err := stdinLines.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}